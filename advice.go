@@ -0,0 +1,128 @@
+package infura
+
+import (
+	"fmt"
+	"time"
+)
+
+// AdviceAction is the action recommended by SuggestedGasFees.Advice
+type AdviceAction int
+
+const (
+	// AdviceSendNow recommends sending the transaction now
+	AdviceSendNow AdviceAction = iota
+	// AdviceWaitShort recommends waiting a few blocks before sending
+	AdviceWaitShort
+	// AdviceWaitLong recommends waiting for congestion to clear before sending
+	AdviceWaitLong
+)
+
+// String returns the name of the action
+func (a AdviceAction) String() string {
+	switch a {
+	case AdviceSendNow:
+		return "send-now"
+	case AdviceWaitShort:
+		return "wait-short"
+	case AdviceWaitLong:
+		return "wait-long"
+	default:
+		return fmt.Sprintf("AdviceAction(%d)", int(a))
+	}
+}
+
+// Advice is a send-or-wait recommendation derived from a fee suggestion
+type Advice struct {
+	Action AdviceAction
+	Reason string
+	// Level is the fee level to use when sending
+	Level Priority
+}
+
+// Default thresholds used by Advice
+const (
+	DefaultAdviceHighCongestion = 0.8
+	DefaultAdviceLowCongestion  = 0.3
+	DefaultAdviceMaxLowWait     = 30 * time.Second
+)
+
+// AdviceConfig overrides the thresholds used by AdviceWith
+// Zero fields take the Default* values
+type AdviceConfig struct {
+	// HighCongestion is the networkCongestion at or above which the network counts as congested
+	HighCongestion float64
+	// LowCongestion is the networkCongestion at or below which the network counts as quiet
+	LowCongestion float64
+	// MaxLowWait is the longest acceptable maxWaitTimeEstimate of the low level; above it,
+	// a low recommendation is raised to medium
+	MaxLowWait time.Duration
+}
+
+func (cfg AdviceConfig) withDefaults() AdviceConfig {
+	if cfg.HighCongestion <= 0 {
+		cfg.HighCongestion = DefaultAdviceHighCongestion
+	}
+	if cfg.LowCongestion <= 0 {
+		cfg.LowCongestion = DefaultAdviceLowCongestion
+	}
+	if cfg.MaxLowWait <= 0 {
+		cfg.MaxLowWait = DefaultAdviceMaxLowWait
+	}
+	return cfg
+}
+
+// Advice recommends whether to send now or wait using the default thresholds
+// See AdviceWith for the rules
+func (f *SuggestedGasFees) Advice() Advice {
+	return f.AdviceWith(AdviceConfig{})
+}
+
+// AdviceWith recommends whether to send now or wait, applying these rules in order:
+//
+//   - Congested (networkCongestion >= HighCongestion):
+//     base fee falling: wait long at low; base fee rising: send now at high;
+//     otherwise wait short at medium.
+//   - Quiet (networkCongestion <= LowCongestion): send now at low.
+//   - Otherwise: base and priority fees both rising: send now at high;
+//     either rising: send now at medium; base fee falling: wait short at medium;
+//     otherwise send now at medium.
+//
+// A low recommendation is raised to medium when the low level's maxWaitTimeEstimate
+// exceeds MaxLowWait. Trends other than "up" and "down" count as flat.
+func (f *SuggestedGasFees) AdviceWith(cfg AdviceConfig) Advice {
+	cfg = cfg.withDefaults()
+	baseRising := f.BaseFeeTrend == "up"
+	baseFalling := f.BaseFeeTrend == "down"
+	tipRising := f.PriorityFeeTrend == "up"
+
+	var advice Advice
+	switch {
+	case f.NetworkCongestion.IsSet() && f.NetworkCongestion.Float64() >= cfg.HighCongestion:
+		switch {
+		case baseFalling:
+			advice = Advice{AdviceWaitLong, "network is congested and base fee is falling", PriorityLow}
+		case baseRising:
+			advice = Advice{AdviceSendNow, "network is congested and base fee is rising", PriorityHigh}
+		default:
+			advice = Advice{AdviceWaitShort, "network is congested", PriorityMedium}
+		}
+	case f.NetworkCongestion.IsSet() && f.NetworkCongestion.Float64() <= cfg.LowCongestion:
+		advice = Advice{AdviceSendNow, "network is quiet", PriorityLow}
+	case baseRising && tipRising:
+		advice = Advice{AdviceSendNow, "base and priority fees are rising", PriorityHigh}
+	case baseRising:
+		advice = Advice{AdviceSendNow, "base fee is rising", PriorityMedium}
+	case tipRising:
+		advice = Advice{AdviceSendNow, "priority fee is rising", PriorityMedium}
+	case baseFalling:
+		advice = Advice{AdviceWaitShort, "base fee is falling", PriorityMedium}
+	default:
+		advice = Advice{AdviceSendNow, "fees are stable", PriorityMedium}
+	}
+
+	if advice.Level == PriorityLow && time.Duration(f.Low.MaxWaitTimeEstimate)*time.Millisecond > cfg.MaxLowWait {
+		advice.Level = PriorityMedium
+		advice.Reason += "; low level wait estimate is too long"
+	}
+	return advice
+}
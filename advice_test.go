@@ -0,0 +1,99 @@
+package infura
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSuggestedGasFees_Advice(t *testing.T) {
+	tests := []struct {
+		congestion float64
+		baseTrend  string
+		tipTrend   string
+		lowMaxWait int64
+		wantAction AdviceAction
+		wantLevel  Priority
+	}{
+		// congested
+		{0.9, "down", "down", 0, AdviceWaitLong, PriorityLow},
+		{0.9, "down", "up", 0, AdviceWaitLong, PriorityLow},
+		{0.9, "up", "down", 0, AdviceSendNow, PriorityHigh},
+		{0.9, "up", "up", 0, AdviceSendNow, PriorityHigh},
+		{0.9, "", "down", 0, AdviceWaitShort, PriorityMedium},
+		{0.9, "", "up", 0, AdviceWaitShort, PriorityMedium},
+		{0.8, "down", "", 0, AdviceWaitLong, PriorityLow},
+		{0.9, "down", "", 60000, AdviceWaitLong, PriorityMedium},
+		// quiet
+		{0.1, "down", "down", 0, AdviceSendNow, PriorityLow},
+		{0.1, "up", "up", 0, AdviceSendNow, PriorityLow},
+		{0.3, "", "", 0, AdviceSendNow, PriorityLow},
+		{0.1, "up", "up", 30000, AdviceSendNow, PriorityLow},
+		{0.1, "up", "up", 30001, AdviceSendNow, PriorityMedium},
+		// moderate
+		{0.5, "up", "up", 0, AdviceSendNow, PriorityHigh},
+		{0.5, "up", "down", 0, AdviceSendNow, PriorityMedium},
+		{0.5, "up", "", 0, AdviceSendNow, PriorityMedium},
+		{0.5, "down", "up", 0, AdviceSendNow, PriorityMedium},
+		{0.5, "", "up", 0, AdviceSendNow, PriorityMedium},
+		{0.5, "down", "down", 0, AdviceWaitShort, PriorityMedium},
+		{0.5, "down", "", 0, AdviceWaitShort, PriorityMedium},
+		{0.5, "", "down", 0, AdviceSendNow, PriorityMedium},
+		{0.5, "", "", 0, AdviceSendNow, PriorityMedium},
+	}
+
+	for _, tt := range tests {
+		fees := &SuggestedGasFees{
+			Low:               GasFeeLevel{MaxWaitTimeEstimate: tt.lowMaxWait},
+			NetworkCongestion: NewCongestion(tt.congestion),
+			BaseFeeTrend:      tt.baseTrend,
+			PriorityFeeTrend:  tt.tipTrend,
+		}
+		got := fees.Advice()
+		if got.Action != tt.wantAction || got.Level != tt.wantLevel {
+			t.Errorf("congestion=%v base=%q tip=%q lowMaxWait=%d: got %s at %s (%s), want %s at %s",
+				tt.congestion, tt.baseTrend, tt.tipTrend, tt.lowMaxWait,
+				got.Action, got.Level, got.Reason, tt.wantAction, tt.wantLevel)
+		}
+		if got.Reason == "" {
+			t.Errorf("congestion=%v base=%q tip=%q: expected a reason", tt.congestion, tt.baseTrend, tt.tipTrend)
+		}
+	}
+}
+
+func TestSuggestedGasFees_AdviceWith(t *testing.T) {
+	fees := &SuggestedGasFees{
+		Low:               GasFeeLevel{MaxWaitTimeEstimate: 45000},
+		NetworkCongestion: NewCongestion(0.6),
+		BaseFeeTrend:      "down",
+	}
+
+	tests := []struct {
+		name       string
+		cfg        AdviceConfig
+		wantAction AdviceAction
+		wantLevel  Priority
+	}{
+		{"defaults", AdviceConfig{}, AdviceWaitShort, PriorityMedium},
+		{"lower high threshold", AdviceConfig{HighCongestion: 0.5}, AdviceWaitLong, PriorityMedium},
+		{"lower high threshold and longer wait", AdviceConfig{HighCongestion: 0.5, MaxLowWait: time.Minute}, AdviceWaitLong, PriorityLow},
+		{"higher low threshold", AdviceConfig{LowCongestion: 0.7, MaxLowWait: time.Minute}, AdviceSendNow, PriorityLow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := fees.AdviceWith(tt.cfg)
+			if got.Action != tt.wantAction || got.Level != tt.wantLevel {
+				t.Errorf("got %s at %s (%s), want %s at %s", got.Action, got.Level, got.Reason, tt.wantAction, tt.wantLevel)
+			}
+		})
+	}
+}
+
+func TestAdviceAction_String(t *testing.T) {
+	if got := AdviceWaitLong.String(); got != "wait-long" {
+		t.Errorf("Expected wait-long, got %s", got)
+	}
+	if got := AdviceAction(7).String(); got != "AdviceAction(7)" {
+		t.Errorf("Expected AdviceAction(7), got %s", got)
+	}
+}
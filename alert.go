@@ -0,0 +1,238 @@
+package infura
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"sync"
+	"time"
+)
+
+// AlertDirection tells whether a threshold was crossed upwards or downwards
+type AlertDirection int
+
+const (
+	// AlertAbove is fired when the base fee rises above the upper threshold
+	AlertAbove AlertDirection = iota
+	// AlertBelow is fired when the base fee falls back below the lower threshold
+	AlertBelow
+)
+
+// String returns "above" or "below"
+func (d AlertDirection) String() string {
+	switch d {
+	case AlertAbove:
+		return "above"
+	case AlertBelow:
+		return "below"
+	default:
+		return fmt.Sprintf("AlertDirection(%d)", int(d))
+	}
+}
+
+// AlertThresholds configures the alert band of a chain, in wei
+// Upper is required; Lower defaults to Upper (no hysteresis) and must not exceed it
+type AlertThresholds struct {
+	Upper *big.Int
+	Lower *big.Int
+}
+
+// NewAlertThresholdsGwei creates thresholds from Gwei strings such as "60" or "39.5", in
+// any form NormalizeGwei accepts. An empty lower defaults to upper; the band is otherwise
+// validated by NewAlerter.
+func NewAlertThresholdsGwei(upper, lower string) (AlertThresholds, error) {
+	var t AlertThresholds
+	var err error
+	if t.Upper, err = gweiToWei(upper); err != nil {
+		return AlertThresholds{}, fmt.Errorf("invalid upper threshold: %w", err)
+	}
+	if lower != "" {
+		if t.Lower, err = gweiToWei(lower); err != nil {
+			return AlertThresholds{}, fmt.Errorf("invalid lower threshold: %w", err)
+		}
+	}
+	return t, nil
+}
+
+// AlertEvent describes a threshold crossing
+type AlertEvent struct {
+	ChainID int64
+	// BaseFee is the estimatedBaseFee reading in wei that triggered the alert
+	BaseFee *big.Int
+	// Threshold is the crossed threshold in wei
+	Threshold *big.Int
+	Direction AlertDirection
+	Time      time.Time
+}
+
+// Alerter watches the estimated base fee of one or more chains and calls back once per
+// threshold crossing. After an AlertAbove, no further alert fires until the base fee drops
+// below the lower threshold (AlertBelow), and vice versa. Chains start in the below state.
+// The crossing state can be persisted with Save and restored with Load across restarts.
+type Alerter struct {
+	client     *Client
+	thresholds map[int64]AlertThresholds
+	onAlert    func(AlertEvent)
+	mu         sync.Mutex
+
+	stateMu sync.Mutex
+	state   map[int64]alertState
+}
+
+// alertState is the hysteresis state and last reading of a chain
+type alertState struct {
+	above       bool
+	lastBaseFee *big.Int
+	lastTime    time.Time
+}
+
+// NewAlerter creates an Alerter for the given per-chain thresholds
+// onAlert is never called concurrently
+func NewAlerter(client *Client, thresholds map[int64]AlertThresholds, onAlert func(AlertEvent)) (*Alerter, error) {
+	if onAlert == nil {
+		return nil, fmt.Errorf("alert callback must not be nil")
+	}
+	copied := make(map[int64]AlertThresholds, len(thresholds))
+	for chainID, t := range thresholds {
+		if t.Upper == nil {
+			return nil, fmt.Errorf("upper threshold for chain %d must not be nil", chainID)
+		}
+		if t.Lower == nil {
+			t.Lower = t.Upper
+		}
+		if t.Lower.Cmp(t.Upper) > 0 {
+			return nil, fmt.Errorf("lower threshold for chain %d exceeds upper threshold", chainID)
+		}
+		copied[chainID] = AlertThresholds{Upper: copyInt(t.Upper), Lower: copyInt(t.Lower)}
+	}
+	return &Alerter{client: client, thresholds: copied, onAlert: onAlert, state: make(map[int64]alertState)}, nil
+}
+
+// Run polls every configured chain at pollInterval until ctx is done, then returns ctx.Err()
+// Poll errors and unparseable readings are skipped
+func (a *Alerter) Run(ctx context.Context, pollInterval time.Duration) error {
+	if pollInterval <= 0 {
+		return fmt.Errorf("poll interval must be positive, got %v", pollInterval)
+	}
+
+	var wg sync.WaitGroup
+	for chainID, thresholds := range a.thresholds {
+		events, err := a.client.WatchSuggestedGasFees(ctx, chainID, pollInterval)
+		if err != nil {
+			return err
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			a.watchChain(chainID, thresholds, events)
+		}()
+	}
+	wg.Wait()
+	return ctx.Err()
+}
+
+// watchChain applies the hysteresis state machine to the events of a single chain
+func (a *Alerter) watchChain(chainID int64, thresholds AlertThresholds, events <-chan WatchEvent) {
+	for event := range events {
+		if event.Err != nil {
+			continue
+		}
+		baseFee, err := gweiToWei(event.Fees.EstimatedBaseFee)
+		if err != nil {
+			continue
+		}
+		if alert, ok := a.observe(chainID, thresholds, baseFee, event.Time); ok {
+			a.mu.Lock()
+			a.onAlert(alert)
+			a.mu.Unlock()
+		}
+	}
+}
+
+// observe records a reading and returns the alert to fire, if it crosses a threshold
+func (a *Alerter) observe(chainID int64, thresholds AlertThresholds, baseFee *big.Int, at time.Time) (AlertEvent, bool) {
+	a.stateMu.Lock()
+	defer a.stateMu.Unlock()
+
+	state := a.state[chainID]
+	state.lastBaseFee = baseFee
+	state.lastTime = at
+
+	alert := AlertEvent{ChainID: chainID, BaseFee: copyInt(baseFee), Time: at}
+	fire := true
+	switch {
+	case !state.above && baseFee.Cmp(thresholds.Upper) > 0:
+		state.above = true
+		alert.Direction = AlertAbove
+		alert.Threshold = copyInt(thresholds.Upper)
+	case state.above && baseFee.Cmp(thresholds.Lower) < 0:
+		state.above = false
+		alert.Direction = AlertBelow
+		alert.Threshold = copyInt(thresholds.Lower)
+	default:
+		fire = false
+	}
+
+	a.state[chainID] = state
+	return alert, fire
+}
+
+type alerterSnapshot struct {
+	Version int                            `json:"version"`
+	Chains  map[int64]alerterChainSnapshot `json:"chains"`
+}
+
+type alerterChainSnapshot struct {
+	Above bool `json:"above"`
+	// LastBaseFee is the last reading in wei, empty if none
+	LastBaseFee string    `json:"lastBaseFee,omitempty"`
+	LastTime    time.Time `json:"lastTime"`
+}
+
+// Save writes the crossing state and last reading of every chain as versioned JSON
+func (a *Alerter) Save(w io.Writer) error {
+	a.stateMu.Lock()
+	snapshot := alerterSnapshot{Version: snapshotVersion, Chains: make(map[int64]alerterChainSnapshot, len(a.state))}
+	for chainID, state := range a.state {
+		saved := alerterChainSnapshot{Above: state.above, LastTime: state.lastTime}
+		if state.lastBaseFee != nil {
+			saved.LastBaseFee = state.lastBaseFee.String()
+		}
+		snapshot.Chains[chainID] = saved
+	}
+	a.stateMu.Unlock()
+
+	if err := json.NewEncoder(w).Encode(snapshot); err != nil {
+		return fmt.Errorf("failed to encode alerter snapshot: %w", err)
+	}
+	return nil
+}
+
+// Load replaces the crossing state with a snapshot written by Save
+// Call it before Run; thresholds are not part of the snapshot
+func (a *Alerter) Load(r io.Reader) error {
+	var snapshot alerterSnapshot
+	if err := decodeSnapshot(r, &snapshot, &snapshot.Version); err != nil {
+		return err
+	}
+
+	state := make(map[int64]alertState, len(snapshot.Chains))
+	for chainID, saved := range snapshot.Chains {
+		restored := alertState{above: saved.Above, lastTime: saved.LastTime}
+		if saved.LastBaseFee != "" {
+			baseFee, ok := new(big.Int).SetString(saved.LastBaseFee, 10)
+			if !ok {
+				return fmt.Errorf("invalid base fee %q for chain %d in snapshot", saved.LastBaseFee, chainID)
+			}
+			restored.lastBaseFee = baseFee
+		}
+		state[chainID] = restored
+	}
+
+	a.stateMu.Lock()
+	a.state = state
+	a.stateMu.Unlock()
+	return nil
+}
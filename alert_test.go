@@ -0,0 +1,208 @@
+package infura
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newBaseFeeServer serves one estimatedBaseFee per poll from baseFees, repeating the last value
+func newBaseFeeServer(t *testing.T, baseFees []string) (*httptest.Server, *int32) {
+	t.Helper()
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(&calls, 1)) - 1
+		if n >= len(baseFees) {
+			n = len(baseFees) - 1
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(SuggestedGasFees{EstimatedBaseFee: baseFees[n]})
+	}))
+	return server, &calls
+}
+
+func TestAlerter_Hysteresis(t *testing.T) {
+	series := []string{"30", "50", "61", "70", "65", "45", "40", "39.5", "35", "60", "60.000000001", "20"}
+	server, calls := newBaseFeeServer(t, series)
+	defer server.Close()
+
+	clock := newFakeClock(time.Unix(0, 0))
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL), WithClock(clock))
+
+	var events []AlertEvent
+	alerter, err := NewAlerter(client, map[int64]AlertThresholds{
+		1: {Upper: mustWei(t, "60"), Lower: mustWei(t, "40")},
+	}, func(e AlertEvent) { events = append(events, e) })
+	if err != nil {
+		t.Fatalf("NewAlerter failed: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- alerter.Run(ctx, time.Minute) }()
+
+	for int(atomic.LoadInt32(calls)) < len(series) {
+		waitForWaiters(t, clock, 1)
+		clock.Advance(time.Minute)
+	}
+	// Let the last reading be processed before stopping
+	waitForWaiters(t, clock, 1)
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+
+	expected := []struct {
+		baseFee   string
+		threshold string
+		direction AlertDirection
+		minute    int64
+	}{
+		{baseFee: "61", threshold: "60", direction: AlertAbove, minute: 2},
+		{baseFee: "39.5", threshold: "40", direction: AlertBelow, minute: 7},
+		{baseFee: "60.000000001", threshold: "60", direction: AlertAbove, minute: 10},
+		{baseFee: "20", threshold: "40", direction: AlertBelow, minute: 11},
+	}
+	if len(events) != len(expected) {
+		t.Fatalf("Expected %d events, got %d: %+v", len(expected), len(events), events)
+	}
+	for i, want := range expected {
+		got := events[i]
+		if got.ChainID != 1 || got.Direction != want.direction {
+			t.Errorf("event %d: expected chain 1 %s, got chain %d %s", i, want.direction, got.ChainID, got.Direction)
+		}
+		if got.BaseFee.Cmp(mustWei(t, want.baseFee)) != 0 || got.Threshold.Cmp(mustWei(t, want.threshold)) != 0 {
+			t.Errorf("event %d: expected reading %s over threshold %s, got %s / %s", i, want.baseFee, want.threshold,
+				formatWeiAsGwei(got.BaseFee), formatWeiAsGwei(got.Threshold))
+		}
+		if wantTime := time.Unix(want.minute*60, 0); !got.Time.Equal(wantTime) {
+			t.Errorf("event %d: expected time %v, got %v", i, wantTime, got.Time)
+		}
+	}
+}
+
+func TestNewAlerter_InvalidThresholds(t *testing.T) {
+	client := NewClientWithAPIKey("test-api-key")
+	callback := func(AlertEvent) {}
+
+	tests := []struct {
+		name       string
+		thresholds map[int64]AlertThresholds
+		callback   func(AlertEvent)
+	}{
+		{name: "nil callback", thresholds: map[int64]AlertThresholds{1: {Upper: big.NewInt(1)}}},
+		{name: "missing upper", thresholds: map[int64]AlertThresholds{1: {Lower: big.NewInt(1)}}, callback: callback},
+		{name: "lower above upper", thresholds: map[int64]AlertThresholds{1: {Upper: big.NewInt(1), Lower: big.NewInt(2)}}, callback: callback},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewAlerter(client, tt.thresholds, tt.callback); err == nil {
+				t.Fatal("Expected error but got nil")
+			}
+		})
+	}
+}
+
+func TestNewAlertThresholdsGwei(t *testing.T) {
+	thresholds, err := NewAlertThresholdsGwei("60", "39.5")
+	if err != nil {
+		t.Fatalf("NewAlertThresholdsGwei failed: %v", err)
+	}
+	if thresholds.Upper.Cmp(mustWei(t, "60")) != 0 || thresholds.Lower.Cmp(mustWei(t, "39.5")) != 0 {
+		t.Errorf("Expected 60 and 39.5 Gwei in wei, got %s and %s", thresholds.Upper, thresholds.Lower)
+	}
+
+	thresholds, err = NewAlertThresholdsGwei("60", "")
+	if err != nil {
+		t.Fatalf("NewAlertThresholdsGwei failed: %v", err)
+	}
+	if thresholds.Lower != nil {
+		t.Errorf("Expected no lower threshold, got %s", thresholds.Lower)
+	}
+
+	thresholds, err = NewAlertThresholdsGwei("+60.0", "")
+	if err != nil || thresholds.Upper.Cmp(mustWei(t, "60")) != 0 {
+		t.Errorf("Expected a spelling NormalizeGwei accepts, got %v, %v", thresholds.Upper, err)
+	}
+
+	for _, tt := range []struct{ upper, lower string }{{"", ""}, {"sixty", ""}, {"60", "-1"}, {"60", "0.0000000001"}} {
+		if _, err := NewAlertThresholdsGwei(tt.upper, tt.lower); err == nil {
+			t.Errorf("Expected an error for %q/%q, got nil", tt.upper, tt.lower)
+		}
+	}
+}
+
+// runAlerter runs alerter against series on a fake clock until every value has been polled
+func runAlerter(t *testing.T, clock *fakeClock, alerter *Alerter, calls *int32, polls int) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- alerter.Run(ctx, time.Minute) }()
+
+	for int(atomic.LoadInt32(calls)) < polls {
+		waitForWaiters(t, clock, 1)
+		clock.Advance(time.Minute)
+	}
+	waitForWaiters(t, clock, 1)
+	cancel()
+	<-done
+}
+
+func TestAlerter_SaveLoadAcrossRestart(t *testing.T) {
+	thresholds := map[int64]AlertThresholds{1: {Upper: mustWei(t, "60"), Lower: mustWei(t, "40")}}
+
+	// First process: the base fee rises above the upper threshold
+	server, calls := newBaseFeeServer(t, []string{"30", "70"})
+	clock := newFakeClock(time.Unix(0, 0))
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL), WithClock(clock))
+
+	var before []AlertEvent
+	alerter, _ := NewAlerter(client, thresholds, func(e AlertEvent) { before = append(before, e) })
+	runAlerter(t, clock, alerter, calls, 2)
+	server.Close()
+
+	if len(before) != 1 || before[0].Direction != AlertAbove {
+		t.Fatalf("Expected a single AlertAbove before restart, got %+v", before)
+	}
+
+	var snapshot bytes.Buffer
+	if err := alerter.Save(&snapshot); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	// Second process: still above the band, then back below it
+	server, calls = newBaseFeeServer(t, []string{"65", "70", "35"})
+	defer server.Close()
+	clock = newFakeClock(time.Unix(0, 0))
+	client = NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL), WithClock(clock))
+
+	var after []AlertEvent
+	restarted, _ := NewAlerter(client, thresholds, func(e AlertEvent) { after = append(after, e) })
+	if err := restarted.Load(&snapshot); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	runAlerter(t, clock, restarted, calls, 3)
+
+	if len(after) != 1 || after[0].Direction != AlertBelow {
+		t.Fatalf("Expected only AlertBelow after restart, got %+v", after)
+	}
+}
+
+func TestAlerter_LoadRejectsUnknownVersion(t *testing.T) {
+	alerter, _ := NewAlerter(NewClientWithAPIKey("test-api-key"),
+		map[int64]AlertThresholds{1: {Upper: big.NewInt(1)}}, func(AlertEvent) {})
+
+	if err := alerter.Load(strings.NewReader(`{"version": 2, "chains": {}}`)); err == nil {
+		t.Error("Expected error for newer snapshot version")
+	}
+	if err := alerter.Load(strings.NewReader(`{"version": 1, "chains": {"1": {"above": true, "lastBaseFee": "x"}}}`)); err == nil {
+		t.Error("Expected error for invalid base fee")
+	}
+}
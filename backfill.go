@@ -0,0 +1,256 @@
+package infura
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"sync"
+	"time"
+)
+
+// BaseFeePoint is the base fee of a block, in Gwei
+type BaseFeePoint struct {
+	Block   int64
+	BaseFee *big.Float
+}
+
+// BaseFeeSource starts a live feed of block base fees, in increasing block order, such as
+// WatchLatestBaseFee. The channel is closed when the feed stops; BackfilledBaseFeeSeries
+// then calls the source again.
+type BaseFeeSource func(ctx context.Context) (<-chan BaseFeePoint, error)
+
+// BackfilledBaseFeeSeries is a contiguous, block-ordered base fee series for charts: it is
+// backfilled once from GetBaseFeeHistory, then extended from a live source. It is safe for
+// concurrent use.
+//
+// Points are keyed by block number, so the series is strictly ordered and has no
+// duplicates: a live point for a block the series already has is dropped. The history
+// window has no block numbers; it is aligned on the live point that follows it, whose base
+// fee must appear in the window (the latest occurrence is used, checked against the points
+// already in the series). The window is fetched again whenever a live point leaves a gap,
+// e.g. after the source restarted or missed blocks, and the missing blocks are filled from
+// it. A gap that cannot be filled, because the window does not reach back far enough or
+// cannot be aligned, is kept and counted by Gaps.
+type BackfilledBaseFeeSeries struct {
+	client  *Client
+	chainID int64
+	maxLen  int
+	changed chan struct{}
+
+	mu     sync.RWMutex
+	points []BaseFeePoint
+	gaps   int
+}
+
+// NewBackfilledBaseFeeSeries creates an empty series of a chain retaining at most maxLen
+// points (oldest are dropped first); maxLen <= 0 retains everything
+func NewBackfilledBaseFeeSeries(client *Client, chainID int64, maxLen int) *BackfilledBaseFeeSeries {
+	return &BackfilledBaseFeeSeries{
+		client:  client,
+		chainID: chainID,
+		maxLen:  maxLen,
+		changed: make(chan struct{}, 1),
+	}
+}
+
+// Run feeds the series from source until ctx is done, then returns ctx.Err()
+// When the source fails to start or its channel is closed, Run waits restartDelay on the
+// client's clock and starts it again.
+func (s *BackfilledBaseFeeSeries) Run(ctx context.Context, source BaseFeeSource, restartDelay time.Duration) error {
+	for {
+		points, err := source(ctx)
+		if err != nil {
+			log.Printf("[WARN] base fee source of chain %d failed to start: %v", s.chainID, s.client.mask(err.Error()))
+		} else {
+			for point := range points {
+				s.add(ctx, point)
+			}
+		}
+		if err := s.client.sleep(ctx, restartDelay); err != nil {
+			return err
+		}
+	}
+}
+
+// Points returns a copy of the series, oldest block first
+func (s *BackfilledBaseFeeSeries) Points() []BaseFeePoint {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	points := make([]BaseFeePoint, len(s.points))
+	for i, p := range s.points {
+		points[i] = BaseFeePoint{Block: p.Block, BaseFee: new(big.Float).Copy(p.BaseFee)}
+	}
+	return points
+}
+
+// Len returns the number of points in the series
+func (s *BackfilledBaseFeeSeries) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.points)
+}
+
+// Gaps returns the number of gaps in the series that could not be filled
+func (s *BackfilledBaseFeeSeries) Gaps() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.gaps
+}
+
+// Changed returns a channel that receives a value after the series changed
+// Notifications are coalesced: a single pending value stands for any number of changes,
+// so a slow reader calls Points once for all of them.
+func (s *BackfilledBaseFeeSeries) Changed() <-chan struct{} {
+	return s.changed
+}
+
+// add appends a live point, backfilling the blocks missing before it
+func (s *BackfilledBaseFeeSeries) add(ctx context.Context, point BaseFeePoint) {
+	if point.BaseFee == nil {
+		return
+	}
+	s.mu.RLock()
+	last, ok := s.lastBlock()
+	s.mu.RUnlock()
+	if ok && point.Block <= last {
+		return
+	}
+
+	var history []*big.Float
+	if !ok || point.Block > last+1 {
+		fetched, err := s.client.GetBaseFeeHistory(ctx, s.chainID)
+		if err == nil {
+			history, err = parseHistory(fetched)
+		}
+		if err != nil {
+			log.Printf("[WARN] failed to backfill base fees of chain %d: %v", s.chainID, s.client.mask(err.Error()))
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	last, ok = s.lastBlock()
+	if ok && point.Block <= last {
+		return
+	}
+	if history != nil {
+		s.backfill(history, point)
+	}
+	if last, ok = s.lastBlock(); ok && point.Block > last+1 {
+		s.gaps++
+	}
+	s.points = append(s.points, BaseFeePoint{Block: point.Block, BaseFee: new(big.Float).Copy(point.BaseFee)})
+	if s.maxLen > 0 && len(s.points) > s.maxLen {
+		s.points = append([]BaseFeePoint(nil), s.points[len(s.points)-s.maxLen:]...)
+	}
+
+	select {
+	case s.changed <- struct{}{}:
+	default:
+	}
+}
+
+// lastBlock returns the block of the newest point, and false if the series is empty
+func (s *BackfilledBaseFeeSeries) lastBlock() (int64, bool) {
+	if len(s.points) == 0 {
+		return 0, false
+	}
+	return s.points[len(s.points)-1].Block, true
+}
+
+// backfill appends the blocks between the newest point and next from a history window
+// It aligns the window on next: the latest entry equal to next's base fee whose preceding
+// entries agree with the points already in the series.
+func (s *BackfilledBaseFeeSeries) backfill(history []*big.Float, next BaseFeePoint) {
+	for k := len(history) - 1; k >= 0; k-- {
+		if history[k].Cmp(next.BaseFee) != 0 || !s.agrees(history, k, next.Block) {
+			continue
+		}
+		from := next.Block - int64(k)
+		if last, ok := s.lastBlock(); ok {
+			from = max(from, last+1)
+		}
+		for block := from; block < next.Block; block++ {
+			value := history[k-int(next.Block-block)]
+			s.points = append(s.points, BaseFeePoint{Block: block, BaseFee: new(big.Float).Copy(value)})
+		}
+		return
+	}
+}
+
+// agrees reports whether the history window, with entry k at block, matches every point
+// of the series it covers
+func (s *BackfilledBaseFeeSeries) agrees(history []*big.Float, k int, block int64) bool {
+	first := block - int64(k)
+	for i := len(s.points) - 1; i >= 0 && s.points[i].Block >= first; i-- {
+		if s.points[i].Block >= block {
+			continue
+		}
+		if history[k-int(block-s.points[i].Block)].Cmp(s.points[i].BaseFee) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// WatchLatestBaseFee polls the latest block header over the RPC backend (see WithRPC) at
+// interval and emits the base fee of every new block, in Gwei, until ctx is done. It is a
+// BaseFeeSource for BackfilledBaseFeeSeries:
+//
+//	series.Run(ctx, func(ctx context.Context) (<-chan infura.BaseFeePoint, error) {
+//		return client.WatchLatestBaseFee(ctx, 1, 2*time.Second)
+//	}, 5*time.Second)
+//
+// Polls that fail or return a block without a base fee are skipped. Blocks produced
+// between two polls are not emitted; BackfilledBaseFeeSeries fills them from history.
+func (c *Client) WatchLatestBaseFee(ctx context.Context, chainID int64, interval time.Duration) (<-chan BaseFeePoint, error) {
+	if c.rpc == nil {
+		return nil, fmt.Errorf("no RPC backend configured (use WithRPC)")
+	}
+	if interval <= 0 {
+		return nil, fmt.Errorf("interval must be positive, got %v", interval)
+	}
+
+	out := make(chan BaseFeePoint)
+	go func() {
+		defer close(out)
+		last := int64(-1)
+		for {
+			if point, err := c.latestBlockBaseFee(ctx, chainID); err == nil && point.Block > last {
+				last = point.Block
+				select {
+				case out <- point:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if c.sleep(ctx, interval) != nil {
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+// latestBlockBaseFee fetches the number and base fee of the latest block
+func (c *Client) latestBlockBaseFee(ctx context.Context, chainID int64) (BaseFeePoint, error) {
+	var block *rpcBlockHeader
+	if err := c.callRPC(ctx, chainID, "eth_getBlockByNumber", []interface{}{"latest", false}, &block); err != nil {
+		return BaseFeePoint{}, err
+	}
+	if block == nil || block.BaseFeePerGas == nil {
+		return BaseFeePoint{}, ErrNoBaseFee
+	}
+	number, err := parseHexQuantity(block.Number)
+	if err != nil {
+		return BaseFeePoint{}, fmt.Errorf("invalid block number: %w", err)
+	}
+	baseFee, err := parseHexQuantity(*block.BaseFeePerGas)
+	if err != nil {
+		return BaseFeePoint{}, fmt.Errorf("invalid baseFeePerGas: %w", err)
+	}
+	gwei := new(big.Float).SetPrec(256).SetInt(baseFee)
+	gwei.Quo(gwei, new(big.Float).SetInt(weiPerGwei))
+	return BaseFeePoint{Block: number.Int64(), BaseFee: gwei}, nil
+}
@@ -0,0 +1,236 @@
+package infura
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newHistoryServer serves the current value of history as baseFeeHistory and counts the
+// requests
+func newHistoryServer(history *atomic.Value) (*httptest.Server, *int32) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(history.Load().(string)))
+	}))
+	return server, &calls
+}
+
+func point(block int64, gwei float64) BaseFeePoint {
+	return BaseFeePoint{Block: block, BaseFee: big.NewFloat(gwei)}
+}
+
+// assertSeries checks that the series has exactly the given values, at consecutive blocks
+// from first, and is strictly ordered
+func assertSeries(t *testing.T, s *BackfilledBaseFeeSeries, first int64, want ...float64) {
+	t.Helper()
+	points := s.Points()
+	if len(points) != len(want) {
+		t.Fatalf("Expected %d points, got %d: %v", len(want), len(points), points)
+	}
+	for i, p := range points {
+		if i > 0 && p.Block <= points[i-1].Block {
+			t.Errorf("Expected strictly increasing blocks, got %d after %d", p.Block, points[i-1].Block)
+		}
+		got, _ := p.BaseFee.Float64()
+		if p.Block != first+int64(i) || got != want[i] {
+			t.Errorf("Point %d: expected block %d = %v, got block %d = %v", i, first+int64(i), want[i], p.Block, got)
+		}
+	}
+}
+
+func TestBackfilledBaseFeeSeries_Overlap(t *testing.T) {
+	var history atomic.Value
+	history.Store(`["10", "11", "12", "13"]`)
+	server, calls := newHistoryServer(&history)
+	defer server.Close()
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL))
+
+	s := NewBackfilledBaseFeeSeries(client, 1, 0)
+	ctx := context.Background()
+	s.add(ctx, point(100, 13))
+	assertSeries(t, s, 97, 10, 11, 12, 13)
+
+	// A repeated and an older block are duplicates; the next block needs no history
+	s.add(ctx, point(100, 13))
+	s.add(ctx, point(99, 12))
+	s.add(ctx, point(101, 14))
+	assertSeries(t, s, 97, 10, 11, 12, 13, 14)
+	if got := atomic.LoadInt32(calls); got != 1 {
+		t.Errorf("Expected history to be fetched once, got %d", got)
+	}
+	if s.Gaps() != 0 {
+		t.Errorf("Expected no gaps, got %d", s.Gaps())
+	}
+}
+
+func TestBackfilledBaseFeeSeries_FillsGap(t *testing.T) {
+	var history atomic.Value
+	history.Store(`["12", "13"]`)
+	server, calls := newHistoryServer(&history)
+	defer server.Close()
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL))
+
+	s := NewBackfilledBaseFeeSeries(client, 1, 0)
+	ctx := context.Background()
+	s.add(ctx, point(100, 13))
+	s.add(ctx, point(101, 14))
+
+	// Blocks 102 and 103 were missed; the window ends with a later block (105)
+	history.Store(`["13", "14", "15", "16", "17", "18"]`)
+	s.add(ctx, point(104, 17))
+	assertSeries(t, s, 99, 12, 13, 14, 15, 16, 17)
+	if got := atomic.LoadInt32(calls); got != 2 {
+		t.Errorf("Expected history to be fetched again for the gap, got %d", got)
+	}
+	if s.Gaps() != 0 {
+		t.Errorf("Expected the gap to be filled, got %d gaps", s.Gaps())
+	}
+}
+
+func TestBackfilledBaseFeeSeries_RejectsMisalignedWindow(t *testing.T) {
+	var history atomic.Value
+	history.Store(`["13"]`)
+	server, _ := newHistoryServer(&history)
+	defer server.Close()
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL))
+
+	s := NewBackfilledBaseFeeSeries(client, 1, 0)
+	ctx := context.Background()
+	s.add(ctx, point(100, 13))
+
+	// 17 appears twice; only the occurrence consistent with block 100 = 13 aligns
+	history.Store(`["13", "20", "21", "17", "17"]`)
+	s.add(ctx, point(103, 17))
+	assertSeries(t, s, 100, 13, 20, 21, 17)
+
+	// A window without the new value leaves the gap
+	history.Store(`["1", "2"]`)
+	s.add(ctx, point(106, 30))
+	if s.Gaps() != 1 || s.Len() != 5 {
+		t.Errorf("Expected one unfilled gap and 5 points, got %d gaps and %d points", s.Gaps(), s.Len())
+	}
+}
+
+func TestBackfilledBaseFeeSeries_MaxLen(t *testing.T) {
+	var history atomic.Value
+	history.Store(`["10", "11", "12", "13"]`)
+	server, _ := newHistoryServer(&history)
+	defer server.Close()
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL))
+
+	s := NewBackfilledBaseFeeSeries(client, 1, 3)
+	s.add(context.Background(), point(100, 13))
+	s.add(context.Background(), point(101, 14))
+	assertSeries(t, s, 99, 12, 13, 14)
+}
+
+func TestBackfilledBaseFeeSeries_RunRestartsSource(t *testing.T) {
+	var history atomic.Value
+	history.Store(`["11", "12"]`)
+	server, calls := newHistoryServer(&history)
+	defer server.Close()
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL))
+
+	s := NewBackfilledBaseFeeSeries(client, 1, 0)
+	feeds := [][]BaseFeePoint{
+		{point(10, 12), point(11, 13)},
+		// After the restart the source resumes at block 14
+		{point(14, 16), point(15, 17)},
+	}
+	var mu sync.Mutex
+	source := func(ctx context.Context) (<-chan BaseFeePoint, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if len(feeds) == 0 {
+			return nil, errors.New("source unavailable")
+		}
+		feed := feeds[0]
+		feeds = feeds[1:]
+		if len(feeds) == 0 {
+			history.Store(`["12", "13", "14", "15", "16"]`)
+		}
+		out := make(chan BaseFeePoint, len(feed))
+		for _, p := range feed {
+			out <- p
+		}
+		close(out)
+		return out, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- s.Run(ctx, source, time.Millisecond) }()
+
+	deadline := time.After(5 * time.Second)
+	for s.Len() < 7 {
+		select {
+		case <-s.Changed():
+		case <-deadline:
+			t.Fatalf("Expected the series to reach 7 points, got %v", s.Points())
+		}
+	}
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+
+	assertSeries(t, s, 9, 11, 12, 13, 14, 15, 16, 17)
+	if got := atomic.LoadInt32(calls); got != 2 {
+		t.Errorf("Expected the history to be fetched at start and after the restart, got %d", got)
+	}
+}
+
+// headerRPC answers eth_getBlockByNumber with the next header of a list
+type headerRPC struct {
+	mu      sync.Mutex
+	headers []string
+}
+
+func (r *headerRPC) CallRPC(ctx context.Context, chainID int64, method string, params []interface{}, result interface{}) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.headers) == 0 {
+		return errors.New("no more blocks")
+	}
+	header := r.headers[0]
+	if len(r.headers) > 1 {
+		r.headers = r.headers[1:]
+	}
+	return json.Unmarshal([]byte(header), result)
+}
+
+func TestWatchLatestBaseFee(t *testing.T) {
+	rpc := &headerRPC{headers: []string{
+		`{"number": "0x10", "baseFeePerGas": "0x3b9aca00"}`,
+		`{"number": "0x10", "baseFeePerGas": "0x3b9aca00"}`,
+		`{"number": "0x12", "baseFeePerGas": "0x77359400"}`,
+	}}
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithRPC(rpc))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	points, err := client.WatchLatestBaseFee(ctx, 1, time.Millisecond)
+	if err != nil {
+		t.Fatalf("WatchLatestBaseFee failed: %v", err)
+	}
+	for _, want := range []BaseFeePoint{point(16, 1), point(18, 2)} {
+		got := <-points
+		if got.Block != want.Block || got.BaseFee.Cmp(want.BaseFee) != 0 {
+			t.Errorf("Expected %v, got block %d = %v", want, got.Block, got.BaseFee)
+		}
+	}
+
+	if _, err := NewClientWithAPIKeyAndOptions("test-api-key").WatchLatestBaseFee(ctx, 1, time.Second); err == nil {
+		t.Error("Expected an error without an RPC backend")
+	}
+}
@@ -0,0 +1,68 @@
+package infura
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// Backoff computes exponential backoff delays, as used by the client's retries (see
+// WithRetry and WithRetryBackoff) and by WaitForLowCongestion. The zero value is usable
+// and always returns 0; set Base to start. A Backoff holds no state, so one value can be
+// shared by concurrent callers.
+//
+//	b := infura.Backoff{Base: 100 * time.Millisecond, Cap: 10 * time.Second, Jitter: 0.2}
+//	time.Sleep(b.NextDelay(attempt))
+type Backoff struct {
+	// Base is the delay after the first attempt
+	Base time.Duration
+	// Cap is the maximum delay (0 = no maximum)
+	Cap time.Duration
+	// Multiplier is the growth factor per attempt; values below 1, including the zero
+	// value, mean 2
+	Multiplier float64
+	// Jitter is the fraction, between 0 and 1, by which a delay is randomly shortened:
+	// with 0.2 the delay is between 80% and 100% of the computed value. Values outside
+	// [0, 1] are clamped.
+	Jitter float64
+	// Seed makes the jitter deterministic: the same Seed and attempt always give the same
+	// delay. Leave it 0 outside tests, so concurrent callers do not retry in lockstep.
+	Seed uint64
+}
+
+// NextDelay returns the delay after the given attempt, numbered from 1: Base times
+// Multiplier^(attempt-1), capped at Cap and shortened by up to Jitter. Attempts below 1
+// are treated as 1.
+func (b Backoff) NextDelay(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	multiplier := b.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+	limit := float64(math.MaxInt64)
+	if b.Cap > 0 {
+		limit = float64(b.Cap)
+	}
+
+	delay := float64(b.Base) * math.Pow(multiplier, float64(attempt-1))
+	if delay > limit || math.IsInf(delay, 0) || math.IsNaN(delay) {
+		delay = limit
+	}
+	if jitter := min(max(b.Jitter, 0), 1); jitter > 0 {
+		delay -= delay * jitter * b.random(attempt)
+	}
+	if delay >= float64(math.MaxInt64) {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(delay)
+}
+
+// random returns a number in [0, 1), derived from Seed and attempt when Seed is set
+func (b Backoff) random(attempt int) float64 {
+	if b.Seed == 0 {
+		return rand.Float64()
+	}
+	return rand.New(rand.NewPCG(b.Seed, uint64(attempt))).Float64()
+}
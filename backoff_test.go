@@ -0,0 +1,106 @@
+package infura
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestBackoff_NextDelay(t *testing.T) {
+	tests := []struct {
+		name    string
+		backoff Backoff
+		want    []time.Duration
+	}{
+		{
+			name:    "doubling by default",
+			backoff: Backoff{Base: 100 * time.Millisecond},
+			want:    []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond},
+		},
+		{
+			name:    "multiplier and cap",
+			backoff: Backoff{Base: time.Second, Cap: 20 * time.Second, Multiplier: 3},
+			want:    []time.Duration{time.Second, 3 * time.Second, 9 * time.Second, 20 * time.Second, 20 * time.Second},
+		},
+		{
+			name:    "constant",
+			backoff: Backoff{Base: time.Second, Multiplier: 1},
+			want:    []time.Duration{time.Second, time.Second, time.Second},
+		},
+		{
+			name:    "zero value",
+			backoff: Backoff{},
+			want:    []time.Duration{0, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i, want := range tt.want {
+				if got := tt.backoff.NextDelay(i + 1); got != want {
+					t.Errorf("NextDelay(%d) = %v, want %v", i+1, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestBackoff_Overflow(t *testing.T) {
+	b := Backoff{Base: time.Hour}
+	if got := b.NextDelay(200); got != time.Duration(math.MaxInt64) {
+		t.Errorf("Expected the delay to saturate without a cap, got %v", got)
+	}
+	if got := b.NextDelay(0); got != time.Hour {
+		t.Errorf("Expected attempt 0 to be treated as 1, got %v", got)
+	}
+}
+
+func TestBackoff_JitterBounds(t *testing.T) {
+	b := Backoff{Base: time.Second, Cap: 10 * time.Second, Jitter: 0.25}
+	for attempt := 1; attempt <= 8; attempt++ {
+		full := Backoff{Base: time.Second, Cap: 10 * time.Second}.NextDelay(attempt)
+		lower := time.Duration(float64(full) * 0.75)
+		for i := 0; i < 100; i++ {
+			if got := b.NextDelay(attempt); got < lower || got > full {
+				t.Fatalf("NextDelay(%d) = %v, want within [%v, %v]", attempt, got, lower, full)
+			}
+		}
+	}
+}
+
+func TestBackoff_SeededJitter(t *testing.T) {
+	b := Backoff{Base: time.Second, Jitter: 1, Seed: 42}
+	varied := false
+	for attempt := 1; attempt <= 5; attempt++ {
+		first := b.NextDelay(attempt)
+		if again := b.NextDelay(attempt); again != first {
+			t.Errorf("NextDelay(%d) = %v then %v, want the same delay for a seed", attempt, first, again)
+		}
+		if first != b.Base<<(attempt-1) {
+			varied = true
+		}
+	}
+	if !varied {
+		t.Error("Expected the jitter to shorten some delays")
+	}
+	if other := (Backoff{Base: time.Second, Jitter: 1, Seed: 7}); other.NextDelay(3) == b.NextDelay(3) {
+		t.Error("Expected another seed to give another delay")
+	}
+}
+
+func TestWithRetryBackoff(t *testing.T) {
+	client := NewClientWithOptions("test-api-key", "",
+		WithRetry(5, time.Second),
+		WithRetryBackoff(Backoff{Base: 50 * time.Millisecond, Cap: 120 * time.Millisecond}))
+
+	want := []time.Duration{50 * time.Millisecond, 100 * time.Millisecond, 120 * time.Millisecond}
+	for i, w := range want {
+		if got := client.retryDelay(i + 1); got != w {
+			t.Errorf("retryDelay(%d) = %v, want %v", i+1, got, w)
+		}
+	}
+
+	if _, err := New("test-api-key", "", WithRetryBackoff(Backoff{Base: -time.Second})); err == nil {
+		t.Error("Expected a negative base delay to be rejected")
+	}
+}
@@ -0,0 +1,71 @@
+package infura
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// ErrNoBaseFee is returned by GetLatestBaseFee when the latest block has no baseFeePerGas,
+// e.g. on a chain that has not activated EIP-1559
+var ErrNoBaseFee = errors.New("block has no base fee")
+
+// latestBaseFeeResource is the cache resource name of GetLatestBaseFee results
+const latestBaseFeeResource = "rpc/latestBaseFee"
+
+// rpcBlockHeader holds the fields of an eth_getBlockByNumber result used by the client
+type rpcBlockHeader struct {
+	Number        string  `json:"number"`
+	BaseFeePerGas *string `json:"baseFeePerGas"`
+}
+
+// GetLatestBaseFee retrieves the baseFeePerGas of the latest block in wei via
+// eth_getBlockByNumber, the value the BASEFEE opcode returns in that block. Blocks without
+// a base fee (pre-EIP-1559 chains) yield ErrNoBaseFee. Requires an RPC backend configured
+// with WithRPC.
+// With WithCache the result is cached for the chain's cache TTL like Gas API responses.
+func (c *Client) GetLatestBaseFee(ctx context.Context, chainID int64, opts ...CallOption) (*big.Int, error) {
+	if c.rpc == nil {
+		return nil, fmt.Errorf("no RPC backend configured (use WithRPC)")
+	}
+
+	ctx, cancel := withCallOptions(ctx, opts)
+	defer cancel()
+
+	ttl := c.settingsFor(ctx, chainID).cacheTTL
+	key := cacheKey(chainID, latestBaseFeeResource)
+	if c.cache != nil && ttl > 0 {
+		if entry, ok := c.cache.get(key); ok && c.clock.Now().Sub(entry.fetchedAt) < ttl {
+			recordCallMeta(ctx, func(meta *CallMeta) {
+				meta.Cached = true
+				meta.FetchedAt = entry.fetchedAt
+			})
+			return new(big.Int).SetBytes(entry.body), nil
+		}
+	}
+
+	var block *rpcBlockHeader
+	if err := c.callRPC(ctx, chainID, "eth_getBlockByNumber", []interface{}{"latest", false}, &block); err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, fmt.Errorf("latest block of chain %d not found", chainID)
+	}
+	if block.BaseFeePerGas == nil {
+		return nil, fmt.Errorf("%w: block %s of chain %d", ErrNoBaseFee, block.Number, chainID)
+	}
+	baseFee, err := parseHexQuantity(*block.BaseFeePerGas)
+	if err != nil {
+		return nil, fmt.Errorf("invalid baseFeePerGas: %w", err)
+	}
+
+	if c.cache != nil && ttl > 0 {
+		fetchedAt := c.clock.Now()
+		c.cache.set(key, cacheEntry{body: baseFee.Bytes(), fetchedAt: fetchedAt})
+		recordCallMeta(ctx, func(meta *CallMeta) {
+			meta.FetchedAt = fetchedAt
+		})
+	}
+	return baseFee, nil
+}
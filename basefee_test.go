@@ -0,0 +1,114 @@
+package infura
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newBlockServer serves block as the eth_getBlockByNumber result and counts the calls
+func newBlockServer(t *testing.T, block string) (*httptest.Server, *int32) {
+	t.Helper()
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		var req rpcRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		if req.Method != "eth_getBlockByNumber" || !reflect.DeepEqual(req.Params, []interface{}{"latest", false}) {
+			t.Errorf("unexpected request: %+v", req)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":` + block + `}`))
+	}))
+	return server, &calls
+}
+
+func TestGetLatestBaseFee(t *testing.T) {
+	server, _ := newBlockServer(t, `{"number":"0x1312d00","hash":"0xabc","baseFeePerGas":"0x6fc23ac00","transactions":[]}`)
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithRPC(NewHTTPRPC(map[int64]string{1: server.URL}, nil)))
+
+	baseFee, err := client.GetLatestBaseFee(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetLatestBaseFee failed: %v", err)
+	}
+	if baseFee.String() != "30000000000" {
+		t.Errorf("Expected 30000000000 wei, got %s", baseFee)
+	}
+}
+
+func TestGetLatestBaseFee_PreLondonBlock(t *testing.T) {
+	server, _ := newBlockServer(t, `{"number":"0xc5d487","hash":"0xabc","transactions":[]}`)
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithRPC(NewHTTPRPC(map[int64]string{56: server.URL}, nil)))
+
+	_, err := client.GetLatestBaseFee(context.Background(), 56)
+	if !errors.Is(err, ErrNoBaseFee) {
+		t.Fatalf("Expected ErrNoBaseFee, got %v", err)
+	}
+}
+
+func TestGetLatestBaseFee_Errors(t *testing.T) {
+	for _, block := range []string{`null`, `{"number":"0x1","baseFeePerGas":"12"}`} {
+		server, _ := newBlockServer(t, block)
+		client := NewClientWithAPIKeyAndOptions("test-api-key",
+			WithRPC(NewHTTPRPC(map[int64]string{1: server.URL}, nil)))
+		if _, err := client.GetLatestBaseFee(context.Background(), 1); err == nil || errors.Is(err, ErrNoBaseFee) {
+			t.Errorf("block %s: expected an error, got %v", block, err)
+		}
+		server.Close()
+	}
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key")
+	if _, err := client.GetLatestBaseFee(context.Background(), 1); err == nil {
+		t.Error("Expected an error without an RPC backend")
+	}
+}
+
+func TestGetLatestBaseFee_Cached(t *testing.T) {
+	server, calls := newBlockServer(t, `{"number":"0x1312d00","baseFeePerGas":"0x3b9aca00"}`)
+	defer server.Close()
+
+	clock := newFakeClock(time.Unix(0, 0))
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithRPC(NewHTTPRPC(map[int64]string{1: server.URL}, nil)),
+		WithCache(12*time.Second),
+		WithClock(clock))
+
+	for i := 0; i < 2; i++ {
+		var meta CallMeta
+		baseFee, err := client.GetLatestBaseFee(ContextWithCallMeta(context.Background(), &meta), 1)
+		if err != nil {
+			t.Fatalf("GetLatestBaseFee failed: %v", err)
+		}
+		if baseFee.Int64() != 1000000000 {
+			t.Errorf("Expected 1 gwei, got %s", baseFee)
+		}
+		if meta.Cached != (i == 1) {
+			t.Errorf("call %d: Cached = %v", i, meta.Cached)
+		}
+	}
+	if got := atomic.LoadInt32(calls); got != 1 {
+		t.Errorf("Expected 1 RPC call within the TTL, got %d", got)
+	}
+
+	clock.Advance(12 * time.Second)
+	if _, err := client.GetLatestBaseFee(context.Background(), 1); err != nil {
+		t.Fatalf("GetLatestBaseFee failed: %v", err)
+	}
+	if got := atomic.LoadInt32(calls); got != 2 {
+		t.Errorf("Expected a refresh after the TTL, got %d calls", got)
+	}
+}
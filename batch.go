@@ -0,0 +1,84 @@
+package infura
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// BatchError reports the chains that failed in a multi-chain operation
+// errors.Is and errors.As match against every per-chain error
+type BatchError struct {
+	failed map[int64]error
+	total  int
+}
+
+// newBatchError returns a *BatchError for the failed chains, or nil if none failed
+func newBatchError(failed map[int64]error, total int) error {
+	if len(failed) == 0 {
+		return nil
+	}
+	return &BatchError{failed: maps.Clone(failed), total: total}
+}
+
+// Failed returns the error of each failed chain, keyed by chain ID
+func (e *BatchError) Failed() map[int64]error {
+	return maps.Clone(e.failed)
+}
+
+// Error implements the error interface
+func (e *BatchError) Error() string {
+	parts := make([]string, 0, len(e.failed))
+	for _, chainID := range e.chainIDs() {
+		parts = append(parts, fmt.Sprintf("chain %d: %v", chainID, e.failed[chainID]))
+	}
+	return fmt.Sprintf("%d of %d chains failed: %s", len(e.failed), e.total, strings.Join(parts, "; "))
+}
+
+// Unwrap returns the per-chain errors ordered by chain ID
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, 0, len(e.failed))
+	for _, chainID := range e.chainIDs() {
+		errs = append(errs, e.failed[chainID])
+	}
+	return errs
+}
+
+func (e *BatchError) chainIDs() []int64 {
+	return slices.Sorted(maps.Keys(e.failed))
+}
+
+// GetSuggestedGasFeesBatch retrieves suggested gas fees for several chains concurrently
+// The result holds every chain that succeeded; if any chain failed, a *BatchError is
+// returned alongside it. Duplicate chain IDs are fetched once.
+// Call options apply to each chain's request separately.
+func (c *Client) GetSuggestedGasFeesBatch(ctx context.Context, chainIDs []int64, opts ...CallOption) (map[int64]*SuggestedGasFees, error) {
+	unique := slices.Compact(slices.Sorted(slices.Values(chainIDs)))
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results = make(map[int64]*SuggestedGasFees, len(unique))
+		failed  = make(map[int64]error)
+	)
+	for _, chainID := range unique {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fees, err := c.GetSuggestedGasFees(ctx, chainID, opts...)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failed[chainID] = err
+				return
+			}
+			results[chainID] = fees
+		}()
+	}
+	wg.Wait()
+
+	return results, newBatchError(failed, len(unique))
+}
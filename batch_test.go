@@ -0,0 +1,87 @@
+package infura
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGetSuggestedGasFeesBatch_PartialFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.Contains(r.URL.Path, "/networks/59144/"):
+			w.WriteHeader(http.StatusTooManyRequests)
+		case strings.Contains(r.URL.Path, "/networks/999/"):
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"estimatedBaseFee": "10"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL))
+
+	results, err := client.GetSuggestedGasFeesBatch(context.Background(), []int64{1, 59144, 137, 999, 1})
+
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("Expected *BatchError, got %v", err)
+	}
+	failed := batchErr.Failed()
+	if len(failed) != 2 || failed[59144] == nil || failed[999] == nil {
+		t.Fatalf("Expected failures for chains 59144 and 999 exactly, got %v", failed)
+	}
+	if !errors.Is(failed[59144], ErrRateLimited) {
+		t.Errorf("Expected chain 59144 to be rate limited, got %v", failed[59144])
+	}
+	if !errors.Is(err, ErrRateLimited) || !errors.Is(err, ErrNotFound) {
+		t.Error("Expected errors.Is to match per-chain errors through the batch error")
+	}
+	if errors.Is(err, ErrUnauthorized) {
+		t.Error("Expected errors.Is not to match errors no chain returned")
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Error("Expected errors.As to find an *APIError")
+	}
+
+	if len(results) != 2 || results[1] == nil || results[137] == nil {
+		t.Fatalf("Expected results for chains 1 and 137, got %v", results)
+	}
+	if results[1].EstimatedBaseFee != "10" {
+		t.Errorf("Expected estimated base fee 10, got %s", results[1].EstimatedBaseFee)
+	}
+	if !strings.Contains(err.Error(), "2 of 4 chains failed") {
+		t.Errorf("Unexpected error message: %v", err)
+	}
+}
+
+func TestGetSuggestedGasFeesBatch_AllSucceed(t *testing.T) {
+	server := newFeesServer(t, `{"estimatedBaseFee": "10"}`)
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL))
+
+	results, err := client.GetSuggestedGasFeesBatch(context.Background(), []int64{1, 10, 137})
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+	if len(results) != 3 {
+		t.Errorf("Expected 3 results, got %d", len(results))
+	}
+}
+
+func TestBatchError_FailedIsCopy(t *testing.T) {
+	err := newBatchError(map[int64]error{1: ErrServerError}, 2).(*BatchError)
+	err.Failed()[2] = ErrNotFound
+	if len(err.Failed()) != 1 {
+		t.Error("Expected Failed to return a copy")
+	}
+	if newBatchError(nil, 2) != nil {
+		t.Error("Expected nil error when no chain failed")
+	}
+}
@@ -0,0 +1,64 @@
+package infura
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// GetSuggestedGasFeesAtBlock retrieves suggested gas fees relative to a given block
+// block is "latest", "pending", a decimal block number ("19000000") or a hex block number ("0x121eac0")
+// The block is sent as the "block" query parameter of the suggestedGasFees endpoint. Infura
+// does not document this parameter; if the API ignores it, the result is the same as
+// GetSuggestedGasFees for the latest block.
+// Fallback fees and the sanity check do not apply, since both describe the latest block;
+// fee caps set with WithMaxFeeCap are still enforced.
+func (c *Client) GetSuggestedGasFeesAtBlock(ctx context.Context, chainID int64, block string, opts ...CallOption) (*SuggestedGasFees, error) {
+	if err := validateBlockTag(block); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := withCallOptions(ctx, opts)
+	defer cancel()
+
+	result, err := Get[SuggestedGasFees](ctx, c, chainID, "suggestedGasFees", url.Values{"block": {block}})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.applyFeeCap(ctx, chainID, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// validateBlockTag checks that block is "latest", "pending" or a decimal or 0x-prefixed hex block number
+func validateBlockTag(block string) error {
+	switch {
+	case block == "latest" || block == "pending":
+		return nil
+	case strings.HasPrefix(block, "0x") || strings.HasPrefix(block, "0X"):
+		if isHexDigits(block[2:]) {
+			return nil
+		}
+	case block != "" && isDigits(block):
+		return nil
+	}
+	return fmt.Errorf("invalid block %q: must be \"latest\", \"pending\" or a decimal or hex block number", block)
+}
+
+// isHexDigits reports whether s is a non-empty string of hex digits
+func isHexDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		if (ch < '0' || ch > '9') && (ch < 'a' || ch > 'f') && (ch < 'A' || ch > 'F') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,97 @@
+package infura
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetSuggestedGasFeesAtBlock_QueryEncoding(t *testing.T) {
+	var gotPath, gotQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotQuery = r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"estimatedBaseFee": "30"}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL))
+
+	tests := []struct {
+		block     string
+		wantQuery string
+	}{
+		{"latest", "block=latest"},
+		{"pending", "block=pending"},
+		{"19000000", "block=19000000"},
+		{"0", "block=0"},
+		{"0x121eac0", "block=0x121eac0"},
+		{"0X121EAC0", "block=0X121EAC0"},
+	}
+
+	for _, tt := range tests {
+		result, err := client.GetSuggestedGasFeesAtBlock(context.Background(), 1, tt.block)
+		if err != nil {
+			t.Errorf("block %q: unexpected error: %v", tt.block, err)
+			continue
+		}
+		if result.EstimatedBaseFee != "30" {
+			t.Errorf("block %q: unexpected result %+v", tt.block, result)
+		}
+		if gotPath != "/v3/test-api-key/networks/1/suggestedGasFees" {
+			t.Errorf("block %q: unexpected path %s", tt.block, gotPath)
+		}
+		if gotQuery != tt.wantQuery {
+			t.Errorf("block %q: expected query %q, got %q", tt.block, tt.wantQuery, gotQuery)
+		}
+	}
+}
+
+func TestGetSuggestedGasFeesAtBlock_InvalidBlock(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL))
+
+	for _, block := range []string{"", "Latest", "earliest", "0x", "0xg1", "-1", "+1", "1.5", "1e6", " 1", "1&x=y"} {
+		if _, err := client.GetSuggestedGasFeesAtBlock(context.Background(), 1, block); err == nil {
+			t.Errorf("block %q: expected error", block)
+		}
+	}
+	if requests != 0 {
+		t.Errorf("Expected invalid blocks to be rejected before any request, got %d requests", requests)
+	}
+}
+
+func TestGetSuggestedGasFeesAtBlock_CachedPerBlock(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL), WithCache(time.Minute))
+
+	ctx := context.Background()
+	for _, block := range []string{"0x1", "0x2", "0x1"} {
+		if _, err := client.GetSuggestedGasFeesAtBlock(ctx, 1, block); err != nil {
+			t.Fatalf("block %s: %v", block, err)
+		}
+	}
+	if _, err := client.GetSuggestedGasFees(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(queries) != 3 || queries[0] != "block=0x1" || queries[1] != "block=0x2" || queries[2] != "" {
+		t.Errorf("Expected one request per distinct block plus one for latest, got %q", queries)
+	}
+}
@@ -0,0 +1,56 @@
+package infura
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+)
+
+// WithBudgetFromContext enforces a request budget carried by the context of each call, e.g.
+// to give every inbound request of a server a quota of Infura calls shared by everything it
+// triggers. The budget is the *atomic.Int64 stored in the context under key:
+//
+//	var remaining atomic.Int64
+//	remaining.Store(5)
+//	ctx = context.WithValue(ctx, budgetKey{}, &remaining)
+//
+// Each request attempt, retries included, takes one unit before it is sent and gives it
+// back unless it gets a successful response (status below 400), so only successful
+// requests are counted. When the budget is not positive the call fails with
+// ErrBudgetExhausted without sending a request. Responses served from the cache are free.
+// A context without a value under key has no budget; a value of another type fails the
+// call. A nil key is invalid.
+func WithBudgetFromContext(key interface{}) ClientOption {
+	return func(c *Client) {
+		if key == nil {
+			c.rejectOption("WithBudgetFromContext", "key must not be nil")
+			return
+		}
+		c.budgetKey = key
+	}
+}
+
+// reserveBudget takes one unit from the budget of ctx and returns the budget to refund it
+// to, or nil if the call has no budget
+func (c *Client) reserveBudget(ctx context.Context) (*atomic.Int64, error) {
+	if c.budgetKey == nil {
+		return nil, nil
+	}
+	value := ctx.Value(c.budgetKey)
+	if value == nil {
+		return nil, nil
+	}
+	budget, ok := value.(*atomic.Int64)
+	if !ok || budget == nil {
+		return nil, fmt.Errorf("request budget in context has type %T, want a non-nil *atomic.Int64", value)
+	}
+	for {
+		remaining := budget.Load()
+		if remaining <= 0 {
+			return nil, ErrBudgetExhausted
+		}
+		if budget.CompareAndSwap(remaining, remaining-1) {
+			return budget, nil
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package infura
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type budgetKey struct{}
+
+func TestWithBudgetFromContext_Budgeted(t *testing.T) {
+	server, calls := newCountingServer(t)
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithBudgetFromContext(budgetKey{}))
+
+	var remaining atomic.Int64
+	remaining.Store(2)
+	ctx := context.WithValue(context.Background(), budgetKey{}, &remaining)
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetSuggestedGasFees(ctx, 1); err != nil {
+			t.Fatalf("GetSuggestedGasFees %d failed: %v", i, err)
+		}
+	}
+	if got := remaining.Load(); got != 0 {
+		t.Errorf("Expected the budget to be used up, %d left", got)
+	}
+	if _, err := client.GetSuggestedGasFees(ctx, 1); !errors.Is(err, ErrBudgetExhausted) {
+		t.Errorf("Expected ErrBudgetExhausted, got %v", err)
+	}
+	if got := atomic.LoadInt32(calls); got != 2 {
+		t.Errorf("Expected 2 requests, got %d", got)
+	}
+}
+
+func TestWithBudgetFromContext_Unbudgeted(t *testing.T) {
+	server, calls := newCountingServer(t)
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithBudgetFromContext(budgetKey{}))
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.GetSuggestedGasFees(context.Background(), 1); err != nil {
+			t.Fatalf("GetSuggestedGasFees %d failed: %v", i, err)
+		}
+	}
+	if got := atomic.LoadInt32(calls); got != 3 {
+		t.Errorf("Expected 3 requests, got %d", got)
+	}
+}
+
+func TestWithBudgetFromContext_FailuresAreRefunded(t *testing.T) {
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"estimatedBaseFee": "10"}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithRetry(2, time.Millisecond),
+		WithBudgetFromContext(budgetKey{}))
+
+	var remaining atomic.Int64
+	remaining.Store(1)
+	ctx := context.WithValue(context.Background(), budgetKey{}, &remaining)
+	if _, err := client.GetSuggestedGasFees(ctx, 1); err != nil {
+		t.Fatalf("GetSuggestedGasFees failed: %v", err)
+	}
+	if got := remaining.Load(); got != 0 {
+		t.Errorf("Expected only the successful attempt to be charged, %d left", got)
+	}
+}
+
+func TestWithBudgetFromContext_WrongType(t *testing.T) {
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBudgetFromContext(budgetKey{}))
+	ctx := context.WithValue(context.Background(), budgetKey{}, 5)
+	if _, err := client.GetSuggestedGasFees(ctx, 1); err == nil || errors.Is(err, ErrBudgetExhausted) {
+		t.Errorf("Expected a type error, got %v", err)
+	}
+}
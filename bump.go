@@ -0,0 +1,71 @@
+package infura
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// DefaultMinBumpPercent is the minimum fee increase most nodes require to replace a pending transaction
+const DefaultMinBumpPercent = 10
+
+// Bump returns the fee level to use for replacing a stuck transaction sent with current
+// Each of maxFeePerGas and maxPriorityFeePerGas is the greater of the fresh medium suggestion
+// and the current value raised by minBumpPercent (rounded up to the next wei), so both
+// satisfy the node's replacement rule. If the resulting tip exceeds the max fee, the max fee
+// is raised to the tip. The wait estimates are copied from the medium level.
+// The math is exact: fees are handled in wei and the percentage as a decimal fraction.
+func (f *SuggestedGasFees) Bump(current GasFeeLevel, minBumpPercent float64) (GasFeeLevel, error) {
+	if minBumpPercent < 0 || math.IsNaN(minBumpPercent) || math.IsInf(minBumpPercent, 0) {
+		return GasFeeLevel{}, fmt.Errorf("invalid bump percent: %v", minBumpPercent)
+	}
+	factor, ok := new(big.Rat).SetString(strconv.FormatFloat(minBumpPercent, 'f', -1, 64))
+	if !ok {
+		return GasFeeLevel{}, fmt.Errorf("invalid bump percent: %v", minBumpPercent)
+	}
+	// factor = 1 + minBumpPercent/100
+	factor.Quo(factor, big.NewRat(100, 1)).Add(factor, big.NewRat(1, 1))
+
+	maxFee, err := bumpFee("maxFeePerGas", current.SuggestedMaxFeePerGas, f.Medium.SuggestedMaxFeePerGas, factor)
+	if err != nil {
+		return GasFeeLevel{}, err
+	}
+	tip, err := bumpFee("maxPriorityFeePerGas", current.SuggestedMaxPriorityFeePerGas, f.Medium.SuggestedMaxPriorityFeePerGas, factor)
+	if err != nil {
+		return GasFeeLevel{}, err
+	}
+	if tip.Cmp(maxFee) > 0 {
+		maxFee = tip
+	}
+
+	return GasFeeLevel{
+		SuggestedMaxPriorityFeePerGas: formatWeiAsGwei(tip),
+		SuggestedMaxFeePerGas:         formatWeiAsGwei(maxFee),
+		MinWaitTimeEstimate:           f.Medium.MinWaitTimeEstimate,
+		MaxWaitTimeEstimate:           f.Medium.MaxWaitTimeEstimate,
+	}, nil
+}
+
+// bumpFee returns max(fresh, ceil(current * factor)) in wei
+func bumpFee(field, current, fresh string, factor *big.Rat) (*big.Int, error) {
+	currentWei, err := ParseGweiToWei(current)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse current %s: %w", field, err)
+	}
+	freshWei, err := ParseGweiToWei(fresh)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse suggested %s: %w", field, err)
+	}
+
+	bumped := new(big.Rat).Mul(new(big.Rat).SetInt(currentWei), factor)
+	minimum, remainder := new(big.Int).QuoRem(bumped.Num(), bumped.Denom(), new(big.Int))
+	if remainder.Sign() > 0 {
+		minimum.Add(minimum, big.NewInt(1))
+	}
+
+	if freshWei.Cmp(minimum) > 0 {
+		return freshWei, nil
+	}
+	return minimum, nil
+}
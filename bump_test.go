@@ -0,0 +1,117 @@
+package infura
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSuggestedGasFees_Bump(t *testing.T) {
+	fees := &SuggestedGasFees{
+		Medium: GasFeeLevel{
+			SuggestedMaxPriorityFeePerGas: "2",
+			SuggestedMaxFeePerGas:         "30",
+			MinWaitTimeEstimate:           15000,
+			MaxWaitTimeEstimate:           45000,
+		},
+	}
+
+	tests := []struct {
+		name    string
+		current GasFeeLevel
+		percent float64
+		wantTip string
+		wantMax string
+	}{
+		{
+			name:    "fresh suggestion exceeds the bump",
+			current: GasFeeLevel{SuggestedMaxPriorityFeePerGas: "1", SuggestedMaxFeePerGas: "20"},
+			percent: 10,
+			wantTip: "2",
+			wantMax: "30",
+		},
+		{
+			name:    "bump exceeds the fresh suggestion",
+			current: GasFeeLevel{SuggestedMaxPriorityFeePerGas: "3", SuggestedMaxFeePerGas: "40"},
+			percent: 10,
+			wantTip: "3.3",
+			wantMax: "44",
+		},
+		{
+			name:    "mixed: fresh max fee, bumped tip",
+			current: GasFeeLevel{SuggestedMaxPriorityFeePerGas: "1.9", SuggestedMaxFeePerGas: "25"},
+			percent: 10,
+			wantTip: "2.09",
+			wantMax: "30",
+		},
+		{
+			name:    "fresh equals the bump",
+			current: GasFeeLevel{SuggestedMaxPriorityFeePerGas: "1.6", SuggestedMaxFeePerGas: "24"},
+			percent: 25,
+			wantTip: "2",
+			wantMax: "30",
+		},
+		{
+			name:    "rounded up to the next wei",
+			current: GasFeeLevel{SuggestedMaxPriorityFeePerGas: "0.000000003", SuggestedMaxFeePerGas: "100.000000001"},
+			percent: 10,
+			wantTip: "2",
+			wantMax: "110.000000002",
+		},
+		{
+			name:    "fractional percent is exact",
+			current: GasFeeLevel{SuggestedMaxPriorityFeePerGas: "10", SuggestedMaxFeePerGas: "100"},
+			percent: 12.5,
+			wantTip: "11.25",
+			wantMax: "112.5",
+		},
+		{
+			name:    "zero bump",
+			current: GasFeeLevel{SuggestedMaxPriorityFeePerGas: "5", SuggestedMaxFeePerGas: "50"},
+			percent: 0,
+			wantTip: "5",
+			wantMax: "50",
+		},
+		{
+			name:    "tip above max fee raises max fee",
+			current: GasFeeLevel{SuggestedMaxPriorityFeePerGas: "40", SuggestedMaxFeePerGas: "20"},
+			percent: 10,
+			wantTip: "44",
+			wantMax: "44",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := fees.Bump(tt.current, tt.percent)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got.SuggestedMaxPriorityFeePerGas != tt.wantTip || got.SuggestedMaxFeePerGas != tt.wantMax {
+				t.Errorf("Expected tip %s / max %s, got tip %s / max %s",
+					tt.wantTip, tt.wantMax, got.SuggestedMaxPriorityFeePerGas, got.SuggestedMaxFeePerGas)
+			}
+			if got.MinWaitTimeEstimate != 15000 || got.MaxWaitTimeEstimate != 45000 {
+				t.Errorf("Expected medium wait estimates, got %d-%d", got.MinWaitTimeEstimate, got.MaxWaitTimeEstimate)
+			}
+		})
+	}
+}
+
+func TestSuggestedGasFees_Bump_Invalid(t *testing.T) {
+	fees := &SuggestedGasFees{
+		Medium: GasFeeLevel{SuggestedMaxPriorityFeePerGas: "2", SuggestedMaxFeePerGas: "30"},
+	}
+	valid := GasFeeLevel{SuggestedMaxPriorityFeePerGas: "1", SuggestedMaxFeePerGas: "20"}
+
+	for _, percent := range []float64{-1, math.NaN(), math.Inf(1)} {
+		if _, err := fees.Bump(valid, percent); err == nil {
+			t.Errorf("percent %v: expected error", percent)
+		}
+	}
+	if _, err := fees.Bump(GasFeeLevel{SuggestedMaxPriorityFeePerGas: "1", SuggestedMaxFeePerGas: "abc"}, 10); err == nil {
+		t.Error("Expected error for unparseable current fee")
+	}
+	if _, err := (&SuggestedGasFees{}).Bump(valid, 10); err == nil {
+		t.Error("Expected error for missing fresh suggestion")
+	}
+}
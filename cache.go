@@ -0,0 +1,106 @@
+package infura
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// responseCache stores the last successful raw response per (resource, chain)
+type responseCache struct {
+	mu      sync.RWMutex
+	entries map[string]cacheEntry
+}
+
+// cacheEntry is a cached raw JSON response and the time it was fetched
+type cacheEntry struct {
+	body      []byte
+	fetchedAt time.Time
+}
+
+// cacheKey returns the cache key of a per-network resource
+func cacheKey(chainID int64, resource string) string {
+	return fmt.Sprintf("%d/%s", chainID, resource)
+}
+
+func (rc *responseCache) get(key string) (cacheEntry, bool) {
+	rc.mu.RLock()
+	defer rc.mu.RUnlock()
+	entry, ok := rc.entries[key]
+	return entry, ok
+}
+
+func (rc *responseCache) set(key string, entry cacheEntry) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.entries[key] = entry
+}
+
+// WithCache enables in-memory caching of successful responses for the given TTL
+// Cached responses are keyed by endpoint and chain ID and shared by all goroutines
+func WithCache(ttl time.Duration) ClientOption {
+	return func(c *Client) {
+		c.cacheTTL = ttl
+		if c.cache == nil {
+			c.cache = &responseCache{entries: make(map[string]cacheEntry)}
+		}
+	}
+}
+
+// WithServeStaleOnError makes cache-enabled clients return the last good response when a
+// refresh fails with a retryable error (rate limiting, 5xx or a transport failure), as long
+// as that response was fetched no more than maxStaleAge ago. Older entries are not served
+// and the error is returned. Stale results are flagged via CallMeta.Stale and CallMeta.FetchedAt
+// This option has no effect unless WithCache is also used
+func WithServeStaleOnError(maxStaleAge time.Duration) ClientOption {
+	return func(c *Client) {
+		c.maxStaleAge = maxStaleAge
+	}
+}
+
+// getCachedNetworkResource serves a per-network resource through the response cache
+func (c *Client) getCachedNetworkResource(ctx context.Context, creds *credentials, settings requestSettings, chainID int64, resource string, result interface{}) error {
+	key := cacheKey(chainID, resource)
+	now := c.clock.Now()
+
+	entry, cached := c.cache.get(key)
+	if cached && now.Sub(entry.fetchedAt) < settings.cacheTTL {
+		recordCallMeta(ctx, func(meta *CallMeta) {
+			meta.Cached = true
+			meta.FetchedAt = entry.fetchedAt
+		})
+		return c.decodeCachedBody(entry.body, result)
+	}
+
+	raw, err := c.fetchNetworkResourceRaw(ctx, creds, settings, chainID, resource)
+	if err != nil {
+		if cached && c.maxStaleAge > 0 && isRetryable(err) && ctx.Err() == nil &&
+			now.Sub(entry.fetchedAt) <= c.maxStaleAge {
+			recordCallMeta(ctx, func(meta *CallMeta) {
+				meta.Stale = true
+				meta.FetchedAt = entry.fetchedAt
+			})
+			return c.decodeCachedBody(entry.body, result)
+		}
+		return err
+	}
+
+	fetchedAt := c.clock.Now()
+	c.cache.set(key, cacheEntry{body: raw, fetchedAt: fetchedAt})
+	recordCallMeta(ctx, func(meta *CallMeta) {
+		meta.FetchedAt = fetchedAt
+	})
+	return c.decodeCachedBody(raw, result)
+}
+
+// decodeCachedBody decodes a raw cached response into result
+func (c *Client) decodeCachedBody(body []byte, result interface{}) error {
+	if result == nil {
+		return nil
+	}
+	if err := c.decoder().Unmarshal(body, result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return c.checkPrecision(result)
+}
@@ -0,0 +1,161 @@
+package infura
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newSwitchableServer returns a mock server that fails with 503 while failing is set and
+// otherwise serves a busyThreshold equal to the number of successful responses so far
+func newSwitchableServer(t *testing.T, failing *atomic.Bool) (*httptest.Server, *int32) {
+	t.Helper()
+	var calls, successes int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		n := atomic.AddInt32(&successes, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"busyThreshold": "%d"}`, n)
+	}))
+	return server, &calls
+}
+
+func TestWithCache(t *testing.T) {
+	var failing atomic.Bool
+	server, calls := newSwitchableServer(t, &failing)
+	defer server.Close()
+
+	clock := newFakeClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithClock(clock),
+		WithCache(10*time.Second))
+
+	ctx := context.Background()
+	first, err := client.GetBusyThreshold(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetBusyThreshold failed: %v", err)
+	}
+
+	clock.Advance(5 * time.Second)
+	var meta CallMeta
+	second, err := client.GetBusyThreshold(ContextWithCallMeta(ctx, &meta), 1)
+	if err != nil {
+		t.Fatalf("GetBusyThreshold failed: %v", err)
+	}
+	if second.BusyThreshold != first.BusyThreshold {
+		t.Errorf("Expected cached value %s, got %s", first.BusyThreshold, second.BusyThreshold)
+	}
+	if !meta.Cached {
+		t.Error("Expected CallMeta.Cached to be true within the TTL")
+	}
+	if got := atomic.LoadInt32(calls); got != 1 {
+		t.Errorf("Expected 1 upstream request within the TTL, got %d", got)
+	}
+
+	// Different chains are cached independently
+	if _, err := client.GetBusyThreshold(ctx, 137); err != nil {
+		t.Fatalf("GetBusyThreshold failed: %v", err)
+	}
+
+	clock.Advance(10 * time.Second)
+	third, err := client.GetBusyThreshold(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetBusyThreshold failed: %v", err)
+	}
+	if third.BusyThreshold != "3" {
+		t.Errorf("Expected a refreshed value after TTL expiry, got %s", third.BusyThreshold)
+	}
+}
+
+func TestWithServeStaleOnError(t *testing.T) {
+	var failing atomic.Bool
+	server, _ := newSwitchableServer(t, &failing)
+	defer server.Close()
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := newFakeClock(start)
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithClock(clock),
+		WithCache(10*time.Second),
+		WithServeStaleOnError(2*time.Minute))
+
+	ctx := context.Background()
+	if _, err := client.GetBusyThreshold(ctx, 1); err != nil {
+		t.Fatalf("GetBusyThreshold failed: %v", err)
+	}
+
+	// Upstream fails after the TTL: the stale entry is served and flagged
+	failing.Store(true)
+	clock.Advance(time.Minute)
+	var meta CallMeta
+	stale, err := client.GetBusyThreshold(ContextWithCallMeta(ctx, &meta), 1)
+	if err != nil {
+		t.Fatalf("Expected stale value, got error: %v", err)
+	}
+	if stale.BusyThreshold != "1" {
+		t.Errorf("Expected stale busyThreshold 1, got %s", stale.BusyThreshold)
+	}
+	if !meta.Stale {
+		t.Error("Expected CallMeta.Stale to be true")
+	}
+	if !meta.FetchedAt.Equal(start) {
+		t.Errorf("Expected FetchedAt %v, got %v", start, meta.FetchedAt)
+	}
+
+	// Beyond maxStaleAge the error is propagated
+	clock.Advance(90 * time.Second)
+	if _, err := client.GetBusyThreshold(ctx, 1); !errors.Is(err, ErrServerError) {
+		t.Fatalf("Expected ErrServerError once the entry is too old, got %v", err)
+	}
+
+	// A later success replaces the stale entry
+	failing.Store(false)
+	var freshMeta CallMeta
+	fresh, err := client.GetBusyThreshold(ContextWithCallMeta(ctx, &freshMeta), 1)
+	if err != nil {
+		t.Fatalf("GetBusyThreshold failed: %v", err)
+	}
+	if fresh.BusyThreshold != "2" || freshMeta.Stale {
+		t.Errorf("Expected fresh busyThreshold 2, got %s (stale=%v)", fresh.BusyThreshold, freshMeta.Stale)
+	}
+
+	failing.Store(true)
+	clock.Advance(time.Minute)
+	stale, err = client.GetBusyThreshold(ctx, 1)
+	if err != nil {
+		t.Fatalf("Expected stale value, got error: %v", err)
+	}
+	if stale.BusyThreshold != "2" {
+		t.Errorf("Expected the replaced entry to be served, got %s", stale.BusyThreshold)
+	}
+}
+
+func TestWithServeStaleOnError_RequiresCache(t *testing.T) {
+	var failing atomic.Bool
+	server, _ := newSwitchableServer(t, &failing)
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithServeStaleOnError(time.Hour))
+
+	if _, err := client.GetBusyThreshold(context.Background(), 1); err != nil {
+		t.Fatalf("GetBusyThreshold failed: %v", err)
+	}
+	failing.Store(true)
+	if _, err := client.GetBusyThreshold(context.Background(), 1); err == nil {
+		t.Fatal("Expected error without a cache")
+	}
+}
@@ -0,0 +1,148 @@
+package infura
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// AddressLength is the length of an Ethereum address in bytes
+const AddressLength = 20
+
+// Address is an Ethereum account or contract address
+// It is encoded in JSON and text as a 0x-prefixed, lowercase hex string.
+type Address [AddressLength]byte
+
+// ParseAddress decodes a 0x-prefixed hex address of exactly 20 bytes (40 hex digits)
+// Mixed-case (checksummed) addresses are accepted; the checksum is not verified
+func ParseAddress(s string) (Address, error) {
+	var a Address
+	digits, ok := strings.CutPrefix(s, "0x")
+	if !ok {
+		digits, ok = strings.CutPrefix(s, "0X")
+	}
+	if !ok {
+		return a, fmt.Errorf("invalid address %q: missing 0x prefix", s)
+	}
+	if len(digits) != 2*AddressLength {
+		return a, fmt.Errorf("invalid address %q: must be %d bytes (%d hex digits), got %d hex digits", s, AddressLength, 2*AddressLength, len(digits))
+	}
+	if _, err := hex.Decode(a[:], []byte(digits)); err != nil {
+		return Address{}, fmt.Errorf("invalid address %q: %w", s, err)
+	}
+	return a, nil
+}
+
+// Hex returns the address as a 0x-prefixed, lowercase hex string
+func (a Address) Hex() string {
+	return "0x" + hex.EncodeToString(a[:])
+}
+
+// String returns the address like Hex
+func (a Address) String() string {
+	return a.Hex()
+}
+
+// MarshalText encodes the address like Hex
+func (a Address) MarshalText() ([]byte, error) {
+	return []byte(a.Hex()), nil
+}
+
+// UnmarshalText decodes an address with ParseAddress
+func (a *Address) UnmarshalText(text []byte) error {
+	parsed, err := ParseAddress(string(text))
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
+
+// EncodeHexQuantity encodes a non-negative integer as an Ethereum JSON-RPC hex quantity
+// ("0x0", "0x1a2b"): lowercase, without leading zeros. nil encodes as "0x0".
+func EncodeHexQuantity(v *big.Int) (string, error) {
+	if v != nil && v.Sign() < 0 {
+		return "", fmt.Errorf("hex quantity must not be negative, got %s", v)
+	}
+	return formatHexQuantity(v), nil
+}
+
+// DecodeHexQuantity decodes an Ethereum JSON-RPC hex quantity such as "0x1a2b"
+func DecodeHexQuantity(s string) (*big.Int, error) {
+	return parseHexQuantity(s)
+}
+
+// EncodeHexData encodes bytes as Ethereum JSON-RPC hex data: "0x" followed by two
+// lowercase hex digits per byte ("0x" for no data)
+func EncodeHexData(data []byte) string {
+	return "0x" + hex.EncodeToString(data)
+}
+
+// DecodeHexData decodes Ethereum JSON-RPC hex data, which must be 0x-prefixed with an
+// even number of hex digits
+func DecodeHexData(s string) ([]byte, error) {
+	digits, ok := strings.CutPrefix(s, "0x")
+	if !ok {
+		digits, ok = strings.CutPrefix(s, "0X")
+	}
+	if !ok {
+		return nil, fmt.Errorf("invalid hex data %q: missing 0x prefix", s)
+	}
+	data, err := hex.DecodeString(digits)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex data: %w", err)
+	}
+	return data, nil
+}
+
+// CallMsg is the transaction call object of eth_call and eth_estimateGas
+// Nil and empty fields are omitted from the JSON encoding; a nil To is a contract creation.
+type CallMsg struct {
+	From     *Address
+	To       *Address
+	Gas      *big.Int
+	GasPrice *big.Int
+	Value    *big.Int
+	Data     []byte
+}
+
+// rpcCallMsg is the JSON-RPC encoding of CallMsg
+type rpcCallMsg struct {
+	From     *Address `json:"from,omitempty"`
+	To       *Address `json:"to,omitempty"`
+	Gas      string   `json:"gas,omitempty"`
+	GasPrice string   `json:"gasPrice,omitempty"`
+	Value    string   `json:"value,omitempty"`
+	Data     string   `json:"data,omitempty"`
+}
+
+// MarshalJSON encodes the message with hex quantities and hex data as Ethereum expects
+// Negative quantities are rejected.
+func (m CallMsg) MarshalJSON() ([]byte, error) {
+	raw := rpcCallMsg{From: m.From, To: m.To}
+	quantities := []struct {
+		name  string
+		value *big.Int
+		out   *string
+	}{
+		{name: "gas", value: m.Gas, out: &raw.Gas},
+		{name: "gasPrice", value: m.GasPrice, out: &raw.GasPrice},
+		{name: "value", value: m.Value, out: &raw.Value},
+	}
+	for _, q := range quantities {
+		if q.value == nil {
+			continue
+		}
+		encoded, err := EncodeHexQuantity(q.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", q.name, err)
+		}
+		*q.out = encoded
+	}
+	if len(m.Data) > 0 {
+		raw.Data = EncodeHexData(m.Data)
+	}
+	return json.Marshal(raw)
+}
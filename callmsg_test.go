@@ -0,0 +1,142 @@
+package infura
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func mustParseAddress(t *testing.T, s string) *Address {
+	t.Helper()
+	a, err := ParseAddress(s)
+	if err != nil {
+		t.Fatalf("Failed to parse address %q: %v", s, err)
+	}
+	return &a
+}
+
+func TestCallMsg_MarshalJSON(t *testing.T) {
+	gwei30, _ := new(big.Int).SetString("30000000000", 10)
+	oneEther, _ := new(big.Int).SetString("1000000000000000000", 10)
+	msg := CallMsg{
+		From:     mustParseAddress(t, "0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045"),
+		To:       mustParseAddress(t, "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"),
+		Gas:      big.NewInt(21000),
+		GasPrice: gwei30,
+		Value:    oneEther,
+		Data:     []byte{0xa9, 0x05, 0x9c, 0xbb, 0x00},
+	}
+
+	got, err := json.Marshal(msg)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	want := `{"from":"0xd8da6bf26964af9d7eed9e03e53415d37aa96045","to":"0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48",` +
+		`"gas":"0x5208","gasPrice":"0x6fc23ac00","value":"0xde0b6b3a7640000","data":"0xa9059cbb00"}`
+	if string(got) != want {
+		t.Errorf("Expected\n%s\ngot\n%s", want, got)
+	}
+}
+
+func TestCallMsg_MarshalJSONOmitsEmptyFields(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  CallMsg
+		want string
+	}{
+		{name: "empty", msg: CallMsg{}, want: `{}`},
+		{name: "zero value kept", msg: CallMsg{Value: new(big.Int), Data: []byte{}}, want: `{"value":"0x0"}`},
+		{name: "contract creation", msg: CallMsg{Data: []byte{0x60, 0x80}}, want: `{"data":"0x6080"}`},
+	}
+	for _, tt := range tests {
+		got, err := json.Marshal(tt.msg)
+		if err != nil {
+			t.Fatalf("%s: Marshal failed: %v", tt.name, err)
+		}
+		if string(got) != tt.want {
+			t.Errorf("%s: expected %s, got %s", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestCallMsg_MarshalJSONNegative(t *testing.T) {
+	_, err := json.Marshal(CallMsg{Value: big.NewInt(-1)})
+	if err == nil || !strings.Contains(err.Error(), "invalid value") {
+		t.Errorf("Expected an invalid value error, got %v", err)
+	}
+}
+
+func TestParseAddress(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr string
+	}{
+		{input: "0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045", want: "0xd8da6bf26964af9d7eed9e03e53415d37aa96045"},
+		{input: "0X0000000000000000000000000000000000000000", want: "0x0000000000000000000000000000000000000000"},
+		{input: "d8dA6BF26964aF9D7eEd9e03E53415D37aA96045", wantErr: "missing 0x prefix"},
+		{input: "0xd8dA6BF26964aF9D7eEd9e03E53415D37aA960", wantErr: "got 38 hex digits"},
+		{input: "0xd8dA6BF26964aF9D7eEd9e03E53415D37aA9604500", wantErr: "got 42 hex digits"},
+		{input: "0xzz8dA6BF26964aF9D7eEd9e03E53415D37aA9604", wantErr: "invalid byte"},
+	}
+	for _, tt := range tests {
+		got, err := ParseAddress(tt.input)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected an error containing %q for %q, got %v", tt.wantErr, tt.input, err)
+			}
+			continue
+		}
+		if err != nil || got.Hex() != tt.want {
+			t.Errorf("Expected %s for %q, got %s, %v", tt.want, tt.input, got, err)
+		}
+	}
+}
+
+func TestAddress_JSON(t *testing.T) {
+	var decoded struct {
+		To Address `json:"to"`
+	}
+	if err := json.Unmarshal([]byte(`{"to":"0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"}`), &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if decoded.To.Hex() != "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48" {
+		t.Errorf("Expected the lowercase address, got %s", decoded.To)
+	}
+	if err := json.Unmarshal([]byte(`{"to":"0x1234"}`), &decoded); err == nil {
+		t.Error("Expected an error for a short address, got nil")
+	}
+}
+
+func TestHexEncoding(t *testing.T) {
+	for v, want := range map[int64]string{0: "0x0", 1: "0x1", 255: "0xff", 21000: "0x5208"} {
+		got, err := EncodeHexQuantity(big.NewInt(v))
+		if err != nil || got != want {
+			t.Errorf("Expected %q for %d, got %q, %v", want, v, got, err)
+		}
+		back, err := DecodeHexQuantity(got)
+		if err != nil || back.Int64() != v {
+			t.Errorf("Expected %q to decode to %d, got %v, %v", got, v, back, err)
+		}
+	}
+	if got, _ := EncodeHexQuantity(nil); got != "0x0" {
+		t.Errorf("Expected 0x0 for nil, got %q", got)
+	}
+	if _, err := EncodeHexQuantity(big.NewInt(-5)); err == nil {
+		t.Error("Expected an error for a negative quantity, got nil")
+	}
+
+	if got := EncodeHexData(nil); got != "0x" {
+		t.Errorf("Expected 0x for nil data, got %q", got)
+	}
+	data, err := DecodeHexData("0xA9059cbb")
+	if err != nil || EncodeHexData(data) != "0xa9059cbb" {
+		t.Errorf("Expected a9059cbb, got %x, %v", data, err)
+	}
+	for _, invalid := range []string{"a9059cbb", "0xa90", "0xzz"} {
+		if _, err := DecodeHexData(invalid); err == nil {
+			t.Errorf("Expected an error for %q, got nil", invalid)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package infura
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// CallOption configures a single API call, overriding chain and client settings
+type CallOption func(*callOptions)
+
+// callOptions holds the per-call settings
+type callOptions struct {
+	timeout time.Duration
+	headers http.Header
+}
+
+// WithCallTimeout bounds a single call, including retries, to timeout
+// The call runs with a child context, so a shorter deadline already set by the caller still
+// applies and the caller's context is not affected. It takes precedence over the chain
+// (WithChainTimeout) and client (WithTimeout) timeouts, which no longer bound each attempt.
+func WithCallTimeout(timeout time.Duration) CallOption {
+	return func(o *callOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithCallHeader sets a header on the Gas API requests of a single call, e.g. a tenant ID
+// It replaces a client header of the same name set with WithHeader; headers with other
+// names are merged. Helpers issuing several requests send it on each of them.
+func WithCallHeader(key, value string) CallOption {
+	return func(o *callOptions) {
+		if o.headers == nil {
+			o.headers = make(http.Header)
+		}
+		o.headers.Set(key, value)
+	}
+}
+
+type callHeadersKey struct{}
+
+type callTimeoutKey struct{}
+
+// callTimeout returns the per-call timeout attached to ctx, or 0
+func callTimeout(ctx context.Context) time.Duration {
+	timeout, _ := ctx.Value(callTimeoutKey{}).(time.Duration)
+	return timeout
+}
+
+// callHeaders returns the per-call headers attached to ctx, if any
+func callHeaders(ctx context.Context) http.Header {
+	headers, _ := ctx.Value(callHeadersKey{}).(http.Header)
+	return headers
+}
+
+// withCallOptions derives the context for a call with the given options
+// The returned cancel function must be called when the call returns
+func withCallOptions(ctx context.Context, opts []CallOption) (context.Context, context.CancelFunc) {
+	var options callOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if options.headers != nil {
+		// Headers of an enclosing call are kept unless overridden
+		headers := callHeaders(ctx).Clone()
+		if headers == nil {
+			headers = make(http.Header, len(options.headers))
+		}
+		for key, values := range options.headers {
+			headers[key] = values
+		}
+		ctx = context.WithValue(ctx, callHeadersKey{}, headers)
+	}
+
+	if options.timeout > 0 {
+		ctx = context.WithValue(ctx, callTimeoutKey{}, options.timeout)
+		return context.WithTimeout(ctx, options.timeout)
+	}
+	return ctx, func() {}
+}
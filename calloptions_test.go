@@ -0,0 +1,171 @@
+package infura
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newDeadlineClient returns a client whose transport reports the deadline of every request context
+func newDeadlineClient(deadlines chan<- time.Time) *Client {
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		deadline, _ := req.Context().Deadline()
+		deadlines <- deadline
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{}`)),
+			Request:    req,
+		}, nil
+	})
+	return NewClientWithAPIKeyAndOptions("test-api-key", WithTransport(transport))
+}
+
+func TestWithCallTimeout_Deadline(t *testing.T) {
+	deadlines := make(chan time.Time, 1)
+	client := newDeadlineClient(deadlines)
+
+	start := time.Now()
+	if _, err := client.GetSuggestedGasFees(context.Background(), 1, WithCallTimeout(2*time.Second)); err != nil {
+		t.Fatalf("GetSuggestedGasFees failed: %v", err)
+	}
+	deadline := <-deadlines
+	if deadline.IsZero() {
+		t.Fatal("Expected the request context to have a deadline")
+	}
+	if d := deadline.Sub(start); d < 2*time.Second || d > 2*time.Second+500*time.Millisecond {
+		t.Errorf("Expected a deadline about 2s away, got %v", d)
+	}
+
+	// Without the option only the client timeout applies
+	start = time.Now()
+	if _, err := client.GetBusyThreshold(context.Background(), 1); err != nil {
+		t.Fatalf("GetBusyThreshold failed: %v", err)
+	}
+	if d := (<-deadlines).Sub(start); d < DefaultTimeout || d > DefaultTimeout+500*time.Millisecond {
+		t.Errorf("Expected the client timeout without WithCallTimeout, got a deadline %v away", d)
+	}
+}
+
+func TestWithCallTimeout_KeepsShorterCallerDeadline(t *testing.T) {
+	deadlines := make(chan time.Time, 1)
+	client := newDeadlineClient(deadlines)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	callerDeadline, _ := ctx.Deadline()
+
+	if _, err := client.GetBaseFeePercentile(ctx, 1, WithCallTimeout(time.Minute)); err != nil {
+		t.Fatalf("GetBaseFeePercentile failed: %v", err)
+	}
+	if deadline := <-deadlines; !deadline.Equal(callerDeadline) {
+		t.Errorf("Expected the shorter caller deadline %v, got %v", callerDeadline, deadline)
+	}
+}
+
+func TestWithCallTimeout_DoesNotAffectCallerContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(200 * time.Millisecond):
+		case <-r.Context().Done():
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL))
+
+	ctx := context.Background()
+	_, err := client.GetSuggestedGasFees(ctx, 1, WithCallTimeout(20*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if ctx.Err() != nil {
+		t.Errorf("Expected the caller's context to be unaffected, got %v", ctx.Err())
+	}
+
+	if _, err := client.GetSuggestedGasFees(ctx, 1); err != nil {
+		t.Errorf("Expected a call without the option to succeed, got %v", err)
+	}
+}
+
+func TestWithCallHeader(t *testing.T) {
+	type seen struct{ tenant, gateway, accept string }
+	requests := make(chan seen, 4)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests <- seen{r.Header.Get("X-Tenant"), r.Header.Get("X-Gateway"), r.Header.Get("Accept")}
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/baseFeeHistory") {
+			w.Write([]byte(`[]`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithHeader("X-Tenant", "default"),
+		WithHeader("X-Gateway", "edge-1"),
+	)
+
+	if _, err := client.GetSuggestedGasFees(context.Background(), 1, WithCallHeader("x-tenant", "acme")); err != nil {
+		t.Fatalf("GetSuggestedGasFees failed: %v", err)
+	}
+	if got := <-requests; got != (seen{"acme", "edge-1", "application/json"}) {
+		t.Errorf("Expected the per-call tenant merged with client headers, got %+v", got)
+	}
+
+	// The next call is not affected
+	if _, err := client.GetBusyThreshold(context.Background(), 1); err != nil {
+		t.Fatalf("GetBusyThreshold failed: %v", err)
+	}
+	if got := <-requests; got.tenant != "default" {
+		t.Errorf("Expected the client tenant header, got %q", got.tenant)
+	}
+
+	// Helpers send the header on every request they issue
+	if _, err := client.GetBaseFeeSnapshot(context.Background(), 1, WithCallHeader("X-Tenant", "globex")); err != nil {
+		t.Fatalf("GetBaseFeeSnapshot failed: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if got := <-requests; got.tenant != "globex" {
+			t.Errorf("Expected the per-call tenant on snapshot request %d, got %q", i, got.tenant)
+		}
+	}
+}
+
+func TestWithCallHeader_ConcurrentTenants(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"busyThreshold":%q}`, r.Header.Get("X-Tenant"))
+	}))
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(tenant string) {
+			defer wg.Done()
+			threshold, err := client.GetBusyThreshold(context.Background(), 1, WithCallHeader("X-Tenant", tenant))
+			if err != nil {
+				t.Errorf("GetBusyThreshold failed: %v", err)
+				return
+			}
+			if threshold.BusyThreshold != tenant {
+				t.Errorf("Expected tenant %q, got %q", tenant, threshold.BusyThreshold)
+			}
+		}(fmt.Sprintf("tenant-%d", i))
+	}
+	wg.Wait()
+}
@@ -0,0 +1,65 @@
+package infura
+
+import (
+	"maps"
+	"slices"
+)
+
+// NativeCurrency describes the native currency of a chain
+type NativeCurrency struct {
+	Name     string
+	Symbol   string
+	Decimals int
+}
+
+// ChainInfo is the metadata of a chain supported by the Infura Gas API
+type ChainInfo struct {
+	ID             int64
+	Name           string
+	NativeCurrency NativeCurrency
+	Testnet        bool
+}
+
+var ether = NativeCurrency{Name: "Ether", Symbol: "ETH", Decimals: 18}
+
+// genericCurrency labels the native currency of chains missing from the registry
+var genericCurrency = NativeCurrency{Name: "Native Token", Symbol: "NATIVE", Decimals: 18}
+
+// chainRegistry holds the metadata of known chains by ID
+var chainRegistry = map[int64]ChainInfo{
+	1:        {ID: 1, Name: "Ethereum Mainnet", NativeCurrency: ether},
+	10:       {ID: 10, Name: "OP Mainnet", NativeCurrency: ether},
+	25:       {ID: 25, Name: "Cronos", NativeCurrency: NativeCurrency{Name: "Cronos", Symbol: "CRO", Decimals: 18}},
+	56:       {ID: 56, Name: "BNB Smart Chain", NativeCurrency: NativeCurrency{Name: "BNB", Symbol: "BNB", Decimals: 18}},
+	137:      {ID: 137, Name: "Polygon", NativeCurrency: NativeCurrency{Name: "POL", Symbol: "POL", Decimals: 18}},
+	250:      {ID: 250, Name: "Fantom Opera", NativeCurrency: NativeCurrency{Name: "Fantom", Symbol: "FTM", Decimals: 18}},
+	324:      {ID: 324, Name: "zkSync Era", NativeCurrency: ether},
+	8453:     {ID: 8453, Name: "Base", NativeCurrency: ether},
+	42161:    {ID: 42161, Name: "Arbitrum One", NativeCurrency: ether},
+	42220:    {ID: 42220, Name: "Celo", NativeCurrency: NativeCurrency{Name: "Celo", Symbol: "CELO", Decimals: 18}},
+	43114:    {ID: 43114, Name: "Avalanche C-Chain", NativeCurrency: NativeCurrency{Name: "Avalanche", Symbol: "AVAX", Decimals: 18}},
+	59144:    {ID: 59144, Name: "Linea", NativeCurrency: ether},
+	59141:    {ID: 59141, Name: "Linea Sepolia", NativeCurrency: ether, Testnet: true},
+	11155111: {ID: 11155111, Name: "Sepolia", NativeCurrency: ether, Testnet: true},
+}
+
+// LookupChain returns the metadata of a known chain
+func LookupChain(chainID int64) (ChainInfo, bool) {
+	info, ok := chainRegistry[chainID]
+	return info, ok
+}
+
+// KnownChains returns the IDs of all chains in the registry in ascending order
+func KnownChains() []int64 {
+	return slices.Sorted(maps.Keys(chainRegistry))
+}
+
+// NativeCurrencyOf returns the name and symbol of a chain's native currency, such as
+// "POL"/"POL" for Polygon, or "Native Token"/"NATIVE" for chains missing from the registry
+func NativeCurrencyOf(chainID int64) (name, symbol string) {
+	currency := genericCurrency
+	if info, ok := LookupChain(chainID); ok {
+		currency = info.NativeCurrency
+	}
+	return currency.Name, currency.Symbol
+}
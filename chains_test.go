@@ -0,0 +1,57 @@
+package infura
+
+import "testing"
+
+func TestLookupChain(t *testing.T) {
+	info, ok := LookupChain(137)
+	if !ok {
+		t.Fatal("Expected chain 137 to be known")
+	}
+	if info.ID != 137 || info.NativeCurrency.Symbol != "POL" || info.NativeCurrency.Decimals != 18 {
+		t.Errorf("Unexpected chain info: %+v", info)
+	}
+
+	if info, ok := LookupChain(11155111); !ok || !info.Testnet {
+		t.Errorf("Expected Sepolia to be a known testnet, got %+v (%v)", info, ok)
+	}
+	if _, ok := LookupChain(999999); ok {
+		t.Error("Expected unknown chain to be missing")
+	}
+}
+
+func TestKnownChains(t *testing.T) {
+	chains := KnownChains()
+	if len(chains) == 0 || chains[0] != 1 {
+		t.Fatalf("Expected Ethereum first, got %v", chains)
+	}
+	for i := 1; i < len(chains); i++ {
+		if chains[i] <= chains[i-1] {
+			t.Fatalf("Expected ascending chain IDs, got %v", chains)
+		}
+	}
+	for _, id := range chains {
+		if info, _ := LookupChain(id); info.ID != id || info.Name == "" {
+			t.Errorf("Inconsistent registry entry for %d: %+v", id, info)
+		}
+	}
+}
+
+func TestNativeCurrencyOf(t *testing.T) {
+	tests := []struct {
+		chainID            int64
+		wantName, wantSymb string
+	}{
+		{1, "Ether", "ETH"},
+		{137, "POL", "POL"},
+		{56, "BNB", "BNB"},
+		{43114, "Avalanche", "AVAX"},
+		{8453, "Ether", "ETH"},
+		{999999, "Native Token", "NATIVE"},
+	}
+	for _, tt := range tests {
+		name, symbol := NativeCurrencyOf(tt.chainID)
+		if name != tt.wantName || symbol != tt.wantSymb {
+			t.Errorf("Chain %d: expected %s/%s, got %s/%s", tt.chainID, tt.wantName, tt.wantSymb, name, symbol)
+		}
+	}
+}
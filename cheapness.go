@@ -0,0 +1,110 @@
+package infura
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// DefaultCheapPercentile is the percentile rank below which GetGasCheapness reports gas as
+// cheap: the current base fee is lower than about a quarter of the recent history
+const DefaultCheapPercentile = 25.0
+
+// Cheapness locates the current base fee within the recent base fee history
+type Cheapness struct {
+	// EstimatedBaseFee is the current estimated base fee in Gwei, from suggestedGasFees
+	EstimatedBaseFee string
+	// PercentileRank is the share of the history, from 0 to 100, below the current base fee;
+	// history entries equal to it count as half below, so a flat history ranks it at 50
+	PercentileRank float64
+	// Threshold is the percentile rank below which gas counts as cheap
+	Threshold float64
+	// IsCheap reports whether PercentileRank is below Threshold
+	IsCheap bool
+	// HistorySize is the number of history entries the rank was computed from
+	HistorySize int
+}
+
+// GetGasCheapness reports whether gas is cheap relative to recent history, using
+// DefaultCheapPercentile as the threshold. See GetGasCheapnessWith.
+func (c *Client) GetGasCheapness(ctx context.Context, chainID int64, opts ...CallOption) (*Cheapness, error) {
+	return c.GetGasCheapnessWith(ctx, chainID, DefaultCheapPercentile, opts...)
+}
+
+// GetGasCheapnessWith fetches suggestedGasFees and baseFeeHistory concurrently and ranks the
+// estimated base fee within the history: gas is cheap when its percentile rank is below
+// threshold. A threshold of 25 means the current base fee is among the lowest quarter of
+// recent blocks; raise it to act more often, lower it to wait for deeper dips. The
+// threshold must be between 0 and 100. An empty history yields ErrEmptyHistory.
+func (c *Client) GetGasCheapnessWith(ctx context.Context, chainID int64, threshold float64, opts ...CallOption) (*Cheapness, error) {
+	if !(threshold >= 0 && threshold <= 100) {
+		return nil, fmt.Errorf("threshold must be between 0 and 100, got %v", threshold)
+	}
+
+	ctx, cancel := withCallOptions(ctx, opts)
+	defer cancel()
+
+	var (
+		wg         sync.WaitGroup
+		fees       *SuggestedGasFees
+		history    BaseFeeHistory
+		feesErr    error
+		historyErr error
+	)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		fees, feesErr = c.GetSuggestedGasFees(ctx, chainID)
+	}()
+	go func() {
+		defer wg.Done()
+		history, historyErr = c.GetBaseFeeHistory(ctx, chainID)
+	}()
+	wg.Wait()
+
+	if feesErr != nil {
+		return nil, fmt.Errorf("failed to get suggested gas fees: %w", feesErr)
+	}
+	if historyErr != nil {
+		return nil, fmt.Errorf("failed to get base fee history: %w", historyErr)
+	}
+
+	rank, err := history.PercentileRank(fees.EstimatedBaseFee)
+	if err != nil {
+		return nil, err
+	}
+	return &Cheapness{
+		EstimatedBaseFee: fees.EstimatedBaseFee,
+		PercentileRank:   rank,
+		Threshold:        threshold,
+		IsCheap:          rank < threshold,
+		HistorySize:      len(history),
+	}, nil
+}
+
+// PercentileRank returns the share of the history, from 0 to 100, below the Gwei value
+// fee; entries equal to fee count as half below. Values are compared exactly.
+func (h BaseFeeHistory) PercentileRank(fee string) (float64, error) {
+	if len(h) == 0 {
+		return 0, ErrEmptyHistory
+	}
+	current, err := parseGwei(fee)
+	if err != nil {
+		return 0, fmt.Errorf("invalid base fee: %w", err)
+	}
+	values, err := parseHistory(h)
+	if err != nil {
+		return 0, err
+	}
+
+	var below float64
+	for _, v := range values {
+		switch v.Cmp(current) {
+		case -1:
+			below++
+		case 0:
+			below += 0.5
+		}
+	}
+	return below / float64(len(values)) * 100, nil
+}
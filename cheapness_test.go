@@ -0,0 +1,103 @@
+package infura
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// cheapnessHistory holds ten entries from 10 to 19 Gwei
+const cheapnessHistory = `["15", "10", "19", "11", "18", "12", "17", "13", "16", "14"]`
+
+func TestGetGasCheapness(t *testing.T) {
+	tests := []struct {
+		name     string
+		baseFee  string
+		wantRank float64
+		wantIs   bool
+	}{
+		{"below history", "9.5", 0, true},
+		{"low", "12.5", 30, false},
+		{"equal to an entry", "11", 15, true},
+		{"above history", "25", 100, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newDashboardServer(map[string]string{
+				"suggestedGasFees": `{"estimatedBaseFee": "` + tt.baseFee + `"}`,
+				"baseFeeHistory":   cheapnessHistory,
+			})
+			defer server.Close()
+
+			client := NewClientWithOptions("test-api-key", "test-api-secret", WithBaseURL(server.URL))
+			cheapness, err := client.GetGasCheapness(context.Background(), 1)
+			if err != nil {
+				t.Fatalf("GetGasCheapness() error = %v", err)
+			}
+			want := Cheapness{
+				EstimatedBaseFee: tt.baseFee,
+				PercentileRank:   tt.wantRank,
+				Threshold:        DefaultCheapPercentile,
+				IsCheap:          tt.wantIs,
+				HistorySize:      10,
+			}
+			if *cheapness != want {
+				t.Errorf("GetGasCheapness() = %+v, want %+v", *cheapness, want)
+			}
+		})
+	}
+}
+
+func TestGetGasCheapnessWith_Threshold(t *testing.T) {
+	server := newDashboardServer(map[string]string{
+		"suggestedGasFees": `{"estimatedBaseFee": "12.5"}`,
+		"baseFeeHistory":   cheapnessHistory,
+	})
+	defer server.Close()
+
+	client := NewClientWithOptions("test-api-key", "test-api-secret", WithBaseURL(server.URL))
+	cheapness, err := client.GetGasCheapnessWith(context.Background(), 1, 50)
+	if err != nil {
+		t.Fatalf("GetGasCheapnessWith() error = %v", err)
+	}
+	if !cheapness.IsCheap || cheapness.Threshold != 50 {
+		t.Errorf("Expected cheap at threshold 50, got %+v", cheapness)
+	}
+
+	if _, err := client.GetGasCheapnessWith(context.Background(), 1, 101); err == nil {
+		t.Error("Expected an error for a threshold above 100")
+	}
+}
+
+func TestGetGasCheapness_Errors(t *testing.T) {
+	server := newDashboardServer(map[string]string{
+		"suggestedGasFees": `{"estimatedBaseFee": "12"}`,
+		"baseFeeHistory":   `[]`,
+	})
+	defer server.Close()
+
+	client := NewClientWithOptions("test-api-key", "test-api-secret", WithBaseURL(server.URL))
+	if _, err := client.GetGasCheapness(context.Background(), 1); !errors.Is(err, ErrEmptyHistory) {
+		t.Errorf("Expected ErrEmptyHistory, got %v", err)
+	}
+
+	failing := newDashboardServer(map[string]string{"baseFeeHistory": cheapnessHistory})
+	defer failing.Close()
+	client = NewClientWithOptions("test-api-key", "test-api-secret", WithBaseURL(failing.URL))
+	if _, err := client.GetGasCheapness(context.Background(), 1); !errors.Is(err, ErrServerError) {
+		t.Errorf("Expected ErrServerError, got %v", err)
+	}
+}
+
+func TestBaseFeeHistory_PercentileRank(t *testing.T) {
+	history := BaseFeeHistory{"20", "20", "20", "20"}
+	if rank, err := history.PercentileRank("20"); err != nil || rank != 50 {
+		t.Errorf("PercentileRank() = %v, %v; want 50 for a flat history", rank, err)
+	}
+	if _, err := history.PercentileRank("abc"); err == nil {
+		t.Error("Expected an error for an invalid base fee")
+	}
+	if _, err := (BaseFeeHistory{"1", "x"}).PercentileRank("1"); err == nil {
+		t.Error("Expected an error for an invalid history entry")
+	}
+}
@@ -5,10 +5,18 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
+	"log/slog"
+	"net"
 	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"golang.org/x/time/rate"
@@ -22,75 +30,193 @@ const (
 )
 
 // Client represents the Infura Gas API client
+//
+// A Client is safe for concurrent use by multiple goroutines. Its configuration is
+// fixed once the constructor returns; the only mutable state (credentials and the
+// debug flag) is swapped atomically via SetCredentials and SetDebug.
 type Client struct {
+	creds       atomic.Pointer[credentials]
+	authMode    AuthMode
+	baseURL     string
+	pathPrefix  string
+	httpClient  *http.Client
+	debug       atomic.Bool
+	rateLimiter *rate.Limiter
+	rateMode    RateLimitMode
+	retry       retryConfig
+
+	// timeout is the timeout set with WithTimeout, applied to httpClient once options are applied
+	timeout *time.Duration
+
+	acceptEncoding string
+	userAgent      string
+	json           Decoder
+	decoderOpts    DecoderOptions
+
+	clock       Clock
+	cache       *responseCache
+	cacheTTL    time.Duration
+	maxStaleAge time.Duration
+
+	rpc    RPCCaller
+	sanity *sanityChecker
+	feeCap *feeCapConfig
+
+	deprecationHandler  func(DeprecationNotice)
+	deprecationWarnings bool
+	deprecationSeen     sync.Map
+
+	tlsInfo        bool
+	tlsInfoHandler func(TLSInfo)
+
+	redirectHosts map[string]bool
+
+	// baseURLErr and transportErr are set by invalid options that make every request fail
+	// (see unusableError), even with the constructors that ignore invalid options
+	baseURLErr   error
+	transportErr error
+
+	fallbackFees map[int64]SuggestedGasFees
+
+	chainOverrides map[int64]*chainOverride
+
+	etags *etagStore
+
+	metrics     *metricsRegistry
+	metricsHook MetricsHook
+
+	failover  failoverConfig
+	credits   *creditTracker
+	history   *callHistory
+	keyPool   *keyPool
+	usage     *usageTracker
+	clockSkew *clockSkewCheck
+	inFlight  *inFlightLimiter
+
+	// budgetKey is the context key of the request budget (see WithBudgetFromContext)
+	budgetKey interface{}
+
+	// providedHTTPClient is set by WithHTTPClient; transportOptions names the options
+	// that replaced or changed the transport (see WithUseProvidedTransportAsIs)
+	providedHTTPClient bool
+	transportOptions   []string
+	transportAsIs      bool
+
+	// strictContentType rejects successful responses that are not JSON
+	strictContentType bool
+
+	// disableKeepAlives and dialContext are installed on a copy of the transport (see
+	// WithDisableKeepAlives and WithDialContext)
+	disableKeepAlives bool
+	dialContext       func(ctx context.Context, network, addr string) (net.Conn, error)
+
+	// middleware wraps the final transport, outermost first (see WithTransportMiddleware)
+	middleware []func(http.RoundTripper) http.RoundTripper
+
+	// headers are sent with every request (see WithHeader)
+	headers http.Header
+
+	// slogger receives structured debug records (see WithSlogLogger)
+	slogger *slog.Logger
+
+	// optionErrs collects the invalid options rejected while constructing the client
+	optionErrs []error
+}
+
+// credentials is an immutable API Key / API Key Secret pair
+// A new value is stored on every change so in-flight requests keep a consistent view
+type credentials struct {
 	apiKey       string
 	apiKeySecret string
-	baseURL      string
-	httpClient   *http.Client
-	debug        bool
-	rateLimiter  *rate.Limiter
+	// mode is the auth mode forced with WithAuthMode, empty to follow the secret
+	mode AuthMode
+}
+
+// hasSecret returns true if API Key Secret is provided
+func (cr *credentials) hasSecret() bool {
+	return cr.apiKeySecret != ""
+}
+
+// basicAuth reports whether requests with these credentials use Basic Auth rather than the
+// API Key in the URL path: the forced mode if any, otherwise whether a secret is set
+func (cr *credentials) basicAuth() bool {
+	if cr.mode != "" {
+		return cr.mode == AuthModeBasic
+	}
+	return cr.hasSecret()
+}
+
+// authHeader returns the Basic Auth header value
+func (cr *credentials) authHeader() string {
+	auth := cr.apiKey + ":" + cr.apiKeySecret
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(auth))
 }
 
 // NewClient creates a new Infura Gas API client
 // If apiKeySecret is empty, only API Key authentication will be used (API Key in URL path)
 // If apiKeySecret is provided, Basic Auth will be used (API Key + Secret)
 func NewClient(apiKey, apiKeySecret string) *Client {
-	return &Client{
-		apiKey:       apiKey,
-		apiKeySecret: apiKeySecret,
-		baseURL:      BaseURL,
-		httpClient: &http.Client{
-			Timeout: DefaultTimeout,
-		},
-	}
+	return newClient(apiKey, apiKeySecret, nil)
 }
 
 // NewClientWithAPIKey creates a new client using only API Key (no secret)
 // This uses the URL path authentication method: /v3/{apiKey}/networks/{chainId}/suggestedGasFees
 func NewClientWithAPIKey(apiKey string) *Client {
-	return &Client{
-		apiKey:       apiKey,
-		apiKeySecret: "",
-		baseURL:      BaseURL,
-		httpClient: &http.Client{
-			Timeout: DefaultTimeout,
-		},
-	}
+	return newClient(apiKey, "", nil)
 }
 
 // NewClientWithOptions creates a new client with custom options
 // If apiKeySecret is empty, only API Key authentication will be used
 func NewClientWithOptions(apiKey, apiKeySecret string, opts ...ClientOption) *Client {
-	client := &Client{
-		apiKey:       apiKey,
-		apiKeySecret: apiKeySecret,
-		baseURL:      BaseURL,
-		httpClient: &http.Client{
-			Timeout: DefaultTimeout,
-		},
-	}
-
-	for _, opt := range opts {
-		opt(client)
-	}
-
-	return client
+	return newClient(apiKey, apiKeySecret, opts)
 }
 
 // NewClientWithAPIKeyAndOptions creates a new client with only API Key and custom options
 func NewClientWithAPIKeyAndOptions(apiKey string, opts ...ClientOption) *Client {
+	return newClient(apiKey, "", opts)
+}
+
+// New creates a new client with custom options and validates them
+// Unlike NewClientWithOptions, which ignores invalid options and keeps the default for
+// those settings (except an invalid base URL or an unusable WithDialContext, which make
+// every request fail), New
+// reports every invalid option at once in a joined error; each of them matches
+// ErrInvalidOption
+// If apiKeySecret is empty, only API Key authentication will be used
+func New(apiKey, apiKeySecret string, opts ...ClientOption) (*Client, error) {
+	client := newClient(apiKey, apiKeySecret, opts)
+	if err := errors.Join(client.optionErrs...); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// newClient builds a client with default settings and applies the default options (see
+// SetDefaultOptions), then the given options
+func newClient(apiKey, apiKeySecret string, opts []ClientOption) *Client {
 	client := &Client{
-		apiKey:       apiKey,
-		apiKeySecret: "",
-		baseURL:      BaseURL,
+		baseURL:   BaseURL,
+		clock:     realClock{},
+		userAgent: defaultUserAgent,
+		json:      stdJSON{},
+		credits:   newCreditTracker(),
 		httpClient: &http.Client{
 			Timeout: DefaultTimeout,
 		},
 	}
+	client.creds.Store(&credentials{apiKey: apiKey, apiKeySecret: apiKeySecret})
 
-	for _, opt := range opts {
+	for _, opt := range withDefaultOptions(opts) {
 		opt(client)
 	}
+	client.finalizeAuthMode()
+	client.finalizeTransport()
+	client.finalizeTransportSettings()
+	client.finalizeHTTPClient()
+	client.finalizeRedirects()
+	client.finalizeMiddleware()
+	client.finalizeChainOverrides()
+	client.finalizeDecoder()
 
 	return client
 }
@@ -98,31 +224,158 @@ func NewClientWithAPIKeyAndOptions(apiKey string, opts ...ClientOption) *Client
 // ClientOption is a function that configures a Client
 type ClientOption func(*Client)
 
+// unusableError returns the error of an invalid option the client cannot fall back from
+// safely, such as an invalid base URL; requests fail with it instead of being sent
+func (c *Client) unusableError() error {
+	return errors.Join(c.baseURLErr, c.transportErr)
+}
+
+// rejectOption records an invalid option; the setting it targets is left unchanged
+func (c *Client) rejectOption(option string, format string, args ...interface{}) {
+	c.optionErrs = append(c.optionErrs, fmt.Errorf("%w %s: %s", ErrInvalidOption, option, fmt.Sprintf(format, args...)))
+}
+
 // WithBaseURL sets a custom base URL
+// The URL must be absolute and use http or https. It may include a path prefix
+// (e.g. "https://proxy.example.com/infura/gas"), with or without a trailing slash;
+// endpoint paths are appended after the prefix
+// An invalid URL is never replaced by the default: with the constructors that ignore
+// invalid options, every request of the client fails with the option's error, so the API
+// key is not sent to a host the caller did not choose.
 func WithBaseURL(baseURL string) ClientOption {
 	return func(c *Client) {
+		if err := validateBaseURL(baseURL); err != nil {
+			c.rejectOption("WithBaseURL", "%v", err)
+			c.baseURLErr = c.optionErrs[len(c.optionErrs)-1]
+			return
+		}
 		c.baseURL = baseURL
+		c.baseURLErr = nil
 	}
 }
 
+// WithPathPrefix adds a path segment, e.g. a tenant or project path required by a
+// gateway, between the base URL (including failover URLs) and every endpoint, in both
+// auth modes: {baseURL}/{prefix}/networks/... or {baseURL}/{prefix}/v3/{apiKey}/networks/...
+// Leading and trailing slashes are ignored; an empty prefix removes it. The prefix must
+// not contain a query, a fragment or whitespace.
+func WithPathPrefix(prefix string) ClientOption {
+	return func(c *Client) {
+		if strings.ContainsAny(prefix, "?# \t\r\n") {
+			c.rejectOption("WithPathPrefix", "prefix must be a plain path, got %q", prefix)
+			return
+		}
+		c.pathPrefix = strings.Trim(prefix, "/")
+	}
+}
+
+// validateBaseURL checks that baseURL is an absolute http(s) URL
+func validateBaseURL(baseURL string) error {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("base URL must be an absolute http(s) URL, got %q", baseURL)
+	}
+	return nil
+}
+
 // WithHTTPClient sets a custom HTTP client
+// The client's Timeout is kept unless WithTimeout is also given; a zero Timeout (no limit)
+// is replaced by DefaultTimeout. The client passed in is never modified. A nil client is
+// invalid.
+//
+// The package has no transport settings of its own: no dial, TLS or idle timeouts are
+// applied to the client's Transport, and WithDisableKeepAlives has no effect with this
+// option. The options that change the transport are WithTransport, which replaces it (when
+// both are given, the one applied last wins), and WithDialContext, which sets the dialer of
+// a copy. Use WithUseProvidedTransportAsIs to turn these combinations into a
+// construction-time panic. WithTransportMiddleware wraps the resulting transport without
+// replacing it.
 func WithHTTPClient(httpClient *http.Client) ClientOption {
 	return func(c *Client) {
+		if httpClient == nil {
+			c.rejectOption("WithHTTPClient", "HTTP client must not be nil")
+			return
+		}
 		c.httpClient = httpClient
+		c.providedHTTPClient = true
+	}
+}
+
+// WithTransport sets the RoundTripper used for requests, e.g. an infuratest.Recorder
+// The HTTP client is copied, so a client passed to WithHTTPClient is not modified
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(c *Client) {
+		httpClient := *c.httpClient
+		httpClient.Transport = transport
+		c.httpClient = &httpClient
+		c.transportOptions = append(c.transportOptions, "WithTransport")
+	}
+}
+
+// WithUseProvidedTransportAsIs asserts that the transport of the client passed to
+// WithHTTPClient is used exactly as configured. The constructor panics if an option that
+// changes the transport, such as WithTransport, is given as well, in any order, so a
+// transport tuned elsewhere cannot be overridden by accident. The client's Timeout is not
+// part of the transport and still follows WithTimeout. Without WithHTTPClient the option
+// is invalid.
+func WithUseProvidedTransportAsIs() ClientOption {
+	return func(c *Client) {
+		c.transportAsIs = true
 	}
 }
 
-// WithTimeout sets a custom timeout
+// finalizeTransport enforces WithUseProvidedTransportAsIs once all options are applied
+func (c *Client) finalizeTransport() {
+	if !c.transportAsIs {
+		return
+	}
+	if !c.providedHTTPClient {
+		c.rejectOption("WithUseProvidedTransportAsIs", "requires a client set with WithHTTPClient")
+		return
+	}
+	if len(c.transportOptions) > 0 {
+		panic(fmt.Sprintf("infura: WithUseProvidedTransportAsIs: %s would change the transport of the client passed to WithHTTPClient", strings.Join(c.transportOptions, ", ")))
+	}
+}
+
+// WithTimeout sets a custom timeout, which must be positive
+// It bounds each attempt of a request, unless WithChainTimeout or WithCallTimeout applies.
+// It takes precedence over the Timeout of a client set with WithHTTPClient, in any order
 func WithTimeout(timeout time.Duration) ClientOption {
 	return func(c *Client) {
-		c.httpClient.Timeout = timeout
+		if timeout <= 0 {
+			c.rejectOption("WithTimeout", "timeout must be positive, got %v", timeout)
+			return
+		}
+		c.timeout = &timeout
+	}
+}
+
+// finalizeHTTPClient applies the effective timeout once all options are applied:
+// WithTimeout, then the HTTP client's own non-zero Timeout, then DefaultTimeout
+// The HTTP client is copied before it is changed
+func (c *Client) finalizeHTTPClient() {
+	timeout := c.httpClient.Timeout
+	switch {
+	case c.timeout != nil:
+		timeout = *c.timeout
+	case timeout == 0:
+		timeout = DefaultTimeout
+	}
+	if timeout != c.httpClient.Timeout {
+		httpClient := *c.httpClient
+		httpClient.Timeout = timeout
+		c.httpClient = &httpClient
 	}
 }
 
 // WithDebug enables debug mode to print HTTP request and response details
 func WithDebug(debug bool) ClientOption {
 	return func(c *Client) {
-		c.debug = debug
+		c.debug.Store(debug)
 	}
 }
 
@@ -130,135 +383,453 @@ func WithDebug(debug bool) ClientOption {
 // rate is the number of requests per second
 // burst is the maximum number of requests that can be made in a single burst
 // Example: WithRateLimit(10, 20) allows 10 requests per second with a burst of 20
+// Both rate and burst must be positive
 func WithRateLimit(ratePerSecond float64, burst int) ClientOption {
 	return func(c *Client) {
+		if !(ratePerSecond > 0) || burst <= 0 {
+			c.rejectOption("WithRateLimit", "rate and burst must be positive, got %v/s with burst %d", ratePerSecond, burst)
+			return
+		}
 		c.rateLimiter = rate.NewLimiter(rate.Limit(ratePerSecond), burst)
 	}
 }
 
+// WithHeader adds a header sent with every Gas API request, e.g. for an API gateway
+// Calling it again with the same key replaces the value. A per-call header set with
+// WithCallHeader takes precedence over a client header of the same name.
+func WithHeader(key, value string) ClientOption {
+	return func(c *Client) {
+		if key == "" {
+			c.rejectOption("WithHeader", "header name must not be empty")
+			return
+		}
+		if c.headers == nil {
+			c.headers = make(http.Header)
+		}
+		c.headers.Set(key, value)
+	}
+}
+
+// SetDebug enables or disables debug mode at runtime
+// It is safe to call while requests are in flight on other goroutines
+func (c *Client) SetDebug(debug bool) {
+	c.debug.Store(debug)
+}
+
+// Debug reports whether debug mode is currently enabled
+func (c *Client) Debug() bool {
+	return c.debug.Load()
+}
+
+// SetCredentials replaces the API Key and API Key Secret used for subsequent requests
+// An empty apiKeySecret switches the client to API Key (URL path) authentication
+// It is safe to call while requests are in flight; each request uses a consistent pair.
+// A mode forced with WithAuthMode still applies to the new pair.
+func (c *Client) SetCredentials(apiKey, apiKeySecret string) {
+	c.creds.Store(&credentials{apiKey: apiKey, apiKeySecret: apiKeySecret, mode: c.authMode})
+}
+
+// credentials returns the current credentials snapshot
+// A Client not built by a constructor has empty credentials
+func (c *Client) credentials() *credentials {
+	if creds := c.creds.Load(); creds != nil {
+		return creds
+	}
+	return &credentials{}
+}
+
 // hasSecret returns true if API Key Secret is provided
 func (c *Client) hasSecret() bool {
-	return c.apiKeySecret != ""
+	return c.credentials().hasSecret()
 }
 
 // getAuthHeader returns the Basic Auth header value
 // Only used when API Key Secret is provided
 func (c *Client) getAuthHeader() string {
-	auth := c.apiKey + ":" + c.apiKeySecret
-	return "Basic " + base64.StdEncoding.EncodeToString([]byte(auth))
+	return c.credentials().authHeader()
 }
 
 // doRequest performs an HTTP request and returns the response
 func (c *Client) doRequest(ctx context.Context, method, endpoint string, body io.Reader) (*http.Response, error) {
+	creds, err := c.requestCredentials()
+	if err != nil {
+		return nil, err
+	}
+	return c.doRequestWithCredentials(ctx, creds, c.defaultSettings(), method, endpoint, body)
+}
+
+// requestURL returns the URL of an endpoint for a request with the given settings
+func (c *Client) requestURL(settings requestSettings, endpoint string) string {
+	base := c.baseURLFor(settings)
+	if c.pathPrefix != "" {
+		base = joinURL(base, c.pathPrefix)
+	}
+	return joinURL(base, endpoint)
+}
+
+// joinURL appends an endpoint path to a base URL that may carry a path prefix,
+// producing exactly one slash between them
+func joinURL(baseURL, endpoint string) string {
+	return strings.TrimRight(baseURL, "/") + "/" + strings.TrimLeft(endpoint, "/")
+}
+
+// doRequestWithCredentials performs an HTTP request authenticated with the given credentials snapshot
+func (c *Client) doRequestWithCredentials(ctx context.Context, creds *credentials, settings requestSettings, method, endpoint string, body io.Reader) (*http.Response, error) {
+	if c.httpClient == nil {
+		// Only possible for a Client not built by a constructor
+		return nil, fmt.Errorf("no HTTP client configured; create the client with New or NewClient")
+	}
+	if err := c.unusableError(); err != nil {
+		return nil, err
+	}
+
+	budget, err := c.reserveBudget(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// The reserved unit is given back unless the request succeeds
+	spent := false
+	defer func() {
+		if budget != nil && !spent {
+			budget.Add(1)
+		}
+	}()
+
 	// Apply rate limiting if configured
-	if c.rateLimiter != nil {
-		if err := c.rateLimiter.Wait(ctx); err != nil {
+	for _, limiter := range settings.limiters {
+		if err := c.waitRateLimit(ctx, limiter); err != nil {
 			return nil, fmt.Errorf("rate limiter wait failed: %w", err)
 		}
 	}
 
-	url := c.baseURL + endpoint
+	if err := c.inFlight.acquire(ctx); err != nil {
+		return nil, err
+	}
+	// The slot is released when the response body is closed, or on failure
+	handedOff := false
+	defer func() {
+		if !handedOff {
+			c.inFlight.release()
+		}
+	}()
+
+	// The effective timeout is applied through the request context rather than the HTTP
+	// client's Timeout, which would cut a longer chain or call timeout short. It covers
+	// reading the body, so it is cancelled when the body is closed, or on failure.
+	cancel := context.CancelFunc(func() {})
+	if timeout := c.attemptTimeout(ctx, settings); timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	}
+	defer func() {
+		if !handedOff {
+			cancel()
+		}
+	}()
+
+	url := c.requestURL(settings, endpoint)
 	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	// Set Authorization header only if API Key Secret is provided (Basic Auth)
+	// Set Authorization header only for Basic Auth (an API Key Secret or WithAuthMode)
 	// Otherwise, API Key will be included in the URL path
-	if c.hasSecret() {
-		req.Header.Set("Authorization", c.getAuthHeader())
+	if creds.basicAuth() {
+		req.Header.Set("Authorization", creds.authHeader())
 	}
 
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+	if c.acceptEncoding != "" {
+		req.Header.Set("Accept-Encoding", c.acceptEncoding)
+	}
+	for key, values := range settings.headers {
+		req.Header[key] = values
+	}
 
 	// Debug: Print request details
-	if c.debug {
-		c.logRequest(req, body)
+	logger := c.debugLogger(ctx)
+	if logger != nil {
+		logRequest(logger, req, body)
+	}
+
+	httpClient := c.httpClient
+	if httpClient.Timeout != 0 {
+		withoutTimeout := *httpClient
+		withoutTimeout.Timeout = 0
+		httpClient = &withoutTimeout
+	}
+
+	if err := c.credits.charge(endpoint, c.clock.Now()); err != nil {
+		return nil, err
+	}
+	if c.keyPool != nil {
+		c.keyPool.recordRequest(creds)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	start := time.Now()
+	resp, err := httpClient.Do(req)
+	elapsed := time.Since(start)
+	c.metrics.observeRequest(endpoint, elapsed)
+	if c.usage != nil {
+		c.usage.observe(endpoint, c.credits.cost(endpoint), c.clock.Now())
+	}
 	if err != nil {
-		if c.debug {
-			log.Printf("[DEBUG] Request failed: %v\n", err)
+		if logger != nil {
+			logger.Printf("[DEBUG] Request failed: %v\n", err)
+		}
+		c.logAttempt(ctx, creds, settings, method, url, endpoint, start, 0, err)
+		if errors.Is(err, ErrRedirectAuthStripped) {
+			// Not a network failure: retrying would be redirected the same way
+			err = fmt.Errorf("failed to execute request: %w", err)
+		} else {
+			err = &transportError{err: err}
 		}
-		return nil, fmt.Errorf("failed to execute request: %w", err)
+		c.observeRequest(ctx, settings, endpoint, elapsed, 0, err)
+		return nil, err
 	}
+	c.logAttempt(ctx, creds, settings, method, url, endpoint, start, resp.StatusCode, nil)
+	c.observeRequest(ctx, settings, endpoint, elapsed, resp.StatusCode, nil)
+	spent = resp.StatusCode < 400
 
 	// Debug: Print response headers (body will be logged in doJSONRequest)
-	if c.debug {
-		c.logResponseHeaders(resp)
+	if logger != nil {
+		logResponseHeaders(logger, resp)
+	}
+
+	if c.acceptEncoding != "" {
+		if err := decodeResponseBody(resp); err != nil {
+			resp.Body.Close()
+			return nil, err
+		}
 	}
 
+	resp.Body = c.inFlight.releaseOnClose(&cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancel})
+	handedOff = true
 	return resp, nil
 }
 
+// cancelOnCloseBody cancels the context of its request when closed
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close closes the body and cancels the request context
+func (b *cancelOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// checkResult reports whether result can receive a decoded response
+// A nil interface is allowed and means the response body is not decoded; a typed nil
+// pointer is rejected before any request is sent, as decoding into it would fail or panic.
+func checkResult(result interface{}) error {
+	if result == nil {
+		return nil
+	}
+	if v := reflect.ValueOf(result); v.Kind() == reflect.Pointer && v.IsNil() {
+		return fmt.Errorf("result must not be a nil pointer (%T); pass nil to discard the response", result)
+	}
+	return nil
+}
+
 // doJSONRequest performs a JSON request and unmarshals the response
+// result may be nil to discard the response body
 func (c *Client) doJSONRequest(ctx context.Context, method, endpoint string, body interface{}, result interface{}) error {
-	var bodyReader io.Reader
+	creds, err := c.requestCredentials()
+	if err != nil {
+		return err
+	}
+	return c.doJSONRequestWithCredentials(ctx, creds, c.defaultSettings(), method, endpoint, body, result)
+}
+
+// doJSONRequestWithCredentials performs a JSON request authenticated with the given credentials snapshot
+// Retryable failures are retried according to the client's retry settings, then on each
+// failover URL in turn (see WithFailoverURLs)
+func (c *Client) doJSONRequestWithCredentials(ctx context.Context, creds *credentials, settings requestSettings, method, endpoint string, body interface{}, result interface{}) error {
+	if err := checkResult(result); err != nil {
+		return err
+	}
+
 	var bodyBytes []byte
 	if body != nil {
 		var err error
-		bodyBytes, err = json.Marshal(body)
+		bodyBytes, err = c.decoder().Marshal(body)
 		if err != nil {
 			return fmt.Errorf("failed to marshal request body: %w", err)
 		}
+	}
+
+	baseURLs := append([]string{c.baseURL}, c.failover.urls...)
+	if c.retry.maxElapsed > 0 {
+		settings.retryStart = c.clock.Now()
+	}
+	for i := 0; ; i++ {
+		settings.baseURL = baseURLs[i]
+		canFailover := i+1 < len(baseURLs)
+		attempts, err := c.doJSONAttempts(ctx, creds, settings, method, endpoint, bodyBytes, result, canFailover)
+		if err == nil || !canFailover || !isRetryable(err) || ctx.Err() != nil || errors.Is(err, ErrRetryBudgetExhausted) {
+			return err
+		}
+		settings.priorAttempts += attempts
+		if budgetErr := c.retryBudgetError(settings.retryStart, settings.priorAttempts, err); budgetErr != nil {
+			return budgetErr
+		}
+		var ok bool
+		if creds, endpoint, ok = c.retryCredentials(creds, endpoint); !ok {
+			return err
+		}
+		if logger := c.debugLogger(ctx); logger != nil {
+			logger.Printf("[DEBUG] Failing over to %s after error: %v\n", baseURLs[i+1], err)
+		}
+		c.logStructured(ctx, "infura failover", slog.String("url_masked", creds.mask(baseURLs[i+1])), slog.String("error", creds.mask(err.Error())))
+	}
+}
+
+// doJSONAttempts performs a JSON request against settings.baseURL, retrying retryable failures
+// When canFailover is set, errors that fail over immediately (see WithFailoverOn) are
+// returned without retrying. It returns the number of attempts made.
+func (c *Client) doJSONAttempts(ctx context.Context, creds *credentials, settings requestSettings, method, endpoint string, bodyBytes []byte, result interface{}, canFailover bool) (int, error) {
+	for attempt := 1; ; attempt++ {
+		settings.attempt = attempt
+		err := c.doJSONAttempt(ctx, creds, settings, method, endpoint, bodyBytes, result)
+		if err != nil && !errors.Is(err, errNotModified) {
+			c.metrics.observeError(endpoint)
+		}
+		if err == nil || attempt >= settings.maxAttempts || !shouldRetry(err) || ctx.Err() != nil {
+			return attempt, err
+		}
+		if canFailover && c.failover.immediate(err) {
+			return attempt, err
+		}
+		if budgetErr := c.retryBudgetError(settings.retryStart, settings.priorAttempts+attempt, err); budgetErr != nil {
+			return attempt, budgetErr
+		}
+		var ok bool
+		if creds, endpoint, ok = c.retryCredentials(creds, endpoint); !ok {
+			return attempt, err
+		}
+
+		if logger := c.debugLogger(ctx); logger != nil {
+			logger.Printf("[DEBUG] Attempt %d failed, retrying: %v\n", attempt, err)
+		}
+		c.logStructured(ctx, "infura retry", slog.Int("attempt", attempt), slog.String("error", creds.mask(err.Error())))
+		if err := c.sleep(ctx, c.retryBackoff(settings.retryStart, attempt)); err != nil {
+			return attempt, err
+		}
+	}
+}
+
+// doJSONAttempt performs a single JSON request attempt and unmarshals the response
+func (c *Client) doJSONAttempt(ctx context.Context, creds *credentials, settings requestSettings, method, endpoint string, bodyBytes []byte, result interface{}) (err error) {
+	start := time.Now()
+	statusCode := 0
+	defer func() {
+		recorded := err
+		if errors.Is(err, errNotModified) {
+			// A 304 answering a conditional request is a success
+			recorded = nil
+		}
+		c.recordCall(creds, method, c.requestURL(settings, endpoint), start, statusCode, recorded)
+		if c.keyPool != nil {
+			c.keyPool.recordResult(creds, recorded, c.clock.Now())
+		}
+	}()
+
+	var bodyReader io.Reader
+	if bodyBytes != nil {
 		bodyReader = bytes.NewReader(bodyBytes)
 	}
 
-	resp, err := c.doRequest(ctx, method, endpoint, bodyReader)
+	resp, err := c.doRequestWithCredentials(ctx, creds, settings, method, endpoint, bodyReader)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
+	statusCode = resp.StatusCode
 
-	// Read response body for debug and error handling
-	respBodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
+	// The body is only kept for debug output, error responses and custom decoders;
+	// otherwise a successful response is read into a pooled buffer and decoded from there
+	logger := c.debugLogger(ctx)
+	pooled := logger == nil && result != nil && resp.StatusCode >= 200 && resp.StatusCode < 300 && c.pooledDecoding()
+
+	var respBodyBytes []byte
+	if !pooled {
+		// Read response body for debug and error handling
+		respBodyBytes, err = io.ReadAll(resp.Body)
+		if err != nil {
+			return &transportError{err: err, readingBody: true}
+		}
+
+		// Debug: Print response body
+		if logger != nil {
+			logResponseBody(logger, respBodyBytes)
+		}
+	}
+
+	c.handleDeprecation(ctx, creds, endpoint, resp.Header)
+	c.checkClockSkew(ctx, resp.Header)
+	c.captureTLS(ctx, creds, endpoint, resp.TLS)
+	if settings.onResponse != nil {
+		settings.onResponse(resp)
 	}
 
-	// Debug: Print response body
-	if c.debug {
-		c.logResponseBody(respBodyBytes)
+	if resp.StatusCode == http.StatusNotModified && settings.headers.Get("If-None-Match") != "" {
+		return errNotModified
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(respBodyBytes))
+		return newAPIError(resp.StatusCode, respBodyBytes)
+	}
+	if err := c.checkContentType(creds, resp, respBodyBytes); err != nil {
+		return err
+	}
+
+	if pooled {
+		if err := decodePooledBody(resp.Body, c.decoder(), result); err != nil {
+			return err
+		}
+		return c.checkPrecision(result)
 	}
 
 	if result != nil {
-		if err := json.Unmarshal(respBodyBytes, result); err != nil {
-			if c.debug {
-				log.Printf("[DEBUG] Failed to unmarshal response: %v\n", err)
+		if err := c.decoder().Unmarshal(respBodyBytes, result); err != nil {
+			if logger != nil {
+				logger.Printf("[DEBUG] Failed to unmarshal response: %v\n", err)
 			}
 			return fmt.Errorf("failed to decode response: %w", err)
 		}
-		if c.debug {
+		if logger != nil {
 			resultBytes, _ := json.MarshalIndent(result, "", "  ")
-			log.Printf("[DEBUG] Parsed response object:\n%s\n", string(resultBytes))
+			logger.Printf("[DEBUG] Parsed response object:\n%s\n", string(resultBytes))
 		}
+		return c.checkPrecision(result)
 	}
 
 	return nil
 }
 
 // logRequest logs detailed HTTP request information
-func (c *Client) logRequest(req *http.Request, body io.Reader) {
-	log.Printf("[DEBUG] ========== HTTP Request ==========\n")
-	log.Printf("[DEBUG] Method: %s\n", req.Method)
-	log.Printf("[DEBUG] URL: %s\n", req.URL.String())
-	log.Printf("[DEBUG] Protocol: %s\n", req.Proto)
-	log.Printf("[DEBUG] Host: %s\n", req.Host)
-
-	log.Printf("[DEBUG] Headers:\n")
+func logRequest(logger *log.Logger, req *http.Request, body io.Reader) {
+	logger.Printf("[DEBUG] ========== HTTP Request ==========\n")
+	logger.Printf("[DEBUG] Method: %s\n", req.Method)
+	logger.Printf("[DEBUG] URL: %s\n", req.URL.String())
+	logger.Printf("[DEBUG] Protocol: %s\n", req.Proto)
+	logger.Printf("[DEBUG] Host: %s\n", req.Host)
+
+	logger.Printf("[DEBUG] Headers:\n")
 	for key, values := range req.Header {
 		for _, value := range values {
 			// Mask Authorization header for security
 			if key == "Authorization" {
-				log.Printf("[DEBUG]   %s: %s\n", key, maskAuthHeader(value))
+				logger.Printf("[DEBUG]   %s: %s\n", key, maskAuthHeader(value))
 			} else {
-				log.Printf("[DEBUG]   %s: %s\n", key, value)
+				logger.Printf("[DEBUG]   %s: %s\n", key, value)
 			}
 		}
 	}
@@ -279,43 +850,43 @@ func (c *Client) logRequest(req *http.Request, body io.Reader) {
 				}
 			}
 			if bodyStr != "" {
-				log.Printf("[DEBUG] Request Body:\n%s\n", bodyStr)
+				logger.Printf("[DEBUG] Request Body:\n%s\n", bodyStr)
 			}
 		}
 	}
-	log.Printf("[DEBUG] ====================================\n")
+	logger.Printf("[DEBUG] ====================================\n")
 }
 
 // logResponseHeaders logs HTTP response headers
-func (c *Client) logResponseHeaders(resp *http.Response) {
-	log.Printf("[DEBUG] ========== HTTP Response Headers ==========\n")
-	log.Printf("[DEBUG] Status: %s\n", resp.Status)
-	log.Printf("[DEBUG] Status Code: %d\n", resp.StatusCode)
-	log.Printf("[DEBUG] Protocol: %s\n", resp.Proto)
+func logResponseHeaders(logger *log.Logger, resp *http.Response) {
+	logger.Printf("[DEBUG] ========== HTTP Response Headers ==========\n")
+	logger.Printf("[DEBUG] Status: %s\n", resp.Status)
+	logger.Printf("[DEBUG] Status Code: %d\n", resp.StatusCode)
+	logger.Printf("[DEBUG] Protocol: %s\n", resp.Proto)
 
-	log.Printf("[DEBUG] Headers:\n")
+	logger.Printf("[DEBUG] Headers:\n")
 	for key, values := range resp.Header {
 		for _, value := range values {
-			log.Printf("[DEBUG]   %s: %s\n", key, value)
+			logger.Printf("[DEBUG]   %s: %s\n", key, value)
 		}
 	}
-	log.Printf("[DEBUG] ============================================\n")
+	logger.Printf("[DEBUG] ============================================\n")
 }
 
 // logResponseBody logs HTTP response body
-func (c *Client) logResponseBody(bodyBytes []byte) {
-	log.Printf("[DEBUG] ========== HTTP Response Body ==========\n")
+func logResponseBody(logger *log.Logger, bodyBytes []byte) {
+	logger.Printf("[DEBUG] ========== HTTP Response Body ==========\n")
 	if len(bodyBytes) > 0 {
 		var prettyJSON bytes.Buffer
 		if err := json.Indent(&prettyJSON, bodyBytes, "", "  "); err == nil {
-			log.Printf("%s\n", prettyJSON.String())
+			logger.Printf("%s\n", prettyJSON.String())
 		} else {
-			log.Printf("%s\n", string(bodyBytes))
+			logger.Printf("%s\n", string(bodyBytes))
 		}
 	} else {
-		log.Printf("[DEBUG] (empty body)\n")
+		logger.Printf("[DEBUG] (empty body)\n")
 	}
-	log.Printf("[DEBUG] ===========================================\n")
+	logger.Printf("[DEBUG] ===========================================\n")
 }
 
 // maskAuthHeader masks the authorization header for security
@@ -3,9 +3,16 @@ package infura
 import (
 	"context"
 	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"log"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -13,12 +20,12 @@ import (
 func TestNewClient(t *testing.T) {
 	client := NewClient("test-api-key", "test-api-secret")
 
-	if client.apiKey != "test-api-key" {
-		t.Errorf("Expected apiKey 'test-api-key', got '%s'", client.apiKey)
+	if client.credentials().apiKey != "test-api-key" {
+		t.Errorf("Expected apiKey 'test-api-key', got '%s'", client.credentials().apiKey)
 	}
 
-	if client.apiKeySecret != "test-api-secret" {
-		t.Errorf("Expected apiKeySecret 'test-api-secret', got '%s'", client.apiKeySecret)
+	if client.credentials().apiKeySecret != "test-api-secret" {
+		t.Errorf("Expected apiKeySecret 'test-api-secret', got '%s'", client.credentials().apiKeySecret)
 	}
 
 	if client.baseURL != BaseURL {
@@ -156,12 +163,12 @@ func TestDoJSONRequest_ErrorStatus(t *testing.T) {
 func TestNewClientWithAPIKey(t *testing.T) {
 	client := NewClientWithAPIKey("test-api-key")
 
-	if client.apiKey != "test-api-key" {
-		t.Errorf("Expected apiKey 'test-api-key', got '%s'", client.apiKey)
+	if client.credentials().apiKey != "test-api-key" {
+		t.Errorf("Expected apiKey 'test-api-key', got '%s'", client.credentials().apiKey)
 	}
 
-	if client.apiKeySecret != "" {
-		t.Errorf("Expected empty apiKeySecret, got '%s'", client.apiKeySecret)
+	if client.credentials().apiKeySecret != "" {
+		t.Errorf("Expected empty apiKeySecret, got '%s'", client.credentials().apiKeySecret)
 	}
 
 	if client.hasSecret() {
@@ -218,11 +225,77 @@ func TestDoRequest_APIKeyOnly(t *testing.T) {
 func TestWithDebug(t *testing.T) {
 	client := NewClientWithOptions("test-api-key", "test-api-secret", WithDebug(true))
 
-	if !client.debug {
+	if !client.Debug() {
 		t.Error("Expected debug to be true")
 	}
 }
 
+func TestSetDebug(t *testing.T) {
+	client := NewClientWithAPIKey("test-api-key")
+
+	if client.Debug() {
+		t.Error("Expected debug to be false by default")
+	}
+
+	client.SetDebug(true)
+	if !client.Debug() {
+		t.Error("Expected debug to be true after SetDebug(true)")
+	}
+
+	client.SetDebug(false)
+	if client.Debug() {
+		t.Error("Expected debug to be false after SetDebug(false)")
+	}
+}
+
+func TestSetDebug_ConcurrentToggle(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"message": "success"}`))
+	}))
+	defer server.Close()
+
+	// Silence debug output produced while the flag is toggled on
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	client := NewClientWithOptions("test-api-key", "test-api-secret", WithBaseURL(server.URL))
+
+	done := make(chan struct{})
+	var toggler sync.WaitGroup
+	toggler.Add(1)
+	go func() {
+		defer toggler.Done()
+		for i := 0; ; i++ {
+			select {
+			case <-done:
+				return
+			default:
+				client.SetDebug(i%2 == 0)
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				var result map[string]interface{}
+				if err := client.doJSONRequest(context.Background(), "GET", "/test", nil, &result); err != nil {
+					t.Errorf("doJSONRequest failed: %v", err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(done)
+	toggler.Wait()
+}
+
 func TestDoRequest_WithDebug(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -273,3 +346,485 @@ func TestDoJSONRequest_WithDebug(t *testing.T) {
 		t.Errorf("Expected message 'success', got '%s'", result.Message)
 	}
 }
+
+func TestSetCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"busyThreshold": "1"}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("old-key", WithBaseURL(server.URL))
+	client.SetCredentials("new-key", "new-secret")
+
+	if !client.hasSecret() {
+		t.Error("hasSecret() should return true after SetCredentials with a secret")
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(client.getAuthHeader(), "Basic "))
+	if err != nil {
+		t.Fatalf("Failed to decode auth header: %v", err)
+	}
+	if string(decoded) != "new-key:new-secret" {
+		t.Errorf("Expected decoded auth 'new-key:new-secret', got '%s'", string(decoded))
+	}
+
+	client.SetCredentials("new-key", "")
+	if client.hasSecret() {
+		t.Error("hasSecret() should return false after SetCredentials with an empty secret")
+	}
+}
+
+func TestClient_ConcurrentUse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Every request must use a single credentials snapshot for both the path and the header
+		auth := r.Header.Get("Authorization")
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/v3/"):
+			if auth != "" {
+				t.Errorf("Path auth request %s carried an Authorization header", r.URL.Path)
+			}
+		case strings.HasPrefix(r.URL.Path, "/networks/"):
+			if auth == "" {
+				t.Errorf("Basic auth request %s is missing the Authorization header", r.URL.Path)
+			}
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/suggestedGasFees"):
+			w.Write([]byte(`{"low": {"suggestedMaxFeePerGas": "1"}}`))
+		case strings.HasSuffix(r.URL.Path, "/baseFeeHistory"):
+			w.Write([]byte(`["1", "2"]`))
+		case strings.HasSuffix(r.URL.Path, "/baseFeePercentile"):
+			w.Write([]byte(`{"baseFeePercentile": "1"}`))
+		default:
+			w.Write([]byte(`{"busyThreshold": "1"}`))
+		}
+	}))
+	defer server.Close()
+
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	client := NewClientWithOptions("test-api-key", "test-api-secret", WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	done := make(chan struct{})
+	var mutators sync.WaitGroup
+	mutators.Add(1)
+	go func() {
+		defer mutators.Done()
+		for i := 0; ; i++ {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			if i%2 == 0 {
+				client.SetCredentials("test-api-key", "")
+			} else {
+				client.SetCredentials("test-api-key", "test-api-secret")
+			}
+			client.SetDebug(i%3 == 0)
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				if _, err := client.GetSuggestedGasFees(ctx, 1); err != nil {
+					t.Errorf("GetSuggestedGasFees failed: %v", err)
+				}
+				if _, err := client.GetBaseFeeHistory(ctx, 1); err != nil {
+					t.Errorf("GetBaseFeeHistory failed: %v", err)
+				}
+				if _, err := client.GetBaseFeePercentile(ctx, 1); err != nil {
+					t.Errorf("GetBaseFeePercentile failed: %v", err)
+				}
+				if _, err := client.GetBusyThreshold(ctx, 1); err != nil {
+					t.Errorf("GetBusyThreshold failed: %v", err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(done)
+	mutators.Wait()
+}
+
+func TestWithBaseURL_PathPrefix(t *testing.T) {
+	var gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"low": {"suggestedMaxFeePerGas": "1"}}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		baseURL  string
+		secret   string
+		wantPath string
+	}{
+		{name: "api key, no trailing slash", baseURL: server.URL + "/infura/gas", wantPath: "/infura/gas/v3/test-api-key/networks/1/suggestedGasFees"},
+		{name: "api key, trailing slash", baseURL: server.URL + "/infura/gas/", wantPath: "/infura/gas/v3/test-api-key/networks/1/suggestedGasFees"},
+		{name: "basic auth, no trailing slash", baseURL: server.URL + "/infura/gas", secret: "test-api-secret", wantPath: "/infura/gas/networks/1/suggestedGasFees"},
+		{name: "basic auth, trailing slash", baseURL: server.URL + "/infura/gas/", secret: "test-api-secret", wantPath: "/infura/gas/networks/1/suggestedGasFees"},
+		{name: "no prefix, trailing slash", baseURL: server.URL + "/", wantPath: "/v3/test-api-key/networks/1/suggestedGasFees"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClientWithOptions("test-api-key", tt.secret, WithBaseURL(tt.baseURL))
+			if _, err := client.GetSuggestedGasFees(context.Background(), 1); err != nil {
+				t.Fatalf("GetSuggestedGasFees failed: %v", err)
+			}
+			if gotPath != tt.wantPath {
+				t.Errorf("Expected path %s, got %s", tt.wantPath, gotPath)
+			}
+		})
+	}
+}
+
+// roundTripFunc adapts a function to http.RoundTripper
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestWithTransport(t *testing.T) {
+	var gotURL string
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		gotURL = req.URL.String()
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"busyThreshold": "42"}`)),
+			Request:    req,
+		}, nil
+	})
+
+	shared := &http.Client{}
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithHTTPClient(shared), WithTransport(transport))
+
+	result, err := client.GetBusyThreshold(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetBusyThreshold failed: %v", err)
+	}
+	if result.BusyThreshold != "42" {
+		t.Errorf("Expected busy threshold 42, got %s", result.BusyThreshold)
+	}
+	if gotURL != BaseURL+"/v3/test-api-key/networks/1/busyThreshold" {
+		t.Errorf("Unexpected request URL: %s", gotURL)
+	}
+	if shared.Transport != nil {
+		t.Error("Expected WithTransport not to modify the client passed to WithHTTPClient")
+	}
+}
+
+func TestNew(t *testing.T) {
+	client, err := New("test-api-key", "", WithBaseURL("http://localhost:8545/gas"), WithTimeout(time.Second), WithRateLimit(5, 1))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	if client.BaseURL() != "http://localhost:8545/gas" {
+		t.Errorf("Expected the custom base URL, got %s", client.BaseURL())
+	}
+	if client.Timeout() != time.Second {
+		t.Errorf("Expected a 1s timeout, got %v", client.Timeout())
+	}
+}
+
+func TestNew_InvalidOptions(t *testing.T) {
+	client, err := New("test-api-key", "",
+		WithBaseURL("not a url"),
+		WithTimeout(-5*time.Second),
+		WithHTTPClient(nil),
+		WithRateLimit(0, 10),
+		WithRateLimit(10, -1),
+	)
+	if client != nil {
+		t.Error("Expected a nil client")
+	}
+	if !errors.Is(err, ErrInvalidOption) {
+		t.Fatalf("Expected ErrInvalidOption, got %v", err)
+	}
+
+	for _, want := range []string{
+		`WithBaseURL: base URL must be an absolute http(s) URL, got "not a url"`,
+		"WithTimeout: timeout must be positive, got -5s",
+		"WithHTTPClient: HTTP client must not be nil",
+		"WithRateLimit: rate and burst must be positive, got 0/s with burst 10",
+		"WithRateLimit: rate and burst must be positive, got 10/s with burst -1",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error to contain %q, got:\n%v", want, err)
+		}
+	}
+	if n := len(err.(interface{ Unwrap() []error }).Unwrap()); n != 5 {
+		t.Errorf("Expected 5 joined errors, got %d", n)
+	}
+}
+
+func TestNew_BaseURLSchemes(t *testing.T) {
+	for _, baseURL := range []string{"ftp://gas.example.com", "gas.example.com", "https://", "://bad", ""} {
+		if _, err := New("test-api-key", "", WithBaseURL(baseURL)); !errors.Is(err, ErrInvalidOption) {
+			t.Errorf("Expected %q to be rejected, got %v", baseURL, err)
+		}
+	}
+}
+
+func TestNewClientWithOptions_IgnoresInvalidOptions(t *testing.T) {
+	client := NewClientWithOptions("test-api-key", "", WithTimeout(-time.Second), WithHTTPClient(nil))
+
+	if client.httpClient == nil || client.httpClient.Timeout != DefaultTimeout {
+		t.Errorf("Expected the default HTTP client, got %+v", client.httpClient)
+	}
+}
+
+func TestNewClientWithOptions_InvalidBaseURLFailsRequests(t *testing.T) {
+	var requests int32
+	client := NewClientWithOptions("test-api-key", "",
+		WithBaseURL("not a url"),
+		WithTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			atomic.AddInt32(&requests, 1)
+			return nil, errors.New("unexpected request")
+		})))
+
+	_, err := client.GetSuggestedGasFees(context.Background(), 1)
+	if !errors.Is(err, ErrInvalidOption) || !strings.Contains(err.Error(), "WithBaseURL") {
+		t.Errorf("Expected the WithBaseURL error, got %v", err)
+	}
+	if got := atomic.LoadInt32(&requests); got != 0 {
+		t.Errorf("Expected no request to be sent, got %d", got)
+	}
+
+	// A later valid base URL replaces the invalid one
+	client = NewClientWithOptions("test-api-key", "", WithBaseURL("not a url"), WithBaseURL("https://gas.example.com"))
+	if err := client.unusableError(); err != nil || client.BaseURL() != "https://gas.example.com" {
+		t.Errorf("Expected the valid base URL, got %s (%v)", client.BaseURL(), err)
+	}
+}
+
+func TestWithHTTPClient_Timeout(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []ClientOption
+		want time.Duration
+	}{
+		{"default", nil, DefaultTimeout},
+		{"zero-value client", []ClientOption{WithHTTPClient(&http.Client{})}, DefaultTimeout},
+		{"client timeout", []ClientOption{WithHTTPClient(&http.Client{Timeout: 3 * time.Second})}, 3 * time.Second},
+		{"WithTimeout after client", []ClientOption{WithHTTPClient(&http.Client{Timeout: 3 * time.Second}), WithTimeout(time.Second)}, time.Second},
+		{"WithTimeout before client", []ClientOption{WithTimeout(time.Second), WithHTTPClient(&http.Client{Timeout: 3 * time.Second})}, time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := New("test-api-key", "", tt.opts...)
+			if err != nil {
+				t.Fatalf("New failed: %v", err)
+			}
+			if got := client.Timeout(); got != tt.want {
+				t.Errorf("Expected timeout %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestWithHTTPClient_NotModified(t *testing.T) {
+	shared := &http.Client{}
+	NewClientWithAPIKeyAndOptions("test-api-key", WithHTTPClient(shared), WithTimeout(time.Second))
+	if shared.Timeout != 0 {
+		t.Errorf("Expected the shared client to keep its zero timeout, got %v", shared.Timeout)
+	}
+}
+
+func TestWithHTTPClient_Nil(t *testing.T) {
+	if _, err := New("test-api-key", "", WithHTTPClient(nil)); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("Expected ErrInvalidOption, got %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	// The legacy constructors keep the default HTTP client
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithHTTPClient(nil), WithBaseURL(server.URL))
+	if _, err := client.GetBusyThreshold(context.Background(), 1); err != nil {
+		t.Errorf("Expected the default HTTP client to be used, got %v", err)
+	}
+}
+
+func TestWithUseProvidedTransportAsIs(t *testing.T) {
+	tuned := &http.Transport{TLSHandshakeTimeout: 42 * time.Second}
+	provided := &http.Client{Transport: tuned}
+
+	client, err := New("test-api-key", "", WithHTTPClient(provided), WithUseProvidedTransportAsIs(), WithTimeout(time.Second))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	if client.httpClient.Transport != tuned {
+		t.Errorf("Expected the provided transport to be used as is, got %v", client.httpClient.Transport)
+	}
+	if client.httpClient.Timeout != time.Second {
+		t.Errorf("Expected WithTimeout to still apply, got %v", client.httpClient.Timeout)
+	}
+
+	if _, err := New("test-api-key", "", WithUseProvidedTransportAsIs()); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("Expected ErrInvalidOption without WithHTTPClient, got %v", err)
+	}
+}
+
+func TestWithUseProvidedTransportAsIs_PanicsOnConflict(t *testing.T) {
+	provided := &http.Client{Transport: &http.Transport{}}
+	replacement := roundTripFunc(func(r *http.Request) (*http.Response, error) { return nil, nil })
+
+	for name, opts := range map[string][]ClientOption{
+		"transport after client":  {WithHTTPClient(provided), WithTransport(replacement), WithUseProvidedTransportAsIs()},
+		"transport before client": {WithTransport(replacement), WithUseProvidedTransportAsIs(), WithHTTPClient(provided)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				r := recover()
+				if r == nil || !strings.Contains(fmt.Sprint(r), "WithTransport") {
+					t.Errorf("Expected a panic naming WithTransport, got %v", r)
+				}
+			}()
+			NewClientWithOptions("test-api-key", "", opts...)
+		})
+	}
+
+	// Without the assertion the option applied last wins, as before
+	client := NewClientWithOptions("test-api-key", "", WithHTTPClient(provided), WithTransport(replacement))
+	if client.httpClient.Transport == nil || provided.Transport == nil {
+		t.Error("Expected WithTransport to replace the transport of a copy")
+	}
+}
+
+func TestZeroValueClient(t *testing.T) {
+	var client Client
+	_, err := client.GetSuggestedGasFees(context.Background(), 1)
+	if err == nil || !strings.Contains(err.Error(), "no HTTP client configured") {
+		t.Errorf("Expected a configuration error, got %v", err)
+	}
+}
+
+func TestWithPathPrefix(t *testing.T) {
+	tests := []struct {
+		name     string
+		baseURL  string
+		prefix   string
+		secret   string
+		wantPath string
+	}{
+		{name: "basic auth", prefix: "tenants/acme", secret: "test-api-secret", wantPath: "/tenants/acme/networks/1/busyThreshold"},
+		{name: "url path auth", prefix: "tenants/acme", wantPath: "/tenants/acme/v3/test-api-key/networks/1/busyThreshold"},
+		{name: "surrounding slashes", prefix: "/tenants/acme/", secret: "test-api-secret", wantPath: "/tenants/acme/networks/1/busyThreshold"},
+		{name: "base URL with path", baseURL: "/gateway/", prefix: "/project-1", wantPath: "/gateway/project-1/v3/test-api-key/networks/1/busyThreshold"},
+		{name: "empty prefix", prefix: "/", secret: "test-api-secret", wantPath: "/networks/1/busyThreshold"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotPath string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotPath = r.URL.Path
+				w.Write([]byte(`{"busyThreshold": "42"}`))
+			}))
+			defer server.Close()
+
+			client, err := New("test-api-key", tt.secret, WithBaseURL(server.URL+tt.baseURL), WithPathPrefix(tt.prefix))
+			if err != nil {
+				t.Fatalf("New failed: %v", err)
+			}
+			if _, err := client.GetBusyThreshold(context.Background(), 1); err != nil {
+				t.Fatalf("GetBusyThreshold failed: %v", err)
+			}
+			if gotPath != tt.wantPath {
+				t.Errorf("Expected path %s, got %s", tt.wantPath, gotPath)
+			}
+		})
+	}
+}
+
+func TestWithPathPrefix_Invalid(t *testing.T) {
+	for _, prefix := range []string{"tenant?id=1", "tenant#frag", "my tenant"} {
+		if _, err := New("test-api-key", "", WithPathPrefix(prefix)); !errors.Is(err, ErrInvalidOption) {
+			t.Errorf("WithPathPrefix(%q): expected ErrInvalidOption, got %v", prefix, err)
+		}
+	}
+}
+
+func TestDoJSONRequest_ResultTarget(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`{"busyThreshold": "42"}`))
+	}))
+	defer server.Close()
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL))
+
+	t.Run("nil interface discards the response", func(t *testing.T) {
+		calls = 0
+		if err := client.doJSONRequest(context.Background(), "GET", "/busyThreshold", nil, nil); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if calls != 1 {
+			t.Errorf("Expected the request to be sent, got %d calls", calls)
+		}
+	})
+
+	t.Run("typed nil pointer is rejected", func(t *testing.T) {
+		calls = 0
+		var result *BusyThreshold
+		err := client.doJSONRequest(context.Background(), "GET", "/busyThreshold", nil, result)
+		if err == nil || !strings.Contains(err.Error(), "nil pointer (*infura.BusyThreshold)") {
+			t.Fatalf("Expected a nil pointer error, got %v", err)
+		}
+		if calls != 0 {
+			t.Errorf("Expected no request to be sent, got %d calls", calls)
+		}
+	})
+
+	t.Run("valid pointer is decoded", func(t *testing.T) {
+		var result BusyThreshold
+		if err := client.doJSONRequest(context.Background(), "GET", "/busyThreshold", nil, &result); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if result.BusyThreshold != "42" {
+			t.Errorf("Expected busyThreshold 42, got %q", result.BusyThreshold)
+		}
+	})
+}
+
+func TestGetNetworkResource_NilPointerResult(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`{"busyThreshold": "42"}`))
+	}))
+	defer server.Close()
+
+	// The cached path decodes without going through doJSONRequest
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL), WithCache(time.Minute))
+	var result *BusyThreshold
+	if err := client.getNetworkResource(context.Background(), 1, "busyThreshold", result); err == nil {
+		t.Fatal("Expected an error for a nil pointer result")
+	}
+	if calls != 0 {
+		t.Errorf("Expected no request to be sent, got %d calls", calls)
+	}
+}
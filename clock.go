@@ -0,0 +1,76 @@
+package infura
+
+import (
+	"context"
+	"time"
+)
+
+// Clock provides the current time and timers to the client
+// The default uses the system clock; tests can supply their own via WithClock
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// realClock is the Clock backed by the time package
+type realClock struct{}
+
+// Now returns the current system time
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+// After waits for the duration to elapse and then sends the current time on the returned channel
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// WithClock sets the clock used for cache expiry and other time-based behavior
+func WithClock(clock Clock) ClientOption {
+	return func(c *Client) {
+		if clock != nil {
+			c.clock = clock
+		}
+	}
+}
+
+// WithNowFunc sets the function that gives the current time to the client, e.g. to control
+// credit resets and cache expiry in tests; timers still use the system clock. It is a
+// shortcut for WithClock with a Clock whose Now calls now. A nil function is invalid.
+func WithNowFunc(now func() time.Time) ClientOption {
+	return func(c *Client) {
+		if now == nil {
+			c.rejectOption("WithNowFunc", "function must not be nil")
+			return
+		}
+		c.clock = nowFuncClock{now: now}
+	}
+}
+
+// nowFuncClock is a Clock reading the time from a function, with system timers
+type nowFuncClock struct {
+	now func() time.Time
+}
+
+// Now returns the time given by the function
+func (c nowFuncClock) Now() time.Time {
+	return c.now()
+}
+
+// After waits for the duration to elapse on the system clock
+func (nowFuncClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// sleep waits for d on the client's clock or until ctx is done, whichever comes first
+func (c *Client) sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	select {
+	case <-c.clock.After(d):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
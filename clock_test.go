@@ -0,0 +1,109 @@
+package infura
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a manually advanced Clock for deterministic tests
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []fakeWaiter
+}
+
+type fakeWaiter struct {
+	deadline time.Time
+	ch       chan time.Time
+}
+
+func newFakeClock(now time.Time) *fakeClock {
+	return &fakeClock{now: now}
+}
+
+func (f *fakeClock) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+func (f *fakeClock) After(d time.Duration) <-chan time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- f.now
+		return ch
+	}
+	f.waiters = append(f.waiters, fakeWaiter{deadline: f.now.Add(d), ch: ch})
+	return ch
+}
+
+// Advance moves the clock forward and fires every timer whose deadline has passed
+func (f *fakeClock) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+	remaining := f.waiters[:0]
+	for _, w := range f.waiters {
+		if !w.deadline.After(f.now) {
+			w.ch <- f.now
+		} else {
+			remaining = append(remaining, w)
+		}
+	}
+	f.waiters = remaining
+}
+
+// Waiters returns the number of pending timers
+func (f *fakeClock) Waiters() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.waiters)
+}
+
+// waitForWaiters blocks until at least n timers are pending on the fake clock
+func waitForWaiters(t *testing.T, clock *fakeClock, n int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for clock.Waiters() < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for %d pending timers", n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestWithClock(t *testing.T) {
+	clock := newFakeClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	client := NewClientWithOptions("test-api-key", "", WithClock(clock))
+	if client.clock != clock {
+		t.Error("Expected client to use the supplied clock")
+	}
+
+	client = NewClientWithOptions("test-api-key", "", WithClock(nil))
+	if _, ok := client.clock.(realClock); !ok {
+		t.Errorf("Expected nil clock to keep the real clock, got %T", client.clock)
+	}
+}
+
+func TestFakeClock_After(t *testing.T) {
+	clock := newFakeClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	ch := clock.After(time.Minute)
+
+	clock.Advance(30 * time.Second)
+	select {
+	case <-ch:
+		t.Fatal("Timer fired too early")
+	default:
+	}
+
+	clock.Advance(30 * time.Second)
+	select {
+	case <-ch:
+	default:
+		t.Fatal("Timer did not fire at its deadline")
+	}
+}
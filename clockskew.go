@@ -0,0 +1,61 @@
+package infura
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// clockSkewCheck compares the Date header of responses with the local clock
+type clockSkewCheck struct {
+	threshold time.Duration
+	onSkew    func(skew time.Duration)
+	once      sync.Once
+}
+
+// WithClockSkewCheck compares the Date header of every response with the client's clock
+// and reports a skew larger than threshold once per client: to onSkew if it is not nil,
+// otherwise as a [WARN] log line. A wrong local clock breaks time-based logic such as
+// cache expiry and signed requests. The skew is the server time minus the local time when
+// the response arrived, so a positive skew means the local clock is behind; it is also
+// recorded in CallMeta.ClockSkew. Date has a resolution of one second and responses take
+// time to arrive, so use a threshold of several seconds. A threshold that is not
+// positive is invalid.
+func WithClockSkewCheck(threshold time.Duration, onSkew func(skew time.Duration)) ClientOption {
+	return func(c *Client) {
+		if threshold <= 0 {
+			c.rejectOption("WithClockSkewCheck", "threshold must be positive, got %v", threshold)
+			return
+		}
+		c.clockSkew = &clockSkewCheck{threshold: threshold, onSkew: onSkew}
+	}
+}
+
+// checkClockSkew measures the skew between the Date header and the local clock, records it
+// in the call metadata and reports it if it exceeds the threshold
+func (c *Client) checkClockSkew(ctx context.Context, header http.Header) {
+	if c.clockSkew == nil {
+		return
+	}
+	date, err := http.ParseTime(header.Get("Date"))
+	if err != nil {
+		return
+	}
+	skew := date.Sub(c.clock.Now())
+	recordCallMeta(ctx, func(meta *CallMeta) {
+		meta.ClockSkew = skew
+	})
+
+	if skew.Abs() <= c.clockSkew.threshold {
+		return
+	}
+	c.clockSkew.once.Do(func() {
+		if c.clockSkew.onSkew != nil {
+			c.clockSkew.onSkew(skew)
+			return
+		}
+		log.Printf("[WARN] Local clock differs from the Infura server's Date by %v (threshold %v)\n", skew, c.clockSkew.threshold)
+	})
+}
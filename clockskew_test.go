@@ -0,0 +1,105 @@
+package infura
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newDateServer returns a server whose Date header is date
+func newDateServer(t *testing.T, date time.Time) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", date.UTC().Format(http.TimeFormat))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"estimatedBaseFee": "10"}`))
+	}))
+}
+
+func TestClockSkewCheck_ReportsOnce(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	server := newDateServer(t, now.Add(90*time.Second))
+	defer server.Close()
+
+	var skews []time.Duration
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithClock(newFakeClock(now)),
+		WithClockSkewCheck(30*time.Second, func(skew time.Duration) { skews = append(skews, skew) }))
+
+	for i := 0; i < 3; i++ {
+		var meta CallMeta
+		if _, err := client.GetSuggestedGasFees(ContextWithCallMeta(context.Background(), &meta), 1); err != nil {
+			t.Fatalf("GetSuggestedGasFees failed: %v", err)
+		}
+		if meta.ClockSkew != 90*time.Second {
+			t.Errorf("Expected skew of 90s in CallMeta, got %v", meta.ClockSkew)
+		}
+	}
+	if len(skews) != 1 || skews[0] != 90*time.Second {
+		t.Errorf("Expected one report of 90s, got %v", skews)
+	}
+}
+
+func TestClockSkewCheck_LocalClockAhead(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	server := newDateServer(t, now.Add(-2*time.Minute))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithClock(newFakeClock(now)),
+		WithClockSkewCheck(time.Minute, nil))
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetSuggestedGasFees(context.Background(), 1); err != nil {
+			t.Fatalf("GetSuggestedGasFees failed: %v", err)
+		}
+	}
+	if got := strings.Count(buf.String(), "[WARN]"); got != 1 {
+		t.Errorf("Expected one warning, got %d: %s", got, buf.String())
+	}
+	if !strings.Contains(buf.String(), "-2m0s") {
+		t.Errorf("Expected the skew in the warning, got %s", buf.String())
+	}
+}
+
+func TestClockSkewCheck_WithinThreshold(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	server := newDateServer(t, now.Add(5*time.Second))
+	defer server.Close()
+
+	reported := false
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithClock(newFakeClock(now)),
+		WithClockSkewCheck(30*time.Second, func(time.Duration) { reported = true }))
+
+	var meta CallMeta
+	if _, err := client.GetSuggestedGasFees(ContextWithCallMeta(context.Background(), &meta), 1); err != nil {
+		t.Fatalf("GetSuggestedGasFees failed: %v", err)
+	}
+	if reported {
+		t.Error("Expected no report within the threshold")
+	}
+	if meta.ClockSkew != 5*time.Second {
+		t.Errorf("Expected skew of 5s in CallMeta, got %v", meta.ClockSkew)
+	}
+}
+
+func TestWithClockSkewCheck_RejectsNonPositive(t *testing.T) {
+	if _, err := New("test-api-key", "", WithClockSkewCheck(0, nil)); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("Expected ErrInvalidOption, got %v", err)
+	}
+}
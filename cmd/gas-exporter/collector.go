@@ -0,0 +1,231 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	infura "github.com/ABT-Tech-Limited/infura-go"
+)
+
+// textContentType is the Content-Type of the Prometheus text exposition format
+const textContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// collector keeps the latest poll result of every chain and serves it as Prometheus metrics
+type collector struct {
+	client *infura.Client
+
+	mu     sync.Mutex
+	chains map[int64]*chainState
+}
+
+// chainState is what the collector knows about one chain
+type chainState struct {
+	fees          *infura.SuggestedGasFees
+	busyThreshold *float64
+	// up is true when the last suggestedGasFees poll succeeded
+	up          bool
+	polls       uint64
+	pollErrors  map[string]uint64
+	lastSuccess time.Time
+}
+
+// newCollector returns a collector that fetches busy thresholds with client
+func newCollector(client *infura.Client) *collector {
+	return &collector{client: client, chains: make(map[int64]*chainState)}
+}
+
+// run watches chainIDs with WatchMany and records every update until ctx is cancelled
+// Busy thresholds change far less often than fees, so they are refreshed separately every
+// busyInterval instead of after each poll. run returns an error if the watcher cannot start
+// or stops before ctx is cancelled.
+func (c *collector) run(ctx context.Context, chainIDs []int64, interval, busyInterval time.Duration) error {
+	updates, err := c.client.WatchMany(ctx, chainIDs, interval)
+	if err != nil {
+		return err
+	}
+	go c.refreshBusyThresholds(ctx, chainIDs, busyInterval)
+	for update := range updates {
+		c.observe(update)
+	}
+	if ctx.Err() == nil {
+		return errors.New("update channel closed before the context was cancelled")
+	}
+	return nil
+}
+
+// observe records a watcher update
+func (c *collector) observe(update infura.ChainUpdate) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	state := c.state(update.ChainID)
+	state.polls++
+	if update.Err != nil {
+		state.up = false
+		state.pollErrors["suggestedGasFees"]++
+		return
+	}
+	state.up = true
+	state.fees = update.Fees
+	state.lastSuccess = update.Time
+}
+
+// refreshBusyThresholds fetches the busy threshold of every chain now and then every
+// interval until ctx is cancelled
+func (c *collector) refreshBusyThresholds(ctx context.Context, chainIDs []int64, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		for _, chainID := range chainIDs {
+			c.refreshBusyThreshold(ctx, chainID)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// refreshBusyThreshold fetches the busy threshold of a chain
+// A failed request keeps the previous value and is counted as a busyThreshold poll error.
+func (c *collector) refreshBusyThreshold(ctx context.Context, chainID int64) {
+	var threshold *float64
+	bt, err := c.client.GetBusyThreshold(ctx, chainID)
+	if err == nil {
+		if v, parseErr := strconv.ParseFloat(bt.BusyThreshold, 64); parseErr == nil {
+			threshold = &v
+		}
+	}
+	if ctx.Err() != nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	state := c.state(chainID)
+	if err != nil {
+		state.pollErrors["busyThreshold"]++
+	} else if threshold != nil {
+		state.busyThreshold = threshold
+	}
+}
+
+// state returns the state of a chain, creating it on first use; c.mu must be held
+func (c *collector) state(chainID int64) *chainState {
+	state, ok := c.chains[chainID]
+	if !ok {
+		state = &chainState{pollErrors: make(map[string]uint64)}
+		c.chains[chainID] = state
+	}
+	return state
+}
+
+// metric is one metric family: its name, type, help text and the samples of every chain
+type metric struct {
+	name, kind, help string
+	samples          []sample
+}
+
+// sample is a single series value; labels holds alternating names and values after chain
+type sample struct {
+	chain  int64
+	labels []string
+	value  float64
+}
+
+// ServeHTTP writes the current state in the Prometheus text exposition format
+func (c *collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	families := c.snapshot()
+
+	w.Header().Set("Content-Type", textContentType)
+	bw := bufio.NewWriter(w)
+	for _, m := range families {
+		if len(m.samples) == 0 {
+			continue
+		}
+		bw.WriteString("# HELP " + m.name + " " + m.help + "\n")
+		bw.WriteString("# TYPE " + m.name + " " + m.kind + "\n")
+		for _, s := range m.samples {
+			bw.WriteString(m.name + `{chain="` + strconv.FormatInt(s.chain, 10) + `"`)
+			for i := 0; i+1 < len(s.labels); i += 2 {
+				bw.WriteString("," + s.labels[i] + "=" + strconv.Quote(s.labels[i+1]))
+			}
+			bw.WriteString("} " + strconv.FormatFloat(s.value, 'g', -1, 64) + "\n")
+		}
+	}
+	bw.Flush()
+}
+
+// snapshot converts the state of every chain, in chain ID order, to metric families
+func (c *collector) snapshot() []*metric {
+	maxFee := &metric{name: "infura_gas_max_fee_gwei", kind: "gauge", help: "Suggested maxFeePerGas in Gwei per fee level."}
+	priorityFee := &metric{name: "infura_gas_max_priority_fee_gwei", kind: "gauge", help: "Suggested maxPriorityFeePerGas in Gwei per fee level."}
+	baseFee := &metric{name: "infura_gas_estimated_base_fee_gwei", kind: "gauge", help: "Estimated base fee of the next block in Gwei."}
+	congestion := &metric{name: "infura_gas_network_congestion", kind: "gauge", help: "Network congestion ratio between 0 and 1."}
+	busy := &metric{name: "infura_gas_busy_threshold_gwei", kind: "gauge", help: "Base fee above which the network is considered busy, in Gwei."}
+	up := &metric{name: "infura_gas_up", kind: "gauge", help: "Whether the last suggestedGasFees poll succeeded."}
+	polls := &metric{name: "infura_gas_polls_total", kind: "counter", help: "Number of suggestedGasFees polls."}
+	pollErrors := &metric{name: "infura_gas_poll_errors_total", kind: "counter", help: "Number of failed polls per endpoint."}
+	lastSuccess := &metric{name: "infura_gas_last_success_timestamp_seconds", kind: "gauge", help: "Unix time of the last successful suggestedGasFees poll."}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	chainIDs := make([]int64, 0, len(c.chains))
+	for id := range c.chains {
+		chainIDs = append(chainIDs, id)
+	}
+	slices.Sort(chainIDs)
+
+	add := func(m *metric, chain int64, value float64, labels ...string) {
+		m.samples = append(m.samples, sample{chain: chain, labels: labels, value: value})
+	}
+	addGwei := func(m *metric, chain int64, gwei string, labels ...string) {
+		if v, err := strconv.ParseFloat(gwei, 64); err == nil {
+			add(m, chain, v, labels...)
+		}
+	}
+
+	for _, id := range chainIDs {
+		state := c.chains[id]
+		if fees := state.fees; fees != nil {
+			levels := []struct {
+				name  string
+				level infura.GasFeeLevel
+			}{{"low", fees.Low}, {"medium", fees.Medium}, {"high", fees.High}}
+			for _, l := range levels {
+				addGwei(maxFee, id, l.level.SuggestedMaxFeePerGas, "level", l.name)
+				addGwei(priorityFee, id, l.level.SuggestedMaxPriorityFeePerGas, "level", l.name)
+			}
+			addGwei(baseFee, id, fees.EstimatedBaseFee)
+			if fees.NetworkCongestion.IsSet() {
+				add(congestion, id, fees.NetworkCongestion.Float64())
+			}
+		}
+		if state.busyThreshold != nil {
+			add(busy, id, *state.busyThreshold)
+		}
+
+		upValue := 0.0
+		if state.up {
+			upValue = 1
+		}
+		add(up, id, upValue)
+		add(polls, id, float64(state.polls))
+		for _, endpoint := range []string{"suggestedGasFees", "busyThreshold"} {
+			add(pollErrors, id, float64(state.pollErrors[endpoint]), "endpoint", endpoint)
+		}
+		if !state.lastSuccess.IsZero() {
+			add(lastSuccess, id, float64(state.lastSuccess.UnixMilli())/1000)
+		}
+	}
+
+	return []*metric{maxFee, priorityFee, baseFee, congestion, busy, up, polls, pollErrors, lastSuccess}
+}
@@ -0,0 +1,234 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	infura "github.com/ABT-Tech-Limited/infura-go"
+)
+
+const mockSuggestedGasFees = `{
+	"low": {"suggestedMaxPriorityFeePerGas": "0.05", "suggestedMaxFeePerGas": "16.668", "minWaitTimeEstimate": 15000, "maxWaitTimeEstimate": 30000},
+	"medium": {"suggestedMaxPriorityFeePerGas": "0.1", "suggestedMaxFeePerGas": "22.1", "minWaitTimeEstimate": 15000, "maxWaitTimeEstimate": 45000},
+	"high": {"suggestedMaxPriorityFeePerGas": "0.3", "suggestedMaxFeePerGas": "27.5", "minWaitTimeEstimate": 15000, "maxWaitTimeEstimate": 60000},
+	"estimatedBaseFee": "16.618",
+	"networkCongestion": 0.5,
+	"latestPriorityFeeRange": ["0", "3"],
+	"historicalPriorityFeeRange": ["0.0001", "1.2"],
+	"historicalBaseFeeRange": ["12", "31"],
+	"priorityFeeTrend": "down",
+	"baseFeeTrend": "up"
+}`
+
+// newMockGasAPI serves suggestedGasFees and busyThreshold for chain 1 and 404 for any other chain
+func newMockGasAPI(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v3/test-key/networks/1/suggestedGasFees":
+			io.WriteString(w, mockSuggestedGasFees)
+		case "/v3/test-key/networks/1/busyThreshold":
+			io.WriteString(w, `{"busyThreshold": "25.5"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"message": "chain not supported"}`)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// scrape returns the body of the collector's /metrics response
+func scrape(t *testing.T, c *collector) string {
+	t.Helper()
+	server := httptest.NewServer(c)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/metrics")
+	if err != nil {
+		t.Fatalf("scrape failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != textContentType {
+		t.Errorf("expected Content-Type %q, got %q", textContentType, ct)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read metrics: %v", err)
+	}
+	return string(body)
+}
+
+func TestCollectorServesMetrics(t *testing.T) {
+	server := newMockGasAPI(t)
+	client, err := infura.New("test-key", "", infura.WithBaseURL(server.URL), infura.WithRetry(1, 0))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c := newCollector(client)
+	done := make(chan error, 1)
+	go func() { done <- c.run(ctx, []int64{1, 137}, 20*time.Millisecond, time.Hour) }()
+
+	// Wait until both chains have been polled at least once
+	deadline := time.Now().Add(5 * time.Second)
+	var body string
+	for {
+		body = scrape(t, c)
+		if strings.Contains(body, `infura_gas_busy_threshold_gwei{chain="1"}`) &&
+			strings.Contains(body, `infura_gas_poll_errors_total{chain="137",endpoint="suggestedGasFees"} 1`) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for both chains to be polled, last scrape:\n%s", body)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"# TYPE infura_gas_max_fee_gwei gauge\n",
+		`infura_gas_max_fee_gwei{chain="1",level="low"} 16.668` + "\n",
+		`infura_gas_max_fee_gwei{chain="1",level="medium"} 22.1` + "\n",
+		`infura_gas_max_fee_gwei{chain="1",level="high"} 27.5` + "\n",
+		`infura_gas_max_priority_fee_gwei{chain="1",level="low"} 0.05` + "\n",
+		`infura_gas_max_priority_fee_gwei{chain="1",level="high"} 0.3` + "\n",
+		`infura_gas_estimated_base_fee_gwei{chain="1"} 16.618` + "\n",
+		`infura_gas_network_congestion{chain="1"} 0.5` + "\n",
+		`infura_gas_busy_threshold_gwei{chain="1"} 25.5` + "\n",
+		`infura_gas_up{chain="1"} 1` + "\n",
+		`infura_gas_up{chain="137"} 0` + "\n",
+		`infura_gas_poll_errors_total{chain="1",endpoint="suggestedGasFees"} 0` + "\n",
+		"# TYPE infura_gas_polls_total counter\n",
+		`infura_gas_last_success_timestamp_seconds{chain="1"} `,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected metrics to contain %q, got:\n%s", want, body)
+		}
+	}
+	for _, unwanted := range []string{
+		`infura_gas_max_fee_gwei{chain="137"`,
+		`infura_gas_busy_threshold_gwei{chain="137"}`,
+		`infura_gas_last_success_timestamp_seconds{chain="137"}`,
+	} {
+		if strings.Contains(body, unwanted) {
+			t.Errorf("expected no %q series for a chain that never succeeded, got:\n%s", unwanted, body)
+		}
+	}
+	if !strings.Contains(body, `infura_gas_poll_errors_total{chain="137",endpoint="busyThreshold"} 1`+"\n") {
+		t.Errorf("expected a busyThreshold error to be counted for chain 137, got:\n%s", body)
+	}
+}
+
+func TestCollectorKeepsFeesAfterFailedPoll(t *testing.T) {
+	c := newCollector(nil)
+	c.observe(infura.ChainUpdate{ChainID: 1, Time: time.Unix(1700000000, 0), Err: context.DeadlineExceeded})
+
+	body := scrape(t, c)
+	for _, want := range []string{
+		`infura_gas_up{chain="1"} 0` + "\n",
+		`infura_gas_polls_total{chain="1"} 1` + "\n",
+		`infura_gas_poll_errors_total{chain="1",endpoint="suggestedGasFees"} 1` + "\n",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected metrics to contain %q, got:\n%s", want, body)
+		}
+	}
+	if strings.Contains(body, "infura_gas_max_fee_gwei") || strings.Contains(body, "infura_gas_network_congestion") {
+		t.Errorf("expected no fee series before a successful poll, got:\n%s", body)
+	}
+}
+
+func TestCollectorObserveDoesNotFetch(t *testing.T) {
+	// A nil client panics on any request, so observe must only record the update
+	c := newCollector(nil)
+	fees := &infura.SuggestedGasFees{EstimatedBaseFee: "16.618"}
+	c.observe(infura.ChainUpdate{ChainID: 1, Time: time.Unix(1700000000, 0), Fees: fees})
+
+	body := scrape(t, c)
+	if !strings.Contains(body, `infura_gas_up{chain="1"} 1`+"\n") {
+		t.Errorf("expected chain 1 to be up, got:\n%s", body)
+	}
+	if strings.Contains(body, "infura_gas_busy_threshold_gwei") {
+		t.Errorf("expected no busy threshold series before a refresh, got:\n%s", body)
+	}
+}
+
+func TestCollectorRunFailsWhenWatcherCannotStart(t *testing.T) {
+	client, err := infura.New("test-key", "")
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	if err := newCollector(client).run(context.Background(), []int64{1}, 0, time.Hour); err == nil {
+		t.Error("expected an error for a non-positive interval")
+	}
+}
+
+func TestCollectorOmitsUnsetCongestion(t *testing.T) {
+	c := newCollector(nil)
+	c.mu.Lock()
+	state := c.state(10)
+	state.up = true
+	state.fees = &infura.SuggestedGasFees{EstimatedBaseFee: "0.001"}
+	c.mu.Unlock()
+
+	body := scrape(t, c)
+	if !strings.Contains(body, `infura_gas_estimated_base_fee_gwei{chain="10"} 0.001`+"\n") {
+		t.Errorf("expected base fee series, got:\n%s", body)
+	}
+	if strings.Contains(body, "infura_gas_network_congestion") {
+		t.Errorf("expected no congestion series when the API sent none, got:\n%s", body)
+	}
+}
+
+func TestParseConfig(t *testing.T) {
+	env := map[string]string{
+		"INFURA_API_KEY":        "env-key",
+		"GAS_EXPORTER_CHAINS":   "1, 137",
+		"GAS_EXPORTER_INTERVAL": "30s",
+	}
+	cfg, err := parseConfig([]string{"-listen", "127.0.0.1:9200", "-chains", "10"}, func(k string) string { return env[k] }, io.Discard)
+	if err != nil {
+		t.Fatalf("parseConfig failed: %v", err)
+	}
+	if cfg.apiKey != "env-key" || cfg.interval != 30*time.Second || cfg.listen != "127.0.0.1:9200" {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+	if len(cfg.chains) != 1 || cfg.chains[0] != 10 {
+		t.Errorf("expected the -chains flag to override the environment, got %v", cfg.chains)
+	}
+	if cfg.busyInterval != 5*time.Minute {
+		t.Errorf("expected default busy interval 5m, got %v", cfg.busyInterval)
+	}
+	if cfg.baseURL != infura.BaseURL {
+		t.Errorf("expected default base URL, got %q", cfg.baseURL)
+	}
+
+	env["GAS_EXPORTER_CHAINS"] = "1,x"
+	if _, err := parseConfig(nil, func(k string) string { return env[k] }, io.Discard); err == nil {
+		t.Error("expected an error for an invalid chain ID")
+	}
+	if _, err := parseConfig([]string{"-chains", "1"}, func(string) string { return "" }, io.Discard); err == nil {
+		t.Error("expected an error without an API key")
+	}
+	if _, err := parseConfig([]string{"-api-key", "k", "-interval", "0s"}, func(string) string { return "" }, io.Discard); err == nil {
+		t.Error("expected an error for a non-positive interval")
+	}
+	if _, err := parseConfig([]string{"-api-key", "k", "-busy-interval", "-1m"}, func(string) string { return "" }, io.Discard); err == nil {
+		t.Error("expected an error for a non-positive busy interval")
+	}
+}
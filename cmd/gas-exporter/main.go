@@ -0,0 +1,156 @@
+// Command gas-exporter polls the Infura Gas API for a set of chains and serves the
+// suggested fees as Prometheus metrics on /metrics.
+//
+// Every flag can also be set through the environment variable shown in its usage;
+// flags take precedence:
+//
+//	INFURA_API_KEY=... gas-exporter -chains 1,137,42161 -interval 15s -listen :9101
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	infura "github.com/ABT-Tech-Limited/infura-go"
+)
+
+// config is the exporter configuration assembled from flags and the environment
+type config struct {
+	apiKey       string
+	apiKeySecret string
+	baseURL      string
+	chains       []int64
+	interval     time.Duration
+	busyInterval time.Duration
+	listen       string
+}
+
+func main() {
+	cfg, err := parseConfig(os.Args[1:], os.Getenv, os.Stderr)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(0)
+		}
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx, cfg); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// parseConfig reads the configuration from args, falling back to getenv for unset flags
+func parseConfig(args []string, getenv func(string) string, output io.Writer) (config, error) {
+	envOr := func(key, fallback string) string {
+		if v := getenv(key); v != "" {
+			return v
+		}
+		return fallback
+	}
+
+	fs := flag.NewFlagSet("gas-exporter", flag.ContinueOnError)
+	fs.SetOutput(output)
+	apiKey := fs.String("api-key", envOr("INFURA_API_KEY", ""), "Infura API key (INFURA_API_KEY)")
+	apiKeySecret := fs.String("api-key-secret", envOr("INFURA_API_KEY_SECRET", ""), "Infura API key secret, enables Basic Auth (INFURA_API_KEY_SECRET)")
+	baseURL := fs.String("base-url", envOr("INFURA_BASE_URL", infura.BaseURL), "Gas API base URL (INFURA_BASE_URL)")
+	chains := fs.String("chains", envOr("GAS_EXPORTER_CHAINS", "1"), "comma-separated chain IDs to poll (GAS_EXPORTER_CHAINS)")
+	interval := fs.String("interval", envOr("GAS_EXPORTER_INTERVAL", "15s"), "time between polls of each chain (GAS_EXPORTER_INTERVAL)")
+	busyInterval := fs.String("busy-interval", envOr("GAS_EXPORTER_BUSY_INTERVAL", "5m"), "time between busyThreshold requests of each chain (GAS_EXPORTER_BUSY_INTERVAL)")
+	listen := fs.String("listen", envOr("GAS_EXPORTER_LISTEN", ":9101"), "address to serve /metrics on (GAS_EXPORTER_LISTEN)")
+	if err := fs.Parse(args); err != nil {
+		return config{}, err
+	}
+
+	cfg := config{
+		apiKey:       *apiKey,
+		apiKeySecret: *apiKeySecret,
+		baseURL:      *baseURL,
+		listen:       *listen,
+	}
+	if cfg.apiKey == "" {
+		return config{}, fmt.Errorf("an API key is required (-api-key or INFURA_API_KEY)")
+	}
+	for _, field := range strings.Split(*chains, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		chainID, err := strconv.ParseInt(field, 10, 64)
+		if err != nil || chainID <= 0 {
+			return config{}, fmt.Errorf("invalid chain ID %q", field)
+		}
+		cfg.chains = append(cfg.chains, chainID)
+	}
+	if len(cfg.chains) == 0 {
+		return config{}, fmt.Errorf("at least one chain ID is required (-chains or GAS_EXPORTER_CHAINS)")
+	}
+	d, err := time.ParseDuration(*interval)
+	if err != nil || d <= 0 {
+		return config{}, fmt.Errorf("invalid interval %q: must be a positive duration such as 15s", *interval)
+	}
+	cfg.interval = d
+	d, err = time.ParseDuration(*busyInterval)
+	if err != nil || d <= 0 {
+		return config{}, fmt.Errorf("invalid busy interval %q: must be a positive duration such as 5m", *busyInterval)
+	}
+	cfg.busyInterval = d
+
+	return cfg, nil
+}
+
+// run polls the configured chains and serves /metrics until ctx is cancelled
+// It returns an error, stopping the server, if the watcher fails: stale metrics would
+// otherwise be served indefinitely.
+func run(ctx context.Context, cfg config) error {
+	client, err := infura.New(cfg.apiKey, cfg.apiKeySecret, infura.WithBaseURL(cfg.baseURL))
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	collector := newCollector(client)
+	watchErr := make(chan error, 1)
+	go func() {
+		err := collector.run(ctx, cfg.chains, cfg.interval, cfg.busyInterval)
+		watchErr <- err
+		if err != nil {
+			cancel()
+		}
+	}()
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", collector)
+	server := &http.Server{Addr: cfg.listen, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	log.Printf("serving metrics for chains %v on %s/metrics", cfg.chains, cfg.listen)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	if err := <-watchErr; err != nil {
+		return fmt.Errorf("watcher stopped: %w", err)
+	}
+	return nil
+}
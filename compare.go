@@ -0,0 +1,174 @@
+package infura
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// maxReportedMismatches is the number of mismatches FeeEquals and FeeLevelEquals describe
+const maxReportedMismatches = 5
+
+// FeeEquals reports whether two suggestions match, treating numeric fields (fees, wait
+// times, congestion and ranges) as equal when they differ by at most tolerancePercent of
+// the larger value, e.g. 0.5 for 0.5%. Trends must match exactly. If they do not match,
+// the string describes the first mismatches by JSON field name with both values, e.g.
+// `medium.suggestedMaxFeePerGas: "24.5" vs "25.1"`. It is meant for tests comparing
+// expected and actual fees. A NaN or negative tolerance is invalid: the suggestions never
+// match and the string describes the tolerance.
+func FeeEquals(a, b *SuggestedGasFees, tolerancePercent float64) (bool, string) {
+	if invalid := invalidTolerance(tolerancePercent); invalid != "" {
+		return false, invalid
+	}
+	if a == nil || b == nil {
+		if a == b {
+			return true, ""
+		}
+		return false, fmt.Sprintf("fees: %v vs %v", feesOrNil(a), feesOrNil(b))
+	}
+
+	d := feeDiff{tolerance: tolerancePercent}
+	d.level("low", a.Low, b.Low)
+	d.level("medium", a.Medium, b.Medium)
+	d.level("high", a.High, b.High)
+	d.gwei("estimatedBaseFee", a.EstimatedBaseFee, b.EstimatedBaseFee)
+	d.congestion(a.NetworkCongestion, b.NetworkCongestion)
+	d.gweiRange("latestPriorityFeeRange", a.LatestPriorityFeeRange, b.LatestPriorityFeeRange)
+	d.gweiRange("historicalPriorityFeeRange", a.HistoricalPriorityFeeRange, b.HistoricalPriorityFeeRange)
+	d.gweiRange("historicalBaseFeeRange", a.HistoricalBaseFeeRange, b.HistoricalBaseFeeRange)
+	d.exact("priorityFeeTrend", a.PriorityFeeTrend, b.PriorityFeeTrend)
+	d.exact("baseFeeTrend", a.BaseFeeTrend, b.BaseFeeTrend)
+	return d.result()
+}
+
+// FeeLevelEquals is FeeEquals for a single fee level
+func FeeLevelEquals(a, b GasFeeLevel, tolerancePercent float64) (bool, string) {
+	if invalid := invalidTolerance(tolerancePercent); invalid != "" {
+		return false, invalid
+	}
+	d := feeDiff{tolerance: tolerancePercent}
+	d.level("", a, b)
+	return d.result()
+}
+
+// invalidTolerance describes a NaN or negative tolerance, and is empty for a valid one
+func invalidTolerance(tolerancePercent float64) string {
+	if math.IsNaN(tolerancePercent) || tolerancePercent < 0 {
+		return fmt.Sprintf("tolerance must be a non-negative percentage, got %v", tolerancePercent)
+	}
+	return ""
+}
+
+// feesOrNil formats a possibly nil suggestion for a mismatch description
+func feesOrNil(f *SuggestedGasFees) string {
+	if f == nil {
+		return "nil"
+	}
+	return "non-nil"
+}
+
+// feeDiff collects the mismatches between two suggestions
+type feeDiff struct {
+	tolerance  float64
+	mismatches []string
+	total      int
+}
+
+// mismatch records a field whose values differ
+func (d *feeDiff) mismatch(field string, a, b interface{}) {
+	d.total++
+	if len(d.mismatches) < maxReportedMismatches {
+		d.mismatches = append(d.mismatches, fmt.Sprintf("%s: %v vs %v", field, a, b))
+	}
+}
+
+// result returns whether no mismatch was found and the description of the mismatches
+func (d *feeDiff) result() (bool, string) {
+	if d.total == 0 {
+		return true, ""
+	}
+	diff := strings.Join(d.mismatches, "; ")
+	if d.total > len(d.mismatches) {
+		diff += fmt.Sprintf(" (and %d more)", d.total-len(d.mismatches))
+	}
+	return false, diff
+}
+
+func (d *feeDiff) level(name string, a, b GasFeeLevel) {
+	prefix := ""
+	if name != "" {
+		prefix = name + "."
+	}
+	d.gwei(prefix+"suggestedMaxPriorityFeePerGas", a.SuggestedMaxPriorityFeePerGas, b.SuggestedMaxPriorityFeePerGas)
+	d.gwei(prefix+"suggestedMaxFeePerGas", a.SuggestedMaxFeePerGas, b.SuggestedMaxFeePerGas)
+	d.number(prefix+"minWaitTimeEstimate", big.NewFloat(float64(a.MinWaitTimeEstimate)), big.NewFloat(float64(b.MinWaitTimeEstimate)), a.MinWaitTimeEstimate, b.MinWaitTimeEstimate)
+	d.number(prefix+"maxWaitTimeEstimate", big.NewFloat(float64(a.MaxWaitTimeEstimate)), big.NewFloat(float64(b.MaxWaitTimeEstimate)), a.MaxWaitTimeEstimate, b.MaxWaitTimeEstimate)
+}
+
+// gwei compares two Gwei strings numerically with CompareGwei, then the tolerance on
+// their values in wei; values CompareGwei rejects must match exactly
+func (d *feeDiff) gwei(field, a, b string) {
+	c, err := CompareGwei(a, b)
+	if err != nil {
+		d.exact(field, a, b)
+		return
+	}
+	if c == 0 {
+		return
+	}
+	x, _ := gweiToWei(a)
+	y, _ := gweiToWei(b)
+	d.number(field, new(big.Float).SetInt(x), new(big.Float).SetInt(y), strconv.Quote(a), strconv.Quote(b))
+}
+
+func (d *feeDiff) gweiRange(field string, a, b []string) {
+	if len(a) != len(b) {
+		d.mismatch(field, fmt.Sprintf("%q", a), fmt.Sprintf("%q", b))
+		return
+	}
+	for i := range a {
+		d.gwei(fmt.Sprintf("%s[%d]", field, i), a[i], b[i])
+	}
+}
+
+func (d *feeDiff) congestion(a, b Congestion) {
+	if !a.IsSet() || !b.IsSet() {
+		if a.IsSet() != b.IsSet() {
+			d.mismatch("networkCongestion", a, b)
+		}
+		return
+	}
+	d.number("networkCongestion", big.NewFloat(a.Float64()), big.NewFloat(b.Float64()), a, b)
+}
+
+// exact records a mismatch unless a and b are identical
+func (d *feeDiff) exact(field, a, b string) {
+	if a != b {
+		d.mismatch(field, strconv.Quote(a), strconv.Quote(b))
+	}
+}
+
+// number records a mismatch if x and y differ by more than the tolerance; shownA and
+// shownB are the values to report
+func (d *feeDiff) number(field string, x, y *big.Float, shownA, shownB interface{}) {
+	if !withinTolerance(x, y, d.tolerance) {
+		d.mismatch(field, shownA, shownB)
+	}
+}
+
+// withinTolerance reports whether |x - y| <= tolerancePercent/100 * max(|x|, |y|)
+func withinTolerance(x, y *big.Float, tolerancePercent float64) bool {
+	if x.Cmp(y) == 0 {
+		return true
+	}
+	diff := new(big.Float).SetPrec(gweiPrecision).Sub(x, y)
+	diff.Abs(diff)
+	largest := new(big.Float).Abs(x)
+	if abs := new(big.Float).Abs(y); abs.Cmp(largest) > 0 {
+		largest = abs
+	}
+	allowed := new(big.Float).SetPrec(gweiPrecision).Mul(largest, big.NewFloat(tolerancePercent/100))
+	return diff.Cmp(allowed) <= 0
+}
@@ -0,0 +1,175 @@
+package infura
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func compareFees() *SuggestedGasFees {
+	return &SuggestedGasFees{
+		Low:                        GasFeeLevel{SuggestedMaxPriorityFeePerGas: "0.05", SuggestedMaxFeePerGas: "24.086058416", MinWaitTimeEstimate: 15000, MaxWaitTimeEstimate: 30000},
+		Medium:                     GasFeeLevel{SuggestedMaxPriorityFeePerGas: "0.1", SuggestedMaxFeePerGas: "32.548151972", MinWaitTimeEstimate: 15000, MaxWaitTimeEstimate: 45000},
+		High:                       GasFeeLevel{SuggestedMaxPriorityFeePerGas: "0.3", SuggestedMaxFeePerGas: "41.161245528", MinWaitTimeEstimate: 15000, MaxWaitTimeEstimate: 60000},
+		EstimatedBaseFee:           "24.036058416",
+		NetworkCongestion:          NewCongestion(0.7143),
+		LatestPriorityFeeRange:     []string{"0.1", "20"},
+		HistoricalPriorityFeeRange: []string{"0.007150439", "113.939736998"},
+		HistoricalBaseFeeRange:     []string{"19.531410688", "36.299069766"},
+		PriorityFeeTrend:           "down",
+		BaseFeeTrend:               "up",
+	}
+}
+
+func TestFeeEquals(t *testing.T) {
+	tests := []struct {
+		name      string
+		modify    func(f *SuggestedGasFees)
+		tolerance float64
+		want      bool
+		wantDiff  []string
+	}{
+		{name: "exact match", modify: func(*SuggestedGasFees) {}, want: true},
+		{
+			name:   "equal numbers written differently",
+			modify: func(f *SuggestedGasFees) { f.Low.SuggestedMaxPriorityFeePerGas = "0.050" },
+			want:   true,
+		},
+		{
+			name: "within tolerance",
+			modify: func(f *SuggestedGasFees) {
+				f.Medium.SuggestedMaxFeePerGas = "32.6"
+				f.NetworkCongestion = NewCongestion(0.715)
+			},
+			tolerance: 1,
+			want:      true,
+		},
+		{
+			name:      "outside tolerance",
+			modify:    func(f *SuggestedGasFees) { f.Medium.SuggestedMaxFeePerGas = "33" },
+			tolerance: 1,
+			wantDiff:  []string{`medium.suggestedMaxFeePerGas: "32.548151972" vs "33"`},
+		},
+		{
+			name:     "exact comparison without tolerance",
+			modify:   func(f *SuggestedGasFees) { f.High.MaxWaitTimeEstimate = 60001 },
+			wantDiff: []string{"high.maxWaitTimeEstimate: 60000 vs 60001"},
+		},
+		{
+			name:      "mismatched trend is always significant",
+			modify:    func(f *SuggestedGasFees) { f.BaseFeeTrend = "down" },
+			tolerance: 100,
+			wantDiff:  []string{`baseFeeTrend: "up" vs "down"`},
+		},
+		{
+			name:      "range length",
+			modify:    func(f *SuggestedGasFees) { f.LatestPriorityFeeRange = []string{"0.1"} },
+			tolerance: 5,
+			wantDiff:  []string{`latestPriorityFeeRange: ["0.1" "20"] vs ["0.1"]`},
+		},
+		{
+			name:      "range element",
+			modify:    func(f *SuggestedGasFees) { f.HistoricalBaseFeeRange[1] = "40" },
+			tolerance: 5,
+			wantDiff:  []string{`historicalBaseFeeRange[1]: "36.299069766" vs "40"`},
+		},
+		{
+			name:      "unset congestion",
+			modify:    func(f *SuggestedGasFees) { f.NetworkCongestion = Congestion{} },
+			tolerance: 5,
+			wantDiff:  []string{"networkCongestion: 0.7143 vs unset"},
+		},
+		{
+			name:      "unparseable fee compared exactly",
+			modify:    func(f *SuggestedGasFees) { f.EstimatedBaseFee = "n/a" },
+			tolerance: 5,
+			wantDiff:  []string{`estimatedBaseFee: "24.036058416" vs "n/a"`},
+		},
+		{
+			name:   "spelling accepted by NormalizeGwei",
+			modify: func(f *SuggestedGasFees) { f.EstimatedBaseFee = "+24.036058416" },
+		},
+		{
+			name:      "more than 9 decimal places compared exactly",
+			modify:    func(f *SuggestedGasFees) { f.EstimatedBaseFee = "24.0360584160" },
+			tolerance: 5,
+			wantDiff:  []string{`estimatedBaseFee: "24.036058416" vs "24.0360584160"`},
+		},
+		{
+			name: "reports first mismatches",
+			modify: func(f *SuggestedGasFees) {
+				for _, level := range []*GasFeeLevel{&f.Low, &f.Medium, &f.High} {
+					level.SuggestedMaxPriorityFeePerGas = "9"
+					level.SuggestedMaxFeePerGas = "99"
+				}
+			},
+			wantDiff: []string{
+				`low.suggestedMaxPriorityFeePerGas: "0.05" vs "9"`,
+				`high.suggestedMaxPriorityFeePerGas: "0.3" vs "9"`,
+				"(and 1 more)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := compareFees(), compareFees()
+			tt.modify(b)
+			want := tt.want || len(tt.wantDiff) == 0
+			got, diff := FeeEquals(a, b, tt.tolerance)
+			if got != want {
+				t.Fatalf("Expected FeeEquals to be %v, got %v (%s)", want, got, diff)
+			}
+			if want && diff != "" {
+				t.Errorf("Expected no diff, got %q", diff)
+			}
+			for _, part := range tt.wantDiff {
+				if !strings.Contains(diff, part) {
+					t.Errorf("Expected the diff to contain %q, got %q", part, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestFeeEqualsNil(t *testing.T) {
+	if ok, diff := FeeEquals(nil, nil, 0); !ok || diff != "" {
+		t.Errorf("Expected nil and nil to match, got %v, %q", ok, diff)
+	}
+	if ok, diff := FeeEquals(compareFees(), nil, 0); ok || diff != "fees: non-nil vs nil" {
+		t.Errorf("Expected fees and nil not to match, got %v, %q", ok, diff)
+	}
+}
+
+func TestFeeLevelEquals(t *testing.T) {
+	a := GasFeeLevel{SuggestedMaxPriorityFeePerGas: "2", SuggestedMaxFeePerGas: "100", MinWaitTimeEstimate: 15000, MaxWaitTimeEstimate: 30000}
+
+	b := a
+	b.SuggestedMaxFeePerGas = "100.4"
+	b.MaxWaitTimeEstimate = 30100
+	if ok, diff := FeeLevelEquals(a, b, 0.5); !ok {
+		t.Errorf("Expected a match within tolerance, got %s", diff)
+	}
+
+	ok, diff := FeeLevelEquals(a, b, 0.1)
+	if ok {
+		t.Fatal("Expected no match outside tolerance, got a match")
+	}
+	want := `suggestedMaxFeePerGas: "100" vs "100.4"; maxWaitTimeEstimate: 30000 vs 30100`
+	if diff != want {
+		t.Errorf("Expected diff %q, got %q", want, diff)
+	}
+}
+
+func TestFeeEqualsInvalidTolerance(t *testing.T) {
+	for _, tolerance := range []float64{math.NaN(), -1} {
+		ok, diff := FeeEquals(compareFees(), compareFees(), tolerance)
+		if ok || !strings.Contains(diff, "tolerance must be a non-negative percentage") {
+			t.Errorf("Expected tolerance %v to be rejected, got %v, %q", tolerance, ok, diff)
+		}
+		ok, diff = FeeLevelEquals(GasFeeLevel{}, GasFeeLevel{}, tolerance)
+		if ok || !strings.Contains(diff, "tolerance must be a non-negative percentage") {
+			t.Errorf("Expected tolerance %v to be rejected for a level, got %v, %q", tolerance, ok, diff)
+		}
+	}
+}
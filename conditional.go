@@ -0,0 +1,89 @@
+package infura
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+)
+
+// errNotModified is returned by doJSONAttempt for a 304 response to a conditional request
+var errNotModified = errors.New("not modified")
+
+// etagStore keeps the last ETag and body per (resource, chain)
+type etagStore struct {
+	mu      sync.RWMutex
+	entries map[string]etagEntry
+}
+
+type etagEntry struct {
+	etag string
+	body []byte
+}
+
+func (s *etagStore) get(key string) (etagEntry, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entry, ok := s.entries[key]
+	return entry, ok
+}
+
+func (s *etagStore) set(key string, entry etagEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[key] = entry
+}
+
+// WithConditionalRequests remembers the ETag of every per-network response and sends it as
+// If-None-Match on the next request for the same resource and chain. A 304 Not Modified
+// response is treated as success and the previously received body is returned (flagged via
+// CallMeta.NotModified). Combined with WithCache, a 304 also refreshes the cache entry.
+func WithConditionalRequests() ClientOption {
+	return func(c *Client) {
+		c.etags = &etagStore{entries: make(map[string]etagEntry)}
+	}
+}
+
+// fetchNetworkResourceRaw fetches the raw body of a per-network resource, using a conditional
+// request when enabled
+func (c *Client) fetchNetworkResourceRaw(ctx context.Context, creds *credentials, settings requestSettings, chainID int64, resource string) (json.RawMessage, error) {
+	endpoint := networkEndpoint(creds, chainID, resource)
+	if c.etags == nil {
+		var raw json.RawMessage
+		err := c.doJSONRequestWithCredentials(ctx, creds, settings, "GET", endpoint, nil, &raw)
+		return raw, err
+	}
+
+	key := cacheKey(chainID, resource)
+	stored, ok := c.etags.get(key)
+	if ok {
+		settings.headers = settings.headers.Clone()
+		if settings.headers == nil {
+			settings.headers = make(http.Header)
+		}
+		settings.headers.Set("If-None-Match", stored.etag)
+	}
+
+	var etag string
+	settings.onResponse = func(resp *http.Response) {
+		etag = resp.Header.Get("ETag")
+	}
+
+	var raw json.RawMessage
+	err := c.doJSONRequestWithCredentials(ctx, creds, settings, "GET", endpoint, nil, &raw)
+	if errors.Is(err, errNotModified) && ok {
+		recordCallMeta(ctx, func(meta *CallMeta) {
+			meta.NotModified = true
+		})
+		return stored.body, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if etag != "" {
+		c.etags.set(key, etagEntry{etag: etag, body: append([]byte(nil), raw...)})
+	}
+	return raw, nil
+}
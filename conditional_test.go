@@ -0,0 +1,161 @@
+package infura
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newETagServer serves body with ETag "v1" and answers 304 when the client presents it
+func newETagServer(t *testing.T, body string, requests, notModified *atomic.Int32) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+}
+
+func TestWithConditionalRequests_NotModified(t *testing.T) {
+	var requests, notModified atomic.Int32
+	server := newETagServer(t, `{"estimatedBaseFee": "30"}`, &requests, &notModified)
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithConditionalRequests())
+
+	var meta CallMeta
+	first, err := client.GetSuggestedGasFees(ContextWithCallMeta(context.Background(), &meta), 1)
+	if err != nil {
+		t.Fatalf("First request failed: %v", err)
+	}
+	if meta.NotModified {
+		t.Error("Expected NotModified to be false for the first request")
+	}
+
+	meta = CallMeta{}
+	second, err := client.GetSuggestedGasFees(ContextWithCallMeta(context.Background(), &meta), 1)
+	if err != nil {
+		t.Fatalf("Conditional request failed: %v", err)
+	}
+	if !meta.NotModified {
+		t.Error("Expected NotModified to be true after a 304")
+	}
+	if second.EstimatedBaseFee != first.EstimatedBaseFee {
+		t.Errorf("Expected the previous body to be reused, got base fee %s", second.EstimatedBaseFee)
+	}
+	if got := notModified.Load(); got != 1 {
+		t.Errorf("Expected 1 conditional hit, got %d", got)
+	}
+
+	// ETags are kept per chain, so another chain gets a full response
+	if _, err := client.GetSuggestedGasFees(context.Background(), 137); err != nil {
+		t.Fatalf("Request for another chain failed: %v", err)
+	}
+	if got := notModified.Load(); got != 1 {
+		t.Errorf("Expected no conditional request for a new chain, got %d hits", got)
+	}
+}
+
+func TestWithConditionalRequests_Disabled(t *testing.T) {
+	var requests, notModified atomic.Int32
+	server := newETagServer(t, `{"estimatedBaseFee": "30"}`, &requests, &notModified)
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL))
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetSuggestedGasFees(context.Background(), 1); err != nil {
+			t.Fatalf("Request %d failed: %v", i, err)
+		}
+	}
+	if got := notModified.Load(); got != 0 {
+		t.Errorf("Expected no conditional requests without the option, got %d", got)
+	}
+}
+
+func TestWithConditionalRequests_RefreshesCache(t *testing.T) {
+	var requests, notModified atomic.Int32
+	server := newETagServer(t, `{"estimatedBaseFee": "30"}`, &requests, &notModified)
+	defer server.Close()
+
+	clock := newFakeClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithClock(clock),
+		WithCache(time.Minute),
+		WithConditionalRequests())
+
+	ctx := context.Background()
+	if _, err := client.GetSuggestedGasFees(ctx, 1); err != nil {
+		t.Fatalf("First request failed: %v", err)
+	}
+	if _, err := client.GetSuggestedGasFees(ctx, 1); err != nil {
+		t.Fatalf("Cached request failed: %v", err)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Fatalf("Expected the second call to be served from cache, got %d requests", got)
+	}
+
+	clock.Advance(2 * time.Minute)
+	var meta CallMeta
+	result, err := client.GetSuggestedGasFees(ContextWithCallMeta(ctx, &meta), 1)
+	if err != nil {
+		t.Fatalf("Revalidation failed: %v", err)
+	}
+	if result.EstimatedBaseFee != "30" || !meta.NotModified {
+		t.Errorf("Expected the cached body revalidated by a 304, got %+v (meta %+v)", result, meta)
+	}
+
+	// The 304 refreshed the cache entry
+	if _, err := client.GetSuggestedGasFees(ctx, 1); err != nil {
+		t.Fatalf("Request after revalidation failed: %v", err)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("Expected 2 requests in total, got %d", got)
+	}
+}
+
+func TestWithConditionalRequests_Concurrent(t *testing.T) {
+	var requests, notModified atomic.Int32
+	server := newETagServer(t, `{"estimatedBaseFee": "30"}`, &requests, &notModified)
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithConditionalRequests())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(chainID int64) {
+			defer wg.Done()
+			for j := 0; j < 3; j++ {
+				result, err := client.GetSuggestedGasFees(context.Background(), chainID)
+				if err != nil {
+					t.Errorf("chain %d: %v", chainID, err)
+					return
+				}
+				if result.EstimatedBaseFee != "30" {
+					t.Errorf("chain %d: unexpected base fee %s", chainID, result.EstimatedBaseFee)
+				}
+			}
+		}(int64(i % 4))
+	}
+	wg.Wait()
+
+	if notModified.Load() == 0 {
+		t.Error("Expected some requests to be answered with 304")
+	}
+}
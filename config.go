@@ -0,0 +1,277 @@
+package infura
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+// redacted replaces credential values in ClientConfig
+const redacted = "REDACTED"
+
+// AuthMode is how a client authenticates to the API
+type AuthMode string
+
+const (
+	// AuthModeBasic sends the API Key and Secret as a Basic Auth header
+	AuthModeBasic AuthMode = "basic"
+	// AuthModeURLPath puts the API Key in the URL path
+	AuthModeURLPath AuthMode = "url-path"
+)
+
+// authModeOf returns the auth mode used with the given credentials
+func authModeOf(creds *credentials) AuthMode {
+	if creds.basicAuth() {
+		return AuthModeBasic
+	}
+	return AuthModeURLPath
+}
+
+// BaseURL returns the base URL requests are sent to
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// AuthMode returns the current auth mode, which follows SetCredentials and WithAuthMode
+func (c *Client) AuthMode() AuthMode {
+	return authModeOf(c.credentials())
+}
+
+// WithAuthMode forces the auth mode instead of deriving it from the API Key Secret
+// AuthModeURLPath puts the API Key in the URL path and never sends the secret;
+// AuthModeBasic sends a Basic Auth header, with an empty secret if none is set, e.g. for a
+// proxy that authenticates on the key alone. It applies to the constructor credentials,
+// those set later with SetCredentials and the keys of WithAPIKeyPool. Other modes are
+// invalid.
+func WithAuthMode(mode AuthMode) ClientOption {
+	return func(c *Client) {
+		if mode != AuthModeBasic && mode != AuthModeURLPath {
+			c.rejectOption("WithAuthMode", "unknown auth mode %q", mode)
+			return
+		}
+		c.authMode = mode
+	}
+}
+
+// finalizeAuthMode applies the mode forced with WithAuthMode to the credentials set by the
+// constructor and WithAPIKeyPool. It runs once after all options have been applied
+func (c *Client) finalizeAuthMode() {
+	if c.authMode == "" {
+		return
+	}
+	if creds := c.creds.Load(); creds != nil {
+		c.creds.Store(&credentials{apiKey: creds.apiKey, apiKeySecret: creds.apiKeySecret, mode: c.authMode})
+	}
+	if c.keyPool != nil {
+		for _, key := range c.keyPool.keys {
+			key.creds = &credentials{apiKey: key.creds.apiKey, apiKeySecret: key.creds.apiKeySecret, mode: c.authMode}
+		}
+	}
+}
+
+// Timeout returns the client-wide HTTP timeout (0 means no timeout)
+func (c *Client) Timeout() time.Duration {
+	if c.httpClient == nil {
+		return 0
+	}
+	return c.httpClient.Timeout
+}
+
+// APIKeyMasked returns the current API key with all but its first and last 4 characters
+// masked (see String); the secret is never exposed
+func (c *Client) APIKeyMasked() string {
+	return maskAPIKey(c.credentials().apiKey)
+}
+
+// ClientConfig is a read-only snapshot of a client's settings for diagnostics
+// Credentials are never included; APIKey is REDACTED when set
+type ClientConfig struct {
+	BaseURL string
+	// PathPrefix is the path set with WithPathPrefix, without surrounding slashes
+	PathPrefix string
+	// AuthMode is "basic" when an API Key Secret is set, otherwise "url-path", unless
+	// WithAuthMode forces one
+	AuthMode AuthMode
+	APIKey   string
+	Timeout  time.Duration
+	Debug    bool
+
+	// RateLimit is the requests per second allowed by WithRateLimit (0 = unlimited)
+	RateLimit     float64
+	RateBurst     int
+	RateLimitMode RateLimitMode
+	// MaxInFlight is the concurrency limit set with WithMaxInFlight (0 = unlimited)
+	MaxInFlight      int
+	InFlightFailFast bool
+
+	RetryMaxAttempts int
+	RetryBaseDelay   time.Duration
+	// RetryMaxElapsed is the retry budget set with WithMaxRetryElapsed (0 = unbounded)
+	RetryMaxElapsed time.Duration
+
+	AcceptEncoding string
+	UserAgent      string
+
+	CacheTTL    time.Duration
+	MaxStaleAge time.Duration
+
+	FallbackChains []int64
+
+	RPCConfigured   bool
+	SanityMaxFactor float64
+
+	FeeCapChains []int64
+	FeeCapMode   FeeCapMode
+
+	DeprecationHandler  bool
+	DeprecationWarnings bool
+
+	// ChainOverrides lists the chains with settings overridden by WithChainOverrides
+	ChainOverrides []int64
+
+	ConditionalRequests bool
+	Metrics             bool
+
+	// CreditLimit is the limit set with WithDailyCreditLimit (0 = unlimited)
+	CreditLimit         int64
+	CreditResetInterval time.Duration
+	CreditResetBoundary CreditResetBoundary
+
+	// CallHistorySize is the number of calls kept by WithCallHistory (0 = disabled)
+	CallHistorySize int
+
+	FailoverURLs []string
+}
+
+// Config returns a snapshot of the client's current configuration with credentials redacted
+func (c *Client) Config() ClientConfig {
+	creds := c.credentials()
+	cfg := ClientConfig{
+		BaseURL:             c.baseURL,
+		PathPrefix:          c.pathPrefix,
+		Timeout:             c.Timeout(),
+		AuthMode:            authModeOf(creds),
+		Debug:               c.Debug(),
+		RetryMaxAttempts:    c.maxAttempts(),
+		RetryBaseDelay:      c.retry.baseDelay,
+		RetryMaxElapsed:     c.retry.maxElapsed,
+		AcceptEncoding:      c.acceptEncoding,
+		UserAgent:           c.userAgent,
+		CacheTTL:            c.cacheTTL,
+		MaxStaleAge:         c.maxStaleAge,
+		FallbackChains:      slices.Sorted(maps.Keys(c.fallbackFees)),
+		RPCConfigured:       c.rpc != nil,
+		DeprecationHandler:  c.deprecationHandler != nil,
+		DeprecationWarnings: c.deprecationWarnings,
+		ChainOverrides:      slices.Sorted(maps.Keys(c.chainOverrides)),
+		ConditionalRequests: c.etags != nil,
+		FailoverURLs:        slices.Clone(c.failover.urls),
+		Metrics:             c.metrics != nil,
+	}
+	if c.credits != nil {
+		cfg.CreditLimit = c.credits.limit
+		cfg.CreditResetInterval = c.credits.interval
+		cfg.CreditResetBoundary = c.credits.boundary
+	}
+	if c.history != nil {
+		cfg.CallHistorySize = len(c.history.records)
+	}
+	if creds.apiKey != "" {
+		cfg.APIKey = redacted
+	}
+	if c.rateLimiter != nil {
+		cfg.RateLimit = float64(c.rateLimiter.Limit())
+		cfg.RateBurst = c.rateLimiter.Burst()
+		cfg.RateLimitMode = c.rateMode
+	}
+	if c.inFlight.enabled() {
+		cfg.MaxInFlight = cap(c.inFlight.slots)
+		cfg.InFlightFailFast = c.inFlight.failFast
+	}
+	if c.sanity != nil {
+		cfg.SanityMaxFactor = c.sanity.config.MaxFactor
+	}
+	if c.feeCap != nil {
+		cfg.FeeCapChains = slices.Sorted(maps.Keys(c.feeCap.caps))
+		cfg.FeeCapMode = c.feeCap.mode
+	}
+	return cfg
+}
+
+// String formats the configuration as one "Name: value" line per setting
+func (cfg ClientConfig) String() string {
+	var b strings.Builder
+	line := func(name string, value interface{}) {
+		fmt.Fprintf(&b, "%s: %v\n", name, value)
+	}
+	line("BaseURL", cfg.BaseURL)
+	line("PathPrefix", cfg.PathPrefix)
+	line("AuthMode", cfg.AuthMode)
+	line("APIKey", cfg.APIKey)
+	line("Timeout", cfg.Timeout)
+	line("Debug", cfg.Debug)
+	line("RateLimit", cfg.RateLimit)
+	line("RateBurst", cfg.RateBurst)
+	line("RateLimitMode", cfg.RateLimitMode)
+	line("MaxInFlight", cfg.MaxInFlight)
+	line("InFlightFailFast", cfg.InFlightFailFast)
+	line("RetryMaxAttempts", cfg.RetryMaxAttempts)
+	line("RetryBaseDelay", cfg.RetryBaseDelay)
+	line("RetryMaxElapsed", cfg.RetryMaxElapsed)
+	line("AcceptEncoding", cfg.AcceptEncoding)
+	line("UserAgent", cfg.UserAgent)
+	line("CacheTTL", cfg.CacheTTL)
+	line("MaxStaleAge", cfg.MaxStaleAge)
+	line("FallbackChains", cfg.FallbackChains)
+	line("RPCConfigured", cfg.RPCConfigured)
+	line("SanityMaxFactor", cfg.SanityMaxFactor)
+	line("FeeCapChains", cfg.FeeCapChains)
+	line("FeeCapMode", cfg.FeeCapMode)
+	line("DeprecationHandler", cfg.DeprecationHandler)
+	line("DeprecationWarnings", cfg.DeprecationWarnings)
+	line("ChainOverrides", cfg.ChainOverrides)
+	line("ConditionalRequests", cfg.ConditionalRequests)
+	line("Metrics", cfg.Metrics)
+	line("CreditLimit", cfg.CreditLimit)
+	line("CreditResetInterval", cfg.CreditResetInterval)
+	line("CreditResetBoundary", cfg.CreditResetBoundary)
+	line("CallHistorySize", cfg.CallHistorySize)
+	line("FailoverURLs", cfg.FailoverURLs)
+	return b.String()
+}
+
+// String returns a one-line summary of the client for logs and panic handlers
+// The API key is masked to its first and last 4 characters and the secret is never shown
+func (c *Client) String() string {
+	if c == nil {
+		return "infura.Client(nil)"
+	}
+	creds := c.credentials()
+	return fmt.Sprintf("infura.Client{BaseURL: %s, AuthMode: %s, APIKey: %s, Timeout: %v, Debug: %v}",
+		c.baseURL, authModeOf(creds), maskAPIKey(creds.apiKey), c.Timeout(), c.Debug())
+}
+
+// GoString implements fmt.GoStringer so %#v is redacted like String
+func (c *Client) GoString() string {
+	if c == nil {
+		return "(*infura.Client)(nil)"
+	}
+	creds := c.credentials()
+	return fmt.Sprintf("&infura.Client{BaseURL:%q, AuthMode:%q, APIKey:%q, Timeout:%d, Debug:%t}",
+		c.baseURL, authModeOf(creds), maskAPIKey(creds.apiKey), c.Timeout(), c.Debug())
+}
+
+// maskAPIKey keeps the first and last 4 characters of an API key
+// Keys of 12 characters or fewer are masked completely, since showing 8 of them would reveal most of the key
+func maskAPIKey(key string) string {
+	switch {
+	case key == "":
+		return ""
+	case len(key) <= 12:
+		return "****"
+	default:
+		return key[:4] + "..." + key[len(key)-4:]
+	}
+}
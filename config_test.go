@@ -0,0 +1,261 @@
+package infura
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestClientConfig_RedactsCredentials(t *testing.T) {
+	client := NewClientWithOptions("secret-api-key", "secret-api-secret",
+		WithBaseURL("https://proxy.example.com/infura"),
+		WithTimeout(5*time.Second),
+		WithDebug(true),
+		WithRateLimit(10, 2),
+		WithRetry(3, 100*time.Millisecond),
+		WithCache(time.Minute),
+		WithFallbackFees(testFallbackFees),
+		WithMaxFeeCap(map[int64]FeeCap{137: {}, 1: {}}, FeeCapClamp))
+
+	cfg := client.Config()
+	if cfg.BaseURL != "https://proxy.example.com/infura" {
+		t.Errorf("Expected base URL, got %s", cfg.BaseURL)
+	}
+	if cfg.AuthMode != "basic" {
+		t.Errorf("Expected basic auth mode, got %s", cfg.AuthMode)
+	}
+	if cfg.Timeout != 5*time.Second || !cfg.Debug {
+		t.Errorf("Expected timeout and debug to be reflected, got %v/%v", cfg.Timeout, cfg.Debug)
+	}
+	if cfg.RateLimit != 10 || cfg.RateBurst != 2 {
+		t.Errorf("Expected rate limit 10/2, got %v/%d", cfg.RateLimit, cfg.RateBurst)
+	}
+	if cfg.RetryMaxAttempts != 3 || cfg.RetryBaseDelay != 100*time.Millisecond {
+		t.Errorf("Expected retry 3/100ms, got %d/%v", cfg.RetryMaxAttempts, cfg.RetryBaseDelay)
+	}
+	if cfg.CacheTTL != time.Minute {
+		t.Errorf("Expected cache TTL 1m, got %v", cfg.CacheTTL)
+	}
+	if len(cfg.FallbackChains) != 1 || cfg.FallbackChains[0] != 1 {
+		t.Errorf("Expected fallback chains [1], got %v", cfg.FallbackChains)
+	}
+	if len(cfg.FeeCapChains) != 2 || cfg.FeeCapChains[0] != 1 || cfg.FeeCapMode != FeeCapClamp {
+		t.Errorf("Expected sorted fee cap chains [1 137] in clamp mode, got %v %s", cfg.FeeCapChains, cfg.FeeCapMode)
+	}
+
+	output := cfg.String()
+	for _, secret := range []string{"secret-api-key", "secret-api-secret"} {
+		if strings.Contains(output, secret) || strings.Contains(cfg.APIKey, secret) {
+			t.Errorf("Config leaks credential %q:\n%s", secret, output)
+		}
+	}
+	for _, want := range []string{"APIKey: REDACTED", "BaseURL: https://proxy.example.com/infura", "FeeCapMode: clamp"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in output:\n%s", want, output)
+		}
+	}
+}
+
+func TestClientConfig_Defaults(t *testing.T) {
+	cfg := NewClientWithAPIKey("test-api-key").Config()
+
+	if cfg.BaseURL != BaseURL || cfg.AuthMode != "url-path" || cfg.Timeout != DefaultTimeout {
+		t.Errorf("Unexpected defaults: %+v", cfg)
+	}
+	if cfg.RetryMaxAttempts != 1 || cfg.RateLimit != 0 {
+		t.Errorf("Expected no retry or rate limit by default, got %+v", cfg)
+	}
+}
+
+func TestClientConfig_Snapshot(t *testing.T) {
+	client := NewClientWithAPIKey("test-api-key")
+	cfg := client.Config()
+
+	client.SetDebug(true)
+	if cfg.Debug {
+		t.Error("Expected snapshot to be unaffected by later changes")
+	}
+	if !client.Config().Debug {
+		t.Error("Expected new snapshot to reflect SetDebug")
+	}
+}
+
+func TestClient_StringRedactsCredentials(t *testing.T) {
+	const (
+		apiKey = "0123456789abcdef0123456789abcdef"
+		secret = "super-secret-value-9f8e7d"
+	)
+	client := NewClientWithOptions(apiKey, secret, WithBaseURL("https://gas.example.com"), WithTimeout(5*time.Second))
+
+	type holder struct {
+		Name   string
+		Client *Client
+	}
+
+	for _, format := range []string{"%v", "%+v", "%#v", "%s"} {
+		for _, value := range []interface{}{client, holder{Name: "svc", Client: client}} {
+			out := fmt.Sprintf(format, value)
+			if strings.Contains(out, apiKey) || strings.Contains(out, secret) {
+				t.Errorf("%s leaked credentials: %s", format, out)
+			}
+			if strings.Contains(out, "5678") || strings.Contains(out, "super") {
+				t.Errorf("%s leaked part of the credentials: %s", format, out)
+			}
+		}
+	}
+
+	if got := client.String(); got != "infura.Client{BaseURL: https://gas.example.com, AuthMode: basic, APIKey: 0123...cdef, Timeout: 5s, Debug: false}" {
+		t.Errorf("Unexpected String output: %s", got)
+	}
+	if got := fmt.Sprintf("%#v", client); got != `&infura.Client{BaseURL:"https://gas.example.com", AuthMode:"basic", APIKey:"0123...cdef", Timeout:5000000000, Debug:false}` {
+		t.Errorf("Unexpected GoString output: %s", got)
+	}
+}
+
+func TestClient_StringShortKey(t *testing.T) {
+	client := NewClientWithAPIKey("short-key")
+	if out := fmt.Sprintf("%+v", client); strings.Contains(out, "short") || !strings.Contains(out, "APIKey: ****") {
+		t.Errorf("Expected a short key to be fully masked, got %s", out)
+	}
+
+	var nilClient *Client
+	if got := nilClient.String(); got != "infura.Client(nil)" {
+		t.Errorf("Unexpected nil String output: %s", got)
+	}
+	if got := fmt.Sprintf("%#v", nilClient); got != "(*infura.Client)(nil)" {
+		t.Errorf("Unexpected nil GoString output: %s", got)
+	}
+}
+
+func TestClient_Accessors(t *testing.T) {
+	client := NewClientWithAPIKeyAndOptions("0123456789abcdef0123",
+		WithBaseURL("https://proxy.example.com/gas"),
+		WithTimeout(7*time.Second))
+
+	if got := client.BaseURL(); got != "https://proxy.example.com/gas" {
+		t.Errorf("Expected the WithBaseURL value, got %s", got)
+	}
+	if got := client.Timeout(); got != 7*time.Second {
+		t.Errorf("Expected 7s timeout, got %v", got)
+	}
+	if got := client.AuthMode(); got != AuthModeURLPath {
+		t.Errorf("Expected url-path auth, got %s", got)
+	}
+	if got := client.APIKeyMasked(); got != "0123...0123" {
+		t.Errorf("Expected masked key 0123...0123, got %s", got)
+	}
+
+	client.SetCredentials("fedcba9876543210fedc", "secret")
+	if got := client.AuthMode(); got != AuthModeBasic {
+		t.Errorf("Expected basic auth after SetCredentials, got %s", got)
+	}
+	if got := client.APIKeyMasked(); got != "fedc...fedc" {
+		t.Errorf("Expected the new masked key, got %s", got)
+	}
+
+	defaults := NewClientWithAPIKey("")
+	if defaults.BaseURL() != BaseURL || defaults.Timeout() != DefaultTimeout || defaults.APIKeyMasked() != "" {
+		t.Errorf("Unexpected defaults: %s %v %q", defaults.BaseURL(), defaults.Timeout(), defaults.APIKeyMasked())
+	}
+}
+
+func TestClient_AccessorsConcurrent(t *testing.T) {
+	client := NewClientWithAPIKey("0123456789abcdef0123")
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			if i%2 == 0 {
+				client.SetCredentials("0123456789abcdef0123", "secret")
+			} else {
+				client.SetCredentials("0123456789abcdef0123", "")
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			if mode := client.AuthMode(); mode != AuthModeBasic && mode != AuthModeURLPath {
+				t.Errorf("Unexpected auth mode %q", mode)
+			}
+			_ = client.APIKeyMasked()
+			_ = client.BaseURL()
+			_ = client.Timeout()
+		}
+	}()
+	wg.Wait()
+}
+
+func TestWithAuthMode(t *testing.T) {
+	var path, authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		authorization = r.Header.Get("Authorization")
+		w.Write([]byte(`{"busyThreshold": "10"}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name              string
+		secret            string
+		mode              AuthMode
+		wantPath          string
+		wantAuthorization string
+	}{
+		{"path with a secret", "secret", AuthModeURLPath, "/v3/test-api-key/networks/1/busyThreshold", ""},
+		{"basic without a secret", "", AuthModeBasic, "/networks/1/busyThreshold",
+			"Basic " + base64.StdEncoding.EncodeToString([]byte("test-api-key:"))},
+		{"basic with a secret", "secret", AuthModeBasic, "/networks/1/busyThreshold",
+			"Basic " + base64.StdEncoding.EncodeToString([]byte("test-api-key:secret"))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := New("test-api-key", tt.secret, WithBaseURL(server.URL), WithAuthMode(tt.mode))
+			if err != nil {
+				t.Fatalf("New failed: %v", err)
+			}
+			if got := client.AuthMode(); got != tt.mode {
+				t.Errorf("Expected auth mode %s, got %s", tt.mode, got)
+			}
+			if _, err := client.GetBusyThreshold(context.Background(), 1); err != nil {
+				t.Fatalf("GetBusyThreshold failed: %v", err)
+			}
+			if path != tt.wantPath {
+				t.Errorf("Expected path %s, got %s", tt.wantPath, path)
+			}
+			if authorization != tt.wantAuthorization {
+				t.Errorf("Expected Authorization %q, got %q", tt.wantAuthorization, authorization)
+			}
+		})
+	}
+}
+
+func TestWithAuthMode_SetCredentials(t *testing.T) {
+	client := NewClientWithOptions("0123456789abcdef0123", "", WithAuthMode(AuthModeBasic))
+	client.SetCredentials("fedcba9876543210fedc", "")
+	if got := client.AuthMode(); got != AuthModeBasic {
+		t.Errorf("Expected the forced basic auth after SetCredentials, got %s", got)
+	}
+
+	client = NewClientWithOptions("0123456789abcdef0123", "secret", WithAuthMode(AuthModeURLPath))
+	client.SetCredentials("fedcba9876543210fedc", "secret")
+	if got := client.AuthMode(); got != AuthModeURLPath {
+		t.Errorf("Expected the forced url-path auth after SetCredentials, got %s", got)
+	}
+}
+
+func TestWithAuthMode_Invalid(t *testing.T) {
+	if _, err := New("test-api-key", "", WithAuthMode("token")); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("Expected ErrInvalidOption, got %v", err)
+	}
+}
@@ -0,0 +1,426 @@
+package infura
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileConfig holds client settings loaded from a YAML or JSON file with LoadConfig
+// It is the input of a client, unlike ClientConfig, which reports the settings of one.
+// Printing a FileConfig with fmt never shows the API Key or Secret.
+type FileConfig struct {
+	APIKey       string
+	APIKeySecret string
+	// BaseURL replaces the default base URL when set
+	BaseURL string
+	// Timeout replaces DefaultTimeout when positive
+	Timeout time.Duration
+	// Retry enables retries when MaxAttempts is set (see WithRetry)
+	Retry RetrySettings
+	// RateLimit enables client-side rate limiting when PerSecond is set (see WithRateLimit)
+	RateLimit RateLimitSettings
+	// CacheTTL enables response caching when positive (see WithCache)
+	CacheTTL time.Duration
+	// DefaultChain is the chain ID the application should query by default (0 = unset)
+	// The client does not use it; read it from the FileConfig returned by LoadConfig
+	DefaultChain int64
+}
+
+// RetrySettings is the retry section of a FileConfig
+type RetrySettings struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+}
+
+// RateLimitSettings is the rate_limit section of a FileConfig
+type RateLimitSettings struct {
+	PerSecond float64
+	Burst     int
+}
+
+// String formats the configuration with the credentials redacted
+func (cfg FileConfig) String() string {
+	apiKey, secret := "", ""
+	if cfg.APIKey != "" {
+		apiKey = redacted
+	}
+	if cfg.APIKeySecret != "" {
+		secret = redacted
+	}
+	return fmt.Sprintf("infura.FileConfig{APIKey: %s, APIKeySecret: %s, BaseURL: %s, Timeout: %v, Retry: %+v, RateLimit: %+v, CacheTTL: %v, DefaultChain: %d}",
+		apiKey, secret, cfg.BaseURL, cfg.Timeout, cfg.Retry, cfg.RateLimit, cfg.CacheTTL, cfg.DefaultChain)
+}
+
+// GoString formats the configuration for %#v like String, so credentials stay redacted
+func (cfg FileConfig) GoString() string {
+	return cfg.String()
+}
+
+// Options returns the client options for the settings of the configuration, not including
+// the credentials
+func (cfg FileConfig) Options() []ClientOption {
+	var opts []ClientOption
+	if cfg.BaseURL != "" {
+		opts = append(opts, WithBaseURL(cfg.BaseURL))
+	}
+	if cfg.Timeout > 0 {
+		opts = append(opts, WithTimeout(cfg.Timeout))
+	}
+	if cfg.Retry.MaxAttempts > 0 {
+		opts = append(opts, WithRetry(cfg.Retry.MaxAttempts, cfg.Retry.BaseDelay))
+	}
+	if cfg.RateLimit.PerSecond > 0 {
+		opts = append(opts, WithRateLimit(cfg.RateLimit.PerSecond, cfg.RateLimit.Burst))
+	}
+	if cfg.CacheTTL > 0 {
+		opts = append(opts, WithCache(cfg.CacheTTL))
+	}
+	return opts
+}
+
+// NewClientFromConfigFile creates a client from a configuration file (see LoadConfig)
+// extra options are applied after those of the file, so they take precedence.
+func NewClientFromConfigFile(path string, extra ...ClientOption) (*Client, error) {
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	return New(cfg.APIKey, cfg.APIKeySecret, append(cfg.Options(), extra...)...)
+}
+
+// LoadConfig reads client settings from a YAML (.yaml, .yml) or JSON (.json) file:
+//
+//	api_key: ${INFURA_API_KEY}
+//	api_key_secret: ${INFURA_API_KEY_SECRET}
+//	base_url: https://gas.api.infura.io
+//	timeout: 10s
+//	retry:
+//	  max_attempts: 3
+//	  base_delay: 500ms
+//	rate_limit:
+//	  per_second: 10
+//	  burst: 20
+//	cache_ttl: 5s
+//	default_chain: 1
+//
+// Only api_key is required. Durations use Go syntax ("500ms", "1m30s"). ${NAME} in a
+// string value is replaced by the environment variable NAME, which must be set. The
+// JSON form uses the same keys and nesting, with durations as strings; there the
+// credentials and base_url must be strings, while YAML reads every scalar as written, so
+// an unquoted numeric api_key keeps its digits.
+//
+// Unknown keys are reported together in one error. Errors name the offending key but
+// never include the value of api_key or api_key_secret.
+func LoadConfig(path string) (FileConfig, error) {
+	var parse func([]byte) (map[string]interface{}, error)
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		parse = parseConfigYAML
+	case ".json":
+		parse = parseConfigJSON
+	default:
+		return FileConfig{}, fmt.Errorf("unsupported config file extension %q (use .yaml, .yml or .json)", ext)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return FileConfig{}, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	tree, err := parse(data)
+	if err != nil {
+		return FileConfig{}, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	cfg, err := decodeConfig(tree)
+	if err != nil {
+		return FileConfig{}, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// configKeys are the keys a config file may contain; sections map to their own keys
+var configKeys = map[string][]string{
+	"api_key":        nil,
+	"api_key_secret": nil,
+	"base_url":       nil,
+	"timeout":        nil,
+	"retry":          {"max_attempts", "base_delay"},
+	"rate_limit":     {"per_second", "burst"},
+	"cache_ttl":      nil,
+	"default_chain":  nil,
+}
+
+// configReader converts the values of a parsed config file, remembering the first error
+type configReader struct {
+	err error
+}
+
+func (r *configReader) fail(key, format string, args ...interface{}) {
+	if r.err == nil {
+		r.err = fmt.Errorf("%s: %s", key, fmt.Sprintf(format, args...))
+	}
+}
+
+// decodeConfig validates the parsed file and converts it to a FileConfig
+func decodeConfig(tree map[string]interface{}) (FileConfig, error) {
+	if unknown := unknownConfigKeys(tree); len(unknown) > 0 {
+		return FileConfig{}, fmt.Errorf("unknown config keys: %s", strings.Join(unknown, ", "))
+	}
+
+	var r configReader
+	section := func(name string) map[string]interface{} {
+		v, ok := tree[name]
+		if !ok || v == nil {
+			return nil
+		}
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			r.fail(name, "must be a mapping")
+		}
+		return m
+	}
+
+	cfg := FileConfig{
+		APIKey:       r.string(tree, "", "api_key"),
+		APIKeySecret: r.string(tree, "", "api_key_secret"),
+		BaseURL:      r.string(tree, "", "base_url"),
+		Timeout:      r.duration(tree, "", "timeout"),
+		CacheTTL:     r.duration(tree, "", "cache_ttl"),
+		DefaultChain: r.int(tree, "", "default_chain"),
+	}
+	if retry := section("retry"); retry != nil {
+		cfg.Retry = RetrySettings{
+			MaxAttempts: int(r.int(retry, "retry.", "max_attempts")),
+			BaseDelay:   r.duration(retry, "retry.", "base_delay"),
+		}
+		if r.err == nil && cfg.Retry.MaxAttempts < 1 {
+			r.fail("retry.max_attempts", "must be at least 1, got %d", cfg.Retry.MaxAttempts)
+		}
+	}
+	if limit := section("rate_limit"); limit != nil {
+		cfg.RateLimit = RateLimitSettings{
+			PerSecond: r.float(limit, "rate_limit.", "per_second"),
+			Burst:     int(r.int(limit, "rate_limit.", "burst")),
+		}
+		if r.err == nil && (!(cfg.RateLimit.PerSecond > 0) || cfg.RateLimit.Burst <= 0) {
+			r.fail("rate_limit", "per_second and burst must be positive, got %v/s with burst %d", cfg.RateLimit.PerSecond, cfg.RateLimit.Burst)
+		}
+	}
+	if r.err != nil {
+		return FileConfig{}, r.err
+	}
+
+	switch {
+	case cfg.APIKey == "":
+		return FileConfig{}, errors.New("api_key is required")
+	case cfg.BaseURL != "" && validateBaseURL(cfg.BaseURL) != nil:
+		return FileConfig{}, fmt.Errorf("base_url: %w", validateBaseURL(cfg.BaseURL))
+	case cfg.Timeout < 0:
+		return FileConfig{}, fmt.Errorf("timeout: must not be negative, got %v", cfg.Timeout)
+	case cfg.CacheTTL < 0:
+		return FileConfig{}, fmt.Errorf("cache_ttl: must not be negative, got %v", cfg.CacheTTL)
+	case cfg.DefaultChain < 0:
+		return FileConfig{}, fmt.Errorf("default_chain: must be positive, got %d", cfg.DefaultChain)
+	}
+	return cfg, nil
+}
+
+// unknownConfigKeys returns the keys of tree that configKeys does not list, sorted
+func unknownConfigKeys(tree map[string]interface{}) []string {
+	var unknown []string
+	for key, value := range tree {
+		subKeys, known := configKeys[key]
+		if !known {
+			unknown = append(unknown, key)
+			continue
+		}
+		if m, ok := value.(map[string]interface{}); ok && subKeys != nil {
+			for subKey := range m {
+				if !slices.Contains(subKeys, subKey) {
+					unknown = append(unknown, key+"."+subKey)
+				}
+			}
+		}
+	}
+	slices.Sort(unknown)
+	return unknown
+}
+
+// text returns the string form of a scalar value with environment references expanded
+func (r *configReader) text(m map[string]interface{}, prefix, key string) (string, bool) {
+	v, ok := m[key]
+	if !ok || v == nil {
+		return "", false
+	}
+	var s string
+	switch v := v.(type) {
+	case string:
+		s = v
+	case json.Number:
+		s = v.String()
+	default:
+		r.fail(prefix+key, "must be a scalar value")
+		return "", false
+	}
+	expanded, err := expandConfigEnv(s)
+	if err != nil {
+		r.fail(prefix+key, "%v", err)
+		return "", false
+	}
+	return expanded, true
+}
+
+func (r *configReader) string(m map[string]interface{}, prefix, key string) string {
+	if _, isNumber := m[key].(json.Number); isNumber {
+		r.fail(prefix+key, "must be a string")
+		return ""
+	}
+	s, _ := r.text(m, prefix, key)
+	return s
+}
+
+func (r *configReader) duration(m map[string]interface{}, prefix, key string) time.Duration {
+	s, ok := r.text(m, prefix, key)
+	if !ok {
+		return 0
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		r.fail(prefix+key, "must be a duration such as \"10s\", got %q", s)
+	}
+	return d
+}
+
+func (r *configReader) int(m map[string]interface{}, prefix, key string) int64 {
+	s, ok := r.text(m, prefix, key)
+	if !ok {
+		return 0
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		r.fail(prefix+key, "must be an integer, got %q", s)
+	}
+	return n
+}
+
+func (r *configReader) float(m map[string]interface{}, prefix, key string) float64 {
+	s, ok := r.text(m, prefix, key)
+	if !ok {
+		return 0
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		r.fail(prefix+key, "must be a number, got %q", s)
+	}
+	return f
+}
+
+// configEnvRef matches an environment reference such as ${INFURA_API_KEY}
+var configEnvRef = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// expandConfigEnv replaces ${NAME} references with the value of the environment variable
+func expandConfigEnv(s string) (string, error) {
+	var missing []string
+	expanded := configEnvRef.ReplaceAllStringFunc(s, func(ref string) string {
+		name := configEnvRef.FindStringSubmatch(ref)[1]
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			missing = append(missing, name)
+		}
+		return value
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("environment variable %s is not set", strings.Join(missing, ", "))
+	}
+	return expanded, nil
+}
+
+// parseConfigJSON parses a JSON config file into a tree of maps, keeping numbers as
+// json.Number
+func parseConfigJSON(data []byte) (map[string]interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var tree map[string]interface{}
+	if err := dec.Decode(&tree); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, errors.New("unexpected data after the top-level object")
+	}
+	return tree, nil
+}
+
+// parseConfigYAML parses a YAML config file into a tree of maps whose scalars are the
+// strings as written, so they are converted like the values of a JSON file. Sequences
+// are kept as []interface{} to be reported as invalid values.
+func parseConfigYAML(data []byte) (map[string]interface{}, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if doc.Kind == 0 {
+		return map[string]interface{}{}, nil
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("line %d: the top level must be a mapping", root.Line)
+	}
+	tree, err := yamlNodeValue(root)
+	if err != nil {
+		return nil, err
+	}
+	return tree.(map[string]interface{}), nil
+}
+
+// yamlNodeValue converts a YAML node to maps, slices, strings and nil; errors give the
+// line number but never a value, which may be a secret
+func yamlNodeValue(node *yaml.Node) (interface{}, error) {
+	switch node.Kind {
+	case yaml.AliasNode:
+		return yamlNodeValue(node.Alias)
+	case yaml.ScalarNode:
+		if node.ShortTag() == "!!null" {
+			return nil, nil
+		}
+		return node.Value, nil
+	case yaml.SequenceNode:
+		values := make([]interface{}, len(node.Content))
+		for i, item := range node.Content {
+			v, err := yamlNodeValue(item)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = v
+		}
+		return values, nil
+	case yaml.MappingNode:
+		m := make(map[string]interface{}, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i]
+			if key.Kind != yaml.ScalarNode {
+				return nil, fmt.Errorf("line %d: mapping keys must be scalars", key.Line)
+			}
+			if _, dup := m[key.Value]; dup {
+				return nil, fmt.Errorf("line %d: duplicate key %q", key.Line, key.Value)
+			}
+			v, err := yamlNodeValue(node.Content[i+1])
+			if err != nil {
+				return nil, err
+			}
+			m[key.Value] = v
+		}
+		return m, nil
+	}
+	return nil, fmt.Errorf("line %d: unsupported YAML node", node.Line)
+}
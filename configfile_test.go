@@ -0,0 +1,161 @@
+package infura
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func configFixture(name string) string {
+	return filepath.Join("testdata", "config", name)
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Setenv("INFURA_TEST_KEY", "env-api-key")
+	want := FileConfig{
+		APIKey:       "env-api-key",
+		APIKeySecret: "s3cret-value",
+		BaseURL:      "https://gas.example.com/infura",
+		Timeout:      10 * time.Second,
+		Retry:        RetrySettings{MaxAttempts: 3, BaseDelay: 500 * time.Millisecond},
+		RateLimit:    RateLimitSettings{PerSecond: 2.5, Burst: 5},
+		CacheTTL:     5 * time.Second,
+		DefaultChain: 137,
+	}
+
+	for _, name := range []string{"full.yaml", "full.json"} {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := LoadConfig(configFixture(name))
+			if err != nil {
+				t.Fatalf("LoadConfig failed: %v", err)
+			}
+			if cfg != want {
+				t.Errorf("Expected %#v, got %#v", want, cfg)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_Minimal(t *testing.T) {
+	for _, name := range []string{"minimal.yml", "minimal.json"} {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := LoadConfig(configFixture(name))
+			if err != nil {
+				t.Fatalf("LoadConfig failed: %v", err)
+			}
+			if cfg != (FileConfig{APIKey: "test-api-key"}) {
+				t.Errorf("Expected only the API key, got %#v", cfg)
+			}
+			if opts := cfg.Options(); len(opts) != 0 {
+				t.Errorf("Expected no options, got %d", len(opts))
+			}
+		})
+	}
+}
+
+func TestLoadConfig_YAMLScalars(t *testing.T) {
+	cfg, err := LoadConfig(configFixture("yaml_scalars.yaml"))
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.APIKey != "00123" {
+		t.Errorf("Expected an unquoted numeric API key to keep its digits, got %q", cfg.APIKey)
+	}
+	if cfg.APIKeySecret != "s3cret\u00a0value" {
+		t.Errorf("Expected YAML escapes in double-quoted strings, got %q", cfg.APIKeySecret)
+	}
+}
+
+func TestLoadConfig_Errors(t *testing.T) {
+	tests := []struct {
+		file    string
+		wantErr string
+	}{
+		{file: "unknown_keys.yaml", wantErr: "unknown config keys: retry.max_attempt, timeuot"},
+		{file: "unknown_keys.json", wantErr: "unknown config keys: retry.max_attempt, timeuot"},
+		{file: "missing_api_key.yaml", wantErr: "api_key is required"},
+		{file: "missing_api_key.json", wantErr: "api_key is required"},
+		{file: "missing_env.yaml", wantErr: "api_key: environment variable INFURA_TEST_UNSET_VARIABLE is not set"},
+		{file: "missing_env.json", wantErr: "api_key: environment variable INFURA_TEST_UNSET_VARIABLE is not set"},
+		{file: "invalid_timeout.yaml", wantErr: `timeout: must be a duration such as "10s", got "ten seconds"`},
+		{file: "invalid_timeout.json", wantErr: `timeout: must be a duration such as "10s", got "ten seconds"`},
+		{file: "negative_cache_ttl.json", wantErr: "cache_ttl: must not be negative"},
+		{file: "invalid_retry.yaml", wantErr: "retry.max_attempts: must be at least 1"},
+		{file: "invalid_retry.json", wantErr: "retry.max_attempts: must be at least 1"},
+		{file: "invalid_rate_limit.json", wantErr: "rate_limit: per_second and burst must be positive"},
+		{file: "invalid_base_url.yaml", wantErr: "base_url: base URL must be an absolute http(s) URL"},
+		{file: "invalid_base_url.json", wantErr: "base_url: base URL must be an absolute http(s) URL"},
+		{file: "invalid_default_chain.yaml", wantErr: `default_chain: must be an integer, got "mainnet"`},
+		{file: "invalid_default_chain.json", wantErr: `default_chain: must be an integer, got "mainnet"`},
+		{file: "numeric_api_key.json", wantErr: "api_key: must be a string"},
+		{file: "malformed.yaml", wantErr: "failed to parse config file"},
+		{file: "malformed.json", wantErr: "failed to parse config file"},
+		{file: "duplicate_key.yaml", wantErr: `line 3: duplicate key "timeout"`},
+		{file: "list_value.yaml", wantErr: "retry: must be a mapping"},
+		{file: "list_value.json", wantErr: "retry: must be a mapping"},
+		{file: "config.toml", wantErr: `unsupported config file extension ".toml" (use .yaml, .yml or .json)`},
+		{file: "does_not_exist.yaml", wantErr: "failed to read config file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			_, err := LoadConfig(configFixture(tt.file))
+			if err == nil {
+				t.Fatalf("Expected an error containing %q, got nil", tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected an error containing %q, got %q", tt.wantErr, err)
+			}
+			for _, secret := range []string{"s3cret-value", "123456789"} {
+				if strings.Contains(err.Error(), secret) {
+					t.Errorf("Expected the error not to reveal a secret, got %q", err)
+				}
+			}
+		})
+	}
+}
+
+func TestFileConfig_StringRedactsCredentials(t *testing.T) {
+	cfg := FileConfig{APIKey: "test-api-key-value", APIKeySecret: "s3cret-value", Timeout: time.Second}
+	for _, format := range []string{"%v", "%+v", "%#v", "%s"} {
+		out := fmt.Sprintf(format, cfg)
+		if strings.Contains(out, "test-api-key-value") || strings.Contains(out, "s3cret-value") {
+			t.Errorf("Expected %s not to reveal credentials, got %q", format, out)
+		}
+		if !strings.Contains(out, "APIKey: REDACTED") {
+			t.Errorf("Expected %s to redact the API key, got %q", format, out)
+		}
+	}
+}
+
+func TestNewClientFromConfigFile(t *testing.T) {
+	t.Setenv("INFURA_TEST_KEY", "env-api-key")
+
+	client, err := NewClientFromConfigFile(configFixture("full.yaml"), WithTimeout(3*time.Second))
+	if err != nil {
+		t.Fatalf("NewClientFromConfigFile failed: %v", err)
+	}
+	cfg := client.Config()
+	if cfg.BaseURL != "https://gas.example.com/infura" || cfg.AuthMode != AuthModeBasic {
+		t.Errorf("Expected the file's base URL with basic auth, got %q and %q", cfg.BaseURL, cfg.AuthMode)
+	}
+	if cfg.Timeout != 3*time.Second {
+		t.Errorf("Expected the extra option's 3s timeout to win, got %v", cfg.Timeout)
+	}
+	if cfg.RetryMaxAttempts != 3 || cfg.RetryBaseDelay != 500*time.Millisecond {
+		t.Errorf("Expected 3 attempts with a 500ms delay, got %d and %v", cfg.RetryMaxAttempts, cfg.RetryBaseDelay)
+	}
+	if cfg.RateLimit != 2.5 || cfg.RateBurst != 5 || cfg.CacheTTL != 5*time.Second {
+		t.Errorf("Expected 2.5/s with burst 5 and a 5s cache TTL, got %v/s, %d and %v", cfg.RateLimit, cfg.RateBurst, cfg.CacheTTL)
+	}
+
+	if _, err := NewClientFromConfigFile(configFixture("full.json"), WithRateLimit(0, 0)); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("Expected ErrInvalidOption for an invalid extra option, got %v", err)
+	}
+	if _, err := NewClientFromConfigFile(configFixture("missing_api_key.json")); err == nil {
+		t.Error("Expected an error for an invalid file")
+	}
+}
@@ -0,0 +1,152 @@
+package infura
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Congestion is the networkCongestion value of SuggestedGasFees, a ratio between 0 and 1
+// The API normally sends a JSON number, but some chains send a quoted number or null;
+// all three are accepted. A null, empty or missing value leaves the Congestion unset,
+// so callers can tell a reported 0 apart from no value (see IsSet).
+//
+// A decoded value remembers how it was written, so marshaling it again reproduces the
+// original JSON exactly, whether quoted, with trailing zeros or with more precision than
+// a float64 holds (see Number). Compare values with Float64 rather than ==, which also
+// compares the original text.
+type Congestion struct {
+	value float64
+	set   bool
+	// raw is the JSON as received, kept only when it differs from what MarshalJSON
+	// would produce for value
+	raw string
+}
+
+// NewCongestion returns a Congestion set to v
+func NewCongestion(v float64) Congestion {
+	return Congestion{value: v, set: true}
+}
+
+// Float64 returns the congestion ratio, or 0 if the value is unset
+func (c Congestion) Float64() float64 {
+	return c.value
+}
+
+// IsSet reports whether the API returned a congestion value
+func (c Congestion) IsSet() bool {
+	return c.set
+}
+
+// IsExact reports whether Float64 holds the received value without rounding
+func (c Congestion) IsExact() bool {
+	return c.raw == "" || !c.set || roundTrips(string(c.Number()), c.value)
+}
+
+// Number returns the congestion exactly as the API sent it, without quotes, or "" if the
+// value is unset. Use it instead of Float64 when the textual number matters, e.g. for
+// accounting.
+func (c Congestion) Number() json.Number {
+	if !c.set {
+		return ""
+	}
+	if text := unquoteNumber(c.raw); isJSONNumber(text) {
+		return json.Number(text)
+	}
+	return json.Number(formatCongestionValue(c.value))
+}
+
+// unquoteNumber returns the number inside raw JSON, which may be a quoted string
+func unquoteNumber(raw string) string {
+	if strings.HasPrefix(raw, `"`) {
+		var text string
+		if json.Unmarshal([]byte(raw), &text) != nil {
+			return ""
+		}
+		return strings.TrimSpace(text)
+	}
+	return raw
+}
+
+// formatCongestionValue is the canonical JSON encoding of a congestion ratio
+func formatCongestionValue(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// UnmarshalJSON accepts a JSON number, a quoted number, or null
+func (c *Congestion) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*c = Congestion{}
+		return nil
+	}
+
+	text := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &text); err != nil {
+			return fmt.Errorf("invalid network congestion: %w", err)
+		}
+		text = strings.TrimSpace(text)
+		if text == "" {
+			*c = Congestion{raw: string(data)}
+			return nil
+		}
+	}
+
+	v, err := strconv.ParseFloat(text, 64)
+	if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+		return fmt.Errorf("invalid network congestion: %s", data)
+	}
+	*c = NewCongestion(v)
+	if string(data) != formatCongestionValue(v) {
+		c.raw = string(data)
+	}
+	return nil
+}
+
+// roundTrips reports whether the decimal text denotes the same number as the shortest
+// formatting of v, i.e. parsing text into a float64 lost nothing the API sent
+func roundTrips(text string, v float64) bool {
+	formatted := strconv.FormatFloat(v, 'g', -1, 64)
+	if text == formatted {
+		return true
+	}
+	want, ok := new(big.Rat).SetString(text)
+	if !ok {
+		return false
+	}
+	got, _ := new(big.Rat).SetString(formatted)
+	return want.Cmp(got) == 0
+}
+
+// isJSONNumber reports whether text is a valid JSON number literal
+func isJSONNumber(text string) bool {
+	if text == "" || (text[0] != '-' && (text[0] < '0' || text[0] > '9')) {
+		return false
+	}
+	return json.Valid([]byte(text))
+}
+
+// MarshalJSON reproduces the JSON the value was decoded from; other values are encoded
+// as a JSON number, or null when unset
+func (c Congestion) MarshalJSON() ([]byte, error) {
+	if c.raw != "" {
+		return []byte(c.raw), nil
+	}
+	if !c.set {
+		return []byte("null"), nil
+	}
+	return []byte(c.Number()), nil
+}
+
+// String formats the ratio like Number, or "unset"
+func (c Congestion) String() string {
+	if !c.set {
+		return "unset"
+	}
+	return string(c.Number())
+}
@@ -0,0 +1,141 @@
+package infura
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCongestionUnmarshal(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    float64
+		wantSet bool
+		wantErr bool
+	}{
+		{name: "float", body: `{"networkCongestion":0.7143}`, want: 0.7143, wantSet: true},
+		{name: "zero", body: `{"networkCongestion":0}`, want: 0, wantSet: true},
+		{name: "string", body: `{"networkCongestion":"0.25"}`, want: 0.25, wantSet: true},
+		{name: "string with spaces", body: `{"networkCongestion":" 1 "}`, want: 1, wantSet: true},
+		{name: "null", body: `{"networkCongestion":null}`},
+		{name: "empty string", body: `{"networkCongestion":""}`},
+		{name: "missing", body: `{}`},
+		{name: "invalid string", body: `{"networkCongestion":"high"}`, wantErr: true},
+		{name: "NaN string", body: `{"networkCongestion":"NaN"}`, wantErr: true},
+		{name: "bool", body: `{"networkCongestion":true}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fees SuggestedGasFees
+			err := json.Unmarshal([]byte(tt.body), &fees)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Expected error, got %v", fees.NetworkCongestion)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if fees.NetworkCongestion.IsSet() != tt.wantSet {
+				t.Errorf("Expected IsSet %v, got %v", tt.wantSet, fees.NetworkCongestion.IsSet())
+			}
+			if fees.NetworkCongestion.Float64() != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, fees.NetworkCongestion.Float64())
+			}
+		})
+	}
+}
+
+func TestCongestionMarshal(t *testing.T) {
+	tests := []struct {
+		value Congestion
+		want  string
+	}{
+		{NewCongestion(0.7143), "0.7143"},
+		{NewCongestion(0), "0"},
+		{Congestion{}, "null"},
+	}
+
+	for _, tt := range tests {
+		got, err := json.Marshal(tt.value)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if string(got) != tt.want {
+			t.Errorf("Expected %s, got %s", tt.want, got)
+		}
+
+		var back Congestion
+		if err := json.Unmarshal(got, &back); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if back != tt.value {
+			t.Errorf("Round trip of %s: expected %+v, got %+v", tt.want, tt.value, back)
+		}
+	}
+}
+
+func TestWaitForLowCongestionUnsetCongestion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"networkCongestion":null}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL))
+	err := client.WaitForLowCongestion(context.Background(), 1, 0.5, time.Millisecond, WithFailureBudget(0))
+	if err == nil || !strings.Contains(err.Error(), "network congestion missing") {
+		t.Errorf("Expected missing congestion error, got %v", err)
+	}
+}
+
+func TestCongestionExactNumber(t *testing.T) {
+	tests := []struct {
+		body      string
+		wantExact bool
+		want      string
+	}{
+		{`0.7143`, true, "0.7143"},
+		{`"0.40"`, true, "0.40"},
+		{`1e-3`, true, "1e-3"},
+		{`0.123456789012345678901234`, false, "0.123456789012345678901234"},
+		{`"0.99999999999999999999"`, false, "0.99999999999999999999"},
+		{`"0x1p-2"`, true, "0.25"},
+	}
+	for _, tt := range tests {
+		var c Congestion
+		if err := json.Unmarshal([]byte(tt.body), &c); err != nil {
+			t.Fatalf("%s: unmarshal failed: %v", tt.body, err)
+		}
+		if c.IsExact() != tt.wantExact || c.Number() != json.Number(tt.want) {
+			t.Errorf("%s: expected exact %v and number %s, got %v and %s", tt.body, tt.wantExact, tt.want, c.IsExact(), c.Number())
+		}
+		// Marshaling reproduces the original JSON
+		out, err := json.Marshal(c)
+		if err != nil || string(out) != tt.body {
+			t.Errorf("%s: expected to marshal unchanged, got %s (%v)", tt.body, out, err)
+		}
+	}
+	if NewCongestion(0.5) != mustUnmarshalCongestion(t, `0.5`) {
+		t.Error("Expected an exactly decoded value to equal NewCongestion")
+	}
+	if (Congestion{}).Number() != "" {
+		t.Error("Expected an unset congestion to have no number")
+	}
+}
+
+func mustUnmarshalCongestion(t *testing.T, body string) Congestion {
+	t.Helper()
+	var c Congestion
+	if err := json.Unmarshal([]byte(body), &c); err != nil {
+		t.Fatalf("unmarshal %s failed: %v", body, err)
+	}
+	return c
+}
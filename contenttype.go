@@ -0,0 +1,64 @@
+package infura
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// contentTypeSnippetSize is how much of an unexpected body is quoted in the error
+const contentTypeSnippetSize = 200
+
+// ErrUnexpectedContentType indicates a successful response that is not JSON, e.g. an HTML
+// page served by a proxy, rejected because WithStrictContentType is set
+var ErrUnexpectedContentType = errors.New("unexpected content type")
+
+// WithStrictContentType rejects 2xx responses whose Content-Type is not JSON
+// (application/json or a +json type) with an error wrapping ErrUnexpectedContentType that
+// quotes the start of the body, instead of a decode error about invalid JSON. A missing
+// Content-Type is rejected too. It is off by default so servers that send JSON with
+// another content type keep working; 204 and 304 responses, which have no body, are not
+// checked.
+func WithStrictContentType() ClientOption {
+	return func(c *Client) {
+		c.strictContentType = true
+	}
+}
+
+// isJSONContentType reports whether a Content-Type header denotes JSON
+func isJSONContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// checkContentType returns an error if strict content type checking is enabled and resp is
+// not JSON. body is the response body if it was read already; otherwise the start of
+// resp.Body is read for the error message
+func (c *Client) checkContentType(creds *credentials, resp *http.Response, body []byte) error {
+	if !c.strictContentType || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	contentType := resp.Header.Get("Content-Type")
+	if isJSONContentType(contentType) {
+		return nil
+	}
+
+	if body == nil {
+		body, _ = io.ReadAll(io.LimitReader(resp.Body, contentTypeSnippetSize+1))
+	}
+	snippet := bytes.TrimSpace(body)
+	if len(snippet) > contentTypeSnippetSize {
+		snippet = append(snippet[:contentTypeSnippetSize:contentTypeSnippetSize], "..."...)
+	}
+	if contentType == "" {
+		contentType = "(none)"
+	}
+	return fmt.Errorf("%w %s; expected application/json, body: %q", ErrUnexpectedContentType, contentType, creds.mask(string(snippet)))
+}
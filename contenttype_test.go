@@ -0,0 +1,81 @@
+package infura
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const captivePortalPage = `<!DOCTYPE html><html><head><title>Sign in to the network</title></head><body>Please log in</body></html>`
+
+func newContentTypeServer(contentType, body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		w.Write([]byte(body))
+	}))
+}
+
+func TestWithStrictContentType_RejectsHTML(t *testing.T) {
+	server := newContentTypeServer("text/html; charset=utf-8", captivePortalPage)
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL), WithStrictContentType())
+	// The body is read before the check with debug output and streamed without it
+	for _, debug := range []bool{false, true} {
+		ctx := context.Background()
+		if debug {
+			ctx = ContextWithDebugWriter(ctx, io.Discard)
+		}
+		_, err := client.GetBusyThreshold(ctx, 1)
+		if !errors.Is(err, ErrUnexpectedContentType) {
+			t.Fatalf("debug=%v: expected ErrUnexpectedContentType, got %v", debug, err)
+		}
+		if !strings.Contains(err.Error(), "unexpected content type text/html; charset=utf-8; expected application/json") ||
+			!strings.Contains(err.Error(), "Sign in to the network") {
+			t.Errorf("debug=%v: expected the content type and a body snippet, got %v", debug, err)
+		}
+	}
+}
+
+func TestWithStrictContentType_TruncatesSnippet(t *testing.T) {
+	server := newContentTypeServer("text/html", "<html>"+strings.Repeat("x", 1000)+"</html>")
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL), WithStrictContentType())
+	_, err := client.GetBusyThreshold(context.Background(), 1)
+	if err == nil || !strings.Contains(err.Error(), `xxx..."`) || len(err.Error()) > 400 {
+		t.Errorf("Expected a truncated snippet, got %v", err)
+	}
+}
+
+func TestWithStrictContentType_AcceptsJSON(t *testing.T) {
+	for _, contentType := range []string{"application/json", "application/json; charset=utf-8", "application/problem+json"} {
+		server := newContentTypeServer(contentType, `{"busyThreshold": "10"}`)
+		client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL), WithStrictContentType())
+		if _, err := client.GetBusyThreshold(context.Background(), 1); err != nil {
+			t.Errorf("%s: unexpected error %v", contentType, err)
+		}
+		server.Close()
+	}
+}
+
+func TestStrictContentTypeOffByDefault(t *testing.T) {
+	server := newContentTypeServer("text/plain", `{"busyThreshold": "10"}`)
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL))
+	if _, err := client.GetBusyThreshold(context.Background(), 1); err != nil {
+		t.Fatalf("Expected JSON with another content type to be accepted, got %v", err)
+	}
+
+	html := newContentTypeServer("text/html", captivePortalPage)
+	defer html.Close()
+	client = NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(html.URL))
+	if _, err := client.GetBusyThreshold(context.Background(), 1); err == nil || errors.Is(err, ErrUnexpectedContentType) {
+		t.Errorf("Expected a decode error without strict checking, got %v", err)
+	}
+}
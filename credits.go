@@ -0,0 +1,198 @@
+package infura
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultCreditResetInterval is how often the credit counter resets unless
+// WithCreditResetInterval is used
+const DefaultCreditResetInterval = 24 * time.Hour
+
+// CreditResetBoundary selects when the credit counter of WithDailyCreditLimit resets
+type CreditResetBoundary int
+
+const (
+	// CreditResetRolling resets the counter every reset interval (see
+	// WithCreditResetInterval), in consecutive windows starting with the first request
+	CreditResetRolling CreditResetBoundary = iota
+	// CreditResetUTCMidnight resets the counter at 00:00 UTC, when Infura resets its daily
+	// request allowance, whatever the local time zone; the reset interval is ignored
+	CreditResetUTCMidnight
+)
+
+// String returns the boundary name
+func (b CreditResetBoundary) String() string {
+	switch b {
+	case CreditResetRolling:
+		return "rolling"
+	case CreditResetUTCMidnight:
+		return "utc-midnight"
+	default:
+		return fmt.Sprintf("CreditResetBoundary(%d)", int(b))
+	}
+}
+
+// creditTracker counts the API credits spent in the current accounting window
+// It is client-side bookkeeping only; Infura's own accounting is authoritative
+type creditTracker struct {
+	mu       sync.Mutex
+	limit    int64
+	interval time.Duration
+	boundary CreditResetBoundary
+	costs    map[string]int64
+
+	used        int64
+	windowStart time.Time
+}
+
+// newCreditTracker returns a tracker without a limit that charges 1 credit per request
+func newCreditTracker() *creditTracker {
+	return &creditTracker{interval: DefaultCreditResetInterval}
+}
+
+// WithDailyCreditLimit makes requests fail fast with ErrCreditBudgetExceeded once limit
+// credits have been spent in the current window (24h unless WithCreditResetInterval or
+// WithCreditResetBoundary is set)
+// The count is client-side accounting of the requests this client sent, not Infura's
+// authoritative figure: other clients sharing the key and server-side rules are not seen
+func WithDailyCreditLimit(limit int64) ClientOption {
+	return func(c *Client) {
+		if limit <= 0 {
+			c.rejectOption("WithDailyCreditLimit", "limit must be positive, got %d", limit)
+			return
+		}
+		c.credits.limit = limit
+	}
+}
+
+// WithCreditResetInterval sets how often the credit counter resets to zero
+// The first window starts with the first request. It has no effect with
+// CreditResetUTCMidnight (see WithCreditResetBoundary).
+func WithCreditResetInterval(interval time.Duration) ClientOption {
+	return func(c *Client) {
+		if interval <= 0 {
+			c.rejectOption("WithCreditResetInterval", "interval must be positive, got %v", interval)
+			return
+		}
+		c.credits.interval = interval
+	}
+}
+
+// WithCreditResetBoundary sets when the credit counter resets: CreditResetRolling (the
+// default) or CreditResetUTCMidnight. Windows are measured on the client's clock (see
+// WithClock and WithNowFunc), so tests can advance time across a reset. An unknown
+// boundary is invalid.
+func WithCreditResetBoundary(boundary CreditResetBoundary) ClientOption {
+	return func(c *Client) {
+		if boundary != CreditResetRolling && boundary != CreditResetUTCMidnight {
+			c.rejectOption("WithCreditResetBoundary", "unknown boundary %v", boundary)
+			return
+		}
+		c.credits.boundary = boundary
+	}
+}
+
+// WithCreditCosts sets the credits charged per request by endpoint name (the last path
+// segment, e.g. "suggestedGasFees"); endpoints not listed cost 1 credit
+func WithCreditCosts(costs map[string]int64) ClientOption {
+	return func(c *Client) {
+		copied := make(map[string]int64, len(costs))
+		for endpoint, cost := range costs {
+			if cost < 0 {
+				c.rejectOption("WithCreditCosts", "cost of %s must not be negative, got %d", endpoint, cost)
+				return
+			}
+			copied[endpoint] = cost
+		}
+		c.credits.costs = copied
+	}
+}
+
+// CreditsUsed returns the credits spent in the current window, counting every request
+// attempt sent to the Gas API (retries included, cached responses excluded)
+func (c *Client) CreditsUsed() int64 {
+	if c.credits == nil {
+		return 0
+	}
+	return c.credits.usedAt(c.clock.Now())
+}
+
+// CreditsResetAt returns when the credit counter next resets, or the zero time if no
+// window has started yet (rolling windows start with the first request)
+func (c *Client) CreditsResetAt() time.Time {
+	if c.credits == nil {
+		return time.Time{}
+	}
+	return c.credits.resetAt(c.clock.Now())
+}
+
+// charge spends the cost of a request to endpoint, or returns ErrCreditBudgetExceeded if
+// it would exceed the limit
+func (t *creditTracker) charge(endpoint string, now time.Time) error {
+	if t == nil {
+		return nil
+	}
+	cost := t.cost(endpoint)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.rollover(now)
+	if t.limit > 0 && t.used+cost > t.limit {
+		return ErrCreditBudgetExceeded
+	}
+	t.used += cost
+	return nil
+}
+
+// cost returns the credits charged for a request to endpoint
+func (t *creditTracker) cost(endpoint string) int64 {
+	if cost, ok := t.costs[metricsEndpoint(endpoint)]; ok {
+		return cost
+	}
+	return 1
+}
+
+// usedAt returns the credits spent in the window containing now
+func (t *creditTracker) usedAt(now time.Time) int64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.rollover(now)
+	return t.used
+}
+
+// resetAt returns the end of the window containing now
+func (t *creditTracker) resetAt(now time.Time) time.Time {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.boundary == CreditResetRolling && t.windowStart.IsZero() {
+		return time.Time{}
+	}
+	t.rollover(now)
+	if t.boundary == CreditResetUTCMidnight {
+		return t.windowStart.AddDate(0, 0, 1)
+	}
+	return t.windowStart.Add(t.interval)
+}
+
+// rollover starts a new window if the current one has ended; rolling windows stay aligned
+// to the first, UTC windows start at midnight
+func (t *creditTracker) rollover(now time.Time) {
+	if t.boundary == CreditResetUTCMidnight {
+		day := now.UTC().Truncate(24 * time.Hour)
+		if !day.Equal(t.windowStart) {
+			t.windowStart = day
+			t.used = 0
+		}
+		return
+	}
+	if t.windowStart.IsZero() {
+		t.windowStart = now
+		return
+	}
+	if elapsed := now.Sub(t.windowStart); elapsed >= t.interval {
+		t.windowStart = t.windowStart.Add(elapsed / t.interval * t.interval)
+		t.used = 0
+	}
+}
@@ -0,0 +1,232 @@
+package infura
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newCreditServer(requests *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+}
+
+func TestDailyCreditLimit(t *testing.T) {
+	var requests int32
+	server := newCreditServer(&requests)
+	defer server.Close()
+
+	clock := newFakeClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithClock(clock),
+		WithDailyCreditLimit(10),
+		WithCreditCosts(map[string]int64{"suggestedGasFees": 4}),
+	)
+	ctx := context.Background()
+
+	// 4 + 4 + 1 + 1 = 10 credits
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetSuggestedGasFees(ctx, 1); err != nil {
+			t.Fatalf("GetSuggestedGasFees %d failed: %v", i, err)
+		}
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetBusyThreshold(ctx, 1); err != nil {
+			t.Fatalf("GetBusyThreshold %d failed: %v", i, err)
+		}
+	}
+	if used := client.CreditsUsed(); used != 10 {
+		t.Errorf("Expected 10 credits used, got %d", used)
+	}
+
+	if _, err := client.GetBusyThreshold(ctx, 1); !errors.Is(err, ErrCreditBudgetExceeded) {
+		t.Fatalf("Expected ErrCreditBudgetExceeded, got %v", err)
+	}
+	if got := atomic.LoadInt32(&requests); got != 4 {
+		t.Errorf("Expected the rejected request not to be sent, got %d requests", got)
+	}
+	if used := client.CreditsUsed(); used != 10 {
+		t.Errorf("Expected a rejected request to cost nothing, got %d", used)
+	}
+
+	// The counter resets after the interval
+	clock.Advance(24 * time.Hour)
+	if used := client.CreditsUsed(); used != 0 {
+		t.Errorf("Expected the counter to reset, got %d", used)
+	}
+	if _, err := client.GetSuggestedGasFees(ctx, 1); err != nil {
+		t.Fatalf("GetSuggestedGasFees after reset failed: %v", err)
+	}
+	if used := client.CreditsUsed(); used != 4 {
+		t.Errorf("Expected 4 credits used, got %d", used)
+	}
+}
+
+func TestCreditResetInterval(t *testing.T) {
+	var requests int32
+	server := newCreditServer(&requests)
+	defer server.Close()
+
+	clock := newFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithClock(clock),
+		WithDailyCreditLimit(1),
+		WithCreditResetInterval(time.Hour),
+	)
+	ctx := context.Background()
+
+	if _, err := client.GetBusyThreshold(ctx, 1); err != nil {
+		t.Fatalf("GetBusyThreshold failed: %v", err)
+	}
+	clock.Advance(59 * time.Minute)
+	if _, err := client.GetBusyThreshold(ctx, 1); !errors.Is(err, ErrCreditBudgetExceeded) {
+		t.Fatalf("Expected ErrCreditBudgetExceeded within the window, got %v", err)
+	}
+
+	// Windows stay aligned to the first one: 2h30m later is 30 minutes into the third window
+	clock.Advance(91 * time.Minute)
+	if _, err := client.GetBusyThreshold(ctx, 1); err != nil {
+		t.Fatalf("GetBusyThreshold in a new window failed: %v", err)
+	}
+	clock.Advance(29 * time.Minute)
+	if _, err := client.GetBusyThreshold(ctx, 1); !errors.Is(err, ErrCreditBudgetExceeded) {
+		t.Fatalf("Expected ErrCreditBudgetExceeded before the window ends, got %v", err)
+	}
+	clock.Advance(time.Minute)
+	if _, err := client.GetBusyThreshold(ctx, 1); err != nil {
+		t.Fatalf("GetBusyThreshold at the window boundary failed: %v", err)
+	}
+}
+
+func TestCreditsUsed_CountsRetriesNotCacheHits(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithRetry(2, time.Millisecond),
+		WithCache(time.Minute),
+	)
+	for i := 0; i < 3; i++ {
+		if _, err := client.GetBusyThreshold(context.Background(), 1); err != nil {
+			t.Fatalf("GetBusyThreshold failed: %v", err)
+		}
+	}
+	if used := client.CreditsUsed(); used != 2 {
+		t.Errorf("Expected 2 credits (one retry, no cache hits), got %d", used)
+	}
+}
+
+func TestCreditOptions_Invalid(t *testing.T) {
+	_, err := New("test-api-key", "",
+		WithDailyCreditLimit(0),
+		WithCreditResetInterval(-time.Hour),
+		WithCreditCosts(map[string]int64{"busyThreshold": -1}),
+		WithCreditResetBoundary(CreditResetBoundary(7)),
+		WithNowFunc(nil),
+	)
+	if !errors.Is(err, ErrInvalidOption) {
+		t.Fatalf("Expected ErrInvalidOption, got %v", err)
+	}
+	if n := len(err.(interface{ Unwrap() []error }).Unwrap()); n != 5 {
+		t.Errorf("Expected 5 errors, got %d: %v", n, err)
+	}
+}
+
+func TestCreditResetBoundary_UTCMidnight(t *testing.T) {
+	var requests int32
+	server := newCreditServer(&requests)
+	defer server.Close()
+
+	// 23:00 in UTC+8 is 15:00 UTC: the reset is 9 hours away, not at local midnight
+	local := time.FixedZone("UTC+8", 8*60*60)
+	clock := newFakeClock(time.Date(2024, 1, 1, 23, 0, 0, 0, local))
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithClock(clock),
+		WithDailyCreditLimit(1),
+		WithCreditResetBoundary(CreditResetUTCMidnight),
+	)
+	ctx := context.Background()
+
+	if want := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC); !client.CreditsResetAt().Equal(want) {
+		t.Errorf("Expected the reset at %v, got %v", want, client.CreditsResetAt())
+	}
+	if _, err := client.GetBusyThreshold(ctx, 1); err != nil {
+		t.Fatalf("GetBusyThreshold failed: %v", err)
+	}
+
+	// Local midnight (16:00 UTC) does not reset the counter
+	clock.Advance(time.Hour)
+	if _, err := client.GetBusyThreshold(ctx, 1); !errors.Is(err, ErrCreditBudgetExceeded) {
+		t.Fatalf("Expected ErrCreditBudgetExceeded before UTC midnight, got %v", err)
+	}
+
+	// One second before and right at 00:00 UTC
+	clock.Advance(8*time.Hour - time.Second)
+	if _, err := client.GetBusyThreshold(ctx, 1); !errors.Is(err, ErrCreditBudgetExceeded) {
+		t.Fatalf("Expected ErrCreditBudgetExceeded at 23:59:59 UTC, got %v", err)
+	}
+	clock.Advance(time.Second)
+	if used := client.CreditsUsed(); used != 0 {
+		t.Errorf("Expected the counter to reset at UTC midnight, got %d", used)
+	}
+	if _, err := client.GetBusyThreshold(ctx, 1); err != nil {
+		t.Fatalf("Expected a request after the reset to succeed, got %v", err)
+	}
+	if want := time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC); !client.CreditsResetAt().Equal(want) {
+		t.Errorf("Expected the next reset at %v, got %v", want, client.CreditsResetAt())
+	}
+}
+
+func TestCreditResetBoundary_Rolling(t *testing.T) {
+	var requests int32
+	server := newCreditServer(&requests)
+	defer server.Close()
+
+	start := time.Date(2024, 1, 1, 15, 30, 0, 0, time.UTC)
+	now := start
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithNowFunc(func() time.Time { return now }),
+		WithDailyCreditLimit(1),
+	)
+	ctx := context.Background()
+
+	if !client.CreditsResetAt().IsZero() {
+		t.Errorf("Expected no reset time before the first request, got %v", client.CreditsResetAt())
+	}
+	if _, err := client.GetBusyThreshold(ctx, 1); err != nil {
+		t.Fatalf("GetBusyThreshold failed: %v", err)
+	}
+	if want := start.Add(24 * time.Hour); !client.CreditsResetAt().Equal(want) {
+		t.Errorf("Expected the reset 24h after the first request, got %v", client.CreditsResetAt())
+	}
+
+	// UTC midnight does not reset a rolling window
+	now = time.Date(2024, 1, 2, 0, 30, 0, 0, time.UTC)
+	if _, err := client.GetBusyThreshold(ctx, 1); !errors.Is(err, ErrCreditBudgetExceeded) {
+		t.Fatalf("Expected ErrCreditBudgetExceeded after UTC midnight, got %v", err)
+	}
+	now = start.Add(24 * time.Hour)
+	if _, err := client.GetBusyThreshold(ctx, 1); err != nil {
+		t.Fatalf("Expected a request after 24h to succeed, got %v", err)
+	}
+}
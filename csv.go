@@ -0,0 +1,167 @@
+package infura
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// Columns of BaseFeeHistory.WriteCSV
+const (
+	// CSVColumnIndex is the position of the entry in the history
+	CSVColumnIndex = "index"
+	// CSVColumnBlockOffset is the implied block offset relative to the latest entry (0 for the
+	// last entry, -1 for the one before, ...), assuming the history is ordered oldest first
+	CSVColumnBlockOffset = "block_offset"
+	// CSVColumnBaseFee is the base fee in Gwei
+	CSVColumnBaseFee = "base_fee"
+)
+
+// Columns of FeeSamples.WriteCSV
+const (
+	CSVColumnTime              = "time"
+	CSVColumnChainID           = "chain_id"
+	CSVColumnLowMaxFee         = "low_max_fee"
+	CSVColumnLowPriorityFee    = "low_priority_fee"
+	CSVColumnMediumMaxFee      = "medium_max_fee"
+	CSVColumnMediumPriorityFee = "medium_priority_fee"
+	CSVColumnHighMaxFee        = "high_max_fee"
+	CSVColumnHighPriorityFee   = "high_priority_fee"
+	CSVColumnEstimatedBaseFee  = "estimated_base_fee"
+	CSVColumnNetworkCongestion = "network_congestion"
+	CSVColumnError             = "error"
+)
+
+// CSVOptions controls CSV export
+type CSVOptions struct {
+	// Columns selects and orders the emitted columns; nil emits every column of the data set
+	Columns []string
+	// NoHeader omits the header row
+	NoHeader bool
+}
+
+var historyCSVColumns = []string{CSVColumnIndex, CSVColumnBlockOffset, CSVColumnBaseFee}
+
+var samplesCSVColumns = []string{
+	CSVColumnTime,
+	CSVColumnChainID,
+	CSVColumnLowMaxFee,
+	CSVColumnLowPriorityFee,
+	CSVColumnMediumMaxFee,
+	CSVColumnMediumPriorityFee,
+	CSVColumnHighMaxFee,
+	CSVColumnHighPriorityFee,
+	CSVColumnEstimatedBaseFee,
+	CSVColumnNetworkCongestion,
+	CSVColumnError,
+}
+
+// WriteCSV writes the history as CSV with every column and a header row
+func (h BaseFeeHistory) WriteCSV(w io.Writer) error {
+	return h.WriteCSVWith(w, CSVOptions{})
+}
+
+// WriteCSVWith writes the history as CSV using the given options
+func (h BaseFeeHistory) WriteCSVWith(w io.Writer, opts CSVOptions) error {
+	return writeCSV(w, opts, historyCSVColumns, len(h), func(i int, column string) string {
+		switch column {
+		case CSVColumnIndex:
+			return strconv.Itoa(i)
+		case CSVColumnBlockOffset:
+			return strconv.Itoa(i - (len(h) - 1))
+		default:
+			return h[i]
+		}
+	})
+}
+
+// FeeSamples is a collection of poll results, e.g. gathered from WatchSuggestedGasFees
+type FeeSamples []WatchEvent
+
+// WriteCSV writes the samples as CSV with every column and a header row
+func (s FeeSamples) WriteCSV(w io.Writer) error {
+	return s.WriteCSVWith(w, CSVOptions{})
+}
+
+// WriteCSVWith writes the samples as CSV using the given options
+// Times are RFC 3339 in UTC; fee columns are in Gwei and empty for failed polls, whose
+// error message is written to the error column
+func (s FeeSamples) WriteCSVWith(w io.Writer, opts CSVOptions) error {
+	return writeCSV(w, opts, samplesCSVColumns, len(s), func(i int, column string) string {
+		event := s[i]
+		switch column {
+		case CSVColumnTime:
+			return event.Time.UTC().Format(time.RFC3339Nano)
+		case CSVColumnChainID:
+			return strconv.FormatInt(event.ChainID, 10)
+		case CSVColumnError:
+			if event.Err != nil {
+				return event.Err.Error()
+			}
+			return ""
+		}
+
+		fees := event.Fees
+		if fees == nil {
+			return ""
+		}
+		switch column {
+		case CSVColumnLowMaxFee:
+			return fees.Low.SuggestedMaxFeePerGas
+		case CSVColumnLowPriorityFee:
+			return fees.Low.SuggestedMaxPriorityFeePerGas
+		case CSVColumnMediumMaxFee:
+			return fees.Medium.SuggestedMaxFeePerGas
+		case CSVColumnMediumPriorityFee:
+			return fees.Medium.SuggestedMaxPriorityFeePerGas
+		case CSVColumnHighMaxFee:
+			return fees.High.SuggestedMaxFeePerGas
+		case CSVColumnHighPriorityFee:
+			return fees.High.SuggestedMaxPriorityFeePerGas
+		case CSVColumnEstimatedBaseFee:
+			return fees.EstimatedBaseFee
+		default:
+			if !fees.NetworkCongestion.IsSet() {
+				return ""
+			}
+			return fees.NetworkCongestion.String()
+		}
+	})
+}
+
+// writeCSV writes rows records using field to render each selected column
+func writeCSV(w io.Writer, opts CSVOptions, available []string, rows int, field func(row int, column string) string) error {
+	columns := opts.Columns
+	if columns == nil {
+		columns = available
+	}
+	for _, column := range columns {
+		if !slices.Contains(available, column) {
+			return fmt.Errorf("unknown CSV column %q", column)
+		}
+	}
+
+	cw := csv.NewWriter(w)
+	if !opts.NoHeader {
+		if err := cw.Write(columns); err != nil {
+			return fmt.Errorf("failed to write CSV header: %w", err)
+		}
+	}
+	record := make([]string, len(columns))
+	for i := 0; i < rows; i++ {
+		for j, column := range columns {
+			record[j] = field(i, column)
+		}
+		if err := cw.Write(record); err != nil {
+			return fmt.Errorf("failed to write CSV row %d: %w", i, err)
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package infura
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files")
+
+// assertGolden compares got with testdata/golden/name, rewriting it when -update is set
+func assertGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", "golden", name)
+	if *updateGolden {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read golden file: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("Output differs from %s\ngot:\n%s\nwant:\n%s", path, got, want)
+	}
+}
+
+var csvSamples = FeeSamples{
+	{
+		ChainID: 1,
+		Time:    time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC),
+		Fees: &SuggestedGasFees{
+			Low:               GasFeeLevel{SuggestedMaxPriorityFeePerGas: "0.05", SuggestedMaxFeePerGas: "24.1"},
+			Medium:            GasFeeLevel{SuggestedMaxPriorityFeePerGas: "0.1", SuggestedMaxFeePerGas: "30.5"},
+			High:              GasFeeLevel{SuggestedMaxPriorityFeePerGas: "2", SuggestedMaxFeePerGas: "41.25"},
+			EstimatedBaseFee:  "24.05",
+			NetworkCongestion: NewCongestion(0.4711),
+		},
+	},
+	{
+		ChainID: 137,
+		Time:    time.Date(2025, 3, 1, 20, 0, 15, 500_000_000, time.FixedZone("UTC+8", 8*3600)),
+		Err:     errors.New(`request failed: status 503, body "unavailable"`),
+	},
+	{
+		ChainID: 1,
+		Time:    time.Date(2025, 3, 1, 12, 0, 30, 0, time.UTC),
+		Fees: &SuggestedGasFees{
+			Low:               GasFeeLevel{SuggestedMaxPriorityFeePerGas: "0.05", SuggestedMaxFeePerGas: "25"},
+			Medium:            GasFeeLevel{SuggestedMaxPriorityFeePerGas: "0.1", SuggestedMaxFeePerGas: "31"},
+			High:              GasFeeLevel{SuggestedMaxPriorityFeePerGas: "2", SuggestedMaxFeePerGas: "42"},
+			EstimatedBaseFee:  "24.9",
+			NetworkCongestion: NewCongestion(1),
+		},
+	},
+}
+
+func TestBaseFeeHistory_WriteCSV(t *testing.T) {
+	history := BaseFeeHistory{"24.05", "24.9", "25.112", "23.7"}
+
+	var buf bytes.Buffer
+	if err := history.WriteCSV(&buf); err != nil {
+		t.Fatalf("WriteCSV failed: %v", err)
+	}
+	assertGolden(t, "history.csv", buf.Bytes())
+
+	buf.Reset()
+	if err := history.WriteCSVWith(&buf, CSVOptions{Columns: []string{CSVColumnBaseFee, CSVColumnBlockOffset}, NoHeader: true}); err != nil {
+		t.Fatalf("WriteCSVWith failed: %v", err)
+	}
+	assertGolden(t, "history_columns.csv", buf.Bytes())
+}
+
+func TestFeeSamples_WriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := csvSamples.WriteCSV(&buf); err != nil {
+		t.Fatalf("WriteCSV failed: %v", err)
+	}
+	assertGolden(t, "samples.csv", buf.Bytes())
+
+	buf.Reset()
+	columns := []string{CSVColumnTime, CSVColumnChainID, CSVColumnMediumMaxFee, CSVColumnNetworkCongestion}
+	if err := csvSamples.WriteCSVWith(&buf, CSVOptions{Columns: columns}); err != nil {
+		t.Fatalf("WriteCSVWith failed: %v", err)
+	}
+	assertGolden(t, "samples_columns.csv", buf.Bytes())
+}
+
+func TestWriteCSV_UnknownColumn(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (BaseFeeHistory{"1"}).WriteCSVWith(&buf, CSVOptions{Columns: []string{CSVColumnChainID}}); err == nil {
+		t.Error("Expected error for a samples column on history")
+	}
+	if err := csvSamples.WriteCSVWith(&buf, CSVOptions{Columns: []string{"gas_used"}}); err == nil {
+		t.Error("Expected error for an unknown column")
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Expected nothing written on error, got %q", buf.String())
+	}
+}
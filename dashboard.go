@@ -0,0 +1,165 @@
+package infura
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// GasDashboard combines the key numbers of the Gas API for one chain as parsed values,
+// ready for display. Fees are in Gwei of the chain's native token.
+type GasDashboard struct {
+	ChainID int64 `json:"chainId"`
+	// MaxFeeGwei and MaxPriorityFeeGwei are the medium level suggestions
+	MaxFeeGwei            float64 `json:"maxFeeGwei"`
+	MaxPriorityFeeGwei    float64 `json:"maxPriorityFeeGwei"`
+	EstimatedBaseFeeGwei  float64 `json:"estimatedBaseFeeGwei"`
+	NetworkCongestion     float64 `json:"networkCongestion"`
+	BusyThresholdGwei     float64 `json:"busyThresholdGwei"`
+	BaseFeePercentileGwei float64 `json:"baseFeePercentileGwei"`
+	// Missing lists the JSON names of the fields above that could not be filled, because
+	// their endpoint failed or returned no usable value; those fields are 0
+	Missing []string `json:"missing,omitempty"`
+}
+
+// dashboardFields are the fields each endpoint fills, in display order
+var dashboardFields = []struct {
+	resource string
+	fields   []string
+}{
+	{resource: "suggestedGasFees", fields: []string{"maxFeeGwei", "maxPriorityFeeGwei", "estimatedBaseFeeGwei", "networkCongestion"}},
+	{resource: "busyThreshold", fields: []string{"busyThresholdGwei"}},
+	{resource: "baseFeePercentile", fields: []string{"baseFeePercentileGwei"}},
+}
+
+// DashboardError reports the endpoints that failed in GetGasDashboard
+// errors.Is and errors.As match against every per-endpoint error
+type DashboardError struct {
+	failed map[string]error
+}
+
+// Failed returns the error of each failed endpoint, keyed by resource name
+// (suggestedGasFees, busyThreshold or baseFeePercentile)
+func (e *DashboardError) Failed() map[string]error {
+	return maps.Clone(e.failed)
+}
+
+// Error implements the error interface
+func (e *DashboardError) Error() string {
+	parts := make([]string, 0, len(e.failed))
+	for _, resource := range e.resources() {
+		parts = append(parts, fmt.Sprintf("%s: %v", resource, e.failed[resource]))
+	}
+	return fmt.Sprintf("%d of %d dashboard endpoints failed: %s", len(e.failed), len(dashboardFields), strings.Join(parts, "; "))
+}
+
+// Unwrap returns the per-endpoint errors ordered by resource name
+func (e *DashboardError) Unwrap() []error {
+	errs := make([]error, 0, len(e.failed))
+	for _, resource := range e.resources() {
+		errs = append(errs, e.failed[resource])
+	}
+	return errs
+}
+
+func (e *DashboardError) resources() []string {
+	return slices.Sorted(maps.Keys(e.failed))
+}
+
+// GetGasDashboard fetches suggestedGasFees, busyThreshold and baseFeePercentile
+// concurrently and returns their key numbers parsed to floats (see GasDashboard).
+//
+// Partial failures are tolerated: the dashboard holds every value that could be fetched
+// and parsed, lists the others in Missing, and a *DashboardError describing the failed
+// endpoints is returned alongside it. A value that does not parse counts as a failure of
+// its endpoint. If all endpoints fail, the dashboard is nil.
+func (c *Client) GetGasDashboard(ctx context.Context, chainID int64, opts ...CallOption) (*GasDashboard, error) {
+	ctx, cancel := withCallOptions(ctx, opts)
+	defer cancel()
+
+	var (
+		wg         sync.WaitGroup
+		fees       *SuggestedGasFees
+		busy       *BusyThreshold
+		percentile *BaseFeePercentile
+		errs       [3]error
+	)
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		fees, errs[0] = c.GetSuggestedGasFees(ctx, chainID)
+	}()
+	go func() {
+		defer wg.Done()
+		busy, errs[1] = c.GetBusyThreshold(ctx, chainID)
+	}()
+	go func() {
+		defer wg.Done()
+		percentile, errs[2] = c.GetBaseFeePercentile(ctx, chainID)
+	}()
+	wg.Wait()
+
+	dashboard := &GasDashboard{ChainID: chainID}
+	if errs[0] == nil {
+		errs[0] = dashboard.setFees(fees)
+	}
+	if errs[1] == nil {
+		dashboard.BusyThresholdGwei, errs[1] = dashboardGwei("busyThreshold", busy.BusyThreshold)
+	}
+	if errs[2] == nil {
+		dashboard.BaseFeePercentileGwei, errs[2] = dashboardGwei("baseFeePercentile", percentile.BaseFeePercentile)
+	}
+
+	failed := make(map[string]error)
+	for i, endpoint := range dashboardFields {
+		if errs[i] != nil {
+			failed[endpoint.resource] = errs[i]
+			dashboard.Missing = append(dashboard.Missing, endpoint.fields...)
+		}
+	}
+	if errs[0] == nil && !fees.NetworkCongestion.IsSet() {
+		dashboard.Missing = append(dashboard.Missing, "networkCongestion")
+	}
+
+	if len(failed) == 0 {
+		return dashboard, nil
+	}
+	if len(failed) == len(dashboardFields) {
+		return nil, &DashboardError{failed: failed}
+	}
+	return dashboard, &DashboardError{failed: failed}
+}
+
+// setFees fills the fields taken from suggestedGasFees; on error none of them are set
+func (d *GasDashboard) setFees(fees *SuggestedGasFees) error {
+	maxFee, err := dashboardGwei("medium.suggestedMaxFeePerGas", fees.Medium.SuggestedMaxFeePerGas)
+	if err != nil {
+		return err
+	}
+	maxPriorityFee, err := dashboardGwei("medium.suggestedMaxPriorityFeePerGas", fees.Medium.SuggestedMaxPriorityFeePerGas)
+	if err != nil {
+		return err
+	}
+	baseFee, err := dashboardGwei("estimatedBaseFee", fees.EstimatedBaseFee)
+	if err != nil {
+		return err
+	}
+	d.MaxFeeGwei = maxFee
+	d.MaxPriorityFeeGwei = maxPriorityFee
+	d.EstimatedBaseFeeGwei = baseFee
+	d.NetworkCongestion = fees.NetworkCongestion.Float64()
+	return nil
+}
+
+// dashboardGwei parses a Gwei amount for display
+func dashboardGwei(field, value string) (float64, error) {
+	gwei, err := parseGwei(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %w", field, value, err)
+	}
+	f, _ := gwei.Float64()
+	return f, nil
+}
@@ -0,0 +1,110 @@
+package infura
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"reflect"
+	"testing"
+)
+
+// newDashboardServer serves the given body per resource; missing resources answer 500
+func newDashboardServer(bodies map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := bodies[path.Base(r.URL.Path)]
+		if !ok {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+}
+
+const dashboardFeesBody = `{
+	"medium": {"suggestedMaxPriorityFeePerGas": "1.5", "suggestedMaxFeePerGas": "32.548151972"},
+	"estimatedBaseFee": "24.036058416",
+	"networkCongestion": 0.7143
+}`
+
+func TestGetGasDashboard(t *testing.T) {
+	server := newDashboardServer(map[string]string{
+		"suggestedGasFees":  dashboardFeesBody,
+		"busyThreshold":     `{"busyThreshold": "37.3"}`,
+		"baseFeePercentile": `{"baseFeePercentile": "22.5"}`,
+	})
+	defer server.Close()
+
+	client := NewClientWithOptions("test-api-key", "test-api-secret", WithBaseURL(server.URL))
+	dashboard, err := client.GetGasDashboard(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetGasDashboard failed: %v", err)
+	}
+
+	want := GasDashboard{
+		ChainID:               1,
+		MaxFeeGwei:            32.548151972,
+		MaxPriorityFeeGwei:    1.5,
+		EstimatedBaseFeeGwei:  24.036058416,
+		NetworkCongestion:     0.7143,
+		BusyThresholdGwei:     37.3,
+		BaseFeePercentileGwei: 22.5,
+	}
+	if !reflect.DeepEqual(*dashboard, want) {
+		t.Errorf("Expected %+v, got %+v", want, *dashboard)
+	}
+}
+
+func TestGetGasDashboard_PartialFailure(t *testing.T) {
+	server := newDashboardServer(map[string]string{
+		"suggestedGasFees":  `{"medium": {"suggestedMaxPriorityFeePerGas": "1.5", "suggestedMaxFeePerGas": "32"}, "estimatedBaseFee": "24"}`,
+		"baseFeePercentile": `{"baseFeePercentile": "not a number"}`,
+	})
+	defer server.Close()
+
+	client := NewClientWithOptions("test-api-key", "test-api-secret", WithBaseURL(server.URL))
+	dashboard, err := client.GetGasDashboard(context.Background(), 1)
+
+	var dashErr *DashboardError
+	if !errors.As(err, &dashErr) {
+		t.Fatalf("Expected a *DashboardError, got %v", err)
+	}
+	failed := dashErr.Failed()
+	if len(failed) != 2 || failed["busyThreshold"] == nil || failed["baseFeePercentile"] == nil {
+		t.Errorf("Expected busyThreshold and baseFeePercentile to fail, got %v", failed)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusInternalServerError {
+		t.Errorf("Expected the 500 APIError to be reachable, got %v", apiErr)
+	}
+
+	if dashboard == nil {
+		t.Fatal("Expected a partial dashboard, got nil")
+	}
+	want := GasDashboard{
+		ChainID:              1,
+		MaxFeeGwei:           32,
+		MaxPriorityFeeGwei:   1.5,
+		EstimatedBaseFeeGwei: 24,
+		Missing:              []string{"busyThresholdGwei", "baseFeePercentileGwei", "networkCongestion"},
+	}
+	if !reflect.DeepEqual(*dashboard, want) {
+		t.Errorf("Expected %+v, got %+v", want, *dashboard)
+	}
+}
+
+func TestGetGasDashboard_AllFailed(t *testing.T) {
+	server := newDashboardServer(nil)
+	defer server.Close()
+
+	client := NewClientWithOptions("test-api-key", "test-api-secret", WithBaseURL(server.URL))
+	dashboard, err := client.GetGasDashboard(context.Background(), 1)
+	if dashboard != nil {
+		t.Errorf("Expected no dashboard, got %+v", dashboard)
+	}
+	var dashErr *DashboardError
+	if !errors.As(err, &dashErr) || len(dashErr.Failed()) != 3 {
+		t.Errorf("Expected all 3 endpoints to fail, got %v", err)
+	}
+}
@@ -0,0 +1,36 @@
+package infura
+
+import (
+	"context"
+	"io"
+	"log"
+)
+
+type debugKey struct{}
+
+// ContextWithDebug enables debug output for calls made with the returned context, as
+// WithDebug does for the whole client. Other calls on the same client are not affected.
+// Output goes to the standard logger, with the usual masking of the Authorization header.
+func ContextWithDebug(ctx context.Context) context.Context {
+	return context.WithValue(ctx, debugKey{}, log.Default())
+}
+
+// ContextWithDebugWriter is like ContextWithDebug but writes the debug output of calls
+// made with the returned context to w instead of the standard logger
+// Output of concurrent requests in the same call (e.g. GetBaseFeeSnapshot) is serialized
+func ContextWithDebugWriter(ctx context.Context, w io.Writer) context.Context {
+	return context.WithValue(ctx, debugKey{}, log.New(w, "", log.LstdFlags))
+}
+
+// debugLogger returns the logger for debug output of a call, or nil if debug is off for it
+// A logger attached to ctx takes precedence over the client-wide debug flag, which is
+// ignored when structured records go to a slog logger (see WithSlogLogger)
+func (c *Client) debugLogger(ctx context.Context) *log.Logger {
+	if logger, ok := ctx.Value(debugKey{}).(*log.Logger); ok {
+		return logger
+	}
+	if c.Debug() && c.slogger == nil {
+		return log.Default()
+	}
+	return nil
+}
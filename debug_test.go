@@ -0,0 +1,119 @@
+package infura
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// syncBuffer is a bytes.Buffer safe for concurrent writes
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// captureLog redirects the standard logger to a buffer for the rest of the test
+func captureLog(t *testing.T) *syncBuffer {
+	t.Helper()
+	buf := &syncBuffer{}
+	output, flags := log.Writer(), log.Flags()
+	log.SetOutput(buf)
+	t.Cleanup(func() {
+		log.SetOutput(output)
+		log.SetFlags(flags)
+	})
+	return buf
+}
+
+func newDebugServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"busyThreshold":"` + strings.TrimPrefix(r.URL.Path, "/networks/") + `"}`))
+	}))
+}
+
+func TestContextWithDebugWriter_OnlyThatCall(t *testing.T) {
+	server := newDebugServer()
+	defer server.Close()
+	global := captureLog(t)
+
+	client := NewClientWithOptions("test-api-key", "test-api-secret-value", WithBaseURL(server.URL))
+
+	var debugOut syncBuffer
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		ctx := ContextWithDebugWriter(context.Background(), &debugOut)
+		if _, err := client.GetBusyThreshold(ctx, 1); err != nil {
+			t.Errorf("GetBusyThreshold failed: %v", err)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 10; i++ {
+			if _, err := client.GetBusyThreshold(context.Background(), 137); err != nil {
+				t.Errorf("GetBusyThreshold failed: %v", err)
+			}
+		}
+	}()
+	wg.Wait()
+
+	out := debugOut.String()
+	for _, want := range []string{"HTTP Request", "/networks/1/busyThreshold", "HTTP Response Body", "Parsed response object"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected debug output to contain %q, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "/networks/137/") {
+		t.Errorf("Expected no output from the other goroutine, got:\n%s", out)
+	}
+	if strings.Contains(out, "test-api-secret-value") || strings.Contains(out, client.getAuthHeader()) {
+		t.Error("Expected the Authorization header to be masked")
+	}
+	if got := global.String(); got != "" {
+		t.Errorf("Expected nothing on the standard logger, got:\n%s", got)
+	}
+}
+
+func TestContextWithDebug(t *testing.T) {
+	server := newDebugServer()
+	defer server.Close()
+	global := captureLog(t)
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL))
+
+	if _, err := client.GetBusyThreshold(context.Background(), 137); err != nil {
+		t.Fatalf("GetBusyThreshold failed: %v", err)
+	}
+	if got := global.String(); got != "" {
+		t.Fatalf("Expected no debug output without debug enabled, got:\n%s", got)
+	}
+
+	if _, err := client.GetBusyThreshold(ContextWithDebug(context.Background()), 1); err != nil {
+		t.Fatalf("GetBusyThreshold failed: %v", err)
+	}
+	if got := global.String(); !strings.Contains(got, "[DEBUG] ========== HTTP Request ==========") {
+		t.Errorf("Expected debug output on the standard logger, got:\n%s", got)
+	}
+	if client.Debug() {
+		t.Error("Expected the client-wide debug flag to stay off")
+	}
+}
@@ -0,0 +1,125 @@
+// Package decimalfees exposes Infura gas suggestions as shopspring/decimal values.
+//
+// It is a separate module so that the core module stays free of the dependency. Fee
+// strings are parsed straight into decimals, never through float64, so every digit the
+// API sent is kept; the accepted syntax is that of infura.FeeToWei (plain decimal Gwei,
+// any number of decimal places).
+//
+//	level, _ := fees.Level(infura.PriorityMedium)
+//	maxFee, _ := decimalfees.MaxFeeGwei(level) // 32.548628689
+package decimalfees
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	infura "github.com/ABT-Tech-Limited/infura-go"
+	"github.com/shopspring/decimal"
+)
+
+// ErrNoCongestion is returned by Congestion when the API sent no networkCongestion
+var ErrNoCongestion = errors.New("network congestion not set")
+
+// gweiDecimals is the number of decimal places between Gwei and wei
+const gweiDecimals = 9
+
+// ParseGwei parses a Gwei amount as returned by the API into a decimal
+func ParseGwei(gwei string) (decimal.Decimal, error) {
+	// FeeToWei validates the syntax; its rounded result is not used
+	if _, err := infura.FeeToWei(gwei, infura.RoundDown); err != nil {
+		return decimal.Decimal{}, err
+	}
+	return decimal.NewFromString(strings.TrimSpace(gwei))
+}
+
+// ParseGweiAsWei parses a Gwei amount as returned by the API into a decimal number of wei
+// The result is exact: amounts with more than 9 decimal places keep their fraction of a wei.
+func ParseGweiAsWei(gwei string) (decimal.Decimal, error) {
+	d, err := ParseGwei(gwei)
+	if err != nil {
+		return decimal.Decimal{}, err
+	}
+	return d.Shift(gweiDecimals), nil
+}
+
+// MaxFeeGwei returns the level's suggestedMaxFeePerGas in Gwei
+func MaxFeeGwei(l infura.GasFeeLevel) (decimal.Decimal, error) {
+	return parseField("suggestedMaxFeePerGas", l.SuggestedMaxFeePerGas, ParseGwei)
+}
+
+// MaxFeeWei returns the level's suggestedMaxFeePerGas in wei
+func MaxFeeWei(l infura.GasFeeLevel) (decimal.Decimal, error) {
+	return parseField("suggestedMaxFeePerGas", l.SuggestedMaxFeePerGas, ParseGweiAsWei)
+}
+
+// MaxPriorityFeeGwei returns the level's suggestedMaxPriorityFeePerGas in Gwei
+func MaxPriorityFeeGwei(l infura.GasFeeLevel) (decimal.Decimal, error) {
+	return parseField("suggestedMaxPriorityFeePerGas", l.SuggestedMaxPriorityFeePerGas, ParseGwei)
+}
+
+// MaxPriorityFeeWei returns the level's suggestedMaxPriorityFeePerGas in wei
+func MaxPriorityFeeWei(l infura.GasFeeLevel) (decimal.Decimal, error) {
+	return parseField("suggestedMaxPriorityFeePerGas", l.SuggestedMaxPriorityFeePerGas, ParseGweiAsWei)
+}
+
+// EstimatedBaseFeeGwei returns the suggestion's estimatedBaseFee in Gwei
+func EstimatedBaseFeeGwei(f *infura.SuggestedGasFees) (decimal.Decimal, error) {
+	if f == nil {
+		return decimal.Decimal{}, fmt.Errorf("suggested gas fees must not be nil")
+	}
+	return parseField("estimatedBaseFee", f.EstimatedBaseFee, ParseGwei)
+}
+
+// EstimatedBaseFeeWei returns the suggestion's estimatedBaseFee in wei
+func EstimatedBaseFeeWei(f *infura.SuggestedGasFees) (decimal.Decimal, error) {
+	if f == nil {
+		return decimal.Decimal{}, fmt.Errorf("suggested gas fees must not be nil")
+	}
+	return parseField("estimatedBaseFee", f.EstimatedBaseFee, ParseGweiAsWei)
+}
+
+// Congestion returns the networkCongestion ratio exactly as the API sent it, or
+// ErrNoCongestion if it was not sent
+func Congestion(c infura.Congestion) (decimal.Decimal, error) {
+	if !c.IsSet() {
+		return decimal.Decimal{}, ErrNoCongestion
+	}
+	d, err := decimal.NewFromString(string(c.Number()))
+	if err != nil {
+		return decimal.Decimal{}, fmt.Errorf("invalid networkCongestion: %w", err)
+	}
+	return d, nil
+}
+
+// BusyThresholdGwei returns the busy threshold in Gwei
+func BusyThresholdGwei(t *infura.BusyThreshold) (decimal.Decimal, error) {
+	if t == nil {
+		return decimal.Decimal{}, fmt.Errorf("busy threshold must not be nil")
+	}
+	return parseField("busyThreshold", t.BusyThreshold, ParseGwei)
+}
+
+// FeeRange converts a [min, max] Gwei range such as LatestPriorityFeeRange or
+// HistoricalBaseFeeRange into decimals
+func FeeRange(r []string) (low, high decimal.Decimal, err error) {
+	if len(r) != 2 {
+		return decimal.Decimal{}, decimal.Decimal{}, fmt.Errorf("fee range must have 2 entries, got %d", len(r))
+	}
+	if low, err = parseField("range minimum", r[0], ParseGwei); err != nil {
+		return decimal.Decimal{}, decimal.Decimal{}, err
+	}
+	if high, err = parseField("range maximum", r[1], ParseGwei); err != nil {
+		return decimal.Decimal{}, decimal.Decimal{}, err
+	}
+	return low, high, nil
+}
+
+// parseField parses a field with parse and names the field in errors
+func parseField(field, value string, parse func(string) (decimal.Decimal, error)) (decimal.Decimal, error) {
+	d, err := parse(value)
+	if err != nil {
+		return decimal.Decimal{}, fmt.Errorf("invalid %s: %w", field, err)
+	}
+	return d, nil
+}
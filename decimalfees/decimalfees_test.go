@@ -0,0 +1,100 @@
+package decimalfees
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	infura "github.com/ABT-Tech-Limited/infura-go"
+	"github.com/shopspring/decimal"
+)
+
+// sampleJSON holds values with more digits than a float64 can carry
+const sampleJSON = `{
+	"low": {"suggestedMaxPriorityFeePerGas": "0.000000000000000001", "suggestedMaxFeePerGas": "24.086058416"},
+	"medium": {"suggestedMaxPriorityFeePerGas": "1.5", "suggestedMaxFeePerGas": "12345678901234567890.123456789"},
+	"high": {"suggestedMaxPriorityFeePerGas": "2", "suggestedMaxFeePerGas": "41.1611999041234"},
+	"estimatedBaseFee": "24.036058416000000001",
+	"networkCongestion": 0.71430000000000000001,
+	"latestPriorityFeeRange": ["0.000131", "3.3333333333333333333"],
+	"historicalBaseFeeRange": ["19.5", "59.999999999"]
+}`
+
+func sample(t *testing.T) *infura.SuggestedGasFees {
+	t.Helper()
+	var fees infura.SuggestedGasFees
+	if err := json.Unmarshal([]byte(sampleJSON), &fees); err != nil {
+		t.Fatalf("failed to decode sample: %v", err)
+	}
+	return &fees
+}
+
+// assertEqual compares decimals exactly, scale aside
+func assertEqual(t *testing.T, name string, got decimal.Decimal, err error, want string) {
+	t.Helper()
+	if err != nil {
+		t.Errorf("%s failed: %v", name, err)
+		return
+	}
+	if !got.Equal(decimal.RequireFromString(want)) {
+		t.Errorf("%s = %s, want %s", name, got, want)
+	}
+}
+
+func TestLevelAccessors(t *testing.T) {
+	fees := sample(t)
+
+	got, err := MaxFeeGwei(fees.Medium)
+	assertEqual(t, "MaxFeeGwei", got, err, "12345678901234567890.123456789")
+	got, err = MaxFeeWei(fees.Medium)
+	assertEqual(t, "MaxFeeWei", got, err, "12345678901234567890123456789")
+	got, err = MaxFeeWei(fees.High)
+	assertEqual(t, "MaxFeeWei", got, err, "41161199904.1234")
+	got, err = MaxPriorityFeeGwei(fees.Low)
+	assertEqual(t, "MaxPriorityFeeGwei", got, err, "0.000000000000000001")
+	got, err = MaxPriorityFeeWei(fees.Low)
+	assertEqual(t, "MaxPriorityFeeWei", got, err, "0.000000001")
+	got, err = MaxPriorityFeeWei(fees.Medium)
+	assertEqual(t, "MaxPriorityFeeWei", got, err, "1500000000")
+}
+
+func TestSuggestionAccessors(t *testing.T) {
+	fees := sample(t)
+
+	got, err := EstimatedBaseFeeGwei(fees)
+	assertEqual(t, "EstimatedBaseFeeGwei", got, err, "24.036058416000000001")
+	got, err = EstimatedBaseFeeWei(fees)
+	assertEqual(t, "EstimatedBaseFeeWei", got, err, "24036058416.000000001")
+	got, err = Congestion(fees.NetworkCongestion)
+	assertEqual(t, "Congestion", got, err, "0.71430000000000000001")
+
+	low, high, err := FeeRange(fees.LatestPriorityFeeRange)
+	assertEqual(t, "FeeRange low", low, err, "0.000131")
+	assertEqual(t, "FeeRange high", high, err, "3.3333333333333333333")
+	low, high, err = FeeRange(fees.HistoricalBaseFeeRange)
+	assertEqual(t, "FeeRange low", low, err, "19.5")
+	assertEqual(t, "FeeRange high", high, err, "59.999999999")
+
+	got, err = BusyThresholdGwei(&infura.BusyThreshold{BusyThreshold: "37.300000000000000007"})
+	assertEqual(t, "BusyThresholdGwei", got, err, "37.300000000000000007")
+}
+
+func TestErrors(t *testing.T) {
+	if _, err := Congestion(infura.Congestion{}); !errors.Is(err, ErrNoCongestion) {
+		t.Errorf("Expected ErrNoCongestion, got %v", err)
+	}
+	for _, input := range []string{"", "-1", "1e9", "0x5", "24,5"} {
+		if _, err := ParseGwei(input); err == nil {
+			t.Errorf("ParseGwei(%q): expected error", input)
+		}
+	}
+	if _, err := MaxFeeGwei(infura.GasFeeLevel{SuggestedMaxFeePerGas: "n/a"}); err == nil {
+		t.Error("Expected an error for an invalid fee")
+	}
+	if _, _, err := FeeRange([]string{"1"}); err == nil {
+		t.Error("Expected an error for a range of one entry")
+	}
+	if _, err := EstimatedBaseFeeGwei(nil); err == nil {
+		t.Error("Expected an error for nil fees")
+	}
+}
@@ -0,0 +1,15 @@
+module github.com/ABT-Tech-Limited/infura-go/decimalfees
+
+go 1.25.1
+
+require (
+	github.com/ABT-Tech-Limited/infura-go v0.0.0
+	github.com/shopspring/decimal v1.4.0
+)
+
+require (
+	golang.org/x/time v0.14.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/ABT-Tech-Limited/infura-go => ../
@@ -0,0 +1,46 @@
+package infura
+
+import (
+	"slices"
+	"sync"
+)
+
+// defaultOptions holds the options set with SetDefaultOptions
+var defaultOptions struct {
+	mu   sync.RWMutex
+	opts []ClientOption
+}
+
+// SetDefaultOptions sets options that every client constructed afterwards applies before
+// its own options, so options passed to a constructor take precedence. It replaces the
+// previous defaults. Clients already constructed are not affected: each one applies the
+// defaults set at the time it was built. Safe to call concurrently with the constructors.
+//
+// The options are applied to every client, so values they hold, such as the HTTP client
+// of WithHTTPClient or the backend of WithRPC, are shared between those clients.
+func SetDefaultOptions(opts ...ClientOption) {
+	defaultOptions.mu.Lock()
+	defer defaultOptions.mu.Unlock()
+	defaultOptions.opts = slices.Clone(opts)
+}
+
+// DefaultOptions returns a copy of the options set with SetDefaultOptions
+func DefaultOptions() []ClientOption {
+	defaultOptions.mu.RLock()
+	defer defaultOptions.mu.RUnlock()
+	return slices.Clone(defaultOptions.opts)
+}
+
+// ResetDefaultOptions removes the options set with SetDefaultOptions
+func ResetDefaultOptions() {
+	SetDefaultOptions()
+}
+
+// withDefaultOptions returns the current default options followed by opts
+func withDefaultOptions(opts []ClientOption) []ClientOption {
+	defaults := DefaultOptions()
+	if len(defaults) == 0 {
+		return opts
+	}
+	return append(defaults, opts...)
+}
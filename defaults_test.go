@@ -0,0 +1,100 @@
+package infura
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// setDefaultOptions sets default options for the rest of the test
+func setDefaultOptions(t *testing.T, opts ...ClientOption) {
+	t.Helper()
+	SetDefaultOptions(opts...)
+	t.Cleanup(ResetDefaultOptions)
+}
+
+func TestSetDefaultOptions_Precedence(t *testing.T) {
+	setDefaultOptions(t, WithTimeout(5*time.Second), WithRetry(3, time.Second), WithUserAgent("defaults/1.0"))
+
+	client := NewClientWithOptions("test-api-key", "", WithTimeout(2*time.Second))
+	cfg := client.Config()
+	if cfg.Timeout != 2*time.Second {
+		t.Errorf("Timeout = %v, want the constructor option to win", cfg.Timeout)
+	}
+	if cfg.RetryMaxAttempts != 3 || cfg.RetryBaseDelay != time.Second {
+		t.Errorf("retry = %d, %v, want the default", cfg.RetryMaxAttempts, cfg.RetryBaseDelay)
+	}
+
+	for name, c := range map[string]*Client{
+		"NewClient":                     NewClient("test-api-key", ""),
+		"NewClientWithAPIKey":           NewClientWithAPIKey("test-api-key"),
+		"NewClientWithAPIKeyAndOptions": NewClientWithAPIKeyAndOptions("test-api-key"),
+	} {
+		if got := c.Timeout(); got != 5*time.Second {
+			t.Errorf("%s: Timeout = %v, want the default 5s", name, got)
+		}
+	}
+}
+
+func TestSetDefaultOptions_ExistingClientsUnaffected(t *testing.T) {
+	setDefaultOptions(t, WithTimeout(5*time.Second))
+	before := NewClient("test-api-key", "")
+
+	SetDefaultOptions(WithTimeout(7*time.Second), WithRetry(4, time.Second))
+	after := NewClient("test-api-key", "")
+
+	if got := before.Timeout(); got != 5*time.Second {
+		t.Errorf("existing client Timeout = %v, want 5s", got)
+	}
+	if got := before.Config().RetryMaxAttempts; got != 1 {
+		t.Errorf("existing client RetryMaxAttempts = %d, want 1", got)
+	}
+	if got := after.Timeout(); got != 7*time.Second {
+		t.Errorf("new client Timeout = %v, want 7s", got)
+	}
+
+	ResetDefaultOptions()
+	if got := NewClient("test-api-key", "").Timeout(); got != DefaultTimeout {
+		t.Errorf("Timeout after reset = %v, want %v", got, DefaultTimeout)
+	}
+	if n := len(DefaultOptions()); n != 0 {
+		t.Errorf("DefaultOptions() after reset has %d options", n)
+	}
+}
+
+func TestSetDefaultOptions_InvalidDefaultReported(t *testing.T) {
+	setDefaultOptions(t, WithRateLimit(0, 0))
+	if _, err := New("test-api-key", ""); err == nil {
+		t.Error("New() error = nil, want the invalid default option reported")
+	}
+}
+
+func TestDefaultOptions_ReturnsCopy(t *testing.T) {
+	setDefaultOptions(t, WithTimeout(5*time.Second))
+	opts := DefaultOptions()
+	opts[0] = WithTimeout(time.Second)
+	if got := NewClient("test-api-key", "").Timeout(); got != 5*time.Second {
+		t.Errorf("Timeout = %v, modifying DefaultOptions() changed the defaults", got)
+	}
+}
+
+func TestSetDefaultOptions_Concurrent(t *testing.T) {
+	t.Cleanup(ResetDefaultOptions)
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			SetDefaultOptions(WithTimeout(time.Duration(i+1) * time.Second))
+		}()
+		go func() {
+			defer wg.Done()
+			got := NewClient("test-api-key", "").Timeout()
+			if got != DefaultTimeout && (got < time.Second || got > 8*time.Second) {
+				t.Errorf("Timeout = %v, want the default or one of the defaults set", got)
+			}
+		}()
+	}
+	wg.Wait()
+}
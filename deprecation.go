@@ -0,0 +1,128 @@
+package infura
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DeprecationNotice describes deprecation signals found in an API response
+type DeprecationNotice struct {
+	// Endpoint is the request path, with the API key redacted
+	Endpoint string
+	// Deprecation is the raw Deprecation header (e.g. "@1735689600" or "true")
+	Deprecation string
+	// DeprecatedAt is the parsed Deprecation date, zero if absent or not a date
+	DeprecatedAt time.Time
+	// Sunset is the raw Sunset header
+	Sunset string
+	// SunsetAt is the parsed Sunset date, zero if absent or unparseable
+	SunsetAt time.Time
+	// Warnings holds the values of all Warning headers
+	Warnings []string
+}
+
+// WithDeprecationHandler sets a callback invoked for every response carrying a Sunset,
+// Deprecation or Warning header. It runs synchronously on the calling goroutine.
+func WithDeprecationHandler(handler func(DeprecationNotice)) ClientOption {
+	return func(c *Client) {
+		c.deprecationHandler = handler
+	}
+}
+
+// WithDeprecationWarnings logs a warning the first time each distinct Sunset, Deprecation
+// or Warning header value is seen
+func WithDeprecationWarnings() ClientOption {
+	return func(c *Client) {
+		c.deprecationWarnings = true
+	}
+}
+
+// parseDeprecationNotice extracts deprecation signals from response headers
+// It returns nil if none are present
+func parseDeprecationNotice(header http.Header) *DeprecationNotice {
+	deprecation := header.Get("Deprecation")
+	sunset := header.Get("Sunset")
+	warnings := header.Values("Warning")
+	if deprecation == "" && sunset == "" && len(warnings) == 0 {
+		return nil
+	}
+
+	notice := &DeprecationNotice{
+		Deprecation: deprecation,
+		Sunset:      sunset,
+		Warnings:    append([]string(nil), warnings...),
+	}
+	notice.DeprecatedAt = parseDeprecationDate(deprecation)
+	if t, err := http.ParseTime(sunset); err == nil {
+		notice.SunsetAt = t
+	}
+	return notice
+}
+
+// parseDeprecationDate parses a Deprecation header value, which is either a structured
+// date ("@<unix seconds>", RFC 9745) or an HTTP-date used by older drafts
+func parseDeprecationDate(value string) time.Time {
+	if seconds, ok := strings.CutPrefix(value, "@"); ok {
+		if n, err := strconv.ParseInt(seconds, 10, 64); err == nil {
+			return time.Unix(n, 0).UTC()
+		}
+		return time.Time{}
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return t
+	}
+	return time.Time{}
+}
+
+// handleDeprecation reports deprecation headers of a response to CallMeta, the configured
+// handler and the warning log
+func (c *Client) handleDeprecation(ctx context.Context, creds *credentials, endpoint string, header http.Header) {
+	notice := parseDeprecationNotice(header)
+	if notice == nil {
+		return
+	}
+	notice.Endpoint = redactAPIKey(creds, endpoint)
+
+	recordCallMeta(ctx, func(meta *CallMeta) {
+		meta.Deprecation = notice
+	})
+
+	if c.deprecationWarnings {
+		for _, value := range notice.headerValues() {
+			if _, seen := c.deprecationSeen.LoadOrStore(value, struct{}{}); !seen {
+				log.Printf("[WARN] Infura API deprecation notice for %s: %s\n", notice.Endpoint, value)
+			}
+		}
+	}
+
+	if c.deprecationHandler != nil {
+		c.deprecationHandler(*notice)
+	}
+}
+
+// headerValues returns each present header as a "Name: value" line
+func (n *DeprecationNotice) headerValues() []string {
+	var values []string
+	if n.Deprecation != "" {
+		values = append(values, "Deprecation: "+n.Deprecation)
+	}
+	if n.Sunset != "" {
+		values = append(values, "Sunset: "+n.Sunset)
+	}
+	for _, warning := range n.Warnings {
+		values = append(values, "Warning: "+warning)
+	}
+	return values
+}
+
+// redactAPIKey replaces the API key in an endpoint path
+func redactAPIKey(creds *credentials, endpoint string) string {
+	if creds.apiKey == "" {
+		return endpoint
+	}
+	return strings.ReplaceAll(endpoint, creds.apiKey, "REDACTED")
+}
@@ -0,0 +1,96 @@
+package infura
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newDeprecatedServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Sunset", "Wed, 31 Dec 2025 23:59:59 GMT")
+		w.Header().Set("Deprecation", "@1735689600")
+		w.Header().Add("Warning", `299 - "suggestedGasFees v1 is deprecated"`)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"estimatedBaseFee": "10"}`))
+	}))
+}
+
+func TestDeprecationHandler(t *testing.T) {
+	server := newDeprecatedServer(t)
+	defer server.Close()
+
+	var notices []DeprecationNotice
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithDeprecationHandler(func(n DeprecationNotice) { notices = append(notices, n) }))
+
+	var meta CallMeta
+	if _, err := client.GetSuggestedGasFees(ContextWithCallMeta(context.Background(), &meta), 1); err != nil {
+		t.Fatalf("GetSuggestedGasFees failed: %v", err)
+	}
+
+	if len(notices) != 1 {
+		t.Fatalf("Expected 1 notice, got %d", len(notices))
+	}
+	notice := notices[0]
+	if want := time.Date(2025, 12, 31, 23, 59, 59, 0, time.UTC); !notice.SunsetAt.Equal(want) {
+		t.Errorf("Expected sunset %v, got %v", want, notice.SunsetAt)
+	}
+	if want := time.Unix(1735689600, 0); !notice.DeprecatedAt.Equal(want) {
+		t.Errorf("Expected deprecation %v, got %v", want, notice.DeprecatedAt)
+	}
+	if len(notice.Warnings) != 1 || !strings.Contains(notice.Warnings[0], "deprecated") {
+		t.Errorf("Unexpected warnings: %v", notice.Warnings)
+	}
+	if strings.Contains(notice.Endpoint, "test-api-key") {
+		t.Errorf("Expected API key to be redacted, got %s", notice.Endpoint)
+	}
+	if meta.Deprecation == nil || meta.Deprecation.Sunset != notice.Sunset {
+		t.Errorf("Expected notice in CallMeta, got %+v", meta.Deprecation)
+	}
+}
+
+func TestDeprecationWarnings_LoggedOnce(t *testing.T) {
+	server := newDeprecatedServer(t)
+	defer server.Close()
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithDeprecationWarnings())
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.GetSuggestedGasFees(context.Background(), 1); err != nil {
+			t.Fatalf("GetSuggestedGasFees failed: %v", err)
+		}
+	}
+
+	output := buf.String()
+	for _, header := range []string{"Sunset:", "Deprecation:", "Warning:"} {
+		if got := strings.Count(output, header); got != 1 {
+			t.Errorf("Expected %s to be logged once, got %d", header, got)
+		}
+	}
+}
+
+func TestParseDeprecationNotice_NoHeaders(t *testing.T) {
+	if notice := parseDeprecationNotice(http.Header{}); notice != nil {
+		t.Errorf("Expected nil notice, got %+v", notice)
+	}
+
+	notice := parseDeprecationNotice(http.Header{"Deprecation": {"true"}})
+	if notice == nil || notice.Deprecation != "true" || !notice.DeprecatedAt.IsZero() {
+		t.Errorf("Expected raw Deprecation without a date, got %+v", notice)
+	}
+}
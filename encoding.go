@@ -0,0 +1,77 @@
+package infura
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// WithAcceptEncoding sets the Accept-Encoding request header explicitly
+// Supported response encodings are gzip, deflate and identity; they are decoded by the
+// client itself, so this also works with custom transports that do not decompress.
+// Calling it without values requests an uncompressed response ("identity").
+//
+// When this option is omitted, the header is left to the transport: Go's default
+// http.Transport requests gzip and decompresses transparently, while custom
+// RoundTrippers may not compress at all.
+func WithAcceptEncoding(values ...string) ClientOption {
+	return func(c *Client) {
+		if len(values) == 0 {
+			values = []string{"identity"}
+		}
+		c.acceptEncoding = strings.Join(values, ", ")
+	}
+}
+
+// decodeResponseBody replaces resp.Body with a decompressing reader based on Content-Encoding
+// It is only used when Accept-Encoding was set explicitly, since the transport then leaves
+// the body compressed
+func decodeResponseBody(resp *http.Response) error {
+	if resp.Uncompressed {
+		return nil
+	}
+
+	encoding := strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding")))
+	var reader io.ReadCloser
+	switch encoding {
+	case "", "identity":
+		return nil
+	case "gzip", "x-gzip":
+		gz, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return fmt.Errorf("failed to decode gzip response: %w", err)
+		}
+		reader = gz
+	case "deflate":
+		zr, err := zlib.NewReader(resp.Body)
+		if err != nil {
+			return fmt.Errorf("failed to decode deflate response: %w", err)
+		}
+		reader = zr
+	default:
+		return fmt.Errorf("unsupported response content encoding: %s", encoding)
+	}
+
+	resp.Body = &decodedBody{Reader: reader, decoder: reader, raw: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return nil
+}
+
+// decodedBody reads from a decompressor and closes both it and the raw body
+type decodedBody struct {
+	io.Reader
+	decoder io.Closer
+	raw     io.Closer
+}
+
+// Close closes the decompressor and the underlying response body
+func (b *decodedBody) Close() error {
+	b.decoder.Close()
+	return b.raw.Close()
+}
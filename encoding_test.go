@@ -0,0 +1,120 @@
+package infura
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newEncodingServer returns a mock server that records the Accept-Encoding header and
+// serves a busyThreshold payload encoded with the given Content-Encoding
+func newEncodingServer(t *testing.T, encoding string, gotHeader *string) *httptest.Server {
+	t.Helper()
+	payload := []byte(`{"busyThreshold": "42"}`)
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*gotHeader = r.Header.Get("Accept-Encoding")
+
+		var buf bytes.Buffer
+		switch encoding {
+		case "gzip":
+			zw := gzip.NewWriter(&buf)
+			zw.Write(payload)
+			zw.Close()
+		case "deflate":
+			zw := zlib.NewWriter(&buf)
+			zw.Write(payload)
+			zw.Close()
+		default:
+			buf.Write(payload)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if encoding != "" {
+			w.Header().Set("Content-Encoding", encoding)
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write(buf.Bytes())
+	}))
+}
+
+func TestWithAcceptEncoding(t *testing.T) {
+	tests := []struct {
+		name       string
+		values     []string
+		encoding   string
+		wantHeader string
+	}{
+		{name: "gzip", values: []string{"gzip"}, encoding: "gzip", wantHeader: "gzip"},
+		{name: "deflate", values: []string{"deflate"}, encoding: "deflate", wantHeader: "deflate"},
+		{name: "multiple values", values: []string{"gzip", "deflate;q=0.5"}, encoding: "gzip", wantHeader: "gzip, deflate;q=0.5"},
+		{name: "identity", values: []string{"identity"}, encoding: "", wantHeader: "identity"},
+		{name: "no values forbids compression", values: nil, encoding: "", wantHeader: "identity"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotHeader string
+			server := newEncodingServer(t, tt.encoding, &gotHeader)
+			defer server.Close()
+
+			client := NewClientWithAPIKeyAndOptions("test-api-key",
+				WithBaseURL(server.URL),
+				WithAcceptEncoding(tt.values...))
+
+			result, err := client.GetBusyThreshold(context.Background(), 1)
+			if err != nil {
+				t.Fatalf("GetBusyThreshold failed: %v", err)
+			}
+			if gotHeader != tt.wantHeader {
+				t.Errorf("Expected Accept-Encoding %q, got %q", tt.wantHeader, gotHeader)
+			}
+			if result.BusyThreshold != "42" {
+				t.Errorf("Expected busyThreshold 42, got %s", result.BusyThreshold)
+			}
+		})
+	}
+}
+
+func TestWithAcceptEncoding_Omitted(t *testing.T) {
+	var gotHeader string
+	server := newEncodingServer(t, "gzip", &gotHeader)
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL))
+
+	result, err := client.GetBusyThreshold(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetBusyThreshold failed: %v", err)
+	}
+	// Go's default transport requests gzip and decodes it transparently
+	if gotHeader != "gzip" {
+		t.Errorf("Expected transport default Accept-Encoding gzip, got %q", gotHeader)
+	}
+	if result.BusyThreshold != "42" {
+		t.Errorf("Expected busyThreshold 42, got %s", result.BusyThreshold)
+	}
+}
+
+func TestWithAcceptEncoding_UnsupportedEncoding(t *testing.T) {
+	var gotHeader string
+	server := newEncodingServer(t, "br", &gotHeader)
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithAcceptEncoding("br"))
+
+	_, err := client.GetBusyThreshold(context.Background(), 1)
+	if err == nil {
+		t.Fatal("Expected error for unsupported content encoding")
+	}
+	if !strings.Contains(err.Error(), "unsupported response content encoding") {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,266 @@
+package infura
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var (
+	// ErrUnauthorized indicates the API rejected the credentials (401 or 403)
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrNotFound indicates the requested resource does not exist (404)
+	ErrNotFound = errors.New("not found")
+	// ErrRateLimited indicates the API rejected the request due to rate limiting (429)
+	ErrRateLimited = errors.New("rate limited")
+	// ErrServerError indicates the API failed to process the request (5xx)
+	ErrServerError = errors.New("server error")
+	// ErrRateLimitedLocally indicates the client-side rate limiter had no token available
+	// within the limit configured by WithRateLimitMode; no request was sent
+	ErrRateLimitedLocally = errors.New("rate limited locally")
+	// ErrTooManyInFlight indicates the limit set with WithMaxInFlight was reached and
+	// WithInFlightFailFast is set; no request was sent
+	ErrTooManyInFlight = errors.New("too many requests in flight")
+	// ErrUnsupportedNetwork indicates the Gas API does not serve the requested chain
+	// The error is an *UnsupportedNetworkError carrying the chain ID
+	ErrUnsupportedNetwork = errors.New("unsupported network")
+	// ErrInvalidOption indicates a ClientOption was given an invalid value (see New)
+	ErrInvalidOption = errors.New("invalid option")
+	// ErrCreditBudgetExceeded indicates the credit limit set with WithDailyCreditLimit was
+	// reached in the current window; no request was sent
+	ErrCreditBudgetExceeded = errors.New("credit budget exceeded")
+	// ErrBudgetExhausted indicates the request budget of the context, enforced with
+	// WithBudgetFromContext, is used up; no request was sent
+	ErrBudgetExhausted = errors.New("request budget exhausted")
+	// ErrRedirectAuthStripped indicates a redirect to another host would have dropped the
+	// Authorization header; the redirect was not followed (see WithRedirectAllowedHosts)
+	ErrRedirectAuthStripped = errors.New("redirect would strip authorization")
+	// ErrPrecisionLoss indicates a response number did not fit in its float64 field and
+	// WithDecoderOptions was set to PrecisionLossError
+	ErrPrecisionLoss = errors.New("precision loss")
+)
+
+// APIError is returned when the API responds with a non-2xx status code
+// Use errors.Is with ErrUnauthorized, ErrNotFound, ErrRateLimited or ErrServerError
+// to classify it without inspecting the status code. Known error messages take precedence
+// over the status and match their own sentinel only: "invalid project id" matches
+// ErrUnauthorized and "daily request count exceeded" matches ErrRateLimited, even with a 403.
+// An exhausted daily quota is not retried.
+type APIError struct {
+	StatusCode int
+	// Body is the raw response body
+	Body string
+	// Message and Code are decoded from a JSON error body such as {"error": "..."},
+	// {"error": {"code": -32005, "message": "..."}} or {"message": "...", "code": "..."};
+	// numeric codes are kept as written. Both are empty when the body has another shape.
+	Message string
+	Code    string
+}
+
+// newAPIError builds the error of a non-2xx response, decoding the message and code of a
+// JSON error body when it has a known shape
+func newAPIError(statusCode int, body []byte) *APIError {
+	apiErr := &APIError{StatusCode: statusCode, Body: string(body)}
+
+	var payload struct {
+		Error   json.RawMessage `json:"error"`
+		Message json.RawMessage `json:"message"`
+		Code    json.RawMessage `json:"code"`
+	}
+	if json.Unmarshal(body, &payload) != nil {
+		return apiErr
+	}
+	var nested struct {
+		Message json.RawMessage `json:"message"`
+		Code    json.RawMessage `json:"code"`
+	}
+	if json.Unmarshal(payload.Error, &nested) == nil && (nested.Message != nil || nested.Code != nil) {
+		// {"error": {"code": ..., "message": ...}}, as in JSON-RPC error responses
+		apiErr.Message = jsonText(nested.Message)
+		apiErr.Code = jsonText(nested.Code)
+		return apiErr
+	}
+	// {"error": "..."} or {"message": "...", "code": ...}; when both are present the
+	// error is often only the status text, so the message wins
+	apiErr.Message = jsonText(payload.Message)
+	if apiErr.Message == "" {
+		apiErr.Message = jsonText(payload.Error)
+	}
+	apiErr.Code = jsonText(payload.Code)
+	return apiErr
+}
+
+// jsonText returns a JSON string's value or a JSON number as written, or "" for other values
+func jsonText(raw json.RawMessage) string {
+	var text string
+	if json.Unmarshal(raw, &text) == nil {
+		return strings.TrimSpace(text)
+	}
+	var number json.Number
+	if json.Unmarshal(raw, &number) == nil {
+		return number.String()
+	}
+	return ""
+}
+
+// Error implements the error interface
+// It shows the decoded message and code when the body has a known shape, otherwise the body
+func (e *APIError) Error() string {
+	switch {
+	case e.Message != "" && e.Code != "":
+		return fmt.Sprintf("API request failed with status %d: %s (code %s)", e.StatusCode, e.Message, e.Code)
+	case e.Message != "":
+		return fmt.Sprintf("API request failed with status %d: %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("API request failed with status %d: %s", e.StatusCode, e.Body)
+}
+
+// messageSentinels maps lowercase fragments of known API error messages to sentinel errors
+var messageSentinels = []struct {
+	fragment string
+	sentinel error
+	// exhausted marks a quota that retrying cannot recover before it resets
+	exhausted bool
+}{
+	{fragment: "invalid project id", sentinel: ErrUnauthorized},
+	{fragment: "project id required", sentinel: ErrUnauthorized},
+	{fragment: "daily request count exceeded", sentinel: ErrRateLimited, exhausted: true},
+	{fragment: "project id request rate exceeded", sentinel: ErrRateLimited},
+}
+
+// knownMessage returns the index in messageSentinels of the error's message, or -1
+func (e *APIError) knownMessage() int {
+	if e.Message == "" {
+		return -1
+	}
+	message := strings.ToLower(e.Message)
+	for i, known := range messageSentinels {
+		if strings.Contains(message, known.fragment) {
+			return i
+		}
+	}
+	return -1
+}
+
+// quotaExhausted reports whether err is an API error for a quota that retrying cannot
+// recover, such as the daily request count
+func quotaExhausted(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	i := apiErr.knownMessage()
+	return i >= 0 && messageSentinels[i].exhausted
+}
+
+// Is reports whether the error matches one of the package sentinel errors
+// A known message matches its own sentinel only, whatever the status: a 403 saying
+// "daily request count exceeded" is ErrRateLimited, not ErrUnauthorized.
+func (e *APIError) Is(target error) bool {
+	if i := e.knownMessage(); i >= 0 {
+		return messageSentinels[i].sentinel == target
+	}
+
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServerError:
+		return e.StatusCode >= 500 && e.StatusCode <= 599
+	default:
+		return false
+	}
+}
+
+// UnsupportedNetworkError is returned when the API responds with 404 for a chain it does not serve
+// It matches ErrUnsupportedNetwork only, not ErrNotFound, so a missing resource and a
+// network that is not served can be told apart; Err holds the API's response
+type UnsupportedNetworkError struct {
+	ChainID int64
+	Err     *APIError
+}
+
+// Error implements the error interface
+func (e *UnsupportedNetworkError) Error() string {
+	return fmt.Sprintf("unsupported network %d: %v", e.ChainID, e.Err)
+}
+
+// Is reports whether target is ErrUnsupportedNetwork
+func (e *UnsupportedNetworkError) Is(target error) bool {
+	return target == ErrUnsupportedNetwork
+}
+
+// unsupportedNetworkHints are lowercase body fragments of 404s for networks the API does not serve
+var unsupportedNetworkHints = []string{
+	"unsupported",
+	"not supported",
+	"invalid network",
+	"unknown network",
+	"network not found",
+	"invalid chain",
+	"unknown chain",
+	"chain not found",
+}
+
+// asUnsupportedNetwork converts a 404 for chainID into an *UnsupportedNetworkError when the
+// body says the network is not served; other errors are returned unchanged
+// The local chain registry is not consulted: the API serves chains it does not list.
+func asUnsupportedNetwork(err error, chainID int64) error {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		return err
+	}
+
+	body := strings.ToLower(apiErr.Body)
+	for _, hint := range unsupportedNetworkHints {
+		if strings.Contains(body, hint) {
+			return &UnsupportedNetworkError{ChainID: chainID, Err: apiErr}
+		}
+	}
+	return err
+}
+
+// transportError wraps a failure to complete the HTTP round trip (DNS, connect, TLS, timeout...)
+// or to read the response body
+type transportError struct {
+	err error
+	// readingBody is set when the connection failed while the response body was read
+	readingBody bool
+}
+
+// Error implements the error interface
+func (e *transportError) Error() string {
+	if e.readingBody {
+		return "failed to read response body: " + e.err.Error()
+	}
+	return "failed to execute request: " + e.err.Error()
+}
+
+// Unwrap returns the underlying error
+func (e *transportError) Unwrap() error {
+	return e.err
+}
+
+// isRetryable reports whether a request that failed with err may succeed if repeated,
+// possibly against another URL or later: rate limiting, server errors and transport
+// failures are retryable; cancellation by the caller, an exhausted daily quota and client
+// errors such as 401 or 404 are not. The retries of WithRetry use the narrower shouldRetry
+func isRetryable(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	var transportErr *transportError
+	if errors.As(err, &transportErr) {
+		return true
+	}
+	if quotaExhausted(err) {
+		return false
+	}
+	return errors.Is(err, ErrRateLimited) || errors.Is(err, ErrServerError)
+}
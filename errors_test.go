@@ -0,0 +1,283 @@
+package infura
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAPIError_Is(t *testing.T) {
+	tests := []struct {
+		status int
+		target error
+		want   bool
+	}{
+		{http.StatusUnauthorized, ErrUnauthorized, true},
+		{http.StatusForbidden, ErrUnauthorized, true},
+		{http.StatusNotFound, ErrNotFound, true},
+		{http.StatusTooManyRequests, ErrRateLimited, true},
+		{http.StatusInternalServerError, ErrServerError, true},
+		{http.StatusServiceUnavailable, ErrServerError, true},
+		{http.StatusBadRequest, ErrServerError, false},
+		{http.StatusNotFound, ErrUnauthorized, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d_%v", tt.status, tt.target), func(t *testing.T) {
+			err := fmt.Errorf("wrapped: %w", &APIError{StatusCode: tt.status})
+			if got := errors.Is(err, tt.target); got != tt.want {
+				t.Errorf("errors.Is(%d, %v) = %v, want %v", tt.status, tt.target, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoJSONRequest_ReturnsAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error": "invalid project id"}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithOptions("test-api-key", "test-api-secret", WithBaseURL(server.URL))
+
+	err := client.doJSONRequest(context.Background(), "GET", "/test", nil, nil)
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected *APIError, got %T: %v", err, err)
+	}
+	if apiErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected status code 401, got %d", apiErr.StatusCode)
+	}
+	if apiErr.Body != `{"error": "invalid project id"}` {
+		t.Errorf("Unexpected body: %s", apiErr.Body)
+	}
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Error("Expected error to match ErrUnauthorized")
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"server error", &APIError{StatusCode: 503}, true},
+		{"rate limited", &APIError{StatusCode: 429}, true},
+		{"daily quota", &APIError{StatusCode: 429, Message: "daily request count exceeded, request rate limited"}, false},
+		{"unauthorized", &APIError{StatusCode: 401}, false},
+		{"not found", &APIError{StatusCode: 404}, false},
+		{"transport", &transportError{err: errors.New("connection refused")}, true},
+		{"canceled", &transportError{err: context.Canceled}, false},
+		{"decode", errors.New("failed to decode response"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRetryable(tt.err); got != tt.want {
+				t.Errorf("isRetryable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnsupportedNetwork(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.Contains(r.URL.Path, "/networks/999999/"):
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"Unsupported chain id"}`))
+		case strings.Contains(r.URL.Path, "/networks/424242/"):
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"Not Found"}`))
+		case strings.Contains(r.URL.Path, "/networks/250/"):
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"Network not supported"}`))
+		case strings.HasSuffix(r.URL.Path, "/baseFeePercentile"):
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"Not Found"}`))
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	tests := []struct {
+		name            string
+		call            func() error
+		wantUnsupported bool
+		wantChainID     int64
+	}{
+		{
+			name:            "chain missing from the registry with unsupported body",
+			call:            func() error { _, err := client.GetSuggestedGasFees(ctx, 999999); return err },
+			wantUnsupported: true,
+			wantChainID:     999999,
+		},
+		{
+			name: "plain 404 for a chain missing from the registry",
+			call: func() error { _, err := client.GetSuggestedGasFees(ctx, 424242); return err },
+		},
+		{
+			name:            "known chain with unsupported body",
+			call:            func() error { _, err := client.GetBusyThreshold(ctx, 250); return err },
+			wantUnsupported: true,
+			wantChainID:     250,
+		},
+		{
+			name: "plain 404 for a known chain",
+			call: func() error { _, err := client.GetBaseFeePercentile(ctx, 1); return err },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			if got := errors.Is(err, ErrUnsupportedNetwork); got != tt.wantUnsupported {
+				t.Fatalf("Expected ErrUnsupportedNetwork to match: %v, got %v (err %v)", tt.wantUnsupported, got, err)
+			}
+			// An unsupported network is kept distinct from a missing resource
+			if got := errors.Is(err, ErrNotFound); got == tt.wantUnsupported {
+				t.Fatalf("Expected ErrNotFound to match: %v, got %v (err %v)", !tt.wantUnsupported, got, err)
+			}
+			if !tt.wantUnsupported {
+				return
+			}
+
+			var unsupported *UnsupportedNetworkError
+			if !errors.As(err, &unsupported) {
+				t.Fatalf("Expected *UnsupportedNetworkError, got %T", err)
+			}
+			if unsupported.ChainID != tt.wantChainID || unsupported.Err.StatusCode != http.StatusNotFound {
+				t.Errorf("Unexpected error details: %+v", unsupported)
+			}
+		})
+	}
+
+	// Supported chains are unaffected
+	if _, err := client.GetSuggestedGasFees(ctx, 1); err != nil {
+		t.Errorf("Expected chain 1 to succeed, got %v", err)
+	}
+}
+
+func TestUnsupportedNetwork_SkipInBatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/networks/424242/") {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"network not supported"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL))
+
+	results, err := client.GetSuggestedGasFeesBatch(context.Background(), []int64{1, 424242, 137})
+	if !errors.Is(err, ErrUnsupportedNetwork) {
+		t.Fatalf("Expected the batch error to match ErrUnsupportedNetwork, got %v", err)
+	}
+	if len(results) != 2 {
+		t.Errorf("Expected the supported chains to succeed, got %v", results)
+	}
+}
+
+func TestNewAPIError_Payloads(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		wantMessage string
+		wantCode    string
+		wantError   string
+	}{
+		{
+			name:        "error string",
+			status:      http.StatusUnauthorized,
+			body:        `{"error": "invalid project id"}`,
+			wantMessage: "invalid project id",
+			wantError:   "API request failed with status 401: invalid project id",
+		},
+		{
+			name:        "JSON-RPC error object",
+			status:      http.StatusTooManyRequests,
+			body:        `{"jsonrpc":"2.0","id":1,"error":{"code":-32005,"message":"daily request count exceeded, request rate limited","data":{"see":"https://infura.io/dashboard"}}}`,
+			wantMessage: "daily request count exceeded, request rate limited",
+			wantCode:    "-32005",
+			wantError:   "API request failed with status 429: daily request count exceeded, request rate limited (code -32005)",
+		},
+		{
+			name:        "status text with message",
+			status:      http.StatusForbidden,
+			body:        `{"statusCode":403,"error":"Forbidden","message":"project ID request rate exceeded","code":"RATE_LIMIT"}`,
+			wantMessage: "project ID request rate exceeded",
+			wantCode:    "RATE_LIMIT",
+			wantError:   "API request failed with status 403: project ID request rate exceeded (code RATE_LIMIT)",
+		},
+		{
+			name:      "unknown JSON shape",
+			status:    http.StatusBadGateway,
+			body:      `{"detail": "upstream unavailable"}`,
+			wantError: `API request failed with status 502: {"detail": "upstream unavailable"}`,
+		},
+		{
+			name:      "plain text",
+			status:    http.StatusServiceUnavailable,
+			body:      "upstream connect error",
+			wantError: "API request failed with status 503: upstream connect error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := newAPIError(tt.status, []byte(tt.body))
+			if err.Message != tt.wantMessage || err.Code != tt.wantCode {
+				t.Errorf("Message, Code = %q, %q, want %q, %q", err.Message, err.Code, tt.wantMessage, tt.wantCode)
+			}
+			if err.Body != tt.body {
+				t.Errorf("Expected the raw body to be kept, got %q", err.Body)
+			}
+			if got := err.Error(); got != tt.wantError {
+				t.Errorf("Error() = %q, want %q", got, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestAPIError_IsKnownMessage(t *testing.T) {
+	tests := []struct {
+		status int
+		body   string
+		target error
+		want   bool
+	}{
+		// Infura reports some authentication failures with 400
+		{http.StatusBadRequest, `{"error": "invalid project id"}`, ErrUnauthorized, true},
+		{http.StatusBadRequest, `{"error": {"code": -32600, "message": "project id required in the url"}}`, ErrUnauthorized, true},
+		{http.StatusForbidden, `{"error": {"code": -32005, "message": "daily request count exceeded, request rate limited"}}`, ErrRateLimited, true},
+		{http.StatusForbidden, `{"error": {"code": -32005, "message": "daily request count exceeded, request rate limited"}}`, ErrUnauthorized, false},
+		// A known message only matches its own sentinel, not the one of its status
+		{http.StatusUnauthorized, `{"error": "project id request rate exceeded"}`, ErrUnauthorized, false},
+		{http.StatusBadRequest, `{"error": "invalid project id"}`, ErrRateLimited, false},
+		// Unknown shapes are only classified by status
+		{http.StatusBadRequest, `invalid project id`, ErrUnauthorized, false},
+	}
+
+	for _, tt := range tests {
+		err := fmt.Errorf("wrapped: %w", newAPIError(tt.status, []byte(tt.body)))
+		if got := errors.Is(err, tt.target); got != tt.want {
+			t.Errorf("Expected errors.Is(%d %s, %v) to be %v, got %v", tt.status, tt.body, tt.target, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+// Package ethfees bridges Infura gas suggestions to go-ethereum dynamic fee transactions.
+//
+// It does not import go-ethereum: DynamicFees carries the same *big.Int values as the
+// GasFeeCap and GasTipCap fields of types.DynamicFeeTx, so the core module stays free of
+// the dependency.
+//
+//	fees, _ := ethfees.FromSuggestion(suggestion, infura.PriorityMedium)
+//	tx := &types.DynamicFeeTx{
+//		GasFeeCap: fees.GasFeeCap,
+//		GasTipCap: fees.GasTipCap,
+//		// ...
+//	}
+package ethfees
+
+import (
+	"fmt"
+	"math/big"
+
+	infura "github.com/ABT-Tech-Limited/infura-go"
+)
+
+// DynamicFees holds the EIP-1559 fee fields of a transaction, in wei
+type DynamicFees struct {
+	// GasFeeCap is the maximum total fee per gas (maxFeePerGas)
+	GasFeeCap *big.Int
+	// GasTipCap is the maximum priority fee per gas (maxPriorityFeePerGas)
+	GasTipCap *big.Int
+}
+
+// FromSuggestion converts the chosen level of a suggestion into exact wei fee fields
+// It fails if a value cannot be represented in wei or the tip exceeds the fee cap,
+// which go-ethereum would reject as well
+func FromSuggestion(fees *infura.SuggestedGasFees, p infura.Priority) (DynamicFees, error) {
+	if fees == nil {
+		return DynamicFees{}, fmt.Errorf("suggested gas fees must not be nil")
+	}
+	level, err := fees.Level(p)
+	if err != nil {
+		return DynamicFees{}, err
+	}
+
+	feeCap, err := infura.ParseGweiToWei(level.SuggestedMaxFeePerGas)
+	if err != nil {
+		return DynamicFees{}, fmt.Errorf("invalid %s maxFeePerGas: %w", p, err)
+	}
+	tipCap, err := infura.ParseGweiToWei(level.SuggestedMaxPriorityFeePerGas)
+	if err != nil {
+		return DynamicFees{}, fmt.Errorf("invalid %s maxPriorityFeePerGas: %w", p, err)
+	}
+	if tipCap.Cmp(feeCap) > 0 {
+		return DynamicFees{}, fmt.Errorf("%s maxPriorityFeePerGas %s exceeds maxFeePerGas %s", p, tipCap, feeCap)
+	}
+
+	return DynamicFees{GasFeeCap: feeCap, GasTipCap: tipCap}, nil
+}
@@ -0,0 +1,71 @@
+package ethfees
+
+import (
+	"testing"
+
+	infura "github.com/ABT-Tech-Limited/infura-go"
+)
+
+var sample = &infura.SuggestedGasFees{
+	Low: infura.GasFeeLevel{
+		SuggestedMaxPriorityFeePerGas: "0.05",
+		SuggestedMaxFeePerGas:         "24.086058416",
+	},
+	Medium: infura.GasFeeLevel{
+		SuggestedMaxPriorityFeePerGas: "0.1",
+		SuggestedMaxFeePerGas:         "32.548628689",
+	},
+	High: infura.GasFeeLevel{
+		SuggestedMaxPriorityFeePerGas: "0.3",
+		SuggestedMaxFeePerGas:         "41.161199904",
+	},
+}
+
+func TestFromSuggestion(t *testing.T) {
+	tests := []struct {
+		priority infura.Priority
+		wantCap  string
+		wantTip  string
+	}{
+		{priority: infura.PriorityLow, wantCap: "24086058416", wantTip: "50000000"},
+		{priority: infura.PriorityMedium, wantCap: "32548628689", wantTip: "100000000"},
+		{priority: infura.PriorityHigh, wantCap: "41161199904", wantTip: "300000000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.priority.String(), func(t *testing.T) {
+			fees, err := FromSuggestion(sample, tt.priority)
+			if err != nil {
+				t.Fatalf("FromSuggestion failed: %v", err)
+			}
+			if fees.GasFeeCap.String() != tt.wantCap {
+				t.Errorf("Expected GasFeeCap %s, got %s", tt.wantCap, fees.GasFeeCap)
+			}
+			if fees.GasTipCap.String() != tt.wantTip {
+				t.Errorf("Expected GasTipCap %s, got %s", tt.wantTip, fees.GasTipCap)
+			}
+		})
+	}
+}
+
+func TestFromSuggestion_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		fees     *infura.SuggestedGasFees
+		priority infura.Priority
+	}{
+		{name: "nil fees", fees: nil, priority: infura.PriorityLow},
+		{name: "unknown priority", fees: sample, priority: infura.Priority(5)},
+		{name: "unparseable fee cap", fees: &infura.SuggestedGasFees{Low: infura.GasFeeLevel{SuggestedMaxFeePerGas: "abc", SuggestedMaxPriorityFeePerGas: "1"}}},
+		{name: "sub-wei precision", fees: &infura.SuggestedGasFees{Low: infura.GasFeeLevel{SuggestedMaxFeePerGas: "1.0000000001", SuggestedMaxPriorityFeePerGas: "1"}}},
+		{name: "tip above fee cap", fees: &infura.SuggestedGasFees{Low: infura.GasFeeLevel{SuggestedMaxFeePerGas: "1", SuggestedMaxPriorityFeePerGas: "2"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := FromSuggestion(tt.fees, tt.priority); err == nil {
+				t.Fatal("Expected error but got nil")
+			}
+		})
+	}
+}
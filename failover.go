@@ -0,0 +1,69 @@
+package infura
+
+import (
+	"errors"
+	"slices"
+)
+
+// failoverConfig holds the failover base URLs and which errors skip the retries
+type failoverConfig struct {
+	urls []string
+	// policySet is true once WithFailoverOn is applied; until then only transport errors
+	// fail over without retrying
+	policySet bool
+	// statuses are the HTTP statuses that fail over without retrying the same URL
+	statuses []int
+	// transportErrors makes connection failures fail over without retrying the same URL
+	transportErrors bool
+}
+
+// WithFailoverURLs sets base URLs tried in order when a request to the base URL fails
+// A request moves on to the next URL after a retryable failure (rate limiting, 5xx or a
+// transport error); authentication failures and other 4xx responses are returned as is.
+// By default, connection failures fail over at once while 429 and 5xx responses are first
+// retried on the same URL as configured with WithRetry; see WithFailoverOn. Every URL gets
+// the full number of attempts, and there is no delay before failing over.
+func WithFailoverURLs(urls ...string) ClientOption {
+	return func(c *Client) {
+		for _, u := range urls {
+			if err := validateBaseURL(u); err != nil {
+				c.rejectOption("WithFailoverURLs", "%v", err)
+				return
+			}
+		}
+		c.failover.urls = slices.Clone(urls)
+	}
+}
+
+// WithFailoverOn sets which failures move to the next failover URL without retrying the
+// same URL first: responses with one of statuses, and connection failures if transportErrors
+// is set. Other retryable failures are retried on the same URL before failing over.
+//
+// For example WithFailoverOn([]int{502}, true) fails over at once on a 502 or a refused
+// connection but retries a 503 on the same URL, and WithFailoverOn(nil, false) always
+// retries before failing over.
+func WithFailoverOn(statuses []int, transportErrors bool) ClientOption {
+	return func(c *Client) {
+		c.failover.policySet = true
+		c.failover.statuses = slices.Clone(statuses)
+		c.failover.transportErrors = transportErrors
+	}
+}
+
+// immediate reports whether err fails over without retrying the same URL
+func (f failoverConfig) immediate(err error) bool {
+	var transportErr *transportError
+	if errors.As(err, &transportErr) {
+		return !f.policySet || f.transportErrors
+	}
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && slices.Contains(f.statuses, apiErr.StatusCode)
+}
+
+// baseURLFor returns the base URL a request with the given settings is sent to
+func (c *Client) baseURLFor(settings requestSettings) string {
+	if settings.baseURL != "" {
+		return settings.baseURL
+	}
+	return c.baseURL
+}
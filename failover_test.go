@@ -0,0 +1,201 @@
+package infura
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// hitLog records which server answered each request, in order
+type hitLog struct {
+	mu   sync.Mutex
+	hits []string
+}
+
+func (l *hitLog) add(name string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.hits = append(l.hits, name)
+}
+
+func (l *hitLog) get() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.hits...)
+}
+
+// newHitServer returns a server that logs its name and answers with status
+func newHitServer(log *hitLog, name string, status int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		log.add(name)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write([]byte(`{}`))
+	}))
+}
+
+// closedServerURL returns the URL of a server that refuses connections
+func closedServerURL() string {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+	return server.URL
+}
+
+func TestFailover_RetriesServerErrorsBeforeFailingOver(t *testing.T) {
+	var hits hitLog
+	primary := newHitServer(&hits, "primary", http.StatusServiceUnavailable)
+	defer primary.Close()
+	secondary := newHitServer(&hits, "secondary", http.StatusOK)
+	defer secondary.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(primary.URL),
+		WithFailoverURLs(secondary.URL),
+		WithRetry(3, time.Millisecond),
+	)
+	if _, err := client.GetBusyThreshold(context.Background(), 1); err != nil {
+		t.Fatalf("GetBusyThreshold failed: %v", err)
+	}
+
+	want := []string{"primary", "primary", "primary", "secondary"}
+	if got := hits.get(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestFailover_ConnectionErrorsFailOverImmediately(t *testing.T) {
+	var hits hitLog
+	secondary := newHitServer(&hits, "secondary", http.StatusOK)
+	defer secondary.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(closedServerURL()),
+		WithFailoverURLs(secondary.URL),
+		WithRetry(3, time.Millisecond),
+		WithCallHistory(10),
+	)
+	if _, err := client.GetBusyThreshold(context.Background(), 1); err != nil {
+		t.Fatalf("GetBusyThreshold failed: %v", err)
+	}
+
+	history := client.CallHistory()
+	if len(history) != 2 || history[0].StatusCode != 0 || history[1].StatusCode != http.StatusOK {
+		t.Fatalf("Expected one failed attempt followed by the failover, got %+v", history)
+	}
+	if got := hits.get(); !reflect.DeepEqual(got, []string{"secondary"}) {
+		t.Errorf("Expected a single request to the secondary, got %v", got)
+	}
+}
+
+func TestFailoverOn(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  ClientOption
+		primary int
+		want    []string
+	}{
+		{"listed status fails over at once", WithFailoverOn([]int{http.StatusBadGateway}, true), http.StatusBadGateway, []string{"primary", "secondary"}},
+		{"unlisted status is retried first", WithFailoverOn([]int{http.StatusBadGateway}, true), http.StatusServiceUnavailable, []string{"primary", "primary", "secondary"}},
+		{"rate limiting is retried first", WithFailoverOn(nil, true), http.StatusTooManyRequests, []string{"primary", "primary", "secondary"}},
+		{"client errors do not fail over", WithFailoverOn([]int{http.StatusUnauthorized}, true), http.StatusUnauthorized, []string{"primary"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hits hitLog
+			primary := newHitServer(&hits, "primary", tt.primary)
+			defer primary.Close()
+			secondary := newHitServer(&hits, "secondary", http.StatusOK)
+			defer secondary.Close()
+
+			client := NewClientWithAPIKeyAndOptions("test-api-key",
+				WithBaseURL(primary.URL),
+				WithFailoverURLs(secondary.URL),
+				WithRetry(2, time.Millisecond),
+				tt.policy,
+			)
+			client.GetBusyThreshold(context.Background(), 1)
+
+			if got := hits.get(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestFailoverOn_RetriesConnectionErrors(t *testing.T) {
+	var hits hitLog
+	secondary := newHitServer(&hits, "secondary", http.StatusOK)
+	defer secondary.Close()
+	primary := newDroppingServer(false)
+	defer primary.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(primary.URL),
+		WithFailoverURLs(secondary.URL),
+		WithRetry(3, time.Millisecond),
+		WithFailoverOn(nil, false),
+		WithCallHistory(10),
+	)
+	if _, err := client.GetBusyThreshold(context.Background(), 1); err != nil {
+		t.Fatalf("GetBusyThreshold failed: %v", err)
+	}
+	if got := len(client.CallHistory()); got != 4 {
+		t.Errorf("Expected 3 attempts on the primary and 1 on the secondary, got %d", got)
+	}
+}
+
+func TestFailoverOn_RefusedConnectionFailsOverWithoutRetry(t *testing.T) {
+	var hits hitLog
+	secondary := newHitServer(&hits, "secondary", http.StatusOK)
+	defer secondary.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(closedServerURL()),
+		WithFailoverURLs(secondary.URL),
+		WithRetry(3, time.Millisecond),
+		WithFailoverOn(nil, false),
+		WithCallHistory(10),
+	)
+	if _, err := client.GetBusyThreshold(context.Background(), 1); err != nil {
+		t.Fatalf("GetBusyThreshold failed: %v", err)
+	}
+	if got := len(client.CallHistory()); got != 2 {
+		t.Errorf("Expected 1 attempt on the primary and 1 on the secondary, got %d", got)
+	}
+}
+
+func TestFailover_AllURLsFail(t *testing.T) {
+	var hits hitLog
+	primary := newHitServer(&hits, "primary", http.StatusServiceUnavailable)
+	defer primary.Close()
+	secondary := newHitServer(&hits, "secondary", http.StatusBadGateway)
+	defer secondary.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(primary.URL),
+		WithFailoverURLs(secondary.URL),
+		WithRetry(2, time.Millisecond),
+	)
+	_, err := client.GetBusyThreshold(context.Background(), 1)
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadGateway {
+		t.Errorf("Expected the last URL's 502, got %v", err)
+	}
+	want := []string{"primary", "primary", "secondary", "secondary"}
+	if got := hits.get(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestWithFailoverURLs_Invalid(t *testing.T) {
+	if _, err := New("test-api-key", "", WithFailoverURLs("https://ok.example.com", "ftp://bad")); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("Expected ErrInvalidOption, got %v", err)
+	}
+}
@@ -0,0 +1,48 @@
+package infura
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+)
+
+// WithFallbackFees sets static per-chain gas fees returned by GetSuggestedGasFees when the
+// API is unavailable. The fallback is used only after a retryable failure (rate limiting,
+// 5xx or a transport error) persists through all retry attempts; authentication failures,
+// unknown chains and cancelled contexts still return an error.
+// Use ContextWithCallMeta to detect that a result came from the fallback (CallMeta.Fallback)
+func WithFallbackFees(fees map[int64]SuggestedGasFees) ClientOption {
+	return func(c *Client) {
+		c.fallbackFees = make(map[int64]SuggestedGasFees, len(fees))
+		for chainID, f := range fees {
+			c.fallbackFees[chainID] = cloneSuggestedGasFees(f)
+		}
+	}
+}
+
+// fallbackSuggestedGasFees returns the configured fallback for chainID when err allows it
+func (c *Client) fallbackSuggestedGasFees(ctx context.Context, chainID int64, err error) (*SuggestedGasFees, bool) {
+	fallback, ok := c.fallbackFees[chainID]
+	if !ok || !isRetryable(err) || ctx.Err() != nil {
+		return nil, false
+	}
+
+	if logger := c.debugLogger(ctx); logger != nil {
+		logger.Printf("[DEBUG] Using fallback gas fees for chain %d after error: %v\n", chainID, err)
+	}
+	c.logStructured(ctx, "infura fallback fees", slog.Int64("chain_id", chainID), slog.String("error", c.mask(err.Error())))
+	recordCallMeta(ctx, func(meta *CallMeta) {
+		meta.Fallback = true
+	})
+
+	result := cloneSuggestedGasFees(fallback)
+	return &result, true
+}
+
+// cloneSuggestedGasFees returns a deep copy of f so callers cannot mutate shared state
+func cloneSuggestedGasFees(f SuggestedGasFees) SuggestedGasFees {
+	f.LatestPriorityFeeRange = slices.Clone(f.LatestPriorityFeeRange)
+	f.HistoricalPriorityFeeRange = slices.Clone(f.HistoricalPriorityFeeRange)
+	f.HistoricalBaseFeeRange = slices.Clone(f.HistoricalBaseFeeRange)
+	return f
+}
@@ -0,0 +1,106 @@
+package infura
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var testFallbackFees = map[int64]SuggestedGasFees{
+	1: {
+		Low:              GasFeeLevel{SuggestedMaxFeePerGas: "50"},
+		Medium:           GasFeeLevel{SuggestedMaxFeePerGas: "75"},
+		High:             GasFeeLevel{SuggestedMaxFeePerGas: "100"},
+		EstimatedBaseFee: "40",
+	},
+}
+
+func TestWithFallbackFees_OutageTriggersFallback(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithRetry(2, time.Millisecond),
+		WithFallbackFees(testFallbackFees))
+
+	var meta CallMeta
+	result, err := client.GetSuggestedGasFees(ContextWithCallMeta(context.Background(), &meta), 1)
+	if err != nil {
+		t.Fatalf("Expected fallback result, got error: %v", err)
+	}
+	if !meta.Fallback {
+		t.Error("Expected CallMeta.Fallback to be true")
+	}
+	if result.Medium.SuggestedMaxFeePerGas != "75" {
+		t.Errorf("Expected fallback medium fee 75, got %s", result.Medium.SuggestedMaxFeePerGas)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("Expected retries to be exhausted before falling back (2 attempts), got %d", got)
+	}
+}
+
+func TestWithFallbackFees_TransportErrorTriggersFallback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(url),
+		WithFallbackFees(testFallbackFees))
+
+	result, err := client.GetSuggestedGasFees(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("Expected fallback result, got error: %v", err)
+	}
+	if result.EstimatedBaseFee != "40" {
+		t.Errorf("Expected fallback base fee 40, got %s", result.EstimatedBaseFee)
+	}
+}
+
+func TestWithFallbackFees_UnauthorizedDoesNotFallback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error": "invalid project id"}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithFallbackFees(testFallbackFees))
+
+	var meta CallMeta
+	result, err := client.GetSuggestedGasFees(ContextWithCallMeta(context.Background(), &meta), 1)
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("Expected ErrUnauthorized, got %v", err)
+	}
+	if result != nil {
+		t.Error("Expected nil result on authentication failure")
+	}
+	if meta.Fallback {
+		t.Error("Expected CallMeta.Fallback to be false")
+	}
+}
+
+func TestWithFallbackFees_UnconfiguredChain(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithFallbackFees(testFallbackFees))
+
+	if _, err := client.GetSuggestedGasFees(context.Background(), 137); !errors.Is(err, ErrServerError) {
+		t.Fatalf("Expected ErrServerError for chain without fallback, got %v", err)
+	}
+}
@@ -0,0 +1,153 @@
+package infura
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// ErrFeeAboveCap indicates a suggested fee exceeds the cap configured with WithMaxFeeCap
+var ErrFeeAboveCap = errors.New("suggested fee above cap")
+
+// FeeCap holds the per-chain maximums enforced by WithMaxFeeCap, in wei
+// A nil field leaves that fee uncapped
+type FeeCap struct {
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+}
+
+// FeeCapMode selects what happens when a suggestion exceeds its cap
+type FeeCapMode int
+
+const (
+	// FeeCapReject makes GetSuggestedGasFees return a *FeeCapError
+	FeeCapReject FeeCapMode = iota
+	// FeeCapClamp lowers offending values to the cap and flags the level as clamped
+	FeeCapClamp
+)
+
+// String returns the lowercase name of the mode
+func (m FeeCapMode) String() string {
+	switch m {
+	case FeeCapReject:
+		return "reject"
+	case FeeCapClamp:
+		return "clamp"
+	default:
+		return fmt.Sprintf("FeeCapMode(%d)", int(m))
+	}
+}
+
+// FeeCapError is returned when a suggested fee exceeds its cap in FeeCapReject mode
+// It matches ErrFeeAboveCap with errors.Is
+type FeeCapError struct {
+	ChainID int64
+	Level   Priority
+	// Field is the offending JSON field, e.g. "suggestedMaxFeePerGas"
+	Field string
+	// Suggested is the suggested value in wei
+	Suggested *big.Int
+	// Cap is the configured maximum in wei
+	Cap *big.Int
+}
+
+// Error implements the error interface
+func (e *FeeCapError) Error() string {
+	return fmt.Sprintf("%s %s for chain %d is %s gwei, above cap of %s gwei",
+		e.Level, e.Field, e.ChainID, formatWeiAsGwei(e.Suggested), formatWeiAsGwei(e.Cap))
+}
+
+// Is reports whether target is ErrFeeAboveCap
+func (e *FeeCapError) Is(target error) bool {
+	return target == ErrFeeAboveCap
+}
+
+// feeCapConfig holds the caps and mode configured with WithMaxFeeCap
+type feeCapConfig struct {
+	caps map[int64]FeeCap
+	mode FeeCapMode
+}
+
+// WithMaxFeeCap enforces per-chain maximums on every result of GetSuggestedGasFees,
+// including fallback and cached data. Comparisons are exact in wei.
+// In FeeCapReject mode an offending suggestion returns a *FeeCapError; in FeeCapClamp mode
+// offending values are replaced by the cap and GasFeeLevel.Clamped and CallMeta.Clamped are set.
+// Suggestions that cannot be parsed are rejected in both modes.
+func WithMaxFeeCap(caps map[int64]FeeCap, mode FeeCapMode) ClientOption {
+	return func(c *Client) {
+		copied := make(map[int64]FeeCap, len(caps))
+		for chainID, limits := range caps {
+			copied[chainID] = FeeCap{
+				MaxFeePerGas:         copyInt(limits.MaxFeePerGas),
+				MaxPriorityFeePerGas: copyInt(limits.MaxPriorityFeePerGas),
+			}
+		}
+		c.feeCap = &feeCapConfig{caps: copied, mode: mode}
+	}
+}
+
+// applyFeeCap enforces the configured cap for chainID on fees, clamping in place if enabled
+func (c *Client) applyFeeCap(ctx context.Context, chainID int64, fees *SuggestedGasFees) error {
+	if c.feeCap == nil {
+		return nil
+	}
+	limits, ok := c.feeCap.caps[chainID]
+	if !ok {
+		return nil
+	}
+
+	clamped := false
+	levels := []*GasFeeLevel{&fees.Low, &fees.Medium, &fees.High}
+	for i, level := range levels {
+		p := Priority(i)
+
+		maxFee, err := ParseGweiToWei(level.SuggestedMaxFeePerGas)
+		if err != nil {
+			return fmt.Errorf("failed to check %s fee cap: %w", p, err)
+		}
+		priorityFee, err := ParseGweiToWei(level.SuggestedMaxPriorityFeePerGas)
+		if err != nil {
+			return fmt.Errorf("failed to check %s fee cap: %w", p, err)
+		}
+
+		if limits.MaxFeePerGas != nil && maxFee.Cmp(limits.MaxFeePerGas) > 0 {
+			if c.feeCap.mode != FeeCapClamp {
+				return &FeeCapError{ChainID: chainID, Level: p, Field: "suggestedMaxFeePerGas", Suggested: maxFee, Cap: copyInt(limits.MaxFeePerGas)}
+			}
+			maxFee = copyInt(limits.MaxFeePerGas)
+			level.SuggestedMaxFeePerGas = formatWeiAsGwei(maxFee)
+			level.Clamped = true
+		}
+
+		priorityCap := limits.MaxPriorityFeePerGas
+		if level.Clamped && (priorityCap == nil || maxFee.Cmp(priorityCap) < 0) {
+			// A clamped maxFeePerGas also bounds the priority fee
+			priorityCap = maxFee
+		}
+		if priorityCap != nil && priorityFee.Cmp(priorityCap) > 0 {
+			if c.feeCap.mode != FeeCapClamp {
+				return &FeeCapError{ChainID: chainID, Level: p, Field: "suggestedMaxPriorityFeePerGas", Suggested: priorityFee, Cap: copyInt(priorityCap)}
+			}
+			level.SuggestedMaxPriorityFeePerGas = formatWeiAsGwei(priorityCap)
+			level.Clamped = true
+		}
+
+		clamped = clamped || level.Clamped
+	}
+
+	if clamped {
+		recordCallMeta(ctx, func(meta *CallMeta) {
+			meta.Clamped = true
+		})
+	}
+	return nil
+}
+
+// copyInt returns an independent copy of i, or nil if i is nil
+func copyInt(i *big.Int) *big.Int {
+	if i == nil {
+		return nil
+	}
+	return new(big.Int).Set(i)
+}
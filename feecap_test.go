@@ -0,0 +1,155 @@
+package infura
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+const feeCapBody = `{
+	"low":    {"suggestedMaxPriorityFeePerGas": "1",   "suggestedMaxFeePerGas": "50"},
+	"medium": {"suggestedMaxPriorityFeePerGas": "2",   "suggestedMaxFeePerGas": "100.000000001"},
+	"high":   {"suggestedMaxPriorityFeePerGas": "150", "suggestedMaxFeePerGas": "200"}
+}`
+
+func mustWei(t *testing.T, gwei string) *big.Int {
+	t.Helper()
+	wei, err := ParseGweiToWei(gwei)
+	if err != nil {
+		t.Fatalf("ParseGweiToWei(%q) failed: %v", gwei, err)
+	}
+	return wei
+}
+
+func TestWithMaxFeeCap_Reject(t *testing.T) {
+	server := newFeesServer(t, feeCapBody)
+	defer server.Close()
+
+	tests := []struct {
+		name      string
+		cap       FeeCap
+		wantLevel Priority
+		wantField string
+	}{
+		{
+			name:      "max fee one wei above cap",
+			cap:       FeeCap{MaxFeePerGas: mustWei(t, "100")},
+			wantLevel: PriorityMedium,
+			wantField: "suggestedMaxFeePerGas",
+		},
+		{
+			name:      "priority fee above cap",
+			cap:       FeeCap{MaxFeePerGas: mustWei(t, "500"), MaxPriorityFeePerGas: mustWei(t, "100")},
+			wantLevel: PriorityHigh,
+			wantField: "suggestedMaxPriorityFeePerGas",
+		},
+		{
+			name:      "low level rejected first",
+			cap:       FeeCap{MaxFeePerGas: mustWei(t, "10")},
+			wantLevel: PriorityLow,
+			wantField: "suggestedMaxFeePerGas",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClientWithAPIKeyAndOptions("test-api-key",
+				WithBaseURL(server.URL),
+				WithMaxFeeCap(map[int64]FeeCap{1: tt.cap}, FeeCapReject))
+
+			result, err := client.GetSuggestedGasFees(context.Background(), 1)
+			if !errors.Is(err, ErrFeeAboveCap) {
+				t.Fatalf("Expected ErrFeeAboveCap, got %v", err)
+			}
+			if result != nil {
+				t.Error("Expected nil result when rejecting")
+			}
+
+			var capErr *FeeCapError
+			if !errors.As(err, &capErr) {
+				t.Fatalf("Expected *FeeCapError, got %T", err)
+			}
+			if capErr.Level != tt.wantLevel || capErr.Field != tt.wantField {
+				t.Errorf("Expected %s %s, got %s %s", tt.wantLevel, tt.wantField, capErr.Level, capErr.Field)
+			}
+			if capErr.Suggested.Cmp(capErr.Cap) <= 0 {
+				t.Errorf("Expected suggested %s above cap %s", capErr.Suggested, capErr.Cap)
+			}
+		})
+	}
+}
+
+func TestWithMaxFeeCap_WithinCap(t *testing.T) {
+	server := newFeesServer(t, feeCapBody)
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithMaxFeeCap(map[int64]FeeCap{
+			1:   {MaxFeePerGas: mustWei(t, "200"), MaxPriorityFeePerGas: mustWei(t, "150")},
+			137: {MaxFeePerGas: mustWei(t, "1")},
+		}, FeeCapReject))
+
+	result, err := client.GetSuggestedGasFees(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("Expected values equal to the cap to pass, got %v", err)
+	}
+	if result.High.Clamped {
+		t.Error("Expected no clamping")
+	}
+}
+
+func TestWithMaxFeeCap_Clamp(t *testing.T) {
+	server := newFeesServer(t, feeCapBody)
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithMaxFeeCap(map[int64]FeeCap{
+			1: {MaxFeePerGas: mustWei(t, "100"), MaxPriorityFeePerGas: mustWei(t, "120")},
+		}, FeeCapClamp))
+
+	var meta CallMeta
+	result, err := client.GetSuggestedGasFees(ContextWithCallMeta(context.Background(), &meta), 1)
+	if err != nil {
+		t.Fatalf("GetSuggestedGasFees failed: %v", err)
+	}
+	if !meta.Clamped {
+		t.Error("Expected CallMeta.Clamped to be set")
+	}
+
+	tests := []struct {
+		level       GasFeeLevel
+		maxFee      string
+		priorityFee string
+		clamped     bool
+	}{
+		{level: result.Low, maxFee: "50", priorityFee: "1", clamped: false},
+		{level: result.Medium, maxFee: "100", priorityFee: "2", clamped: true},
+		// The priority fee is bounded by the clamped maxFeePerGas, not just its own cap
+		{level: result.High, maxFee: "100", priorityFee: "100", clamped: true},
+	}
+	for i, tt := range tests {
+		if tt.level.SuggestedMaxFeePerGas != tt.maxFee || tt.level.SuggestedMaxPriorityFeePerGas != tt.priorityFee {
+			t.Errorf("level %d: expected %s/%s, got %s/%s", i, tt.maxFee, tt.priorityFee,
+				tt.level.SuggestedMaxFeePerGas, tt.level.SuggestedMaxPriorityFeePerGas)
+		}
+		if tt.level.Clamped != tt.clamped {
+			t.Errorf("level %d: expected clamped=%v", i, tt.clamped)
+		}
+	}
+}
+
+func TestWithMaxFeeCap_UnparseableRejected(t *testing.T) {
+	server := newFeesServer(t, `{"low": {"suggestedMaxFeePerGas": "abc", "suggestedMaxPriorityFeePerGas": "1"}}`)
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithMaxFeeCap(map[int64]FeeCap{1: {MaxFeePerGas: mustWei(t, "100")}}, FeeCapClamp))
+
+	if _, err := client.GetSuggestedGasFees(context.Background(), 1); err == nil {
+		t.Fatal("Expected error for unparseable suggestion")
+	}
+}
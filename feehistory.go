@@ -0,0 +1,208 @@
+package infura
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+)
+
+// ErrNoRewards is returned by FeeHistory.SuggestPriorityFee when no block in the window
+// has reward data
+var ErrNoRewards = errors.New("fee history has no rewards")
+
+// FeeHistory is the result of eth_feeHistory with every quantity decoded to wei
+type FeeHistory struct {
+	OldestBlock *big.Int
+	// BaseFeePerGas has one entry per block plus the base fee of the next block
+	BaseFeePerGas []*big.Int
+	GasUsedRatio  []float64
+	// Reward holds, per block, the priority fees paid at each of RewardPercentiles
+	// Nodes may return an empty row for blocks without transactions
+	Reward [][]*big.Int
+	// RewardPercentiles are the percentiles the history was requested with
+	// The node does not echo them; GetFeeHistory fills them in
+	RewardPercentiles []float64
+}
+
+// rpcFeeHistory is the raw eth_feeHistory result with hex quantities
+// RewardPercentiles is not part of the RPC result; it is written by MarshalJSON so a
+// persisted FeeHistory keeps its percentiles
+type rpcFeeHistory struct {
+	OldestBlock       string     `json:"oldestBlock"`
+	BaseFeePerGas     []string   `json:"baseFeePerGas"`
+	GasUsedRatio      []float64  `json:"gasUsedRatio"`
+	Reward            [][]string `json:"reward,omitzero"`
+	RewardPercentiles []float64  `json:"rewardPercentiles,omitzero"`
+}
+
+// UnmarshalJSON decodes an eth_feeHistory result, converting hex quantities to wei
+func (h *FeeHistory) UnmarshalJSON(data []byte) error {
+	var raw rpcFeeHistory
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	oldest, err := parseHexQuantity(raw.OldestBlock)
+	if err != nil {
+		return fmt.Errorf("invalid oldestBlock: %w", err)
+	}
+	baseFees, err := parseHexQuantities(raw.BaseFeePerGas)
+	if err != nil {
+		return fmt.Errorf("invalid baseFeePerGas: %w", err)
+	}
+	var rewards [][]*big.Int
+	if raw.Reward != nil {
+		rewards = make([][]*big.Int, len(raw.Reward))
+		for i, row := range raw.Reward {
+			if rewards[i], err = parseHexQuantities(row); err != nil {
+				return fmt.Errorf("invalid reward of block %d: %w", i, err)
+			}
+		}
+	}
+
+	percentiles := h.RewardPercentiles
+	if raw.RewardPercentiles != nil {
+		percentiles = raw.RewardPercentiles
+	}
+	*h = FeeHistory{
+		OldestBlock:       oldest,
+		BaseFeePerGas:     baseFees,
+		GasUsedRatio:      raw.GasUsedRatio,
+		Reward:            rewards,
+		RewardPercentiles: percentiles,
+	}
+	return nil
+}
+
+// MarshalJSON encodes the history in the eth_feeHistory result format with hex
+// quantities, so it can be decoded again with UnmarshalJSON
+func (h FeeHistory) MarshalJSON() ([]byte, error) {
+	raw := rpcFeeHistory{
+		OldestBlock:       formatHexQuantity(h.OldestBlock),
+		BaseFeePerGas:     formatHexQuantities(h.BaseFeePerGas),
+		GasUsedRatio:      h.GasUsedRatio,
+		RewardPercentiles: h.RewardPercentiles,
+	}
+	if h.Reward != nil {
+		raw.Reward = make([][]string, len(h.Reward))
+		for i, row := range h.Reward {
+			raw.Reward[i] = formatHexQuantities(row)
+		}
+	}
+	return json.Marshal(raw)
+}
+
+// formatHexQuantity encodes a quantity as a hex string; nil is encoded as zero
+func formatHexQuantity(v *big.Int) string {
+	if v == nil {
+		return "0x0"
+	}
+	return "0x" + v.Text(16)
+}
+
+// formatHexQuantities encodes a list of quantities as hex strings
+func formatHexQuantities(values []*big.Int) []string {
+	if values == nil {
+		return nil
+	}
+	formatted := make([]string, len(values))
+	for i, v := range values {
+		formatted[i] = formatHexQuantity(v)
+	}
+	return formatted
+}
+
+// parseHexQuantities decodes a list of hex quantities
+func parseHexQuantities(values []string) ([]*big.Int, error) {
+	parsed := make([]*big.Int, len(values))
+	for i, s := range values {
+		v, err := parseHexQuantity(s)
+		if err != nil {
+			return nil, err
+		}
+		parsed[i] = v
+	}
+	return parsed, nil
+}
+
+// GetFeeHistory retrieves the fee history of the last blockCount blocks up to newestBlock
+// via eth_feeHistory, with the priority fees paid at each of rewardPercentiles (0–100,
+// ascending). newestBlock is "latest", "pending" or a decimal or hex block number.
+// Requires an RPC backend configured with WithRPC
+func (c *Client) GetFeeHistory(ctx context.Context, chainID int64, blockCount int, newestBlock string, rewardPercentiles []float64, opts ...CallOption) (*FeeHistory, error) {
+	if blockCount < 1 {
+		return nil, fmt.Errorf("block count must be positive, got %d", blockCount)
+	}
+	if err := validateBlockTag(newestBlock); err != nil {
+		return nil, err
+	}
+	for i, p := range rewardPercentiles {
+		if p < 0 || p > 100 || (i > 0 && p <= rewardPercentiles[i-1]) {
+			return nil, fmt.Errorf("reward percentiles must be ascending values between 0 and 100, got %v", rewardPercentiles)
+		}
+	}
+	if c.rpc == nil {
+		return nil, fmt.Errorf("no RPC backend configured (use WithRPC)")
+	}
+
+	ctx, cancel := withCallOptions(ctx, opts)
+	defer cancel()
+
+	if isDigits(newestBlock) {
+		n, _ := new(big.Int).SetString(newestBlock, 10)
+		newestBlock = "0x" + n.Text(16)
+	}
+	percentiles := append([]float64{}, rewardPercentiles...)
+	params := []interface{}{"0x" + strconv.FormatInt(int64(blockCount), 16), newestBlock, percentiles}
+
+	result := FeeHistory{RewardPercentiles: percentiles}
+	if err := c.callRPC(ctx, chainID, "eth_feeHistory", params, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SuggestPriorityFee recommends a maxPriorityFeePerGas in wei: the median, across the
+// window, of the rewards paid at the given percentile, which must be one of
+// RewardPercentiles. For example SuggestPriorityFee(50) is the median of the per-block
+// median tips. Blocks with an empty reward row are skipped; if none has rewards,
+// ErrNoRewards is returned.
+func (h *FeeHistory) SuggestPriorityFee(percentile float64) (*big.Int, error) {
+	column := -1
+	for i, p := range h.RewardPercentiles {
+		if p == percentile {
+			column = i
+			break
+		}
+	}
+	if column < 0 {
+		return nil, fmt.Errorf("percentile %v was not requested (have %v)", percentile, h.RewardPercentiles)
+	}
+
+	var tips []*big.Int
+	for i, row := range h.Reward {
+		if len(row) == 0 {
+			continue
+		}
+		if len(row) != len(h.RewardPercentiles) {
+			return nil, fmt.Errorf("reward of block %d has %d entries, expected %d", i, len(row), len(h.RewardPercentiles))
+		}
+		tips = append(tips, row[column])
+	}
+	if len(tips) == 0 {
+		return nil, ErrNoRewards
+	}
+
+	sort.Slice(tips, func(i, j int) bool { return tips[i].Cmp(tips[j]) < 0 })
+	mid := len(tips) / 2
+	if len(tips)%2 == 1 {
+		return new(big.Int).Set(tips[mid]), nil
+	}
+	// Even count: mean of the two middle values, rounded down
+	sum := new(big.Int).Add(tips[mid-1], tips[mid])
+	return sum.Rsh(sum, 1), nil
+}
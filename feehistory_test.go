@@ -0,0 +1,142 @@
+package infura
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// feeHistoryPayload is a mainnet eth_feeHistory result for 5 blocks at the 10th, 50th and
+// 90th percentiles; the fourth block was empty
+const feeHistoryPayload = `{
+	"oldestBlock": "0x1312d00",
+	"baseFeePerGas": ["0x6fc23ac00", "0x71f2f7e92", "0x6e8d8b0a1", "0x6c46ea5a4", "0x6a81cbdf3", "0x6b1e05b75"],
+	"gasUsedRatio": [0.5623, 0.3011, 0.4177, 0, 0.5862],
+	"reward": [
+		["0x5f5e100", "0x3b9aca00", "0x77359400"],
+		["0x2faf080", "0x59682f00", "0xb2d05e00"],
+		["0x5f5e100", "0x3b9aca00", "0x9502f900"],
+		[],
+		["0x1dcd6500", "0x77359400", "0xee6b2800"]
+	]
+}`
+
+func TestGetFeeHistory(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req rpcRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		wantParams := []interface{}{"0x5", "0x1312d04", []interface{}{10.0, 50.0, 90.0}}
+		if req.Method != "eth_feeHistory" || !reflect.DeepEqual(req.Params, wantParams) {
+			t.Errorf("unexpected request: %+v", req)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":` + feeHistoryPayload + `}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithRPC(NewHTTPRPC(map[int64]string{1: server.URL}, nil)))
+
+	history, err := client.GetFeeHistory(context.Background(), 1, 5, "20000004", []float64{10, 50, 90})
+	if err != nil {
+		t.Fatalf("GetFeeHistory failed: %v", err)
+	}
+	if history.OldestBlock.Int64() != 20000000 {
+		t.Errorf("Expected oldest block 20000000, got %s", history.OldestBlock)
+	}
+	if len(history.BaseFeePerGas) != 6 || history.BaseFeePerGas[0].String() != "30000000000" {
+		t.Errorf("Unexpected base fees: %v", history.BaseFeePerGas)
+	}
+	if len(history.Reward) != 5 || len(history.Reward[3]) != 0 {
+		t.Errorf("Unexpected rewards: %v", history.Reward)
+	}
+	if !reflect.DeepEqual(history.RewardPercentiles, []float64{10, 50, 90}) {
+		t.Errorf("Expected the requested percentiles, got %v", history.RewardPercentiles)
+	}
+
+	tests := []struct {
+		percentile float64
+		want       string
+	}{
+		// 0.1, 0.05, 0.1, 0.5 gwei: median of an even count is the mean of the middle two
+		{10, "100000000"},
+		// 1, 1.5, 1, 2 gwei
+		{50, "1250000000"},
+		// 2, 3, 2.5, 4 gwei
+		{90, "2750000000"},
+	}
+	for _, tt := range tests {
+		tip, err := history.SuggestPriorityFee(tt.percentile)
+		if err != nil {
+			t.Fatalf("SuggestPriorityFee(%v) failed: %v", tt.percentile, err)
+		}
+		if tip.String() != tt.want {
+			t.Errorf("SuggestPriorityFee(%v): expected %s wei, got %s", tt.percentile, tt.want, tip)
+		}
+	}
+}
+
+func TestFeeHistory_SuggestPriorityFee_OddWindow(t *testing.T) {
+	var history FeeHistory
+	history.RewardPercentiles = []float64{50}
+	if err := json.Unmarshal([]byte(`{"oldestBlock":"0x1","baseFeePerGas":["0x1","0x1","0x1","0x1"],"gasUsedRatio":[1,1,1],"reward":[["0x3"],["0x1"],["0x2"]]}`), &history); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	tip, err := history.SuggestPriorityFee(50)
+	if err != nil {
+		t.Fatalf("SuggestPriorityFee failed: %v", err)
+	}
+	if tip.Int64() != 2 {
+		t.Errorf("Expected 2 wei, got %s", tip)
+	}
+}
+
+func TestFeeHistory_SuggestPriorityFee_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		history FeeHistory
+		wantErr error
+	}{
+		{"no reward rows", FeeHistory{RewardPercentiles: []float64{50}}, ErrNoRewards},
+		{"only empty rows", FeeHistory{RewardPercentiles: []float64{50}, Reward: [][]*big.Int{{}, {}}}, ErrNoRewards},
+		{"percentile not requested", FeeHistory{RewardPercentiles: []float64{10, 90}, Reward: [][]*big.Int{{big.NewInt(1), big.NewInt(2)}}}, nil},
+		{"short row", FeeHistory{RewardPercentiles: []float64{10, 50}, Reward: [][]*big.Int{{big.NewInt(1)}}}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tip, err := tt.history.SuggestPriorityFee(50)
+			if err == nil {
+				t.Fatalf("Expected error, got %s", tip)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("Expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestGetFeeHistory_InvalidArguments(t *testing.T) {
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithRPC(&mockRPC{}))
+	ctx := context.Background()
+
+	if _, err := client.GetFeeHistory(ctx, 1, 0, "latest", nil); err == nil {
+		t.Error("Expected error for zero block count")
+	}
+	if _, err := client.GetFeeHistory(ctx, 1, 5, "earliest", nil); err == nil {
+		t.Error("Expected error for unsupported block tag")
+	}
+	if _, err := client.GetFeeHistory(ctx, 1, 5, "latest", []float64{50, 10}); err == nil {
+		t.Error("Expected error for descending percentiles")
+	}
+	if _, err := NewClientWithAPIKey("test-api-key").GetFeeHistory(ctx, 1, 5, "latest", nil); err == nil {
+		t.Error("Expected error without RPC backend")
+	}
+}
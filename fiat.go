@@ -0,0 +1,179 @@
+package infura
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// PriceProvider supplies the USD price of a chain's native token
+// Prices are exact rationals so fiat estimates carry no floating point error.
+// The package does not ship a live price source; StaticPriceProvider serves fixed prices.
+type PriceProvider interface {
+	NativeTokenPriceUSD(ctx context.Context, chainID int64) (*big.Rat, error)
+}
+
+// StaticPriceProvider serves fixed USD prices given as decimal strings by chain ID,
+// e.g. StaticPriceProvider{1: "3150.25"}
+type StaticPriceProvider map[int64]string
+
+// NativeTokenPriceUSD implements PriceProvider
+func (p StaticPriceProvider) NativeTokenPriceUSD(ctx context.Context, chainID int64) (*big.Rat, error) {
+	price, ok := p[chainID]
+	if !ok {
+		return nil, fmt.Errorf("no price for chain %d", chainID)
+	}
+	r, ok := new(big.Rat).SetString(price)
+	if !ok {
+		return nil, fmt.Errorf("invalid price %q for chain %d", price, chainID)
+	}
+	return r, nil
+}
+
+// FiatEstimate is the cost of a transaction in the chain's native currency and in USD
+type FiatEstimate struct {
+	ChainID  int64
+	Currency NativeCurrency
+	Level    Priority
+	GasLimit uint64
+
+	// CostWei is the expected cost: min(base fee + tip, maxFeePerGas) * gas limit
+	CostWei *big.Int
+	// MaxCostWei is the highest possible cost: maxFeePerGas * gas limit
+	MaxCostWei *big.Int
+
+	PriceUSD *big.Rat
+	// CostUSD and MaxCostUSD are exact; use FormatUSD to round them for display
+	CostUSD    *big.Rat
+	MaxCostUSD *big.Rat
+}
+
+// CostNative returns the expected cost in the native currency as an exact decimal string
+func (e FiatEstimate) CostNative() string {
+	return formatUnits(e.CostWei, e.Currency.Decimals)
+}
+
+// MaxCostNative returns the highest possible cost in the native currency as an exact decimal string
+func (e FiatEstimate) MaxCostNative() string {
+	return formatUnits(e.MaxCostWei, e.Currency.Decimals)
+}
+
+// EstimateTxCostFiat estimates the cost of a transaction using gasLimit gas at the given fee
+// level, converted to USD with the provider's price for the chain's native token
+// The chain must be in the registry (see LookupChain) to know its native currency decimals.
+func EstimateTxCostFiat(ctx context.Context, provider PriceProvider, fees *SuggestedGasFees, level Priority, gasLimit uint64, chainID int64) (FiatEstimate, error) {
+	if provider == nil {
+		return FiatEstimate{}, errors.New("price provider must not be nil")
+	}
+	if fees == nil {
+		return FiatEstimate{}, errors.New("fees must not be nil")
+	}
+	if gasLimit == 0 {
+		return FiatEstimate{}, errors.New("gas limit must be positive")
+	}
+	chain, ok := LookupChain(chainID)
+	if !ok {
+		return FiatEstimate{}, fmt.Errorf("unknown chain %d: no native currency metadata", chainID)
+	}
+
+	feeLevel, err := fees.Level(level)
+	if err != nil {
+		return FiatEstimate{}, err
+	}
+	maxFee, err := ParseGweiToWei(feeLevel.SuggestedMaxFeePerGas)
+	if err != nil {
+		return FiatEstimate{}, fmt.Errorf("failed to parse %s maxFeePerGas: %w", level, err)
+	}
+	tip, err := ParseGweiToWei(feeLevel.SuggestedMaxPriorityFeePerGas)
+	if err != nil {
+		return FiatEstimate{}, fmt.Errorf("failed to parse %s maxPriorityFeePerGas: %w", level, err)
+	}
+	baseFee, err := ParseGweiToWei(fees.EstimatedBaseFee)
+	if err != nil {
+		return FiatEstimate{}, fmt.Errorf("failed to parse estimated base fee: %w", err)
+	}
+
+	price, err := provider.NativeTokenPriceUSD(ctx, chainID)
+	if err != nil {
+		return FiatEstimate{}, fmt.Errorf("failed to get %s price: %w", chain.NativeCurrency.Symbol, err)
+	}
+	if price == nil || price.Sign() < 0 {
+		return FiatEstimate{}, fmt.Errorf("invalid %s price: %v", chain.NativeCurrency.Symbol, price)
+	}
+
+	expected := new(big.Int).Add(baseFee, tip)
+	if expected.Cmp(maxFee) > 0 {
+		expected.Set(maxFee)
+	}
+	limit := new(big.Int).SetUint64(gasLimit)
+	costWei := expected.Mul(expected, limit)
+	maxCostWei := new(big.Int).Mul(maxFee, limit)
+
+	unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(chain.NativeCurrency.Decimals)), nil)
+	toUSD := func(wei *big.Int) *big.Rat {
+		usd := new(big.Rat).SetFrac(wei, unit)
+		return usd.Mul(usd, price)
+	}
+
+	return FiatEstimate{
+		ChainID:    chainID,
+		Currency:   chain.NativeCurrency,
+		Level:      level,
+		GasLimit:   gasLimit,
+		CostWei:    costWei,
+		MaxCostWei: maxCostWei,
+		PriceUSD:   new(big.Rat).Set(price),
+		CostUSD:    toUSD(costWei),
+		MaxCostUSD: toUSD(maxCostWei),
+	}, nil
+}
+
+// FormatUSD formats an amount of US dollars for display, e.g. "$1,234.57"
+// The amount is rounded to whole cents, half away from zero. A positive amount that rounds
+// to zero is shown as "<$0.01" so a real cost never displays as free.
+func FormatUSD(amount *big.Rat) string {
+	if amount == nil {
+		return "$0.00"
+	}
+
+	cents := roundRat(new(big.Rat).Mul(amount, big.NewRat(100, 1)))
+	if cents.Sign() == 0 && amount.Sign() > 0 {
+		return "<$0.01"
+	}
+
+	sign := ""
+	if cents.Sign() < 0 {
+		sign = "-"
+		cents.Neg(cents)
+	}
+	dollars, rem := new(big.Int).QuoRem(cents, big.NewInt(100), new(big.Int))
+	return fmt.Sprintf("%s$%s.%02d", sign, groupThousands(dollars.String()), rem.Int64())
+}
+
+// roundRat rounds r to the nearest integer, half away from zero
+func roundRat(r *big.Rat) *big.Int {
+	quotient, remainder := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	// |remainder| / denom >= 1/2
+	if new(big.Int).Lsh(new(big.Int).Abs(remainder), 1).Cmp(r.Denom()) >= 0 {
+		if r.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+	return quotient
+}
+
+// groupThousands inserts commas between groups of three digits
+func groupThousands(digits string) string {
+	var b strings.Builder
+	for i, ch := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(ch)
+	}
+	return b.String()
+}
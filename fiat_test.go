@@ -0,0 +1,148 @@
+package infura
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+var fiatFees = &SuggestedGasFees{
+	Medium:           GasFeeLevel{SuggestedMaxPriorityFeePerGas: "2", SuggestedMaxFeePerGas: "40"},
+	High:             GasFeeLevel{SuggestedMaxPriorityFeePerGas: "5", SuggestedMaxFeePerGas: "40"},
+	EstimatedBaseFee: "30",
+}
+
+func TestEstimateTxCostFiat(t *testing.T) {
+	provider := StaticPriceProvider{1: "3000", 137: "0.5"}
+
+	tests := []struct {
+		name           string
+		chainID        int64
+		level          Priority
+		wantCostWei    string
+		wantMaxCostWei string
+		wantNative     string
+		wantUSD        string
+		wantMaxUSD     string
+	}{
+		// (30 + 2) gwei * 21000 = 0.000672 ETH at $3000 = $2.016
+		{"expected below max", 1, PriorityMedium, "672000000000000", "840000000000000", "0.000672", "$2.02", "$2.52"},
+		// 30 + 5 = 35 gwei is under the 40 gwei cap
+		{"high level", 1, PriorityHigh, "735000000000000", "840000000000000", "0.000735", "$2.21", "$2.52"},
+		// 0.000672 POL at $0.50 = $0.000336
+		{"sub-cent cost", 137, PriorityMedium, "672000000000000", "840000000000000", "0.000672", "<$0.01", "<$0.01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			estimate, err := EstimateTxCostFiat(context.Background(), provider, fiatFees, tt.level, 21000, tt.chainID)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if estimate.CostWei.String() != tt.wantCostWei || estimate.MaxCostWei.String() != tt.wantMaxCostWei {
+				t.Errorf("Expected cost %s / max %s wei, got %s / %s", tt.wantCostWei, tt.wantMaxCostWei, estimate.CostWei, estimate.MaxCostWei)
+			}
+			if got := estimate.CostNative(); got != tt.wantNative {
+				t.Errorf("Expected native cost %s, got %s", tt.wantNative, got)
+			}
+			if got := FormatUSD(estimate.CostUSD); got != tt.wantUSD {
+				t.Errorf("Expected %s, got %s", tt.wantUSD, got)
+			}
+			if got := FormatUSD(estimate.MaxCostUSD); got != tt.wantMaxUSD {
+				t.Errorf("Expected max %s, got %s", tt.wantMaxUSD, got)
+			}
+		})
+	}
+}
+
+func TestEstimateTxCostFiat_ExactUSD(t *testing.T) {
+	estimate, err := EstimateTxCostFiat(context.Background(), StaticPriceProvider{1: "3000"}, fiatFees, PriorityMedium, 21000, 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := big.NewRat(2016, 1000); estimate.CostUSD.Cmp(want) != 0 {
+		t.Errorf("Expected exact cost %s, got %s", want.RatString(), estimate.CostUSD.RatString())
+	}
+	if estimate.Currency.Symbol != "ETH" || estimate.Currency.Decimals != 18 {
+		t.Errorf("Unexpected currency %+v", estimate.Currency)
+	}
+}
+
+func TestEstimateTxCostFiat_CappedAtMaxFee(t *testing.T) {
+	fees := *fiatFees
+	fees.EstimatedBaseFee = "39"
+
+	estimate, err := EstimateTxCostFiat(context.Background(), StaticPriceProvider{1: "1"}, &fees, PriorityMedium, 1, 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if estimate.CostWei.Cmp(estimate.MaxCostWei) != 0 {
+		t.Errorf("Expected the cost to be capped at the max fee, got %s > %s", estimate.CostWei, estimate.MaxCostWei)
+	}
+}
+
+type failingPriceProvider struct{}
+
+func (failingPriceProvider) NativeTokenPriceUSD(ctx context.Context, chainID int64) (*big.Rat, error) {
+	return nil, errors.New("price feed down")
+}
+
+func TestEstimateTxCostFiat_Errors(t *testing.T) {
+	provider := StaticPriceProvider{1: "3000", 10: "abc", 8453: "-1"}
+
+	tests := []struct {
+		name     string
+		provider PriceProvider
+		fees     *SuggestedGasFees
+		gasLimit uint64
+		chainID  int64
+	}{
+		{"nil provider", nil, fiatFees, 21000, 1},
+		{"nil fees", provider, nil, 21000, 1},
+		{"zero gas limit", provider, fiatFees, 0, 1},
+		{"unknown chain", provider, fiatFees, 21000, 999999},
+		{"missing price", provider, fiatFees, 21000, 137},
+		{"invalid price", provider, fiatFees, 21000, 10},
+		{"negative price", provider, fiatFees, 21000, 8453},
+		{"provider error", failingPriceProvider{}, fiatFees, 21000, 1},
+		{"unparseable fee", provider, &SuggestedGasFees{EstimatedBaseFee: "30"}, 21000, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := EstimateTxCostFiat(context.Background(), tt.provider, tt.fees, PriorityMedium, tt.gasLimit, tt.chainID); err == nil {
+				t.Error("Expected error")
+			}
+		})
+	}
+}
+
+func TestFormatUSD(t *testing.T) {
+	tests := []struct {
+		amount string
+		want   string
+	}{
+		{"0", "$0.00"},
+		{"2.005", "$2.01"},
+		{"2.0049", "$2.00"},
+		{"2.015", "$2.02"},
+		{"-2.005", "-$2.01"},
+		{"0.004", "<$0.01"},
+		{"0.005", "$0.01"},
+		{"999.995", "$1,000.00"},
+		{"1234567.891", "$1,234,567.89"},
+		{"100", "$100.00"},
+		{"1/3", "$0.33"},
+	}
+
+	for _, tt := range tests {
+		amount, _ := new(big.Rat).SetString(tt.amount)
+		if got := FormatUSD(amount); got != tt.want {
+			t.Errorf("FormatUSD(%s): expected %s, got %s", tt.amount, tt.want, got)
+		}
+	}
+	if got := FormatUSD(nil); got != "$0.00" {
+		t.Errorf("FormatUSD(nil): expected $0.00, got %s", got)
+	}
+}
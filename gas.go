@@ -3,66 +3,64 @@ package infura
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"sync"
 )
 
 // GetSuggestedGasFees retrieves suggested gas fees for a given chain ID
 // If API Key Secret is provided, uses Basic Auth: /networks/{chainId}/suggestedGasFees
 // If only API Key is provided, uses URL path auth: /v3/{apiKey}/networks/{chainId}/suggestedGasFees
-func (c *Client) GetSuggestedGasFees(ctx context.Context, chainID int64) (*SuggestedGasFees, error) {
-	var endpoint string
-	if c.hasSecret() {
-		// Basic Auth: API Key + Secret
-		endpoint = fmt.Sprintf("/networks/%d/suggestedGasFees", chainID)
-	} else {
-		// URL path auth: API Key only
-		endpoint = fmt.Sprintf("/v3/%s/networks/%d/suggestedGasFees", c.apiKey, chainID)
+// If fallback fees are configured (WithFallbackFees), they are returned when the API is unavailable
+// If a sanity check is configured (WithSanityCheck), the result is cross-checked against eth_gasPrice
+// If fee caps are configured (WithMaxFeeCap), they are enforced on every result
+// Call options such as WithCallTimeout apply to this call only
+func (c *Client) GetSuggestedGasFees(ctx context.Context, chainID int64, opts ...CallOption) (*SuggestedGasFees, error) {
+	ctx, cancel := withCallOptions(ctx, opts)
+	defer cancel()
+
+	result, err := c.fetchSuggestedGasFees(ctx, chainID)
+	if err != nil {
+		return nil, err
 	}
 
-	var result SuggestedGasFees
-	if err := c.doJSONRequest(ctx, "GET", endpoint, nil, &result); err != nil {
+	if err := c.applyFeeCap(ctx, chainID, result); err != nil {
 		return nil, err
 	}
 
-	return &result, nil
+	return result, nil
 }
 
-// GetBaseFeeHistory retrieves base fee history for a given chain ID
-// If API Key Secret is provided, uses Basic Auth: /networks/{chainId}/baseFeeHistory
-// If only API Key is provided, uses URL path auth: /v3/{apiKey}/networks/{chainId}/baseFeeHistory
-// The API returns an array of strings directly
-func (c *Client) GetBaseFeeHistory(ctx context.Context, chainID int64) (BaseFeeHistory, error) {
-	var endpoint string
-	if c.hasSecret() {
-		// Basic Auth: API Key + Secret
-		endpoint = fmt.Sprintf("/networks/%d/baseFeeHistory", chainID)
-	} else {
-		// URL path auth: API Key only
-		endpoint = fmt.Sprintf("/v3/%s/networks/%d/baseFeeHistory", c.apiKey, chainID)
+// fetchSuggestedGasFees retrieves live suggested gas fees, falling back to static fees if configured
+func (c *Client) fetchSuggestedGasFees(ctx context.Context, chainID int64) (*SuggestedGasFees, error) {
+	result, err := Get[SuggestedGasFees](ctx, c, chainID, "suggestedGasFees", nil)
+	if err != nil {
+		if fallback, ok := c.fallbackSuggestedGasFees(ctx, chainID, err); ok {
+			return fallback, nil
+		}
+		return nil, err
 	}
 
-	var result BaseFeeHistory
-	if err := c.doJSONRequest(ctx, "GET", endpoint, nil, &result); err != nil {
+	if err := c.checkSuggestedGasFees(ctx, chainID, &result); err != nil {
 		return nil, err
 	}
 
-	return result, nil
+	return &result, nil
+}
+
+// GetBaseFeeHistory retrieves base fee history for a given chain ID
+// If API Key Secret is provided, uses Basic Auth: /networks/{chainId}/baseFeeHistory
+// If only API Key is provided, uses URL path auth: /v3/{apiKey}/networks/{chainId}/baseFeeHistory
+// The API returns an array of strings directly
+func (c *Client) GetBaseFeeHistory(ctx context.Context, chainID int64, opts ...CallOption) (BaseFeeHistory, error) {
+	return Get[BaseFeeHistory](ctx, c, chainID, "baseFeeHistory", nil, opts...)
 }
 
 // GetBaseFeePercentile retrieves base fee percentile for a given chain ID
 // If API Key Secret is provided, uses Basic Auth: /networks/{chainId}/baseFeePercentile
 // If only API Key is provided, uses URL path auth: /v3/{apiKey}/networks/{chainId}/baseFeePercentile
-func (c *Client) GetBaseFeePercentile(ctx context.Context, chainID int64) (*BaseFeePercentile, error) {
-	var endpoint string
-	if c.hasSecret() {
-		// Basic Auth: API Key + Secret
-		endpoint = fmt.Sprintf("/networks/%d/baseFeePercentile", chainID)
-	} else {
-		// URL path auth: API Key only
-		endpoint = fmt.Sprintf("/v3/%s/networks/%d/baseFeePercentile", c.apiKey, chainID)
-	}
-
-	var result BaseFeePercentile
-	if err := c.doJSONRequest(ctx, "GET", endpoint, nil, &result); err != nil {
+func (c *Client) GetBaseFeePercentile(ctx context.Context, chainID int64, opts ...CallOption) (*BaseFeePercentile, error) {
+	result, err := Get[BaseFeePercentile](ctx, c, chainID, "baseFeePercentile", nil, opts...)
+	if err != nil {
 		return nil, err
 	}
 
@@ -72,20 +70,117 @@ func (c *Client) GetBaseFeePercentile(ctx context.Context, chainID int64) (*Base
 // GetBusyThreshold retrieves busy threshold for a given chain ID
 // If API Key Secret is provided, uses Basic Auth: /networks/{chainId}/busyThreshold
 // If only API Key is provided, uses URL path auth: /v3/{apiKey}/networks/{chainId}/busyThreshold
-func (c *Client) GetBusyThreshold(ctx context.Context, chainID int64) (*BusyThreshold, error) {
-	var endpoint string
-	if c.hasSecret() {
+func (c *Client) GetBusyThreshold(ctx context.Context, chainID int64, opts ...CallOption) (*BusyThreshold, error) {
+	result, err := Get[BusyThreshold](ctx, c, chainID, "busyThreshold", nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetBaseFeeSnapshot retrieves base fee history and base fee percentile for a given chain ID
+// The two requests are issued concurrently to minimize the time between them. Infura does
+// not offer an atomic read of both, so they may still straddle a block boundary.
+// An error is returned if either request fails.
+func (c *Client) GetBaseFeeSnapshot(ctx context.Context, chainID int64, opts ...CallOption) (*BaseFeeSnapshot, error) {
+	ctx, cancel := withCallOptions(ctx, opts)
+	defer cancel()
+
+	var (
+		wg            sync.WaitGroup
+		history       BaseFeeHistory
+		percentile    *BaseFeePercentile
+		historyErr    error
+		percentileErr error
+	)
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		history, historyErr = c.GetBaseFeeHistory(ctx, chainID)
+	}()
+	go func() {
+		defer wg.Done()
+		percentile, percentileErr = c.GetBaseFeePercentile(ctx, chainID)
+	}()
+	wg.Wait()
+
+	if historyErr != nil {
+		return nil, fmt.Errorf("failed to get base fee history: %w", historyErr)
+	}
+	if percentileErr != nil {
+		return nil, fmt.Errorf("failed to get base fee percentile: %w", percentileErr)
+	}
+
+	return &BaseFeeSnapshot{History: history, Percentile: percentile}, nil
+}
+
+// networkEndpoint returns the endpoint path of a per-network resource for the given credentials
+// Basic Auth: /networks/{chainId}/{resource}
+// URL path auth: /v3/{apiKey}/networks/{chainId}/{resource}
+func networkEndpoint(creds *credentials, chainID int64, resource string) string {
+	if creds.basicAuth() {
 		// Basic Auth: API Key + Secret
-		endpoint = fmt.Sprintf("/networks/%d/busyThreshold", chainID)
-	} else {
-		// URL path auth: API Key only
-		endpoint = fmt.Sprintf("/v3/%s/networks/%d/busyThreshold", c.apiKey, chainID)
+		return fmt.Sprintf("/networks/%d/%s", chainID, resource)
 	}
+	// URL path auth: API Key only
+	return fmt.Sprintf("/v3/%s/networks/%d/%s", creds.apiKey, chainID, resource)
+}
 
-	var result BusyThreshold
-	if err := c.doJSONRequest(ctx, "GET", endpoint, nil, &result); err != nil {
-		return nil, err
+// Get performs a GET request for a per-network resource and decodes the JSON response into T
+// It is the request path behind the Gas API methods and can be used for endpoints the client
+// has no method for yet. resource is the path below /networks/{chainId}/ (e.g. "busyThreshold")
+// and query, if not empty, is appended as the query string. Authentication, retries, rate
+// limiting, caching and chain overrides apply as for the built-in methods, and errors are
+// the same: *APIError for non-2xx responses and a wrapped decode error for invalid JSON.
+//
+//	threshold, err := infura.Get[infura.BusyThreshold](ctx, client, 1, "busyThreshold", nil)
+func Get[T any](ctx context.Context, c *Client, chainID int64, resource string, query url.Values, opts ...CallOption) (T, error) {
+	var result T
+	if resource == "" {
+		return result, fmt.Errorf("resource must not be empty")
+	}
+	if len(query) > 0 {
+		resource += "?" + query.Encode()
 	}
 
-	return &result, nil
+	ctx, cancel := withCallOptions(ctx, opts)
+	defer cancel()
+
+	if err := c.getNetworkResource(ctx, chainID, resource, &result); err != nil {
+		var zero T
+		return zero, err
+	}
+	return result, nil
+}
+
+// getNetworkResource performs a GET request for a per-network resource
+// A 404 for a network the API does not serve is reported as an *UnsupportedNetworkError
+func (c *Client) getNetworkResource(ctx context.Context, chainID int64, resource string, result interface{}) error {
+	return asUnsupportedNetwork(c.fetchNetworkResource(ctx, chainID, resource, result), chainID)
+}
+
+// fetchNetworkResource performs a GET request for a per-network resource
+// The endpoint path and the Authorization header are built from the same credentials snapshot
+func (c *Client) fetchNetworkResource(ctx context.Context, chainID int64, resource string, result interface{}) error {
+	if err := checkResult(result); err != nil {
+		return err
+	}
+	creds, err := c.requestCredentials()
+	if err != nil {
+		return err
+	}
+	settings := c.settingsFor(ctx, chainID)
+	if c.cache != nil && (settings.cacheTTL > 0 || c.maxStaleAge > 0) {
+		return c.getCachedNetworkResource(ctx, creds, settings, chainID, resource, result)
+	}
+	if c.etags != nil {
+		raw, err := c.fetchNetworkResourceRaw(ctx, creds, settings, chainID, resource)
+		if err != nil {
+			return err
+		}
+		return c.decodeCachedBody(raw, result)
+	}
+	return c.doJSONRequestWithCredentials(ctx, creds, settings, "GET", networkEndpoint(creds, chainID, resource), nil, result)
 }
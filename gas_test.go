@@ -3,10 +3,18 @@ package infura
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/ABT-Tech-Limited/infura-go/infuratest"
 )
 
 func TestGetSuggestedGasFees(t *testing.T) {
@@ -31,7 +39,7 @@ func TestGetSuggestedGasFees(t *testing.T) {
 			MaxWaitTimeEstimate:           60000,
 		},
 		EstimatedBaseFee:           "24.036058416",
-		NetworkCongestion:          0.7143,
+		NetworkCongestion:          NewCongestion(0.7143),
 		LatestPriorityFeeRange:     []string{"0.1", "20"},
 		HistoricalPriorityFeeRange: []string{"0.007150439", "113"},
 		HistoricalBaseFeeRange:     []string{"19.531410688", "36.299069766"},
@@ -99,7 +107,7 @@ func TestGetSuggestedGasFees(t *testing.T) {
 	}
 
 	if result.NetworkCongestion != mockResponse.NetworkCongestion {
-		t.Errorf("Expected NetworkCongestion %f, got %f",
+		t.Errorf("Expected NetworkCongestion %v, got %v",
 			mockResponse.NetworkCongestion,
 			result.NetworkCongestion)
 	}
@@ -165,7 +173,7 @@ func TestGetSuggestedGasFees_APIKeyOnly(t *testing.T) {
 			MaxWaitTimeEstimate:           60000,
 		},
 		EstimatedBaseFee:           "24.036058416",
-		NetworkCongestion:          0.7143,
+		NetworkCongestion:          NewCongestion(0.7143),
 		LatestPriorityFeeRange:     []string{"0.1", "20"},
 		HistoricalPriorityFeeRange: []string{"0.007150439", "113"},
 		HistoricalBaseFeeRange:     []string{"19.531410688", "36.299069766"},
@@ -244,7 +252,7 @@ func TestGetSuggestedGasFees_APIKeyOnly_EmptySecret(t *testing.T) {
 			MaxWaitTimeEstimate:           60000,
 		},
 		EstimatedBaseFee:           "24.036058416",
-		NetworkCongestion:          0.7143,
+		NetworkCongestion:          NewCongestion(0.7143),
 		LatestPriorityFeeRange:     []string{"0.1", "20"},
 		HistoricalPriorityFeeRange: []string{"0.007150439", "113"},
 		HistoricalBaseFeeRange:     []string{"19.531410688", "36.299069766"},
@@ -594,8 +602,160 @@ func TestGetBusyThreshold_ErrorResponse(t *testing.T) {
 	}
 }
 
+func TestGetBaseFeeSnapshot(t *testing.T) {
+	// Both requests must be in flight at the same time for the handler to answer
+	var arrived sync.WaitGroup
+	arrived.Add(2)
+	bothArrived := make(chan struct{})
+	go func() {
+		arrived.Wait()
+		close(bothArrived)
+	}()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		arrived.Done()
+		select {
+		case <-bothArrived:
+		case <-time.After(2 * time.Second):
+			t.Error("Requests were not issued concurrently")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		switch r.URL.Path {
+		case "/networks/1/baseFeeHistory":
+			w.Write([]byte(`["24.1", "25.2", "26.3"]`))
+		case "/networks/1/baseFeePercentile":
+			w.Write([]byte(`{"baseFeePercentile": "25.2"}`))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClientWithOptions("test-api-key", "test-api-secret", WithBaseURL(server.URL))
+
+	snapshot, err := client.GetBaseFeeSnapshot(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetBaseFeeSnapshot failed: %v", err)
+	}
+
+	if len(snapshot.History) != 3 || snapshot.History[2] != "26.3" {
+		t.Errorf("Unexpected history: %v", snapshot.History)
+	}
+	if snapshot.Percentile.BaseFeePercentile != "25.2" {
+		t.Errorf("Expected percentile 25.2, got %s", snapshot.Percentile.BaseFeePercentile)
+	}
+}
+
+func TestGetBaseFeeSnapshot_ErrorResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/baseFeePercentile") {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`["24.1"]`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL))
+
+	_, err := client.GetBaseFeeSnapshot(context.Background(), 1)
+	if err == nil {
+		t.Fatal("Expected error but got nil")
+	}
+	if !strings.Contains(err.Error(), "base fee percentile") {
+		t.Errorf("Expected error to name the failing endpoint, got: %v", err)
+	}
+}
+
+// newLiveClient returns a client replaying testdata/cassettes/{cassette}.json
+// Set INFURA_RECORD=1 together with InfuraAPIKey to re-record the cassette against the live API
+func newLiveClient(t *testing.T, cassette string) *Client {
+	t.Helper()
+	mode := infuratest.ModeReplay
+	if os.Getenv("INFURA_RECORD") != "" {
+		mode = infuratest.ModeRecord
+	}
+
+	recorder, err := infuratest.NewRecorder(filepath.Join("testdata", "cassettes", cassette+".json"), mode, nil)
+	if err != nil {
+		t.Fatalf("Failed to open cassette: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := recorder.Save(); err != nil {
+			t.Errorf("Failed to save cassette: %v", err)
+		}
+	})
+
+	return NewClientWithAPIKeyAndOptions(os.Getenv("InfuraAPIKey"), WithTransport(recorder))
+}
+
+func TestGet(t *testing.T) {
+	type blobFees struct {
+		BlobBaseFee string `json:"blobBaseFee"`
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3/test-api-key/networks/1/blobFees" {
+			t.Errorf("Unexpected path: %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("block"); got != "latest" {
+			t.Errorf("Expected block query parameter, got %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"blobBaseFee":"0.000000001"}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL))
+
+	fees, err := Get[blobFees](context.Background(), client, 1, "blobFees", url.Values{"block": {"latest"}})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if fees.BlobBaseFee != "0.000000001" {
+		t.Errorf("Expected blobBaseFee 0.000000001, got %q", fees.BlobBaseFee)
+	}
+}
+
+func TestGet_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/broken") {
+			w.Write([]byte(`invalid json`))
+			return
+		}
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"error": "Too Many Requests"}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithOptions("test-api-key", "test-api-secret", WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	history, err := Get[BaseFeeHistory](ctx, client, 1, "baseFeeHistory", nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests || !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Expected a 429 *APIError, got %v", err)
+	}
+	if history != nil {
+		t.Errorf("Expected a zero result on error, got %v", history)
+	}
+
+	if _, err := Get[BusyThreshold](ctx, client, 1, "broken", nil); err == nil || !strings.Contains(err.Error(), "failed to decode response") {
+		t.Errorf("Expected a decode error, got %v", err)
+	}
+
+	if _, err := Get[BusyThreshold](ctx, client, 1, "", nil); err == nil {
+		t.Error("Expected an error for an empty resource")
+	}
+}
+
 func TestClient_GetSuggestedGasFees(t *testing.T) {
-	client := NewClientWithAPIKey(os.Getenv("InfuraAPIKey"))
+	client := newLiveClient(t, "suggested_gas_fees")
 	data, err := client.GetSuggestedGasFees(context.Background(), 1)
 	if err != nil {
 		t.Fatalf("Error fetching suggested gas fees: %v", err)
@@ -605,7 +765,7 @@ func TestClient_GetSuggestedGasFees(t *testing.T) {
 }
 
 func TestClient_GetBaseFeeHistory(t *testing.T) {
-	client := NewClientWithAPIKey(os.Getenv("InfuraAPIKey"))
+	client := newLiveClient(t, "base_fee_history")
 	data, err := client.GetBaseFeeHistory(context.Background(), 1)
 	if err != nil {
 		t.Fatalf("Error fetching base fee history: %v", err)
@@ -615,7 +775,7 @@ func TestClient_GetBaseFeeHistory(t *testing.T) {
 }
 
 func TestClient_GetBaseFeePercentile(t *testing.T) {
-	client := NewClientWithAPIKey(os.Getenv("InfuraAPIKey"))
+	client := newLiveClient(t, "base_fee_percentile")
 	data, err := client.GetBaseFeePercentile(context.Background(), 1)
 	if err != nil {
 		t.Fatalf("Error fetching base fee percentile: %v", err)
@@ -625,7 +785,7 @@ func TestClient_GetBaseFeePercentile(t *testing.T) {
 }
 
 func TestClient_GetBusyThreshold(t *testing.T) {
-	client := NewClientWithAPIKey(os.Getenv("InfuraAPIKey"))
+	client := newLiveClient(t, "busy_threshold")
 	data, err := client.GetBusyThreshold(context.Background(), 1)
 	if err != nil {
 		t.Fatalf("Error fetching busy threshold: %v", err)
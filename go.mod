@@ -2,4 +2,7 @@ module github.com/ABT-Tech-Limited/infura-go
 
 go 1.25.1
 
-require golang.org/x/time v0.14.0
+require (
+	golang.org/x/time v0.14.0
+	gopkg.in/yaml.v3 v3.0.1
+)
@@ -0,0 +1,133 @@
+package infura
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Gwei is an exact Gwei amount with up to 9 decimal places, held as an integer number of wei
+// so arithmetic neither rounds nor overflows. The zero value is 0 Gwei. A Gwei is immutable:
+// methods return new values, so it can be copied and shared freely.
+//
+//	fee, _ := infura.ParseGwei("24.086058416")
+//	bumped, _ := fee.MulFloat(1.125) // 27.096815718
+type Gwei struct {
+	wei *big.Int
+}
+
+// ParseGwei parses a decimal Gwei string as returned by the API; it accepts the forms
+// ParseGweiToWei does plus a leading "+" or "-", so every String output parses back
+func ParseGwei(s string) (Gwei, error) {
+	unsigned, negative := strings.CutPrefix(strings.TrimSpace(s), "-")
+	if !negative {
+		unsigned = strings.TrimPrefix(unsigned, "+")
+	}
+	wei, err := ParseGweiToWei(unsigned)
+	if err != nil {
+		return Gwei{}, err
+	}
+	if negative {
+		wei.Neg(wei)
+	}
+	return Gwei{wei: wei}, nil
+}
+
+// GweiFromFloat converts a float64 Gwei amount, taking its shortest decimal representation
+// (0.1 is exactly 0.1 Gwei) rounded to the nearest wei. NaN and infinities are invalid.
+func GweiFromFloat(f float64) (Gwei, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return Gwei{}, fmt.Errorf("invalid gwei value: %v", f)
+	}
+	gwei, ok := new(big.Rat).SetString(strconv.FormatFloat(f, 'f', -1, 64))
+	if !ok {
+		return Gwei{}, fmt.Errorf("invalid gwei value: %v", f)
+	}
+	return Gwei{wei: roundRat(gwei.Mul(gwei, new(big.Rat).SetInt(weiPerGwei)))}, nil
+}
+
+// GweiFromWei returns the Gwei amount of wei; a nil wei is 0
+func GweiFromWei(wei *big.Int) Gwei {
+	if wei == nil {
+		return Gwei{}
+	}
+	return Gwei{wei: new(big.Int).Set(wei)}
+}
+
+// Wei returns the amount in wei as a new big.Int
+func (g Gwei) Wei() *big.Int {
+	return new(big.Int).Set(g.int())
+}
+
+// Add returns g + other
+func (g Gwei) Add(other Gwei) Gwei {
+	return Gwei{wei: new(big.Int).Add(g.int(), other.int())}
+}
+
+// Sub returns g - other, which may be negative
+func (g Gwei) Sub(other Gwei) Gwei {
+	return Gwei{wei: new(big.Int).Sub(g.int(), other.int())}
+}
+
+// MulFloat returns g multiplied by factor, rounded to the nearest wei (halves away from
+// zero). The factor is taken as its shortest decimal representation, so MulFloat(1.125)
+// multiplies by exactly 9/8 and MulFloat(1.1) by exactly 11/10. NaN and infinities are
+// invalid.
+func (g Gwei) MulFloat(factor float64) (Gwei, error) {
+	if math.IsNaN(factor) || math.IsInf(factor, 0) {
+		return Gwei{}, fmt.Errorf("invalid factor: %v", factor)
+	}
+	r, ok := new(big.Rat).SetString(strconv.FormatFloat(factor, 'f', -1, 64))
+	if !ok {
+		return Gwei{}, fmt.Errorf("invalid factor: %v", factor)
+	}
+	return Gwei{wei: roundRat(r.Mul(r, new(big.Rat).SetInt(g.int())))}, nil
+}
+
+// Cmp compares g and other and returns -1, 0 or +1
+func (g Gwei) Cmp(other Gwei) int {
+	return g.int().Cmp(other.int())
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of g
+func (g Gwei) Sign() int {
+	return g.int().Sign()
+}
+
+// String returns the exact decimal amount without trailing zeros, as NormalizeGwei does,
+// e.g. "24.5", "0" or "-1.25"
+func (g Gwei) String() string {
+	wei := g.int()
+	if wei.Sign() < 0 {
+		return "-" + formatWeiAsGwei(new(big.Int).Neg(wei))
+	}
+	return formatWeiAsGwei(wei)
+}
+
+// int returns the wei amount, treating the zero value as 0; callers must not modify it
+func (g Gwei) int() *big.Int {
+	if g.wei == nil {
+		return new(big.Int)
+	}
+	return g.wei
+}
+
+// MaxFeeGwei returns SuggestedMaxFeePerGas as an exact Gwei amount
+func (l GasFeeLevel) MaxFeeGwei() (Gwei, error) {
+	fee, err := ParseGwei(l.SuggestedMaxFeePerGas)
+	if err != nil {
+		return Gwei{}, fmt.Errorf("invalid suggestedMaxFeePerGas: %w", err)
+	}
+	return fee, nil
+}
+
+// MaxPriorityFeeGwei returns SuggestedMaxPriorityFeePerGas as an exact Gwei amount
+func (l GasFeeLevel) MaxPriorityFeeGwei() (Gwei, error) {
+	fee, err := ParseGwei(l.SuggestedMaxPriorityFeePerGas)
+	if err != nil {
+		return Gwei{}, fmt.Errorf("invalid suggestedMaxPriorityFeePerGas: %w", err)
+	}
+	return fee, nil
+}
@@ -0,0 +1,146 @@
+package infura
+
+import (
+	"math"
+	"math/big"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestGwei_StringRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for i := 0; i < 1000; i++ {
+		// Random integer part of up to 30 digits and fractional part of up to 9 digits
+		s := strconv.FormatUint(r.Uint64(), 10) + strconv.FormatUint(r.Uint64(), 10)[:r.IntN(10)+1]
+		if decimals := r.IntN(10); decimals > 0 {
+			s += "." + strconv.FormatUint(r.Uint64N(uint64(math.Pow10(decimals))), 10)
+		}
+		if r.IntN(2) == 0 {
+			s = "-" + s
+		}
+
+		g, err := ParseGwei(s)
+		if err != nil {
+			t.Fatalf("ParseGwei(%q) failed: %v", s, err)
+		}
+		normalized := g.String()
+		again, err := ParseGwei(normalized)
+		if err != nil {
+			t.Fatalf("ParseGwei(%q) failed: %v", normalized, err)
+		}
+		if again.Cmp(g) != 0 || again.String() != normalized {
+			t.Fatalf("round trip of %q: %q -> %q", s, normalized, again.String())
+		}
+		if want, _ := NormalizeGwei(strings.TrimPrefix(s, "-")); strings.TrimPrefix(normalized, "-") != want {
+			t.Fatalf("String() of %q = %q, want %q as NormalizeGwei", s, normalized, want)
+		}
+	}
+}
+
+func TestGwei_MulFloat(t *testing.T) {
+	tests := []struct {
+		value  string
+		factor float64
+		want   string
+	}{
+		// 24086058416 wei * 9/8 = 27096815718
+		{"24.086058416", 1.125, "27.096815718"},
+		// 1 wei * 9/8 = 1.125 wei, rounded to 1 wei
+		{"0.000000001", 1.125, "0.000000001"},
+		// 4 wei * 9/8 = 4.5 wei, rounded away from zero to 5 wei
+		{"0.000000004", 1.125, "0.000000005"},
+		{"-0.000000004", 1.125, "-0.000000005"},
+		{"32", 1.125, "36"},
+		{"2.5", 1.1, "2.75"},
+		{"123456789012345678901234567890.123456789", 1.125, "138888887638888888763888888876.388888888"},
+		{"7", 0, "0"},
+	}
+	for _, tt := range tests {
+		g, err := ParseGwei(tt.value)
+		if err != nil {
+			t.Fatalf("ParseGwei(%q) failed: %v", tt.value, err)
+		}
+		got, err := g.MulFloat(tt.factor)
+		if err != nil {
+			t.Fatalf("%s.MulFloat(%v) failed: %v", tt.value, tt.factor, err)
+		}
+		if got.String() != tt.want {
+			t.Errorf("%s.MulFloat(%v) = %s, want %s", tt.value, tt.factor, got, tt.want)
+		}
+	}
+
+	if _, err := (Gwei{}).MulFloat(math.NaN()); err == nil {
+		t.Error("Expected an error for a NaN factor")
+	}
+}
+
+func TestGwei_Arithmetic(t *testing.T) {
+	a, _ := ParseGwei("24.086058416")
+	b, _ := ParseGwei("0.913941584")
+
+	if got := a.Add(b).String(); got != "25" {
+		t.Errorf("Add = %s, want 25", got)
+	}
+	if got := b.Sub(a).String(); got != "-23.172116832" {
+		t.Errorf("Sub = %s, want -23.172116832", got)
+	}
+	if a.Cmp(b) != 1 || b.Cmp(a) != -1 || a.Cmp(a) != 0 {
+		t.Error("Cmp does not order the values")
+	}
+
+	var zero Gwei
+	if zero.String() != "0" || zero.Sign() != 0 || zero.Add(a).Cmp(a) != 0 {
+		t.Errorf("Expected the zero value to be 0, got %s", zero)
+	}
+
+	huge := GweiFromWei(new(big.Int).Lsh(big.NewInt(1), 200))
+	if sum := huge.Add(huge); sum.Wei().Cmp(new(big.Int).Lsh(big.NewInt(1), 201)) != 0 {
+		t.Errorf("Expected no overflow, got %s", sum)
+	}
+}
+
+func TestGwei_Constructors(t *testing.T) {
+	wei := big.NewInt(1_500_000_000)
+	g := GweiFromWei(wei)
+	wei.SetInt64(0)
+	if g.String() != "1.5" {
+		t.Errorf("Expected GweiFromWei to copy its argument, got %s", g)
+	}
+	g.Wei().SetInt64(0)
+	if g.String() != "1.5" {
+		t.Errorf("Expected Wei to return a copy, got %s", g)
+	}
+
+	for f, want := range map[float64]string{0.1: "0.1", 24.5: "24.5", 1e-10: "0", 6e-10: "0.000000001", -2.25: "-2.25"} {
+		got, err := GweiFromFloat(f)
+		if err != nil || got.String() != want {
+			t.Errorf("GweiFromFloat(%v) = %s, %v; want %s", f, got, err, want)
+		}
+	}
+	if _, err := GweiFromFloat(math.Inf(1)); err == nil {
+		t.Error("Expected an error for an infinite value")
+	}
+	for _, input := range []string{"", "1e9", "--1", "-+1", "0.0000000001"} {
+		if _, err := ParseGwei(input); err == nil {
+			t.Errorf("ParseGwei(%q): expected error", input)
+		}
+	}
+}
+
+func TestGasFeeLevel_GweiAccessors(t *testing.T) {
+	level := GasFeeLevel{SuggestedMaxFeePerGas: "32.548151972", SuggestedMaxPriorityFeePerGas: "1.5"}
+	maxFee, err := level.MaxFeeGwei()
+	if err != nil || maxFee.String() != "32.548151972" {
+		t.Errorf("MaxFeeGwei() = %s, %v", maxFee, err)
+	}
+	tip, err := level.MaxPriorityFeeGwei()
+	if err != nil || tip.String() != "1.5" {
+		t.Errorf("MaxPriorityFeeGwei() = %s, %v", tip, err)
+	}
+
+	if _, err := (GasFeeLevel{SuggestedMaxFeePerGas: "n/a"}).MaxFeeGwei(); err == nil || !strings.Contains(err.Error(), "suggestedMaxFeePerGas") {
+		t.Errorf("Expected an error naming the field, got %v", err)
+	}
+}
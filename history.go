@@ -0,0 +1,99 @@
+package infura
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// CallRecord describes one request attempt kept by WithCallHistory
+type CallRecord struct {
+	// Time is when the attempt started
+	Time   time.Time
+	Method string
+	// URL is the request URL with the API key masked (see APIKeyMasked)
+	URL string
+	// StatusCode is the HTTP status, or 0 if no response was received
+	StatusCode int
+	Duration   time.Duration
+	// Error is the attempt's error message with the API key masked, or empty on success
+	Error string
+}
+
+// callHistory is a fixed-size ring buffer of the most recent request attempts
+type callHistory struct {
+	mu      sync.Mutex
+	records []CallRecord
+	next    int
+	full    bool
+}
+
+// WithCallHistory keeps the last n request attempts (retries included) in memory for
+// post-mortem debugging; read them with CallHistory
+func WithCallHistory(n int) ClientOption {
+	return func(c *Client) {
+		if n <= 0 {
+			c.rejectOption("WithCallHistory", "size must be positive, got %d", n)
+			return
+		}
+		c.history = &callHistory{records: make([]CallRecord, n)}
+	}
+}
+
+// CallHistory returns the recorded request attempts, oldest first
+// It returns nil unless WithCallHistory is set
+func (c *Client) CallHistory() []CallRecord {
+	if c.history == nil {
+		return nil
+	}
+	return c.history.snapshot()
+}
+
+// add stores a record, overwriting the oldest one when the buffer is full
+func (h *callHistory) add(record CallRecord) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.records[h.next] = record
+	h.next = (h.next + 1) % len(h.records)
+	if h.next == 0 {
+		h.full = true
+	}
+}
+
+// snapshot returns a copy of the stored records, oldest first
+func (h *callHistory) snapshot() []CallRecord {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if !h.full {
+		return append([]CallRecord(nil), h.records[:h.next]...)
+	}
+	out := make([]CallRecord, 0, len(h.records))
+	out = append(out, h.records[h.next:]...)
+	return append(out, h.records[:h.next]...)
+}
+
+// mask replaces the API key in s with its masked form
+func (cr *credentials) mask(s string) string {
+	if cr.apiKey == "" {
+		return s
+	}
+	return strings.ReplaceAll(s, cr.apiKey, maskAPIKey(cr.apiKey))
+}
+
+// recordCall adds a request attempt to the call history, if enabled, masking the API key
+func (c *Client) recordCall(creds *credentials, method, url string, start time.Time, statusCode int, err error) {
+	if c.history == nil {
+		return
+	}
+	record := CallRecord{
+		Time:       start,
+		Method:     method,
+		URL:        creds.mask(url),
+		StatusCode: statusCode,
+		Duration:   time.Since(start),
+	}
+	if err != nil {
+		record.Error = creds.mask(err.Error())
+	}
+	c.history.add(record)
+}
@@ -0,0 +1,112 @@
+package infura
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestCallHistory_WrapsAtCapacity(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/networks/3/") {
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte(`bad gateway`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	apiKey := "abcd1234efgh5678ijkl"
+	client := NewClientWithAPIKeyAndOptions(apiKey, WithBaseURL(server.URL), WithCallHistory(3))
+	if got := client.CallHistory(); len(got) != 0 {
+		t.Fatalf("Expected an empty history, got %v", got)
+	}
+
+	for chainID := int64(1); chainID <= 5; chainID++ {
+		client.GetBusyThreshold(context.Background(), chainID)
+	}
+
+	history := client.CallHistory()
+	if len(history) != 3 {
+		t.Fatalf("Expected 3 records, got %d", len(history))
+	}
+	for i, record := range history {
+		wantURL := fmt.Sprintf("%s/v3/abcd...ijkl/networks/%d/busyThreshold", server.URL, i+3)
+		if record.URL != wantURL {
+			t.Errorf("Record %d: expected URL %s, got %s", i, wantURL, record.URL)
+		}
+		if record.Method != "GET" || record.Time.IsZero() || record.Duration <= 0 {
+			t.Errorf("Record %d: unexpected record %+v", i, record)
+		}
+	}
+	if history[0].StatusCode != http.StatusBadGateway || !strings.Contains(history[0].Error, "502") {
+		t.Errorf("Expected the failed call first, got %+v", history[0])
+	}
+	if history[2].StatusCode != http.StatusOK || history[2].Error != "" {
+		t.Errorf("Expected a successful last call, got %+v", history[2])
+	}
+	for _, record := range history {
+		if strings.Contains(record.URL+record.Error, apiKey) {
+			t.Errorf("Expected the API key to be masked, got %+v", record)
+		}
+	}
+}
+
+func TestCallHistory_MasksKeyInTransportErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	apiKey := "abcd1234efgh5678ijkl"
+	client := NewClientWithAPIKeyAndOptions(apiKey, WithBaseURL(server.URL), WithCallHistory(2))
+	if _, err := client.GetBusyThreshold(context.Background(), 1); err == nil {
+		t.Fatal("Expected a transport error")
+	}
+
+	history := client.CallHistory()
+	if len(history) != 1 || history[0].StatusCode != 0 || history[0].Error == "" {
+		t.Fatalf("Expected one failed record, got %+v", history)
+	}
+	if strings.Contains(history[0].Error, apiKey) {
+		t.Errorf("Expected the API key to be masked in %q", history[0].Error)
+	}
+}
+
+func TestCallHistory_Concurrent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL), WithCallHistory(8))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client.GetBusyThreshold(context.Background(), 1)
+			client.CallHistory()
+		}()
+	}
+	wg.Wait()
+
+	if got := len(client.CallHistory()); got != 8 {
+		t.Errorf("Expected the history to stay bounded at 8, got %d", got)
+	}
+}
+
+func TestCallHistory_Disabled(t *testing.T) {
+	if got := NewClientWithAPIKey("test-api-key").CallHistory(); got != nil {
+		t.Errorf("Expected nil without WithCallHistory, got %v", got)
+	}
+	if _, err := New("test-api-key", "", WithCallHistory(0)); err == nil {
+		t.Error("Expected an error for a zero size")
+	}
+}
@@ -0,0 +1,78 @@
+package infura
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// ErrBelowLowFee is returned by EstimateInclusionTime, together with the low level's wait
+// estimates, when the offered fee is below the low level; the actual wait is at least that long
+var ErrBelowLowFee = errors.New("offered fee below the low level")
+
+// EstimateInclusionTime estimates the confirmation wait for a transaction offering
+// maxFeePerGasWei, based on the low/medium/high levels of fees
+//
+// The offer is positioned between the two neighbouring levels by suggestedMaxFeePerGas and
+// their minWaitTimeEstimate and maxWaitTimeEstimate are interpolated linearly. An offer at
+// a level returns that level's estimates. Offers above the high level are clamped to the
+// high estimates, since a higher fee cannot beat the next block. Offers below the low level
+// return the low estimates with an error wrapping ErrBelowLowFee, as they are lower bounds only.
+func EstimateInclusionTime(fees *SuggestedGasFees, maxFeePerGasWei *big.Int) (min, max time.Duration, err error) {
+	if fees == nil {
+		return 0, 0, errors.New("fees must not be nil")
+	}
+	if maxFeePerGasWei == nil || maxFeePerGasWei.Sign() <= 0 {
+		return 0, 0, fmt.Errorf("offered fee must be positive, got %v", maxFeePerGasWei)
+	}
+
+	levels := []GasFeeLevel{fees.Low, fees.Medium, fees.High}
+	prices := make([]*big.Int, len(levels))
+	for i, level := range levels {
+		price, err := ParseGweiToWei(level.SuggestedMaxFeePerGas)
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to parse %s maxFeePerGas: %w", Priority(i), err)
+		}
+		if i > 0 && price.Cmp(prices[i-1]) < 0 {
+			return 0, 0, fmt.Errorf("%s maxFeePerGas is below %s", Priority(i), Priority(i-1))
+		}
+		prices[i] = price
+	}
+
+	if maxFeePerGasWei.Cmp(prices[0]) < 0 {
+		min, max = waitEstimates(fees.Low)
+		return min, max, fmt.Errorf("%w: offered %s wei, low is %s wei", ErrBelowLowFee, maxFeePerGasWei, prices[0])
+	}
+
+	// Highest level the offer reaches
+	i := len(prices) - 1
+	for maxFeePerGasWei.Cmp(prices[i]) < 0 {
+		i--
+	}
+	if i == len(prices)-1 || maxFeePerGasWei.Cmp(prices[i]) == 0 {
+		min, max = waitEstimates(levels[i])
+		return min, max, nil
+	}
+
+	// prices[i] < offer < prices[i+1]
+	position, _ := new(big.Rat).SetFrac(
+		new(big.Int).Sub(maxFeePerGasWei, prices[i]),
+		new(big.Int).Sub(prices[i+1], prices[i]),
+	).Float64()
+	return interpolateDuration(levels[i].MinWaitTimeEstimate, levels[i+1].MinWaitTimeEstimate, position),
+		interpolateDuration(levels[i].MaxWaitTimeEstimate, levels[i+1].MaxWaitTimeEstimate, position),
+		nil
+}
+
+// waitEstimates returns the wait estimates of a level, which the API reports in milliseconds
+func waitEstimates(level GasFeeLevel) (min, max time.Duration) {
+	return time.Duration(level.MinWaitTimeEstimate) * time.Millisecond,
+		time.Duration(level.MaxWaitTimeEstimate) * time.Millisecond
+}
+
+// interpolateDuration returns the duration at position (0 to 1) between two millisecond values
+func interpolateDuration(fromMillis, toMillis int64, position float64) time.Duration {
+	millis := float64(fromMillis) + (float64(toMillis)-float64(fromMillis))*position
+	return time.Duration(millis * float64(time.Millisecond))
+}
@@ -0,0 +1,95 @@
+package infura
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+)
+
+var inclusionFees = &SuggestedGasFees{
+	Low:    GasFeeLevel{SuggestedMaxFeePerGas: "10", MinWaitTimeEstimate: 15000, MaxWaitTimeEstimate: 60000},
+	Medium: GasFeeLevel{SuggestedMaxFeePerGas: "20", MinWaitTimeEstimate: 15000, MaxWaitTimeEstimate: 45000},
+	High:   GasFeeLevel{SuggestedMaxFeePerGas: "40", MinWaitTimeEstimate: 5000, MaxWaitTimeEstimate: 15000},
+}
+
+func TestEstimateInclusionTime(t *testing.T) {
+	tests := []struct {
+		name    string
+		offer   string
+		wantMin time.Duration
+		wantMax time.Duration
+	}{
+		{"at low", "10", 15 * time.Second, 60 * time.Second},
+		{"between low and medium", "15", 15 * time.Second, 52500 * time.Millisecond},
+		{"quarter between low and medium", "12.5", 15 * time.Second, 56250 * time.Millisecond},
+		{"at medium", "20", 15 * time.Second, 45 * time.Second},
+		{"between medium and high", "30", 10 * time.Second, 30 * time.Second},
+		{"at high", "40", 5 * time.Second, 15 * time.Second},
+		{"above high", "400", 5 * time.Second, 15 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			min, max, err := EstimateInclusionTime(inclusionFees, mustWei(t, tt.offer))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if min != tt.wantMin || max != tt.wantMax {
+				t.Errorf("Expected %v-%v, got %v-%v", tt.wantMin, tt.wantMax, min, max)
+			}
+		})
+	}
+}
+
+func TestEstimateInclusionTime_BelowLow(t *testing.T) {
+	min, max, err := EstimateInclusionTime(inclusionFees, mustWei(t, "9.999999999"))
+	if !errors.Is(err, ErrBelowLowFee) {
+		t.Fatalf("Expected ErrBelowLowFee, got %v", err)
+	}
+	if min != 15*time.Second || max != 60*time.Second {
+		t.Errorf("Expected the low estimates as lower bounds, got %v-%v", min, max)
+	}
+}
+
+func TestEstimateInclusionTime_EqualLevels(t *testing.T) {
+	fees := *inclusionFees
+	fees.Medium.SuggestedMaxFeePerGas = "10"
+
+	min, max, err := EstimateInclusionTime(&fees, mustWei(t, "10"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if min != 15*time.Second || max != 45*time.Second {
+		t.Errorf("Expected the faster medium estimates, got %v-%v", min, max)
+	}
+}
+
+func TestEstimateInclusionTime_Invalid(t *testing.T) {
+	unordered := *inclusionFees
+	unordered.High.SuggestedMaxFeePerGas = "15"
+	unparseable := *inclusionFees
+	unparseable.Medium.SuggestedMaxFeePerGas = "abc"
+
+	tests := []struct {
+		name  string
+		fees  *SuggestedGasFees
+		offer *big.Int
+	}{
+		{"nil fees", nil, big.NewInt(1)},
+		{"nil offer", inclusionFees, nil},
+		{"zero offer", inclusionFees, big.NewInt(0)},
+		{"negative offer", inclusionFees, big.NewInt(-1)},
+		{"unordered levels", &unordered, big.NewInt(1)},
+		{"unparseable level", &unparseable, big.NewInt(1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := EstimateInclusionTime(tt.fees, tt.offer)
+			if err == nil || errors.Is(err, ErrBelowLowFee) {
+				t.Errorf("Expected a validation error, got %v", err)
+			}
+		})
+	}
+}
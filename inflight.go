@@ -0,0 +1,101 @@
+package infura
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// inFlightLimiter bounds the number of requests in flight with a semaphore
+type inFlightLimiter struct {
+	slots    chan struct{}
+	failFast bool
+}
+
+// WithMaxInFlight limits the client to n concurrent requests, whatever the method, batch
+// helper or watcher that sends them, e.g. to keep bursts from opening hundreds of
+// connections through a NAT gateway. Unlike WithRateLimit it bounds concurrency, not
+// requests per second; both can be combined. A request holds its slot from the moment it
+// is sent until its response body is read and closed, and a request waiting for a slot
+// gives up when its context is done. Calls to the RPC backend set with WithRPC, such as
+// GetGasPrice or GetLatestBaseFee, hold a slot until they return. See WithInFlightFailFast
+// to fail instead of waiting. A non-positive n is invalid.
+func WithMaxInFlight(n int) ClientOption {
+	return func(c *Client) {
+		if n <= 0 {
+			c.rejectOption("WithMaxInFlight", "limit must be positive, got %d", n)
+			return
+		}
+		failFast := c.inFlight != nil && c.inFlight.failFast
+		c.inFlight = &inFlightLimiter{slots: make(chan struct{}, n), failFast: failFast}
+	}
+}
+
+// WithInFlightFailFast makes requests fail with ErrTooManyInFlight instead of waiting when
+// the limit set with WithMaxInFlight is reached. ErrTooManyInFlight is not retried by
+// WithRetry. It has no effect without WithMaxInFlight.
+func WithInFlightFailFast() ClientOption {
+	return func(c *Client) {
+		if c.inFlight == nil {
+			// Kept for a limit set by a later option
+			c.inFlight = &inFlightLimiter{}
+		}
+		c.inFlight.failFast = true
+	}
+}
+
+// enabled reports whether requests are limited
+func (l *inFlightLimiter) enabled() bool {
+	return l != nil && l.slots != nil
+}
+
+// acquire takes a slot, waiting for one unless the limiter fails fast
+func (l *inFlightLimiter) acquire(ctx context.Context) error {
+	if !l.enabled() {
+		return nil
+	}
+	if l.failFast {
+		select {
+		case l.slots <- struct{}{}:
+			return nil
+		default:
+			return fmt.Errorf("%w: %d requests in flight", ErrTooManyInFlight, cap(l.slots))
+		}
+	}
+	select {
+	case l.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("in-flight limit wait failed: %w", ctx.Err())
+	}
+}
+
+// release returns a slot taken by acquire
+func (l *inFlightLimiter) release() {
+	if l.enabled() {
+		<-l.slots
+	}
+}
+
+// releaseOnClose returns body wrapped so that the slot is released when it is closed
+func (l *inFlightLimiter) releaseOnClose(body io.ReadCloser) io.ReadCloser {
+	if !l.enabled() {
+		return body
+	}
+	return &inFlightBody{ReadCloser: body, release: l.release}
+}
+
+// inFlightBody releases its in-flight slot once, when closed
+type inFlightBody struct {
+	io.ReadCloser
+	release func()
+	once    sync.Once
+}
+
+// Close closes the body and releases the slot
+func (b *inFlightBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
@@ -0,0 +1,189 @@
+package infura
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newConcurrencyServer returns a slow server recording the peak number of concurrent requests
+func newConcurrencyServer(t *testing.T, delay time.Duration, peak *atomic.Int64) *httptest.Server {
+	t.Helper()
+	var current atomic.Int64
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := current.Add(1)
+		defer current.Add(-1)
+		for {
+			old := peak.Load()
+			if n <= old || peak.CompareAndSwap(old, n) {
+				break
+			}
+		}
+		time.Sleep(delay)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"estimatedBaseFee": "10"}`))
+	}))
+}
+
+func TestWithMaxInFlight_BoundsConcurrency(t *testing.T) {
+	var peak atomic.Int64
+	server := newConcurrencyServer(t, 20*time.Millisecond, &peak)
+	defer server.Close()
+
+	const limit = 3
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithMaxInFlight(limit))
+
+	chainIDs := []int64{1, 10, 56, 137, 8453, 42161, 43114, 59144}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.GetSuggestedGasFees(context.Background(), 1); err != nil {
+				t.Errorf("GetSuggestedGasFees failed: %v", err)
+			}
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if _, err := client.GetSuggestedGasFeesBatch(context.Background(), chainIDs); err != nil {
+			t.Errorf("GetSuggestedGasFeesBatch failed: %v", err)
+		}
+	}()
+	wg.Wait()
+
+	if got := peak.Load(); got > limit {
+		t.Errorf("Expected at most %d requests in flight, observed %d", limit, got)
+	}
+	if got := peak.Load(); got < 2 {
+		t.Errorf("Expected requests to run concurrently, observed a peak of %d", got)
+	}
+}
+
+func TestWithMaxInFlight_WaitHonorsContext(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"estimatedBaseFee": "10"}`))
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithMaxInFlight(1))
+
+	go client.GetSuggestedGasFees(context.Background(), 1)
+	waitForInFlight(t, client, 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := client.GetSuggestedGasFees(ctx, 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestWithInFlightFailFast(t *testing.T) {
+	release := make(chan struct{})
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"estimatedBaseFee": "10"}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithInFlightFailFast(),
+		WithMaxInFlight(1),
+		WithRetry(3, time.Millisecond))
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.GetSuggestedGasFees(context.Background(), 1)
+		done <- err
+	}()
+	waitForInFlight(t, client, 1)
+
+	if _, err := client.GetSuggestedGasFees(context.Background(), 1); !errors.Is(err, ErrTooManyInFlight) {
+		t.Errorf("Expected ErrTooManyInFlight, got %v", err)
+	}
+	if got := requests.Load(); got > 1 {
+		t.Errorf("Expected the rejected call to send no request, got %d requests", got)
+	}
+
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatalf("GetSuggestedGasFees failed: %v", err)
+	}
+	if _, err := client.GetSuggestedGasFees(context.Background(), 1); err != nil {
+		t.Errorf("Expected the slot to be released, got %v", err)
+	}
+}
+
+// blockingRPC is an RPCCaller that returns "0x1" once release is closed
+type blockingRPC struct {
+	release chan struct{}
+}
+
+func (b *blockingRPC) CallRPC(ctx context.Context, chainID int64, method string, params []interface{}, result interface{}) error {
+	<-b.release
+	*result.(*string) = "0x1"
+	return nil
+}
+
+func TestWithMaxInFlight_CountsRPCCalls(t *testing.T) {
+	rpc := &blockingRPC{release: make(chan struct{})}
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithRPC(rpc),
+		WithInFlightFailFast(),
+		WithMaxInFlight(1))
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.GetGasPrice(context.Background(), 1)
+		done <- err
+	}()
+	waitForInFlight(t, client, 1)
+
+	if _, err := client.GetMaxPriorityFeePerGas(context.Background(), 1); !errors.Is(err, ErrTooManyInFlight) {
+		t.Errorf("Expected ErrTooManyInFlight while an RPC call holds the slot, got %v", err)
+	}
+
+	close(rpc.release)
+	if err := <-done; err != nil {
+		t.Fatalf("GetGasPrice failed: %v", err)
+	}
+	if got := len(client.inFlight.slots); got != 0 {
+		t.Errorf("Expected the slot to be released, got %d in flight", got)
+	}
+}
+
+func TestWithMaxInFlight_RejectsNonPositive(t *testing.T) {
+	if _, err := New("test-api-key", "", WithMaxInFlight(0)); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("Expected ErrInvalidOption, got %v", err)
+	}
+}
+
+// waitForInFlight waits until n slots of the client's in-flight limit are taken
+func waitForInFlight(t *testing.T, client *Client, n int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for len(client.inFlight.slots) < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected %d requests in flight", n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
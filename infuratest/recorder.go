@@ -0,0 +1,244 @@
+// Package infuratest provides test helpers for code using the infura client
+package infuratest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Mode selects whether a Recorder records live traffic or replays a cassette
+type Mode int
+
+const (
+	// ModeReplay serves responses from the cassette without touching the network
+	ModeReplay Mode = iota
+	// ModeRecord forwards requests to the real transport and records every interaction
+	ModeRecord
+)
+
+// String returns the name of the mode
+func (m Mode) String() string {
+	switch m {
+	case ModeReplay:
+		return "replay"
+	case ModeRecord:
+		return "record"
+	default:
+		return fmt.Sprintf("Mode(%d)", int(m))
+	}
+}
+
+// Interaction is a recorded request/response pair
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest identifies a request by method and path
+// The path includes the query string and has the API key segment replaced by "{apiKey}"
+type RecordedRequest struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+}
+
+// RecordedResponse is a recorded HTTP response
+// Body holds UTF-8 bodies verbatim; other bodies (e.g. compressed ones) are kept in BodyBase64
+type RecordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	BodyBase64 []byte      `json:"bodyBase64,omitempty"`
+}
+
+// cassette is the on-disk format of a Recorder
+type cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Recorder is an http.RoundTripper that records request/response pairs to a cassette file
+// and replays them, for use with infura.WithTransport
+//
+// Requests are matched by method and path (including the query string); the path segment
+// following "/v3/" is treated as the API key and ignored, so cassettes recorded with one key
+// replay with any other (or none). Request bodies and headers are not matched or stored.
+// Several interactions with the same key are replayed in recording order; once exhausted,
+// the last one is repeated.
+type Recorder struct {
+	path string
+	mode Mode
+	next http.RoundTripper
+
+	mu           sync.Mutex
+	interactions []Interaction
+	served       map[string]int
+}
+
+// NewRecorder creates a Recorder backed by the cassette file at path
+// In ModeReplay the cassette must exist. In ModeRecord requests are forwarded to next
+// (http.DefaultTransport if nil) and the cassette is written by Save.
+func NewRecorder(path string, mode Mode, next http.RoundTripper) (*Recorder, error) {
+	r := &Recorder{
+		path:   path,
+		mode:   mode,
+		next:   next,
+		served: make(map[string]int),
+	}
+	if r.next == nil {
+		r.next = http.DefaultTransport
+	}
+
+	switch mode {
+	case ModeReplay:
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cassette: %w", err)
+		}
+		var c cassette
+		if err := json.Unmarshal(data, &c); err != nil {
+			return nil, fmt.Errorf("failed to decode cassette %s: %w", path, err)
+		}
+		r.interactions = c.Interactions
+	case ModeRecord:
+	default:
+		return nil, fmt.Errorf("invalid recorder mode: %v", mode)
+	}
+
+	return r, nil
+}
+
+// Mode returns the mode of the recorder
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// RoundTrip implements http.RoundTripper
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	key := RecordedRequest{Method: req.Method, Path: normalizePath(req.URL)}
+	if r.mode == ModeReplay {
+		return r.replay(req, key)
+	}
+	return r.record(req, key)
+}
+
+// replay serves the next recorded response matching key
+func (r *Recorder) replay(req *http.Request, key RecordedRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var matches []int
+	for i, interaction := range r.interactions {
+		if interaction.Request == key {
+			matches = append(matches, i)
+		}
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("infuratest: no recorded interaction for %s %s", key.Method, key.Path)
+	}
+
+	id := key.Method + " " + key.Path
+	n := min(r.served[id], len(matches)-1)
+	r.served[id]++
+	return r.interactions[matches[n]].Response.toHTTP(req), nil
+}
+
+// record forwards the request and stores the interaction
+func (r *Recorder) record(req *http.Request, key RecordedRequest) (*http.Response, error) {
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	recorded := RecordedResponse{StatusCode: resp.StatusCode, Header: resp.Header.Clone()}
+	if utf8.Valid(body) {
+		recorded.Body = string(body)
+	} else {
+		recorded.BodyBase64 = body
+	}
+
+	r.mu.Lock()
+	r.interactions = append(r.interactions, Interaction{Request: key, Response: recorded})
+	r.mu.Unlock()
+
+	return recorded.toHTTP(req), nil
+}
+
+// Interactions returns a copy of the recorded or loaded interactions
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Interaction(nil), r.interactions...)
+}
+
+// Save writes the recorded interactions to the cassette file, creating parent directories
+// It does nothing in ModeReplay
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(cassette{Interactions: r.Interactions()}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode cassette: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create cassette directory: %w", err)
+	}
+	if err := os.WriteFile(r.path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	return nil
+}
+
+// toHTTP builds a fresh *http.Response for req
+func (rr RecordedResponse) toHTTP(req *http.Request) *http.Response {
+	body := rr.BodyBase64
+	if body == nil {
+		body = []byte(rr.Body)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", rr.StatusCode, http.StatusText(rr.StatusCode)),
+		StatusCode:    rr.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        rr.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// normalizePath returns the request path and query with the API key segment replaced
+func normalizePath(u *url.URL) string {
+	uri := u.RequestURI()
+	path, query, hasQuery := strings.Cut(uri, "?")
+
+	segments := strings.Split(path, "/")
+	for i := 0; i+1 < len(segments); i++ {
+		if segments[i] == "v3" {
+			segments[i+1] = "{apiKey}"
+			break
+		}
+	}
+	path = strings.Join(segments, "/")
+
+	if hasQuery {
+		return path + "?" + query
+	}
+	return path
+}
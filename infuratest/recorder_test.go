@@ -0,0 +1,147 @@
+package infuratest
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func get(t *testing.T, client *http.Client, url string) (int, string) {
+	t.Helper()
+	resp, err := client.Get(url)
+	if err != nil {
+		t.Fatalf("GET %s failed: %v", url, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Failed to read body: %v", err)
+	}
+	return resp.StatusCode, string(body)
+}
+
+func TestRecorder_RecordAndReplay(t *testing.T) {
+	var calls atomic.Int32
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		if strings.HasSuffix(r.URL.Path, "/missing") {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"not found"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Call", string(rune('0'+n)))
+		w.Write([]byte(`{"path":"` + r.URL.Path + `"}`))
+	}))
+	defer upstream.Close()
+
+	path := filepath.Join(t.TempDir(), "cassettes", "gas.json")
+
+	rec, err := NewRecorder(path, ModeRecord, nil)
+	if err != nil {
+		t.Fatalf("NewRecorder failed: %v", err)
+	}
+	recording := &http.Client{Transport: rec}
+	get(t, recording, upstream.URL+"/v3/secret-key/networks/1/suggestedGasFees")
+	get(t, recording, upstream.URL+"/v3/secret-key/networks/1/suggestedGasFees")
+	get(t, recording, upstream.URL+"/v3/secret-key/networks/1/missing")
+	get(t, recording, upstream.URL+"/networks/137/busyThreshold?block=latest")
+	if err := rec.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("Cassette not written: %v", err)
+	}
+	if got := rec.Interactions()[0].Request.Path; got != "/v3/{apiKey}/networks/1/suggestedGasFees" {
+		t.Errorf("Expected the API key to be stripped from recorded paths, got %s", got)
+	}
+
+	upstream.Close()
+	replayer, err := NewRecorder(path, ModeReplay, nil)
+	if err != nil {
+		t.Fatalf("NewRecorder(replay) failed: %v", err)
+	}
+	if got := len(replayer.Interactions()); got != 4 {
+		t.Fatalf("Expected 4 interactions, got %d", got)
+	}
+	replaying := &http.Client{Transport: replayer}
+
+	// A different API key matches the same recording; repeated requests replay in order
+	for _, want := range []string{"1", "2", "2"} {
+		resp, err := replaying.Get("http://replay.invalid/v3/other-key/networks/1/suggestedGasFees")
+		if err != nil {
+			t.Fatalf("Replay failed: %v", err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), "suggestedGasFees") {
+			t.Errorf("Unexpected replayed response: %d %s", resp.StatusCode, body)
+		}
+		if got := resp.Header.Get("X-Call"); got != want {
+			t.Errorf("Expected recorded call %s, got %s", want, got)
+		}
+	}
+
+	if status, _ := get(t, replaying, "http://replay.invalid/v3//networks/1/missing"); status != http.StatusNotFound {
+		t.Errorf("Expected recorded 404, got %d", status)
+	}
+	if _, body := get(t, replaying, "http://replay.invalid/networks/137/busyThreshold?block=latest"); !strings.Contains(body, "busyThreshold") {
+		t.Errorf("Unexpected body for Basic Auth path: %s", body)
+	}
+
+	if got := calls.Load(); got != 4 {
+		t.Errorf("Expected replay not to reach the upstream, got %d calls", got)
+	}
+}
+
+func TestRecorder_ReplayUnmatched(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "empty.json")
+	if err := os.WriteFile(path, []byte(`{"interactions":[]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	rec, err := NewRecorder(path, ModeReplay, nil)
+	if err != nil {
+		t.Fatalf("NewRecorder failed: %v", err)
+	}
+	_, err = (&http.Client{Transport: rec}).Get("http://replay.invalid/networks/1/suggestedGasFees")
+	if err == nil || !strings.Contains(err.Error(), "no recorded interaction for GET /networks/1/suggestedGasFees") {
+		t.Errorf("Expected unmatched request error, got %v", err)
+	}
+}
+
+func TestRecorder_ReplayMissingCassette(t *testing.T) {
+	_, err := NewRecorder(filepath.Join(t.TempDir(), "absent.json"), ModeReplay, nil)
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected os.ErrNotExist, got %v", err)
+	}
+}
+
+func TestRecorder_BinaryBody(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte{0x1f, 0x8b, 0xff, 0x00})
+	}))
+	defer upstream.Close()
+
+	path := filepath.Join(t.TempDir(), "binary.json")
+	rec, _ := NewRecorder(path, ModeRecord, nil)
+	get(t, &http.Client{Transport: rec}, upstream.URL+"/gz")
+	if err := rec.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	replayer, err := NewRecorder(path, ModeReplay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, body := get(t, &http.Client{Transport: replayer}, "http://replay.invalid/gz"); body != "\x1f\x8b\xff\x00" {
+		t.Errorf("Expected binary body to round-trip, got %q", body)
+	}
+}
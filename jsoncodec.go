@@ -0,0 +1,204 @@
+package infura
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"sync"
+)
+
+// Decoder encodes request bodies and decodes Gas API responses
+// The method signatures match encoding/json, so most third-party JSON libraries
+// (json-iterator, goccy/go-json, ...) can be plugged in with a small adapter.
+// Implementations must be safe for concurrent use and honour json.Unmarshaler,
+// which some response types implement.
+type Decoder interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// stdJSON is the default Decoder backed by encoding/json
+type stdJSON struct {
+	opts DecoderOptions
+}
+
+func (stdJSON) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (d stdJSON) Unmarshal(data []byte, v interface{}) error {
+	if !d.opts.UseNumber && !d.opts.DisallowUnknownFields {
+		return json.Unmarshal(data, v)
+	}
+
+	// Like json.Unmarshal, reject malformed input, including trailing data, before touching v;
+	// json.Unmarshal reports the same syntax error the check would
+	if !json.Valid(data) {
+		return json.Unmarshal(data, v)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if d.opts.UseNumber {
+		dec.UseNumber()
+	}
+	if d.opts.DisallowUnknownFields {
+		dec.DisallowUnknownFields()
+	}
+	return dec.Decode(v)
+}
+
+// PrecisionLossPolicy decides what happens when a response number does not fit in the
+// float64 it is decoded into (see DecoderOptions)
+type PrecisionLossPolicy int
+
+const (
+	// PrecisionLossIgnore keeps the rounded value silently (default)
+	PrecisionLossIgnore PrecisionLossPolicy = iota
+	// PrecisionLossWarn keeps the rounded value and logs a [WARN] line
+	PrecisionLossWarn
+	// PrecisionLossError fails the call with an error wrapping ErrPrecisionLoss
+	PrecisionLossError
+)
+
+// DecoderOptions configures how Gas API responses are decoded (see WithDecoderOptions)
+type DecoderOptions struct {
+	// UseNumber decodes numbers into interface{} values as json.Number instead of float64,
+	// e.g. with Get[map[string]interface{}]. Typed fields are unaffected: Congestion keeps
+	// the exact text of a value that was rounded regardless, see Congestion.Number.
+	UseNumber bool
+	// DisallowUnknownFields makes decoding strict: a response with a field the result type
+	// does not declare fails with a decode error instead of being ignored
+	DisallowUnknownFields bool
+	// PrecisionLoss decides what happens when a number such as networkCongestion has more
+	// precision than its float64 field holds
+	PrecisionLoss PrecisionLossPolicy
+}
+
+// WithJSONDecoder replaces encoding/json for request bodies and Gas API responses,
+// including cached ones. Debug output and RPC backends keep using encoding/json.
+func WithJSONDecoder(decoder Decoder) ClientOption {
+	return func(c *Client) {
+		if decoder == nil {
+			c.rejectOption("WithJSONDecoder", "decoder must not be nil")
+			return
+		}
+		c.json = decoder
+	}
+}
+
+// WithDecoderOptions configures decoding of Gas API responses: json.Number for untyped
+// values, strict decoding and the policy for numbers that lose precision as float64.
+//
+// Strict decoding and PrecisionLossError both surface as "failed to decode response"
+// errors, which are not retried, do not trigger failover and are not answered from a
+// stale cache entry: a response that a strict client rejects is rejected every time, so
+// enable DisallowUnknownFields only if you pin the API version, as Infura adds response
+// fields without notice. The precision check runs after decoding and applies with any
+// decoder; UseNumber and DisallowUnknownFields configure the default encoding/json decoder
+// and are rejected in combination with WithJSONDecoder.
+func WithDecoderOptions(opts DecoderOptions) ClientOption {
+	return func(c *Client) {
+		if opts.PrecisionLoss < PrecisionLossIgnore || opts.PrecisionLoss > PrecisionLossError {
+			c.rejectOption("WithDecoderOptions", "unknown precision loss policy %d", opts.PrecisionLoss)
+			return
+		}
+		c.decoderOpts = opts
+	}
+}
+
+// finalizeDecoder applies the options set with WithDecoderOptions to the default decoder
+func (c *Client) finalizeDecoder() {
+	opts := c.decoderOpts
+	if !opts.UseNumber && !opts.DisallowUnknownFields {
+		return
+	}
+	if _, ok := c.decoder().(stdJSON); !ok {
+		c.rejectOption("WithDecoderOptions", "UseNumber and DisallowUnknownFields cannot be combined with WithJSONDecoder; configure the custom decoder instead")
+		return
+	}
+	c.json = stdJSON{opts: opts}
+}
+
+// precisionReporter is implemented by response types with numbers that may be rounded
+// when decoded into a float64
+type precisionReporter interface {
+	// lossyFields describes every field whose decoded value was rounded
+	lossyFields() []string
+}
+
+// checkPrecision applies the PrecisionLoss policy to a decoded result
+func (c *Client) checkPrecision(result interface{}) error {
+	if c.decoderOpts.PrecisionLoss == PrecisionLossIgnore {
+		return nil
+	}
+	reporter, ok := result.(precisionReporter)
+	if !ok {
+		return nil
+	}
+	fields := reporter.lossyFields()
+	if len(fields) == 0 {
+		return nil
+	}
+	if c.decoderOpts.PrecisionLoss == PrecisionLossError {
+		return fmt.Errorf("failed to decode response: %w: %s", ErrPrecisionLoss, strings.Join(fields, ", "))
+	}
+	log.Printf("[WARN] Precision lost decoding Infura API response: %s\n", strings.Join(fields, ", "))
+	return nil
+}
+
+// lossyFields reports a networkCongestion value that was rounded to fit a float64
+func (f *SuggestedGasFees) lossyFields() []string {
+	if f.NetworkCongestion.IsExact() {
+		return nil
+	}
+	return []string{fmt.Sprintf("networkCongestion %s rounded to %v", f.NetworkCongestion.Number(), f.NetworkCongestion.Float64())}
+}
+
+// decoder returns the configured Decoder, or encoding/json for clients built without newClient
+func (c *Client) decoder() Decoder {
+	if c.json == nil {
+		return stdJSON{}
+	}
+	return c.json
+}
+
+// maxPooledBodySize is the largest read buffer returned to bodyBufferPool, so one huge
+// response does not pin its memory for the lifetime of the process
+const maxPooledBodySize = 1 << 20
+
+// bodyBufferPool holds the buffers successful responses are read into on the fast path
+var bodyBufferPool = sync.Pool{
+	New: func() interface{} { return new(bytes.Buffer) },
+}
+
+// pooledDecoding reports whether successful responses can be read into pooled buffers,
+// which requires the default decoder: encoding/json never retains the input, while a
+// custom decoder might
+func (c *Client) pooledDecoding() bool {
+	_, ok := c.decoder().(stdJSON)
+	return ok
+}
+
+// decodePooledBody reads a response body into a pooled buffer and decodes it into v
+// with decoder, which must not retain its input
+// The buffer is reused by later requests, so unlike the buffered path no copy of the
+// body outlives the call; the errors match the buffered path.
+func decodePooledBody(r io.Reader, decoder Decoder, v interface{}) error {
+	buf := bodyBufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer func() {
+		if buf.Cap() <= maxPooledBodySize {
+			bodyBufferPool.Put(buf)
+		}
+	}()
+
+	if _, err := buf.ReadFrom(r); err != nil {
+		return &transportError{err: err, readingBody: true}
+	}
+	if err := decoder.Unmarshal(buf.Bytes(), v); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
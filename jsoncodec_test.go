@@ -0,0 +1,323 @@
+package infura
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingDecoder wraps encoding/json and counts calls, optionally failing every Unmarshal
+type countingDecoder struct {
+	marshals   atomic.Int32
+	unmarshals atomic.Int32
+	failWith   error
+}
+
+func (d *countingDecoder) Marshal(v interface{}) ([]byte, error) {
+	d.marshals.Add(1)
+	return json.Marshal(v)
+}
+
+func (d *countingDecoder) Unmarshal(data []byte, v interface{}) error {
+	d.unmarshals.Add(1)
+	if d.failWith != nil {
+		return d.failWith
+	}
+	return json.Unmarshal(data, v)
+}
+
+func TestWithJSONDecoder_DecodesResponses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"low": {"suggestedMaxFeePerGas": "12"}, "networkCongestion": "0.4"}`))
+	}))
+	defer server.Close()
+
+	decoder := &countingDecoder{}
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL), WithJSONDecoder(decoder), WithCache(time.Minute))
+
+	var counts []int32
+	for i := 0; i < 2; i++ {
+		fees, err := client.GetSuggestedGasFees(context.Background(), 1)
+		if err != nil {
+			t.Fatalf("GetSuggestedGasFees failed: %v", err)
+		}
+		if fees.Low.SuggestedMaxFeePerGas != "12" || fees.NetworkCongestion.Float64() != 0.4 {
+			t.Errorf("Unexpected fees %+v", fees)
+		}
+		counts = append(counts, decoder.unmarshals.Load())
+	}
+	if counts[0] == 0 {
+		t.Fatal("Expected the response to be decoded with the custom decoder")
+	}
+	// The second call is served from the cache and must be decoded with the same decoder
+	if counts[1] != counts[0]+1 {
+		t.Errorf("Expected one Unmarshal call for the cached response, got %d", counts[1]-counts[0])
+	}
+}
+
+func TestWithJSONDecoder_MarshalsRequestBodies(t *testing.T) {
+	var received []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, _ = io.ReadAll(r.Body)
+		w.Write([]byte(`{"ok": true}`))
+	}))
+	defer server.Close()
+
+	decoder := &countingDecoder{}
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL), WithJSONDecoder(decoder))
+
+	var result struct {
+		OK bool `json:"ok"`
+	}
+	if err := client.doJSONRequest(context.Background(), "POST", "/echo", map[string]int{"n": 1}, &result); err != nil {
+		t.Fatalf("doJSONRequest failed: %v", err)
+	}
+	if !result.OK || string(received) != `{"n":1}` {
+		t.Errorf("Unexpected result %+v for body %s", result, received)
+	}
+	if decoder.marshals.Load() != 1 || decoder.unmarshals.Load() != 1 {
+		t.Errorf("Expected 1 Marshal and 1 Unmarshal call, got %d and %d", decoder.marshals.Load(), decoder.unmarshals.Load())
+	}
+}
+
+func TestWithJSONDecoder_WrapsDecodeErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"busyThreshold": "30"}`))
+	}))
+	defer server.Close()
+
+	errDecode := errors.New("decoder exploded")
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL), WithJSONDecoder(&countingDecoder{failWith: errDecode}))
+	if _, err := client.GetBusyThreshold(context.Background(), 1); !errors.Is(err, errDecode) {
+		t.Errorf("Expected the decoder error to be wrapped, got %v", err)
+	}
+}
+
+func TestWithJSONDecoder_RejectsNil(t *testing.T) {
+	if _, err := New("test-api-key", "", WithJSONDecoder(nil)); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("Expected ErrInvalidOption, got %v", err)
+	}
+	// Legacy constructors ignore the invalid option and keep encoding/json
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithJSONDecoder(nil))
+	if _, ok := client.decoder().(stdJSON); !ok {
+		t.Errorf("Expected the default decoder, got %T", client.decoder())
+	}
+}
+
+// bufferedJSON is encoding/json behind a type that disables the pooled fast path
+type bufferedJSON struct{ stdJSON }
+
+func TestDecodePooledBody_DoesNotAliasBuffer(t *testing.T) {
+	var first json.RawMessage
+	var fees SuggestedGasFees
+	if err := decodePooledBody(strings.NewReader(`{"estimatedBaseFee": "11"}`), stdJSON{}, &first); err != nil {
+		t.Fatalf("decodePooledBody failed: %v", err)
+	}
+	if err := decodePooledBody(strings.NewReader(`{"estimatedBaseFee": "22"}`), stdJSON{}, &fees); err != nil {
+		t.Fatalf("decodePooledBody failed: %v", err)
+	}
+	// Overwrite whatever buffer is pooled now
+	for i := 0; i < 10; i++ {
+		decodePooledBody(strings.NewReader(`{"estimatedBaseFee": "99"}`), stdJSON{}, &SuggestedGasFees{})
+	}
+	if string(first) != `{"estimatedBaseFee": "11"}` || fees.EstimatedBaseFee != "22" {
+		t.Errorf("Decoded values changed after the buffer was reused: %s, %q", first, fees.EstimatedBaseFee)
+	}
+}
+
+func TestDoJSONAttempt_PooledAndBufferedPathsAgree(t *testing.T) {
+	bodies := map[string]string{
+		"/v3/test-api-key/networks/1/suggestedGasFees": `{"low": {"suggestedMaxFeePerGas": "12", "minWaitTimeEstimate": 15000}, "networkCongestion": "0.4", "latestPriorityFeeRange": ["0.1", "2"]}`,
+		"/v3/test-api-key/networks/2/suggestedGasFees": `{"low": {}} trailing`,
+		"/v3/test-api-key/networks/3/suggestedGasFees": ``,
+		"/v3/test-api-key/networks/4/suggestedGasFees": `{"networkCongestion": null}` + "\n",
+		"/v3/test-api-key/networks/5/suggestedGasFees": `{"networkCongestion": "NaN"}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(bodies[r.URL.Path]))
+	}))
+	defer server.Close()
+
+	pooled := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL))
+	buffered := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL), WithJSONDecoder(bufferedJSON{}))
+	debugCtx := ContextWithDebugWriter(context.Background(), io.Discard)
+	if !pooled.pooledDecoding() || buffered.pooledDecoding() {
+		t.Fatal("Expected only the default client to use the pooled path")
+	}
+
+	for chainID := int64(1); chainID <= 5; chainID++ {
+		want, wantErr := buffered.GetSuggestedGasFees(context.Background(), chainID)
+		for name, ctx := range map[string]context.Context{
+			"pooled": context.Background(),
+			// Debug output needs the raw body, so the default client falls back to buffering
+			"debug": debugCtx,
+		} {
+			fees, err := pooled.GetSuggestedGasFees(ctx, chainID)
+			if fmt.Sprint(err) != fmt.Sprint(wantErr) {
+				t.Errorf("Chain %d, %s: error %v, buffered error %v", chainID, name, err, wantErr)
+				continue
+			}
+			if !reflect.DeepEqual(fees, want) {
+				t.Errorf("Chain %d, %s: decoded %+v, buffered decoded %+v", chainID, name, fees, want)
+			}
+		}
+	}
+}
+
+// BenchmarkDoJSONAttempt compares the pooled fast path with the buffered path on a large
+// baseFeeHistory response. Run with -benchmem: the pooled path allocates less because
+// the body is read into a reused buffer instead of a fresh one per request.
+func BenchmarkDoJSONAttempt(b *testing.B) {
+	history := make([]string, 5000)
+	for i := range history {
+		history[i] = fmt.Sprintf("%q", fmt.Sprintf("%d.%09d", 10+i%40, i))
+	}
+	body := []byte("[" + strings.Join(history, ",") + "]")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	for _, bc := range []struct {
+		name string
+		opts []ClientOption
+	}{
+		{"pooled", nil},
+		{"buffered", []ClientOption{WithJSONDecoder(bufferedJSON{})}},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			client := NewClientWithAPIKeyAndOptions("test-api-key", append([]ClientOption{WithBaseURL(server.URL)}, bc.opts...)...)
+			b.ReportAllocs()
+			b.SetBytes(int64(len(body)))
+			for i := 0; i < b.N; i++ {
+				result, err := client.GetBaseFeeHistory(context.Background(), 1)
+				if err != nil || len(result) != len(history) {
+					b.Fatalf("GetBaseFeeHistory returned %d entries, error %v", len(result), err)
+				}
+			}
+		})
+	}
+}
+
+// highPrecisionCongestion has more significant digits than a float64 holds
+const highPrecisionCongestion = "0.123456789012345678901234"
+
+// newBodyServer answers every request with body
+func newBodyServer(t *testing.T, body string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestWithDecoderOptions_UseNumber(t *testing.T) {
+	server := newBodyServer(t, `{"estimatedBaseFee": "1", "networkCongestion": `+highPrecisionCongestion+`}`)
+	client, err := New("test-api-key", "", WithBaseURL(server.URL), WithDecoderOptions(DecoderOptions{UseNumber: true}))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	fees, err := client.GetSuggestedGasFees(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetSuggestedGasFees failed: %v", err)
+	}
+	if got := fees.NetworkCongestion.Number(); got != highPrecisionCongestion {
+		t.Errorf("Expected the exact congestion %s, got %s", highPrecisionCongestion, got)
+	}
+	if fees.NetworkCongestion.IsExact() || fees.NetworkCongestion.Float64() != 0.12345678901234568 {
+		t.Errorf("Expected a rounded float64, got %v (exact %v)", fees.NetworkCongestion.Float64(), fees.NetworkCongestion.IsExact())
+	}
+
+	raw, err := Get[map[string]interface{}](context.Background(), client, 1, "suggestedGasFees", nil)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if got, ok := raw["networkCongestion"].(json.Number); !ok || got != highPrecisionCongestion {
+		t.Errorf("Expected json.Number %s, got %T %v", highPrecisionCongestion, raw["networkCongestion"], raw["networkCongestion"])
+	}
+}
+
+func TestWithDecoderOptions_PrecisionLoss(t *testing.T) {
+	lossy := newBodyServer(t, `{"networkCongestion": "`+highPrecisionCongestion+`"}`)
+	exact := newBodyServer(t, `{"networkCongestion": 0.40}`)
+
+	strict := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(lossy.URL), WithDecoderOptions(DecoderOptions{PrecisionLoss: PrecisionLossError}))
+	if _, err := strict.GetSuggestedGasFees(context.Background(), 1); !errors.Is(err, ErrPrecisionLoss) {
+		t.Errorf("Expected ErrPrecisionLoss, got %v", err)
+	}
+	// The cached path applies the same check
+	cached := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(lossy.URL), WithCache(time.Minute), WithDecoderOptions(DecoderOptions{PrecisionLoss: PrecisionLossError}))
+	if _, err := cached.GetSuggestedGasFees(context.Background(), 1); !errors.Is(err, ErrPrecisionLoss) {
+		t.Errorf("Expected ErrPrecisionLoss from the cached path, got %v", err)
+	}
+	strictExact := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(exact.URL), WithDecoderOptions(DecoderOptions{PrecisionLoss: PrecisionLossError}))
+	if _, err := strictExact.GetSuggestedGasFees(context.Background(), 1); err != nil {
+		t.Errorf("Expected 0.40 to decode without precision loss, got %v", err)
+	}
+
+	logs := captureLog(t)
+	warn := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(lossy.URL), WithDecoderOptions(DecoderOptions{PrecisionLoss: PrecisionLossWarn}))
+	fees, err := warn.GetSuggestedGasFees(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("Expected a warning only, got %v", err)
+	}
+	if fees.NetworkCongestion.Number() != highPrecisionCongestion {
+		t.Errorf("Expected the exact congestion to be kept, got %s", fees.NetworkCongestion.Number())
+	}
+	if !strings.Contains(logs.String(), "[WARN] Precision lost") || !strings.Contains(logs.String(), highPrecisionCongestion) {
+		t.Errorf("Expected a precision warning, got %q", logs.String())
+	}
+}
+
+func TestWithDecoderOptions_DisallowUnknownFields(t *testing.T) {
+	server := newBodyServer(t, `{"estimatedBaseFee": "1", "newField": true}`)
+
+	lenient := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL))
+	if _, err := lenient.GetSuggestedGasFees(context.Background(), 1); err != nil {
+		t.Fatalf("Expected unknown fields to be ignored by default, got %v", err)
+	}
+	strict := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL), WithDecoderOptions(DecoderOptions{DisallowUnknownFields: true}))
+	_, err := strict.GetSuggestedGasFees(context.Background(), 1)
+	if err == nil || !strings.Contains(err.Error(), `unknown field "newField"`) {
+		t.Errorf("Expected an unknown field error, got %v", err)
+	}
+}
+
+func TestWithDecoderOptions_Validation(t *testing.T) {
+	if _, err := New("test-api-key", "", WithDecoderOptions(DecoderOptions{PrecisionLoss: 7})); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("Expected ErrInvalidOption for an unknown policy, got %v", err)
+	}
+	_, err := New("test-api-key", "", WithJSONDecoder(&countingDecoder{}), WithDecoderOptions(DecoderOptions{UseNumber: true}))
+	if !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("Expected ErrInvalidOption for UseNumber with a custom decoder, got %v", err)
+	}
+	// The precision policy works with any decoder
+	if _, err := New("test-api-key", "", WithJSONDecoder(&countingDecoder{}), WithDecoderOptions(DecoderOptions{PrecisionLoss: PrecisionLossError})); err != nil {
+		t.Errorf("Expected PrecisionLoss to be accepted with a custom decoder, got %v", err)
+	}
+}
+
+func TestStdJSONWithOptions_MatchesUnmarshal(t *testing.T) {
+	decoder := stdJSON{opts: DecoderOptions{UseNumber: true}}
+	for _, body := range []string{`{"estimatedBaseFee": "1"}`, ``, `{"estimatedBaseFee": "1"} x`, `{"estimatedBaseFee": "1"}{}`, `{"estimatedBaseFee": "1"}` + "\n"} {
+		var got, want SuggestedGasFees
+		err := decoder.Unmarshal([]byte(body), &got)
+		wantErr := json.Unmarshal([]byte(body), &want)
+		if (err == nil) != (wantErr == nil) || !reflect.DeepEqual(got, want) {
+			t.Errorf("%q: decoded %+v (%v), json.Unmarshal decoded %+v (%v)", body, got, err, want, wantErr)
+		}
+	}
+}
@@ -0,0 +1,310 @@
+package infura
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultKeyCooldown is how long a pooled API key is quarantined after a rate limit or
+// authentication failure unless WithKeyCooldown is used
+const DefaultKeyCooldown = time.Minute
+
+// ErrAllKeysQuarantined indicates every key of the pool set with WithAPIKeyPool is cooling
+// down after a rate limit or authentication failure; no request was sent
+var ErrAllKeysQuarantined = errors.New("all API keys are quarantined")
+
+// Credential is an API Key with an optional API Key Secret
+// Keys with a secret use Basic Auth, keys without one put the key in the URL path
+type Credential struct {
+	APIKey       string
+	APIKeySecret string
+}
+
+// RotationStrategy selects the next key of a pool set with WithAPIKeyPool
+type RotationStrategy int
+
+const (
+	// RotateRoundRobin uses the available keys in turn
+	RotateRoundRobin RotationStrategy = iota
+	// RotateLeastUsed uses the available key that has sent the fewest requests
+	RotateLeastUsed
+)
+
+// String returns the strategy name
+func (s RotationStrategy) String() string {
+	switch s {
+	case RotateRoundRobin:
+		return "round-robin"
+	case RotateLeastUsed:
+		return "least-used"
+	default:
+		return fmt.Sprintf("RotationStrategy(%d)", int(s))
+	}
+}
+
+// KeyUsage is the usage of one key of the pool, as returned by KeyPoolUsage
+type KeyUsage struct {
+	// APIKey is the masked API Key
+	APIKey string
+	// Requests counts the request attempts sent with the key, retries included
+	Requests int64
+	// RateLimited and Unauthorized count the responses that quarantined the key
+	RateLimited  int64
+	Unauthorized int64
+	// QuarantinedUntil is when the key becomes available again, zero if it is available
+	QuarantinedUntil time.Time
+}
+
+// keyPool rotates requests across several credentials and quarantines failing keys
+type keyPool struct {
+	mu       sync.Mutex
+	strategy RotationStrategy
+	cooldown time.Duration
+	keys     []*pooledKey
+	next     int
+}
+
+// pooledKey is a key of the pool with its usage
+type pooledKey struct {
+	creds *credentials
+	usage KeyUsage
+}
+
+// WithAPIKeyPool spreads requests across several API keys of the same account, e.g. to
+// combine their rate limits. Each call picks a key with strategy; its retries and failover
+// attempts keep that key unless the failed attempt quarantined it, in which case they pick
+// another available key and stop when none is left.
+//
+// A key is quarantined for DefaultKeyCooldown (see WithKeyCooldown) when a response matches
+// ErrRateLimited or ErrUnauthorized: a 429, 401 or 403 status, or a known rate limit or
+// authentication message in any status (see APIError), such as a 403 "daily request count
+// exceeded". Traffic then shifts to the other keys; when every key is quarantined, calls
+// fail with ErrAllKeysQuarantined. Keys with and without a secret may be mixed, each using
+// its own auth mode unless WithAuthMode forces one.
+//
+// The pool replaces the credentials passed to the constructor for requests, including
+// after SetCredentials; AuthMode, APIKeyMasked and Config still describe those. Use
+// KeyPoolUsage to observe the per-key counters. An empty pool, a key without an API Key
+// or an unknown strategy is invalid.
+func WithAPIKeyPool(keys []Credential, strategy RotationStrategy) ClientOption {
+	return func(c *Client) {
+		if len(keys) == 0 {
+			c.rejectOption("WithAPIKeyPool", "pool must have at least one key")
+			return
+		}
+		if strategy != RotateRoundRobin && strategy != RotateLeastUsed {
+			c.rejectOption("WithAPIKeyPool", "unknown rotation strategy %v", strategy)
+			return
+		}
+		pool := &keyPool{strategy: strategy, cooldown: DefaultKeyCooldown}
+		if c.keyPool != nil {
+			pool.cooldown = c.keyPool.cooldown
+		}
+		for i, key := range keys {
+			if key.APIKey == "" {
+				c.rejectOption("WithAPIKeyPool", "key %d has no API Key", i)
+				return
+			}
+			pool.keys = append(pool.keys, &pooledKey{
+				creds: &credentials{apiKey: key.APIKey, apiKeySecret: key.APIKeySecret},
+				usage: KeyUsage{APIKey: maskAPIKey(key.APIKey)},
+			})
+		}
+		c.keyPool = pool
+	}
+}
+
+// WithKeyCooldown sets how long a key of the pool set with WithAPIKeyPool is quarantined
+// after a rate limit or authentication failure. It has no effect without a pool.
+func WithKeyCooldown(cooldown time.Duration) ClientOption {
+	return func(c *Client) {
+		if cooldown <= 0 {
+			c.rejectOption("WithKeyCooldown", "cool-down must be positive, got %v", cooldown)
+			return
+		}
+		if c.keyPool == nil {
+			// Kept for a pool set by a later option
+			c.keyPool = &keyPool{}
+		}
+		c.keyPool.cooldown = cooldown
+	}
+}
+
+// KeyPoolUsage returns the usage of each key of the pool set with WithAPIKeyPool, in the
+// order the keys were given, or nil without a pool
+func (c *Client) KeyPoolUsage() []KeyUsage {
+	if !c.hasKeyPool() {
+		return nil
+	}
+	return c.keyPool.snapshot(c.clock.Now())
+}
+
+// hasKeyPool reports whether requests rotate across a key pool
+func (c *Client) hasKeyPool() bool {
+	return c.keyPool != nil && len(c.keyPool.keys) > 0
+}
+
+// requestCredentials returns the credentials of the next call: a key of the pool, if
+// one is set, otherwise the client's credentials
+func (c *Client) requestCredentials() (*credentials, error) {
+	if !c.hasKeyPool() {
+		return c.credentials(), nil
+	}
+	return c.keyPool.pick(c.clock.Now())
+}
+
+// retryCredentials returns the credentials and endpoint of the next attempt of a call that
+// used creds. A pooled key quarantined by the failed attempt is replaced by another
+// available key and the endpoint rebuilt for it (see networkEndpoint); ok is false when
+// every key is quarantined. Other credentials, and endpoints of another shape, are kept.
+func (c *Client) retryCredentials(creds *credentials, endpoint string) (*credentials, string, bool) {
+	if !c.hasKeyPool() {
+		return creds, endpoint, true
+	}
+	now := c.clock.Now()
+	if !c.keyPool.quarantined(creds, now) {
+		return creds, endpoint, true
+	}
+	resource, ok := networkResourcePath(creds, endpoint)
+	if !ok {
+		return creds, endpoint, true
+	}
+	next, err := c.keyPool.pick(now)
+	if err != nil {
+		return creds, endpoint, false
+	}
+	if next.basicAuth() {
+		return next, resource, true
+	}
+	return next, "/v3/" + next.apiKey + resource, true
+}
+
+// networkResourcePath returns the /networks/... part of an endpoint built by networkEndpoint
+// for creds
+func networkResourcePath(creds *credentials, endpoint string) (string, bool) {
+	if !creds.basicAuth() {
+		var ok bool
+		if endpoint, ok = strings.CutPrefix(endpoint, "/v3/"+creds.apiKey); !ok {
+			return "", false
+		}
+	}
+	return endpoint, strings.HasPrefix(endpoint, "/networks/")
+}
+
+// quarantined reports whether creds is a key of the pool quarantined at now
+func (p *keyPool) quarantined(creds *credentials, now time.Time) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	key := p.find(creds)
+	return key != nil && now.Before(key.usage.QuarantinedUntil)
+}
+
+// pick selects an available key according to the strategy
+func (p *keyPool) pick(now time.Time) (*credentials, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	chosen := -1
+	for i := range p.keys {
+		idx := (p.next + i) % len(p.keys)
+		key := p.keys[idx]
+		if now.Before(key.usage.QuarantinedUntil) {
+			continue
+		}
+		if chosen < 0 || p.strategy == RotateLeastUsed && key.usage.Requests < p.keys[chosen].usage.Requests {
+			chosen = idx
+		}
+		if p.strategy == RotateRoundRobin {
+			break
+		}
+	}
+	if chosen < 0 {
+		return nil, p.quarantinedError(now)
+	}
+	p.next = (chosen + 1) % len(p.keys)
+	return p.keys[chosen].creds, nil
+}
+
+// quarantinedError describes a pool whose keys are all quarantined
+func (p *keyPool) quarantinedError(now time.Time) error {
+	soonest := p.keys[0].usage.QuarantinedUntil
+	for _, key := range p.keys[1:] {
+		if key.usage.QuarantinedUntil.Before(soonest) {
+			soonest = key.usage.QuarantinedUntil
+		}
+	}
+	return fmt.Errorf("%w: %d keys cooling down, next available in %v", ErrAllKeysQuarantined, len(p.keys), soonest.Sub(now))
+}
+
+// find returns the pool entry of creds, or nil for credentials not from the pool
+func (p *keyPool) find(creds *credentials) *pooledKey {
+	for _, key := range p.keys {
+		if key.creds == creds {
+			return key
+		}
+	}
+	return nil
+}
+
+// recordRequest counts a request attempt sent with creds
+func (p *keyPool) recordRequest(creds *credentials) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if key := p.find(creds); key != nil {
+		key.usage.Requests++
+	}
+}
+
+// recordResult quarantines the key of creds if err is a rate limit or authentication failure
+func (p *keyPool) recordResult(creds *credentials, err error, now time.Time) {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return
+	}
+	rateLimited := errors.Is(apiErr, ErrRateLimited)
+	unauthorized := errors.Is(apiErr, ErrUnauthorized)
+	if !rateLimited && !unauthorized {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	key := p.find(creds)
+	if key == nil {
+		return
+	}
+	if rateLimited {
+		key.usage.RateLimited++
+	} else {
+		key.usage.Unauthorized++
+	}
+	key.usage.QuarantinedUntil = now.Add(p.cooldown)
+}
+
+// snapshot returns a copy of the usage of every key
+func (p *keyPool) snapshot(now time.Time) []KeyUsage {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	usage := make([]KeyUsage, len(p.keys))
+	for i, key := range p.keys {
+		usage[i] = key.usage
+		if !now.Before(usage[i].QuarantinedUntil) {
+			usage[i].QuarantinedUntil = time.Time{}
+		}
+	}
+	return usage
+}
+
+// mask masks every API key of the client, including those of the pool, in s
+func (c *Client) mask(s string) string {
+	s = c.credentials().mask(s)
+	if c.hasKeyPool() {
+		for _, key := range c.keyPool.keys {
+			s = key.creds.mask(s)
+		}
+	}
+	return s
+}
@@ -0,0 +1,246 @@
+package infura
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// keyServer records the key of each request, taken from the path or the Basic Auth
+// header, and answers 429 for the keys in rateLimited
+type keyServer struct {
+	*httptest.Server
+	mu          sync.Mutex
+	keys        []string
+	rateLimited map[string]bool
+}
+
+func newKeyServer(t *testing.T) *keyServer {
+	t.Helper()
+	ks := &keyServer{rateLimited: make(map[string]bool)}
+	ks.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := "?"
+		if user, pass, ok := r.BasicAuth(); ok {
+			key = "basic:" + user + ":" + pass
+		} else if rest, ok := strings.CutPrefix(r.URL.Path, "/v3/"); ok {
+			key = "path:" + strings.SplitN(rest, "/", 2)[0]
+		}
+		ks.mu.Lock()
+		ks.keys = append(ks.keys, key)
+		limited := ks.rateLimited[key]
+		ks.mu.Unlock()
+		if limited {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"busyThreshold": "10"}`))
+	}))
+	t.Cleanup(ks.Close)
+	return ks
+}
+
+func (ks *keyServer) setRateLimited(key string, limited bool) {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	ks.rateLimited[key] = limited
+}
+
+func (ks *keyServer) takeKeys() []string {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	keys := ks.keys
+	ks.keys = nil
+	return keys
+}
+
+var pathKeys = []Credential{{APIKey: "key-one"}, {APIKey: "key-two"}, {APIKey: "key-three"}}
+
+func TestWithAPIKeyPool_RoundRobin(t *testing.T) {
+	server := newKeyServer(t)
+	client := NewClientWithOptions("unused-key", "", WithBaseURL(server.URL), WithAPIKeyPool(pathKeys, RotateRoundRobin))
+
+	for i := 0; i < 6; i++ {
+		if _, err := client.GetBusyThreshold(context.Background(), 1); err != nil {
+			t.Fatalf("GetBusyThreshold failed: %v", err)
+		}
+	}
+	want := []string{"path:key-one", "path:key-two", "path:key-three", "path:key-one", "path:key-two", "path:key-three"}
+	if got := server.takeKeys(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Keys used %v, want %v", got, want)
+	}
+	for i, usage := range client.KeyPoolUsage() {
+		if usage.Requests != 2 {
+			t.Errorf("Key %d: expected 2 requests, got %+v", i, usage)
+		}
+	}
+}
+
+func TestWithAPIKeyPool_QuarantineAndRecovery(t *testing.T) {
+	server := newKeyServer(t)
+	server.setRateLimited("path:key-two", true)
+	clock := newFakeClock(time.Unix(0, 0))
+	client := NewClientWithOptions("unused-key", "",
+		WithBaseURL(server.URL),
+		WithClock(clock),
+		WithAPIKeyPool(pathKeys, RotateRoundRobin),
+		WithKeyCooldown(30*time.Second))
+
+	var errs []error
+	for i := 0; i < 5; i++ {
+		_, err := client.GetBusyThreshold(context.Background(), 1)
+		errs = append(errs, err)
+	}
+	if !errors.Is(errs[1], ErrRateLimited) {
+		t.Errorf("Expected the second call to be rate limited, got %v", errs[1])
+	}
+	want := []string{"path:key-one", "path:key-two", "path:key-three", "path:key-one", "path:key-three"}
+	if got := server.takeKeys(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Keys used %v, want %v", got, want)
+	}
+	usage := client.KeyPoolUsage()
+	if usage[1].RateLimited != 1 || !usage[1].QuarantinedUntil.Equal(time.Unix(30, 0)) {
+		t.Errorf("Expected key two to be quarantined until 30s, got %+v", usage[1])
+	}
+	if usage[1].APIKey != "****" {
+		t.Errorf("Expected a masked key, got %q", usage[1].APIKey)
+	}
+
+	server.setRateLimited("path:key-two", false)
+	clock.Advance(30 * time.Second)
+	for i := 0; i < 3; i++ {
+		if _, err := client.GetBusyThreshold(context.Background(), 1); err != nil {
+			t.Fatalf("GetBusyThreshold failed: %v", err)
+		}
+	}
+	want = []string{"path:key-one", "path:key-two", "path:key-three"}
+	if got := server.takeKeys(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Keys used after the cool-down %v, want %v", got, want)
+	}
+	if usage := client.KeyPoolUsage(); !usage[1].QuarantinedUntil.IsZero() {
+		t.Errorf("Expected key two to be available again, got %+v", usage[1])
+	}
+}
+
+func TestWithAPIKeyPool_RetryPicksAnotherKey(t *testing.T) {
+	server := newKeyServer(t)
+	server.setRateLimited("path:key-one", true)
+	client := NewClientWithOptions("unused-key", "",
+		WithBaseURL(server.URL),
+		WithRetry(3, time.Millisecond),
+		WithAPIKeyPool(pathKeys, RotateRoundRobin))
+
+	if _, err := client.GetBusyThreshold(context.Background(), 1); err != nil {
+		t.Fatalf("Expected the retry to succeed with another key, got %v", err)
+	}
+	want := []string{"path:key-one", "path:key-two"}
+	if got := server.takeKeys(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected keys %v, got %v", want, got)
+	}
+}
+
+func TestWithAPIKeyPool_RetryStopsWhenAllQuarantined(t *testing.T) {
+	server := newKeyServer(t)
+	server.setRateLimited("path:key-one", true)
+	server.setRateLimited("path:key-two", true)
+	client := NewClientWithOptions("unused-key", "",
+		WithBaseURL(server.URL),
+		WithRetry(5, time.Millisecond),
+		WithAPIKeyPool(pathKeys[:2], RotateRoundRobin))
+
+	_, err := client.GetBusyThreshold(context.Background(), 1)
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("Expected ErrRateLimited, got %v", err)
+	}
+	want := []string{"path:key-one", "path:key-two"}
+	if got := server.takeKeys(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected keys %v, got %v", want, got)
+	}
+}
+
+func TestWithAPIKeyPool_AllQuarantined(t *testing.T) {
+	server := newKeyServer(t)
+	server.setRateLimited("path:key-one", true)
+	server.setRateLimited("path:key-two", true)
+	clock := newFakeClock(time.Unix(0, 0))
+	client := NewClientWithOptions("unused-key", "",
+		WithBaseURL(server.URL),
+		WithClock(clock),
+		WithAPIKeyPool(pathKeys[:2], RotateRoundRobin))
+
+	client.GetBusyThreshold(context.Background(), 1)
+	clock.Advance(10 * time.Second)
+	client.GetBusyThreshold(context.Background(), 1)
+
+	_, err := client.GetBusyThreshold(context.Background(), 1)
+	if !errors.Is(err, ErrAllKeysQuarantined) {
+		t.Fatalf("Expected ErrAllKeysQuarantined, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "2 keys cooling down, next available in 50s") {
+		t.Errorf("Expected a descriptive error, got %v", err)
+	}
+	if got := server.takeKeys(); len(got) != 2 {
+		t.Errorf("Expected no request while all keys are quarantined, got %v", got)
+	}
+}
+
+func TestWithAPIKeyPool_MixedAuthModes(t *testing.T) {
+	server := newKeyServer(t)
+	keys := []Credential{{APIKey: "basic-key", APIKeySecret: "basic-secret"}, {APIKey: "path-key"}}
+	client := NewClientWithOptions("unused-key", "", WithBaseURL(server.URL), WithAPIKeyPool(keys, RotateRoundRobin))
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetBusyThreshold(context.Background(), 1); err != nil {
+			t.Fatalf("GetBusyThreshold failed: %v", err)
+		}
+	}
+	want := []string{"basic:basic-key:basic-secret", "path:path-key"}
+	if got := server.takeKeys(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Keys used %v, want %v", got, want)
+	}
+}
+
+func TestWithAPIKeyPool_LeastUsed(t *testing.T) {
+	server := newKeyServer(t)
+	server.setRateLimited("path:key-one", true)
+	clock := newFakeClock(time.Unix(0, 0))
+	client := NewClientWithOptions("unused-key", "",
+		WithBaseURL(server.URL),
+		WithClock(clock),
+		WithAPIKeyPool(pathKeys[:2], RotateLeastUsed),
+		WithKeyCooldown(time.Second))
+
+	// key-one is quarantined after its first request, so key-two takes the next three
+	for i := 0; i < 4; i++ {
+		client.GetBusyThreshold(context.Background(), 1)
+	}
+	server.setRateLimited("path:key-one", false)
+	clock.Advance(time.Second)
+	// key-one has sent fewer requests and is used until it catches up; ties rotate
+	for i := 0; i < 3; i++ {
+		client.GetBusyThreshold(context.Background(), 1)
+	}
+	want := []string{"path:key-one", "path:key-two", "path:key-two", "path:key-two", "path:key-one", "path:key-one", "path:key-two"}
+	if got := server.takeKeys(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Keys used %v, want %v", got, want)
+	}
+}
+
+func TestWithAPIKeyPool_Invalid(t *testing.T) {
+	tests := map[string]ClientOption{
+		"empty pool":       WithAPIKeyPool(nil, RotateRoundRobin),
+		"missing key":      WithAPIKeyPool([]Credential{{APIKeySecret: "secret"}}, RotateRoundRobin),
+		"unknown strategy": WithAPIKeyPool(pathKeys, RotationStrategy(9)),
+		"zero cool-down":   WithKeyCooldown(0),
+	}
+	for name, opt := range tests {
+		if _, err := New("test-api-key", "", opt); !errors.Is(err, ErrInvalidOption) {
+			t.Errorf("%s: expected ErrInvalidOption, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package infura
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// CallMeta carries metadata about how a call was served
+// Attach it to a context with ContextWithCallMeta and inspect it after the call returns
+type CallMeta struct {
+	// Fallback is true when the result is a static fallback value configured with
+	// WithFallbackFees rather than live API data
+	Fallback bool
+	// Cached is true when the result was served from the response cache without a request
+	Cached bool
+	// Stale is true when a refresh failed and an older cached response was served instead
+	// (see WithServeStaleOnError)
+	Stale bool
+	// FetchedAt is when the returned data was fetched from the API
+	// Only set for cache-enabled clients
+	FetchedAt time.Time
+	// Deprecation is set when the response carried Sunset, Deprecation or Warning headers
+	Deprecation *DeprecationNotice
+	// NotModified is true when the API answered a conditional request with 304 Not Modified
+	// and the previously received response was returned (see WithConditionalRequests)
+	NotModified bool
+	// Clamped is true when at least one fee was lowered to the cap set with WithMaxFeeCap
+	Clamped bool
+	// ClockSkew is the server's Date minus the local time when the response arrived,
+	// measured when WithClockSkewCheck is set; positive means the local clock is behind
+	ClockSkew time.Duration
+	// TLS describes the TLS connection of the response, set when WithTLSInfo is used
+	TLS *TLSInfo
+}
+
+type callMetaKey struct{}
+
+// callMetaHolder guards a CallMeta so helpers issuing several requests concurrently
+// with the same context can record metadata safely
+type callMetaHolder struct {
+	mu   sync.Mutex
+	meta *CallMeta
+}
+
+// ContextWithCallMeta returns a context that records call metadata into meta
+// The metadata is written before the call returns; for helpers that issue several
+// requests, it reflects the last request that recorded it
+func ContextWithCallMeta(ctx context.Context, meta *CallMeta) context.Context {
+	return context.WithValue(ctx, callMetaKey{}, &callMetaHolder{meta: meta})
+}
+
+// recordCallMeta applies update to the CallMeta attached to ctx, if any
+func recordCallMeta(ctx context.Context, update func(meta *CallMeta)) {
+	holder, ok := ctx.Value(callMetaKey{}).(*callMetaHolder)
+	if !ok || holder.meta == nil {
+		return
+	}
+	holder.mu.Lock()
+	defer holder.mu.Unlock()
+	update(holder.meta)
+}
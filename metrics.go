@@ -0,0 +1,234 @@
+package infura
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// OpenMetricsContentType is the Content-Type to serve WriteMetrics output with
+const OpenMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+
+// latencyBuckets are the upper bounds in seconds of the request duration histogram
+var latencyBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// metricsRegistry holds per-endpoint request counters and latency histograms
+type metricsRegistry struct {
+	mu        sync.RWMutex
+	endpoints map[string]*endpointMetrics
+}
+
+// endpointMetrics is updated with atomics only, so observations never block each other
+type endpointMetrics struct {
+	requests atomic.Uint64
+	errors   atomic.Uint64
+	// buckets[i] counts requests no slower than latencyBuckets[i]; the last one is +Inf
+	buckets  [9]atomic.Uint64
+	sumNanos atomic.Uint64
+}
+
+// WithMetricsRegistry enables an internal registry of request counts, errors and latency
+// per endpoint, exported in OpenMetrics text format by WriteMetrics
+// This is a dependency-free alternative to wiring a Prometheus client.
+func WithMetricsRegistry() ClientOption {
+	return func(c *Client) {
+		c.metrics = &metricsRegistry{endpoints: make(map[string]*endpointMetrics)}
+	}
+}
+
+// RequestMetric describes a request attempt, as passed to MetricsHook.ObserveRequest
+type RequestMetric struct {
+	// Endpoint is the endpoint name (e.g. "suggestedGasFees"), as in WriteMetrics labels
+	Endpoint string
+	// ChainID is the chain of a /networks/{chainId}/ request, 0 for other requests
+	ChainID int64
+	// Attempt numbers the attempts of a call from 1, retries and failover URLs included
+	Attempt int
+	// StatusCode is the HTTP status of the response, 0 if none was received
+	StatusCode int
+	// Duration is the time until the response headers were received
+	Duration time.Duration
+	// Err is the transport error when no response was received
+	Err error
+	// Tags are the tags set on the call's context with ContextWithTags, nil if none
+	Tags map[string]string
+}
+
+// MetricsHook receives an observation of every request attempt, e.g. to export metrics
+// broken down by tenant through the caller's own metrics library
+type MetricsHook interface {
+	// ObserveRequest is called synchronously on the goroutine of the request, so it
+	// should return quickly
+	ObserveRequest(metric RequestMetric)
+}
+
+// WithMetricsHook passes an observation of every request attempt to hook, including the
+// tags set with ContextWithTags. It can be combined with WithMetricsRegistry. A nil hook
+// is invalid.
+func WithMetricsHook(hook MetricsHook) ClientOption {
+	return func(c *Client) {
+		if hook == nil {
+			c.rejectOption("WithMetricsHook", "hook must not be nil")
+			return
+		}
+		c.metricsHook = hook
+	}
+}
+
+// observeRequest passes a request attempt to the metrics hook, if one is configured
+func (c *Client) observeRequest(ctx context.Context, settings requestSettings, endpoint string, duration time.Duration, statusCode int, err error) {
+	if c.metricsHook == nil {
+		return
+	}
+	chainID, _ := chainIDOfEndpoint(endpoint)
+	c.metricsHook.ObserveRequest(RequestMetric{
+		Endpoint:   metricsEndpoint(endpoint),
+		ChainID:    chainID,
+		Attempt:    settings.priorAttempts + max(settings.attempt, 1),
+		StatusCode: statusCode,
+		Duration:   duration,
+		Err:        err,
+		Tags:       TagsFromContext(ctx),
+	})
+}
+
+// metricsEndpoint returns the endpoint label of a request path: its last segment without
+// the query string, so API keys in the path never end up in labels
+func metricsEndpoint(endpoint string) string {
+	path, _, _ := strings.Cut(endpoint, "?")
+	return path[strings.LastIndexByte(path, '/')+1:]
+}
+
+// endpoint returns the metrics of an endpoint, creating them on first use
+func (m *metricsRegistry) endpoint(endpoint string) *endpointMetrics {
+	name := metricsEndpoint(endpoint)
+	m.mu.RLock()
+	em, ok := m.endpoints[name]
+	m.mu.RUnlock()
+	if ok {
+		return em
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if em, ok = m.endpoints[name]; !ok {
+		em = &endpointMetrics{}
+		m.endpoints[name] = em
+	}
+	return em
+}
+
+// observeRequest records a request sent to endpoint and its latency
+func (m *metricsRegistry) observeRequest(endpoint string, latency time.Duration) {
+	if m == nil {
+		return
+	}
+	em := m.endpoint(endpoint)
+	em.requests.Add(1)
+	em.sumNanos.Add(uint64(latency))
+
+	seconds := latency.Seconds()
+	i := 0
+	for i < len(latencyBuckets) && seconds > latencyBuckets[i] {
+		i++
+	}
+	em.buckets[i].Add(1)
+}
+
+// observeError records a failed request attempt for endpoint
+func (m *metricsRegistry) observeError(endpoint string) {
+	if m == nil {
+		return
+	}
+	m.endpoint(endpoint).errors.Add(1)
+}
+
+// WriteMetrics writes the metrics collected by WithMetricsRegistry in OpenMetrics text format
+// Metrics are labelled by endpoint (e.g. "suggestedGasFees"):
+//   - infura_requests_total: requests sent, including retries
+//   - infura_request_errors_total: failed attempts (transport, status and decode errors)
+//   - infura_request_duration_seconds: histogram of the time until response headers arrive
+//
+// Without WithMetricsRegistry only the terminating "# EOF" line is written.
+func (c *Client) WriteMetrics(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	if c.metrics != nil {
+		c.metrics.write(bw)
+	}
+	bw.WriteString("# EOF\n")
+	return bw.Flush()
+}
+
+// write renders the registry in OpenMetrics text format
+func (m *metricsRegistry) write(w *bufio.Writer) {
+	m.mu.RLock()
+	names := make([]string, 0, len(m.endpoints))
+	for name := range m.endpoints {
+		names = append(names, name)
+	}
+	metrics := make([]*endpointMetrics, len(names))
+	slices.Sort(names)
+	for i, name := range names {
+		metrics[i] = m.endpoints[name]
+	}
+	m.mu.RUnlock()
+
+	w.WriteString("# TYPE infura_requests counter\n")
+	w.WriteString("# HELP infura_requests Requests sent to the Infura Gas API.\n")
+	for i, name := range names {
+		writeSample(w, "infura_requests_total", name, "", strconv.FormatUint(metrics[i].requests.Load(), 10))
+	}
+
+	w.WriteString("# TYPE infura_request_errors counter\n")
+	w.WriteString("# HELP infura_request_errors Failed request attempts.\n")
+	for i, name := range names {
+		writeSample(w, "infura_request_errors_total", name, "", strconv.FormatUint(metrics[i].errors.Load(), 10))
+	}
+
+	w.WriteString("# TYPE infura_request_duration_seconds histogram\n")
+	w.WriteString("# UNIT infura_request_duration_seconds seconds\n")
+	w.WriteString("# HELP infura_request_duration_seconds Time until response headers arrive.\n")
+	for i, name := range names {
+		em := metrics[i]
+		var cumulative uint64
+		for j := range em.buckets {
+			cumulative += em.buckets[j].Load()
+			le := "+Inf"
+			if j < len(latencyBuckets) {
+				le = strconv.FormatFloat(latencyBuckets[j], 'f', -1, 64)
+			}
+			writeSample(w, "infura_request_duration_seconds_bucket", name, le, strconv.FormatUint(cumulative, 10))
+		}
+		writeSample(w, "infura_request_duration_seconds_count", name, "", strconv.FormatUint(cumulative, 10))
+		sum := time.Duration(em.sumNanos.Load()).Seconds()
+		writeSample(w, "infura_request_duration_seconds_sum", name, "", strconv.FormatFloat(sum, 'f', -1, 64))
+	}
+}
+
+// writeSample writes one sample line with an endpoint label and an optional le label
+func writeSample(w *bufio.Writer, metric, endpoint, le, value string) {
+	w.WriteString(metric)
+	w.WriteString(`{endpoint="`)
+	w.WriteString(escapeLabelValue(endpoint))
+	if le != "" {
+		w.WriteString(`",le="`)
+		w.WriteString(le)
+	}
+	w.WriteString(`"} `)
+	w.WriteString(value)
+	w.WriteByte('\n')
+}
+
+// escapeLabelValue escapes backslashes, quotes and newlines in a label value
+func escapeLabelValue(s string) string {
+	if !strings.ContainsAny(s, "\\\"\n") {
+		return s
+	}
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
@@ -0,0 +1,117 @@
+package infura
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteMetrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/busyThreshold") {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("secret-api-key", WithBaseURL(server.URL), WithMetricsRegistry())
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		if _, err := client.GetSuggestedGasFees(ctx, 1); err != nil {
+			t.Fatalf("GetSuggestedGasFees failed: %v", err)
+		}
+	}
+	if _, err := client.GetBusyThreshold(ctx, 137); err == nil {
+		t.Fatal("Expected GetBusyThreshold to fail")
+	}
+
+	var buf bytes.Buffer
+	if err := client.WriteMetrics(&buf); err != nil {
+		t.Fatalf("WriteMetrics failed: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"# TYPE infura_requests counter\n",
+		`infura_requests_total{endpoint="suggestedGasFees"} 3` + "\n",
+		`infura_requests_total{endpoint="busyThreshold"} 1` + "\n",
+		`infura_request_errors_total{endpoint="suggestedGasFees"} 0` + "\n",
+		`infura_request_errors_total{endpoint="busyThreshold"} 1` + "\n",
+		"# TYPE infura_request_duration_seconds histogram\n",
+		`infura_request_duration_seconds_bucket{endpoint="suggestedGasFees",le="+Inf"} 3` + "\n",
+		`infura_request_duration_seconds_count{endpoint="suggestedGasFees"} 3` + "\n",
+		`infura_request_duration_seconds_count{endpoint="busyThreshold"} 1` + "\n",
+		`infura_request_duration_seconds_sum{endpoint="busyThreshold"} `,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+	if !strings.HasSuffix(out, "# EOF\n") {
+		t.Errorf("Expected output to end with # EOF, got:\n%s", out)
+	}
+	if strings.Contains(out, "secret-api-key") {
+		t.Error("Expected the API key not to appear in metrics")
+	}
+
+	// Bucket counts are cumulative
+	last := 0
+	for _, line := range strings.Split(out, "\n") {
+		if strings.HasPrefix(line, `infura_request_duration_seconds_bucket{endpoint="suggestedGasFees"`) {
+			value, err := strconv.Atoi(line[strings.LastIndexByte(line, ' ')+1:])
+			if err != nil || value < last {
+				t.Errorf("Expected cumulative buckets, got %q after %d", line, last)
+			}
+			last = value
+		}
+	}
+}
+
+func TestWriteMetrics_Disabled(t *testing.T) {
+	client := NewClientWithAPIKey("test-api-key")
+
+	var buf bytes.Buffer
+	if err := client.WriteMetrics(&buf); err != nil {
+		t.Fatalf("WriteMetrics failed: %v", err)
+	}
+	if buf.String() != "# EOF\n" {
+		t.Errorf("Expected only # EOF, got %q", buf.String())
+	}
+}
+
+func TestMetricsRegistry_Buckets(t *testing.T) {
+	m := &metricsRegistry{endpoints: make(map[string]*endpointMetrics)}
+	m.observeRequest("/v3/key/networks/1/suggestedGasFees?block=latest", 10*time.Millisecond)
+	m.observeRequest("/networks/1/suggestedGasFees", 100*time.Millisecond)
+	m.observeRequest("/networks/1/suggestedGasFees", 30*time.Second)
+
+	em := m.endpoints["suggestedGasFees"]
+	if em == nil || len(m.endpoints) != 1 {
+		t.Fatalf("Expected a single suggestedGasFees endpoint, got %v", m.endpoints)
+	}
+	if em.buckets[0].Load() != 1 || em.buckets[1].Load() != 1 || em.buckets[len(latencyBuckets)].Load() != 1 {
+		t.Errorf("Unexpected bucket counts")
+	}
+}
+
+func TestMetricsRegistry_ObserveAllocations(t *testing.T) {
+	m := &metricsRegistry{endpoints: make(map[string]*endpointMetrics)}
+	m.observeRequest("/networks/1/suggestedGasFees", time.Millisecond)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		m.observeRequest("/networks/1/suggestedGasFees", time.Millisecond)
+		m.observeError("/networks/1/suggestedGasFees")
+	})
+	if allocs != 0 {
+		t.Errorf("Expected no allocations on the hot path, got %v", allocs)
+	}
+}
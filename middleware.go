@@ -0,0 +1,42 @@
+package infura
+
+import "net/http"
+
+// WithTransportMiddleware wraps the transport the client ends up using, whether the
+// default, the Transport of a client passed to WithHTTPClient or one set with
+// WithTransport, in the given middleware. The first middleware is the outermost: it sees
+// each request first and its response last. Middleware runs after the client has built
+// the request, so it sees the final URL and headers, including the Authorization header.
+//
+// Repeated calls append to the chain. Middleware does not replace the transport, so it
+// may be combined with WithUseProvidedTransportAsIs; the HTTP client is copied, so a
+// client passed to WithHTTPClient is not modified. A nil middleware is invalid.
+func WithTransportMiddleware(mw ...func(http.RoundTripper) http.RoundTripper) ClientOption {
+	return func(c *Client) {
+		for _, m := range mw {
+			if m == nil {
+				c.rejectOption("WithTransportMiddleware", "middleware must not be nil")
+				return
+			}
+		}
+		c.middleware = append(c.middleware, mw...)
+	}
+}
+
+// finalizeMiddleware wraps the final transport in the middleware chain once all options
+// are applied. The HTTP client is copied before it is changed
+func (c *Client) finalizeMiddleware() {
+	if len(c.middleware) == 0 {
+		return
+	}
+	transport := c.httpClient.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		transport = c.middleware[i](transport)
+	}
+	httpClient := *c.httpClient
+	httpClient.Transport = transport
+	c.httpClient = &httpClient
+}
@@ -0,0 +1,140 @@
+package infura
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// tracingMiddleware records when it sees a request and its response, and the
+// Authorization header of the request
+type tracingMiddleware struct {
+	name  string
+	mu    *sync.Mutex
+	trace *[]string
+}
+
+func (m tracingMiddleware) wrap(next http.RoundTripper) http.RoundTripper {
+	return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		m.record(m.name + " request auth=" + req.Header.Get("Authorization"))
+		resp, err := next.RoundTrip(req)
+		m.record(m.name + " response")
+		return resp, err
+	})
+}
+
+func (m tracingMiddleware) record(event string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	*m.trace = append(*m.trace, event)
+}
+
+func TestWithTransportMiddleware_Order(t *testing.T) {
+	server := newDebugServer()
+	defer server.Close()
+
+	var (
+		mu    sync.Mutex
+		trace []string
+	)
+	outer := tracingMiddleware{name: "outer", mu: &mu, trace: &trace}
+	inner := tracingMiddleware{name: "inner", mu: &mu, trace: &trace}
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		outer.record("transport")
+		return http.DefaultTransport.RoundTrip(req)
+	})
+
+	shared := &http.Client{Transport: transport}
+	client, err := New("test-api-key", "test-api-secret",
+		WithBaseURL(server.URL),
+		WithTransportMiddleware(outer.wrap),
+		WithHTTPClient(shared),
+		WithTransportMiddleware(inner.wrap),
+		WithUseProvidedTransportAsIs())
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	if _, err := client.GetBusyThreshold(context.Background(), 1); err != nil {
+		t.Fatalf("GetBusyThreshold failed: %v", err)
+	}
+
+	auth := (&credentials{apiKey: "test-api-key", apiKeySecret: "test-api-secret"}).authHeader()
+	want := []string{
+		"outer request auth=" + auth,
+		"inner request auth=" + auth,
+		"transport",
+		"inner response",
+		"outer response",
+	}
+	if strings.Join(trace, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Expected trace\n%s\ngot\n%s", strings.Join(want, "\n"), strings.Join(trace, "\n"))
+	}
+
+	// The client passed to WithHTTPClient still uses its own transport only
+	trace = nil
+	resp, err := shared.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	resp.Body.Close()
+	if len(trace) != 1 || trace[0] != "transport" {
+		t.Errorf("Expected the shared client to use only its transport, got %q", trace)
+	}
+}
+
+func TestWithTransportMiddleware_WrapsWithTransport(t *testing.T) {
+	var wrapped bool
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(`{"busyThreshold": "42"}`)),
+			Request:    req,
+		}, nil
+	})
+	mw := func(next http.RoundTripper) http.RoundTripper {
+		return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			wrapped = true
+			return next.RoundTrip(req)
+		})
+	}
+
+	// The middleware wraps the transport set later, not the one in place when it was given
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithTransportMiddleware(mw), WithTransport(transport))
+	result, err := client.GetBusyThreshold(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetBusyThreshold failed: %v", err)
+	}
+	if !wrapped || result.BusyThreshold != "42" {
+		t.Errorf("Expected the middleware to wrap the transport and busy threshold 42, got %v and %q", wrapped, result.BusyThreshold)
+	}
+}
+
+func TestWithTransportMiddleware_ShortCircuit(t *testing.T) {
+	denied := errors.New("egress denied")
+	deny := func(http.RoundTripper) http.RoundTripper {
+		return roundTripFunc(func(*http.Request) (*http.Response, error) {
+			return nil, denied
+		})
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Expected the request not to reach the server")
+	}))
+	defer server.Close()
+
+	client := NewClientWithOptions("test-api-key", "", WithBaseURL(server.URL), WithTransportMiddleware(deny))
+	if _, err := client.GetBusyThreshold(context.Background(), 1); !errors.Is(err, denied) {
+		t.Errorf("Expected the middleware error, got %v", err)
+	}
+}
+
+func TestWithTransportMiddleware_RejectsNil(t *testing.T) {
+	if _, err := New("test-api-key", "", WithTransportMiddleware(nil)); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("Expected ErrInvalidOption, got %v", err)
+	}
+}
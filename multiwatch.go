@@ -0,0 +1,144 @@
+package infura
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ChainUpdate is a single poll result emitted by WatchMany
+// It is the same type as WatchEvent, so multi-chain streams can be passed to SmoothFeeStream
+type ChainUpdate = WatchEvent
+
+// MultiWatcher polls suggestedGasFees for several chains from a single goroutine
+// Within each interval, the chains are polled one after another with the interval divided
+// evenly between them, so requests are staggered rather than fired simultaneously. All
+// requests go through the client's regular request path, so rate limits apply.
+type MultiWatcher struct {
+	client   *Client
+	interval time.Duration
+	updates  chan ChainUpdate
+	wake     chan struct{}
+
+	mu    sync.Mutex
+	queue []int64
+}
+
+// WatchMany polls suggestedGasFees for every chain in chainIDs, spreading the requests
+// evenly over each interval, and emits the results on the returned channel.
+// Poll errors are delivered as updates with Err set and do not stop the watcher.
+// The channel is closed when ctx is cancelled. Use NewMultiWatcher to add or remove chains
+// while watching.
+func (c *Client) WatchMany(ctx context.Context, chainIDs []int64, interval time.Duration) (<-chan ChainUpdate, error) {
+	if len(chainIDs) == 0 {
+		return nil, fmt.Errorf("at least one chain ID is required")
+	}
+	w, err := c.NewMultiWatcher(ctx, chainIDs, interval)
+	if err != nil {
+		return nil, err
+	}
+	return w.Updates(), nil
+}
+
+// NewMultiWatcher starts a MultiWatcher for the given chains (which may be empty)
+// Chains are polled in the given order; duplicates are ignored
+func (c *Client) NewMultiWatcher(ctx context.Context, chainIDs []int64, interval time.Duration) (*MultiWatcher, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("watch interval must be positive, got %v", interval)
+	}
+
+	w := &MultiWatcher{
+		client:   c,
+		interval: interval,
+		updates:  make(chan ChainUpdate),
+		wake:     make(chan struct{}, 1),
+	}
+	for _, chainID := range chainIDs {
+		if !slices.Contains(w.queue, chainID) {
+			w.queue = append(w.queue, chainID)
+		}
+	}
+
+	go w.run(ctx)
+	return w, nil
+}
+
+// Updates returns the channel of poll results; it is closed when the watcher's context is done
+func (w *MultiWatcher) Updates() <-chan ChainUpdate {
+	return w.updates
+}
+
+// Add starts watching chainID; it is polled next. Adding a watched chain has no effect.
+func (w *MultiWatcher) Add(chainID int64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if slices.Contains(w.queue, chainID) {
+		return
+	}
+	w.queue = append([]int64{chainID}, w.queue...)
+
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Remove stops watching chainID
+func (w *MultiWatcher) Remove(chainID int64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.queue = slices.DeleteFunc(w.queue, func(id int64) bool { return id == chainID })
+}
+
+// Chains returns the watched chain IDs in polling order
+func (w *MultiWatcher) Chains() []int64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return slices.Clone(w.queue)
+}
+
+// next rotates the queue and returns the chain to poll and the delay before the following poll
+func (w *MultiWatcher) next() (int64, time.Duration, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.queue) == 0 {
+		return 0, 0, false
+	}
+	chainID := w.queue[0]
+	w.queue = append(w.queue[1:], chainID)
+	return chainID, w.interval / time.Duration(len(w.queue)), true
+}
+
+func (w *MultiWatcher) run(ctx context.Context) {
+	defer close(w.updates)
+
+	for {
+		chainID, delay, ok := w.next()
+		if !ok {
+			// Nothing to watch until a chain is added
+			select {
+			case <-w.wake:
+				continue
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		update := w.client.pollSuggestedGasFees(ctx, chainID)
+		select {
+		case w.updates <- update:
+		case <-ctx.Done():
+			return
+		}
+
+		select {
+		case <-w.client.clock.After(delay):
+		case <-w.wake:
+			// A newly added chain is polled right away
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package infura
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// newChainEchoServer serves suggestedGasFees whose estimatedBaseFee is the requested chain ID
+func newChainEchoServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	chainPattern := regexp.MustCompile(`/networks/(\d+)/`)
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		match := chainPattern.FindStringSubmatch(r.URL.Path)
+		if match == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(SuggestedGasFees{EstimatedBaseFee: match[1]})
+	}))
+}
+
+// nextUpdate reads one update, then advances the fake clock by step once the watcher sleeps
+func nextUpdate(t *testing.T, clock *fakeClock, updates <-chan ChainUpdate, step time.Duration) ChainUpdate {
+	t.Helper()
+	update, ok := <-updates
+	if !ok {
+		t.Fatal("Updates channel closed unexpectedly")
+	}
+	waitForWaiters(t, clock, 1)
+	clock.Advance(step)
+	return update
+}
+
+func TestWatchMany_StaggeredUpdates(t *testing.T) {
+	server := newChainEchoServer(t)
+	defer server.Close()
+
+	start := time.Unix(0, 0)
+	clock := newFakeClock(start)
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL), WithClock(clock))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	updates, err := client.WatchMany(ctx, []int64{1, 10, 137, 10}, time.Minute)
+	if err != nil {
+		t.Fatalf("WatchMany failed: %v", err)
+	}
+
+	wantChains := []int64{1, 10, 137, 1, 10, 137}
+	for i, wantChain := range wantChains {
+		update := nextUpdate(t, clock, updates, 20*time.Second)
+		if update.Err != nil {
+			t.Fatalf("update %d: unexpected error: %v", i, update.Err)
+		}
+		if update.ChainID != wantChain {
+			t.Errorf("update %d: expected chain %d, got %d", i, wantChain, update.ChainID)
+		}
+		if update.Fees.EstimatedBaseFee != strconv.FormatInt(wantChain, 10) {
+			t.Errorf("update %d: expected fees of chain %d, got %s", i, wantChain, update.Fees.EstimatedBaseFee)
+		}
+		// One minute split across three chains: a poll every 20 seconds
+		if wantTime := start.Add(time.Duration(i) * 20 * time.Second); !update.Time.Equal(wantTime) {
+			t.Errorf("update %d: expected time %v, got %v", i, wantTime, update.Time)
+		}
+	}
+
+	cancel()
+	for range updates {
+	}
+}
+
+func TestMultiWatcher_AddRemove(t *testing.T) {
+	server := newChainEchoServer(t)
+	defer server.Close()
+
+	clock := newFakeClock(time.Unix(0, 0))
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL), WithClock(clock))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	watcher, err := client.NewMultiWatcher(ctx, []int64{1, 10}, time.Minute)
+	if err != nil {
+		t.Fatalf("NewMultiWatcher failed: %v", err)
+	}
+	updates := watcher.Updates()
+
+	if got := nextUpdate(t, clock, updates, 30*time.Second).ChainID; got != 1 {
+		t.Fatalf("Expected chain 1 first, got %d", got)
+	}
+
+	// Chain 10 is polled next; then remove 1 and add 137 before the following poll
+	update := <-updates
+	if update.ChainID != 10 {
+		t.Fatalf("Expected chain 10, got %d", update.ChainID)
+	}
+	waitForWaiters(t, clock, 1)
+	watcher.Remove(1)
+	watcher.Add(137)
+
+	// The added chain is polled right away; the interrupted timer is still pending
+	if update := <-updates; update.ChainID != 137 {
+		t.Fatalf("Expected added chain 137 to be polled next, got %d", update.ChainID)
+	}
+	waitForWaiters(t, clock, 2)
+	clock.Advance(30 * time.Second)
+
+	var got []int64
+	for i := 0; i < 3; i++ {
+		got = append(got, nextUpdate(t, clock, updates, 30*time.Second).ChainID)
+	}
+	if want := []int64{10, 137, 10}; !slices.Equal(got, want) {
+		t.Errorf("Expected %v after Add/Remove, got %v", want, got)
+	}
+	if chains := watcher.Chains(); len(chains) != 2 || slices.Contains(chains, 1) {
+		t.Errorf("Expected chains 10 and 137, got %v", chains)
+	}
+}
+
+func TestWatchMany_InvalidArguments(t *testing.T) {
+	client := NewClientWithAPIKey("test-api-key")
+
+	if _, err := client.WatchMany(context.Background(), nil, time.Second); err == nil {
+		t.Error("Expected error for empty chain list")
+	}
+	if _, err := client.WatchMany(context.Background(), []int64{1}, 0); err == nil {
+		t.Error("Expected error for non-positive interval")
+	}
+}
@@ -0,0 +1,59 @@
+package infura
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"syscall"
+)
+
+// IsTimeout reports whether err is a timeout: the client timeout (WithTimeout), a network
+// timeout reported as a net.Error, or an expired context deadline
+func IsTimeout(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, os.ErrDeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// IsTemporary reports whether err is a network failure that may go away if the request
+// is repeated: a timeout (see IsTimeout), a connection reset or broken pipe, a connection
+// closed before or while the response was read (EOF or unexpected EOF), or a temporary
+// DNS failure. Failures that repeating will not fix, such as an unknown host (NXDOMAIN),
+// a refused connection or a TLS handshake error, are not temporary, and neither is a
+// cancelled context. These are the network errors retried by WithRetry; API errors are
+// classified with errors.Is and the sentinel errors instead (e.g. ErrServerError).
+func IsTemporary(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	if IsTimeout(err) {
+		return true
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsTemporary && !dnsErr.IsNotFound
+	}
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// shouldRetry reports whether a failed attempt is retried with WithRetry: rate limiting,
+// server errors and temporary network failures (see IsTemporary)
+// Other network failures are still retryable in the wider sense of isRetryable, so they
+// fail over, fall back and serve stale cache entries without being retried in place
+func shouldRetry(err error) bool {
+	var transportErr *transportError
+	if errors.As(err, &transportErr) {
+		return IsTemporary(err)
+	}
+	return isRetryable(err)
+}
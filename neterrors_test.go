@@ -0,0 +1,160 @@
+package infura
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// newDroppingServer returns a server that closes every connection without a complete
+// response: before the status line, or midway through the body if midBody is set
+func newDroppingServer(midBody bool) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, buf, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			return
+		}
+		if midBody {
+			buf.WriteString("HTTP/1.1 200 OK\r\nContent-Type: application/json\r\nContent-Length: 100\r\n\r\n{\"busyThres")
+			buf.Flush()
+		}
+		conn.Close()
+	}))
+}
+
+// timeoutError is a net.Error reporting a timeout
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestIsTimeoutAndIsTemporary(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		wantTimeout   bool
+		wantTemporary bool
+	}{
+		{name: "nil"},
+		{name: "net timeout", err: &net.OpError{Op: "read", Net: "tcp", Err: timeoutError{}}, wantTimeout: true, wantTemporary: true},
+		{name: "deadline exceeded", err: fmt.Errorf("wrapped: %w", os.ErrDeadlineExceeded), wantTimeout: true, wantTemporary: true},
+		{name: "context deadline", err: context.DeadlineExceeded, wantTimeout: true, wantTemporary: true},
+		{name: "context canceled", err: context.Canceled},
+		{name: "connection reset", err: &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}, wantTemporary: true},
+		{name: "broken pipe", err: &net.OpError{Op: "write", Net: "tcp", Err: os.NewSyscallError("write", syscall.EPIPE)}, wantTemporary: true},
+		{name: "connection refused", err: &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}},
+		{name: "unexpected EOF", err: &transportError{err: io.ErrUnexpectedEOF, readingBody: true}, wantTemporary: true},
+		{name: "EOF", err: &transportError{err: io.EOF}, wantTemporary: true},
+		{name: "DNS temporary", err: &net.DNSError{Err: "server misbehaving", Name: "gas.api.infura.io", IsTemporary: true}, wantTemporary: true},
+		{name: "DNS timeout", err: &net.DNSError{Err: "i/o timeout", Name: "gas.api.infura.io", IsTimeout: true}, wantTimeout: true, wantTemporary: true},
+		{name: "DNS NXDOMAIN", err: &net.DNSError{Err: "no such host", Name: "gas.api.infura.io", IsNotFound: true}},
+		{name: "TLS certificate", err: &transportError{err: x509.UnknownAuthorityError{}}},
+		{name: "API error", err: &APIError{StatusCode: 503}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsTimeout(tt.err); got != tt.wantTimeout {
+				t.Errorf("Expected IsTimeout to be %v, got %v", tt.wantTimeout, got)
+			}
+			if got := IsTemporary(tt.err); got != tt.wantTemporary {
+				t.Errorf("Expected IsTemporary to be %v, got %v", tt.wantTemporary, got)
+			}
+		})
+	}
+}
+
+func TestShouldRetry(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "temporary transport error", err: &transportError{err: &net.OpError{Op: "read", Err: os.NewSyscallError("read", syscall.ECONNRESET)}}, want: true},
+		{name: "permanent transport error", err: &transportError{err: &net.DNSError{Err: "no such host", IsNotFound: true}}},
+		{name: "server error", err: &APIError{StatusCode: 502}, want: true},
+		{name: "rate limited", err: &APIError{StatusCode: 429}, want: true},
+		{name: "not found", err: &APIError{StatusCode: 404}},
+	}
+	for _, tt := range tests {
+		if got := shouldRetry(tt.err); got != tt.want {
+			t.Errorf("%s: expected shouldRetry to be %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestRetry_ConnectionClosed(t *testing.T) {
+	for _, midBody := range []bool{false, true} {
+		t.Run(fmt.Sprintf("midBody=%v", midBody), func(t *testing.T) {
+			server := newDroppingServer(midBody)
+			defer server.Close()
+
+			client := NewClientWithOptions("test-api-key", "test-api-secret",
+				WithBaseURL(server.URL),
+				WithRetry(3, time.Millisecond),
+				WithCallHistory(10))
+			_, err := client.GetBusyThreshold(context.Background(), 1)
+			if !IsTemporary(err) {
+				t.Fatalf("Expected a temporary error, got %v", err)
+			}
+			if midBody && !errors.Is(err, io.ErrUnexpectedEOF) {
+				t.Errorf("Expected unexpected EOF, got %v", err)
+			}
+			if got := len(client.CallHistory()); got != 3 {
+				t.Errorf("Expected 3 attempts, got %d", got)
+			}
+		})
+	}
+}
+
+func TestRetry_PermanentTransportErrorNotRetried(t *testing.T) {
+	var calls atomic.Int32
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		calls.Add(1)
+		return nil, &net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{Err: "no such host", Name: req.URL.Host, IsNotFound: true}}
+	})
+
+	client := NewClientWithOptions("test-api-key", "test-api-secret",
+		WithTransport(transport),
+		WithRetry(3, time.Millisecond))
+	_, err := client.GetBusyThreshold(context.Background(), 1)
+	if err == nil || IsTemporary(err) {
+		t.Fatalf("Expected a permanent error, got %v", err)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("Expected 1 attempt, got %d", n)
+	}
+}
+
+func TestRetry_ClientTimeoutRetried(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			time.Sleep(100 * time.Millisecond)
+		}
+		w.Write([]byte(`{"busyThreshold": "42"}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithOptions("test-api-key", "test-api-secret",
+		WithBaseURL(server.URL),
+		WithTimeout(50*time.Millisecond),
+		WithRetry(2, time.Millisecond))
+	if _, err := client.GetBusyThreshold(context.Background(), 1); err != nil {
+		t.Fatalf("Expected success on the retry, got %v", err)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("Expected 2 attempts, got %d", n)
+	}
+}
@@ -0,0 +1,179 @@
+package infura
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// ChainOption configures per-chain overrides of client settings
+type ChainOption func(*chainOverride)
+
+// chainOverride holds the settings overridden for one chain; nil fields use the client setting
+type chainOverride struct {
+	timeout     *time.Duration
+	cacheTTL    *time.Duration
+	maxAttempts *int
+	rateShare   *float64
+	limiter     *rate.Limiter
+}
+
+// WithChainOverrides overrides client settings for requests to chainID
+// Settings not overridden fall back to the client-level configuration. Calling it again for
+// the same chain adds to the existing overrides.
+func WithChainOverrides(chainID int64, opts ...ChainOption) ClientOption {
+	return func(c *Client) {
+		if c.chainOverrides == nil {
+			c.chainOverrides = make(map[int64]*chainOverride)
+		}
+		override, ok := c.chainOverrides[chainID]
+		if !ok {
+			override = &chainOverride{}
+			c.chainOverrides[chainID] = override
+		}
+		for _, opt := range opts {
+			opt(override)
+		}
+		if share := override.rateShare; share != nil && !(*share > 0 && *share <= 1) {
+			c.rejectOption("WithChainRateLimitShare", "share of chain %d must be in (0, 1], got %v", chainID, *share)
+			override.rateShare = nil
+		}
+	}
+}
+
+// WithChainTimeout sets the timeout of each attempt of requests to the chain, replacing the
+// client timeout (it may be shorter or longer). A per-call timeout (WithCallTimeout) takes
+// precedence over it.
+func WithChainTimeout(timeout time.Duration) ChainOption {
+	return func(o *chainOverride) {
+		o.timeout = &timeout
+	}
+}
+
+// WithChainCacheTTL sets the cache TTL of the chain's responses
+// It enables the response cache for this chain even if WithCache is not used
+func WithChainCacheTTL(ttl time.Duration) ChainOption {
+	return func(o *chainOverride) {
+		o.cacheTTL = &ttl
+	}
+}
+
+// WithChainRetry sets the total number of attempts per request to the chain
+// Retries use the client's backoff delay (see WithRetry)
+func WithChainRetry(maxAttempts int) ChainOption {
+	return func(o *chainOverride) {
+		o.maxAttempts = &maxAttempts
+	}
+}
+
+// WithChainRateLimitShare caps the chain at share (0, 1] of the client rate limit set with
+// WithRateLimit, so a busy chain cannot consume the whole budget. Requests still count
+// against the client-wide limit. It has no effect without WithRateLimit.
+// A share outside (0, 1] is rejected when the client is created.
+func WithChainRateLimitShare(share float64) ChainOption {
+	return func(o *chainOverride) {
+		o.rateShare = &share
+	}
+}
+
+// finalizeChainOverrides derives per-chain state that depends on client-level options
+// It runs once after all options have been applied
+func (c *Client) finalizeChainOverrides() {
+	for _, override := range c.chainOverrides {
+		if override.cacheTTL != nil && c.cache == nil {
+			c.cache = &responseCache{entries: make(map[string]cacheEntry)}
+		}
+		if share := override.rateShare; share != nil && c.rateLimiter != nil {
+			burst := int(float64(c.rateLimiter.Burst()) * *share)
+			if burst < 1 {
+				burst = 1
+			}
+			override.limiter = rate.NewLimiter(c.rateLimiter.Limit()*rate.Limit(*share), burst)
+		}
+	}
+}
+
+// requestSettings are the effective settings of a single request
+type requestSettings struct {
+	// timeout is the chain timeout (WithChainTimeout), 0 if not overridden; see attemptTimeout
+	timeout     time.Duration
+	maxAttempts int
+	cacheTTL    time.Duration
+	// limiters are waited on in order before the request is sent
+	limiters []*rate.Limiter
+	// headers are added to the request
+	headers http.Header
+	// baseURL replaces the client's base URL when set (see WithFailoverURLs)
+	baseURL string
+	// onResponse, if set, is called with every response before its body is handled
+	onResponse func(*http.Response)
+	// attempt is the 1-based attempt number against baseURL, 0 outside the retry loop
+	attempt int
+	// retryStart is when the first attempt of the request started (see WithMaxRetryElapsed)
+	retryStart time.Time
+	// priorAttempts counts the attempts made against earlier failover URLs
+	priorAttempts int
+}
+
+// defaultSettings returns the client-level request settings
+func (c *Client) defaultSettings() requestSettings {
+	settings := requestSettings{
+		maxAttempts: c.maxAttempts(),
+		cacheTTL:    c.cacheTTL,
+		headers:     c.headers,
+	}
+	if c.rateLimiter != nil {
+		settings.limiters = []*rate.Limiter{c.rateLimiter}
+	}
+	return settings
+}
+
+// settingsFor resolves the request settings for chainID: chain overrides take precedence
+// over client settings, and per-call headers attached to ctx over client headers
+func (c *Client) settingsFor(ctx context.Context, chainID int64) requestSettings {
+	settings := c.defaultSettings()
+
+	if override, ok := c.chainOverrides[chainID]; ok {
+		if override.timeout != nil {
+			settings.timeout = *override.timeout
+		}
+		if override.cacheTTL != nil {
+			settings.cacheTTL = *override.cacheTTL
+		}
+		if override.maxAttempts != nil && *override.maxAttempts >= 1 {
+			settings.maxAttempts = *override.maxAttempts
+		}
+		if override.limiter != nil {
+			settings.limiters = append([]*rate.Limiter{override.limiter}, settings.limiters...)
+		}
+	}
+
+	if headers := callHeaders(ctx); headers != nil {
+		merged := settings.headers.Clone()
+		if merged == nil {
+			merged = make(http.Header, len(headers))
+		}
+		for key, values := range headers {
+			merged[key] = values
+		}
+		settings.headers = merged
+	}
+
+	return settings
+}
+
+// attemptTimeout resolves the timeout of one attempt of a request with the given settings:
+// a per-call timeout (WithCallTimeout) takes precedence over the chain timeout, which takes
+// precedence over the client timeout. A per-call timeout already bounds ctx, including
+// retries, so it returns 0 and no further deadline is added.
+func (c *Client) attemptTimeout(ctx context.Context, settings requestSettings) time.Duration {
+	if callTimeout(ctx) > 0 {
+		return 0
+	}
+	if settings.timeout > 0 {
+		return settings.timeout
+	}
+	return c.httpClient.Timeout
+}
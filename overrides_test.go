@@ -0,0 +1,203 @@
+package infura
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithChainOverrides_Timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(200 * time.Millisecond):
+		case <-r.Context().Done():
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithTimeout(100*time.Millisecond),
+		WithChainOverrides(1, WithChainTimeout(20*time.Millisecond)),
+		WithChainOverrides(59141, WithChainTimeout(2*time.Second)))
+
+	tests := []struct {
+		chainID     int64
+		wantTimeout bool
+	}{
+		{chainID: 1, wantTimeout: true},      // tighter than the client timeout
+		{chainID: 59141, wantTimeout: false}, // looser than the client timeout
+		{chainID: 137, wantTimeout: true},    // client timeout applies
+	}
+
+	var wg sync.WaitGroup
+	for _, tt := range tests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			start := time.Now()
+			_, err := client.GetSuggestedGasFees(context.Background(), tt.chainID)
+			elapsed := time.Since(start)
+			if tt.wantTimeout && err == nil {
+				t.Errorf("chain %d: expected timeout, succeeded after %v", tt.chainID, elapsed)
+			}
+			if !tt.wantTimeout && err != nil {
+				t.Errorf("chain %d: expected success, got %v", tt.chainID, err)
+			}
+			if tt.chainID == 1 && elapsed > 100*time.Millisecond {
+				t.Errorf("chain 1: expected the 20ms chain timeout, took %v", elapsed)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestWithChainOverrides_RetryAndCache(t *testing.T) {
+	chainPattern := regexp.MustCompile(`/networks/(\d+)/`)
+	var mu sync.Mutex
+	calls := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		chain := chainPattern.FindStringSubmatch(r.URL.Path)[1]
+		mu.Lock()
+		calls[chain]++
+		mu.Unlock()
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithRetry(2, time.Millisecond),
+		WithChainOverrides(1, WithChainRetry(4)))
+
+	client.GetSuggestedGasFees(context.Background(), 1)
+	client.GetSuggestedGasFees(context.Background(), 137)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if calls["1"] != 4 || calls["137"] != 2 {
+		t.Errorf("Expected 4 attempts for chain 1 and 2 for chain 137, got %v", calls)
+	}
+}
+
+func TestWithChainOverrides_CacheTTL(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	clock := newFakeClock(time.Unix(0, 0))
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithClock(clock),
+		WithChainOverrides(1, WithChainCacheTTL(time.Minute)))
+
+	for i := 0; i < 3; i++ {
+		client.GetSuggestedGasFees(context.Background(), 1)
+		client.GetSuggestedGasFees(context.Background(), 137)
+	}
+
+	// Chain 1 is cached by its override; chain 137 has no cache
+	if got := atomic.LoadInt32(&calls); got != 4 {
+		t.Errorf("Expected 1 request for chain 1 and 3 for chain 137, got %d total", got)
+	}
+}
+
+func TestWithChainOverrides_RateLimitShare(t *testing.T) {
+	server := newFeesServer(t, `{}`)
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithRateLimit(100, 10),
+		WithRateLimitMode(RateLimitFailFast),
+		WithChainOverrides(1, WithChainRateLimitShare(0.2)))
+
+	// Chain 1 gets a burst of 2 out of the client's 10
+	var limited int
+	for i := 0; i < 5; i++ {
+		if _, err := client.GetSuggestedGasFees(context.Background(), 1); errors.Is(err, ErrRateLimitedLocally) {
+			limited++
+		}
+	}
+	if limited != 3 {
+		t.Errorf("Expected 3 of 5 chain 1 requests to be limited, got %d", limited)
+	}
+
+	// Other chains still have the rest of the client budget
+	if _, err := client.GetSuggestedGasFees(context.Background(), 137); err != nil {
+		t.Errorf("Expected chain 137 to be unaffected, got %v", err)
+	}
+}
+
+func TestWithChainOverrides_TimeoutPrecedence(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(150 * time.Millisecond):
+		case <-r.Context().Done():
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithTimeout(50*time.Millisecond),
+		WithChainOverrides(1, WithChainTimeout(20*time.Millisecond)),
+		WithChainOverrides(59141, WithChainTimeout(2*time.Second)))
+
+	tests := []struct {
+		name        string
+		chainID     int64
+		opts        []CallOption
+		wantTimeout bool
+	}{
+		{name: "client", chainID: 137, wantTimeout: true},
+		{name: "chain over client", chainID: 59141, wantTimeout: false},
+		{name: "call over chain", chainID: 1, opts: []CallOption{WithCallTimeout(2 * time.Second)}, wantTimeout: false},
+		{name: "call over client", chainID: 137, opts: []CallOption{WithCallTimeout(2 * time.Second)}, wantTimeout: false},
+		{name: "short call over long chain", chainID: 59141, opts: []CallOption{WithCallTimeout(20 * time.Millisecond)}, wantTimeout: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.GetSuggestedGasFees(context.Background(), tt.chainID, tt.opts...)
+			if tt.wantTimeout && !IsTimeout(err) {
+				t.Errorf("Expected a timeout, got %v", err)
+			}
+			if !tt.wantTimeout && err != nil {
+				t.Errorf("Expected success, got %v", err)
+			}
+		})
+	}
+}
+
+func TestWithChainRateLimitShare_Invalid(t *testing.T) {
+	for _, share := range []float64{0, -0.5, 1.5, math.NaN()} {
+		_, err := New("test-api-key", "",
+			WithRateLimit(100, 10),
+			WithChainOverrides(1, WithChainRateLimitShare(share)))
+		if !errors.Is(err, ErrInvalidOption) {
+			t.Errorf("Expected ErrInvalidOption for share %v, got %v", share, err)
+		}
+	}
+
+	if _, err := New("test-api-key", "", WithChainOverrides(1, WithChainRateLimitShare(1))); err != nil {
+		t.Errorf("Expected a share of 1 to be accepted, got %v", err)
+	}
+}
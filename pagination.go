@@ -0,0 +1,93 @@
+package infura
+
+import (
+	"context"
+	"iter"
+)
+
+// PageFetcher fetches the page starting at cursor (nil for the first page)
+// It returns the page's items and the cursor of the next page, or nil if this is the last page
+type PageFetcher[T any] func(ctx context.Context, cursor *string) (items []T, next *string, err error)
+
+// Paginator iterates lazily over the items of a cursor-paginated source
+// Pages are fetched on demand; empty pages are skipped and a nil next cursor ends iteration
+//
+//	p := NewPaginator(fetch)
+//	for p.Next(ctx) {
+//		item := p.Item()
+//	}
+//	if err := p.Err(); err != nil { ... }
+//
+// A Paginator is not safe for concurrent use.
+type Paginator[T any] struct {
+	fetch   PageFetcher[T]
+	cursor  *string
+	page    []T
+	current T
+	started bool
+	done    bool
+	err     error
+}
+
+// NewPaginator creates a Paginator over the pages returned by fetch
+func NewPaginator[T any](fetch PageFetcher[T]) *Paginator[T] {
+	return &Paginator[T]{fetch: fetch}
+}
+
+// Next advances to the next item, fetching further pages as needed
+// It returns false when the source is exhausted, a fetch fails, or ctx is done; check Err afterwards
+func (p *Paginator[T]) Next(ctx context.Context) bool {
+	if p.err != nil {
+		return false
+	}
+
+	for len(p.page) == 0 {
+		if p.done || (p.started && p.cursor == nil) {
+			p.done = true
+			return false
+		}
+		if err := ctx.Err(); err != nil {
+			p.err = err
+			return false
+		}
+
+		items, next, err := p.fetch(ctx, p.cursor)
+		if err != nil {
+			p.err = err
+			return false
+		}
+		p.started = true
+		p.page = items
+		p.cursor = next
+	}
+
+	p.current = p.page[0]
+	p.page = p.page[1:]
+	return true
+}
+
+// Item returns the current item; it is only valid after Next returned true
+func (p *Paginator[T]) Item() T {
+	return p.current
+}
+
+// Err returns the error that stopped iteration, or nil if the source was exhausted
+func (p *Paginator[T]) Err() error {
+	return p.err
+}
+
+// All returns an iterator over the remaining items
+// If iteration stops because of an error, the final pair carries the zero item and the error
+func (p *Paginator[T]) All(ctx context.Context) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for p.Next(ctx) {
+			if !yield(p.Item(), nil) {
+				return
+			}
+		}
+		if err := p.Err(); err != nil {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package infura
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// fakePages returns a PageFetcher serving the given pages, using the page index as cursor
+func fakePages(pages [][]int, fetched *int) PageFetcher[int] {
+	return func(ctx context.Context, cursor *string) ([]int, *string, error) {
+		index := 0
+		if cursor != nil {
+			index, _ = strconv.Atoi(*cursor)
+		}
+		*fetched++
+		var next *string
+		if index+1 < len(pages) {
+			s := strconv.Itoa(index + 1)
+			next = &s
+		}
+		return pages[index], next, nil
+	}
+}
+
+func TestPaginator_MultiplePages(t *testing.T) {
+	var fetched int
+	p := NewPaginator(fakePages([][]int{{1, 2}, {}, {3}, {4, 5}}, &fetched))
+
+	var got []int
+	for p.Next(context.Background()) {
+		got = append(got, p.Item())
+	}
+	if err := p.Err(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := []int{1, 2, 3, 4, 5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+	if fetched != 4 {
+		t.Errorf("Expected 4 page fetches, got %d", fetched)
+	}
+	if p.Next(context.Background()) {
+		t.Error("Expected exhausted paginator to stay exhausted")
+	}
+	if fetched != 4 {
+		t.Errorf("Expected no fetch after exhaustion, got %d", fetched)
+	}
+}
+
+func TestPaginator_Lazy(t *testing.T) {
+	var fetched int
+	p := NewPaginator(fakePages([][]int{{1, 2}, {3}}, &fetched))
+
+	if fetched != 0 {
+		t.Fatalf("Expected no fetch before Next, got %d", fetched)
+	}
+	p.Next(context.Background())
+	p.Next(context.Background())
+	if fetched != 1 {
+		t.Errorf("Expected second page to be fetched lazily, got %d fetches", fetched)
+	}
+}
+
+func TestPaginator_EmptySource(t *testing.T) {
+	var fetched int
+	p := NewPaginator(fakePages([][]int{{}}, &fetched))
+
+	if p.Next(context.Background()) {
+		t.Fatal("Expected no items")
+	}
+	if p.Err() != nil {
+		t.Errorf("Expected nil error, got %v", p.Err())
+	}
+}
+
+func TestPaginator_FetchError(t *testing.T) {
+	boom := errors.New("boom")
+	calls := 0
+	p := NewPaginator(func(ctx context.Context, cursor *string) ([]int, *string, error) {
+		calls++
+		if calls == 2 {
+			return nil, nil, boom
+		}
+		next := "next"
+		return []int{calls}, &next, nil
+	})
+
+	var got []int
+	for item, err := range p.All(context.Background()) {
+		if err != nil {
+			if !errors.Is(err, boom) {
+				t.Errorf("Expected boom, got %v", err)
+			}
+			break
+		}
+		got = append(got, item)
+	}
+	if !reflect.DeepEqual(got, []int{1}) {
+		t.Errorf("Expected [1], got %v", got)
+	}
+}
+
+func TestPaginator_ContextCancelled(t *testing.T) {
+	var fetched int
+	p := NewPaginator(fakePages([][]int{{1}, {2}}, &fetched))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if !p.Next(ctx) {
+		t.Fatal("Expected first item")
+	}
+	cancel()
+
+	if p.Next(ctx) {
+		t.Fatal("Expected cancellation to stop iteration")
+	}
+	if !errors.Is(p.Err(), context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", p.Err())
+	}
+	if fetched != 1 {
+		t.Errorf("Expected no fetch after cancellation, got %d", fetched)
+	}
+}
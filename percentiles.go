@@ -0,0 +1,68 @@
+package infura
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"sync"
+)
+
+// maxPercentileRequests bounds the concurrent requests of GetBaseFeePercentiles
+const maxPercentileRequests = 4
+
+// GetBaseFeePercentiles retrieves the base fee at several percentiles (0–100) for a given
+// chain ID, keyed by percentile. Each percentile is requested from baseFeePercentile with
+// the percentile query parameter; duplicates are requested once and at most 4 requests
+// run at a time. Invalid percentiles fail the call before any request is made.
+//
+// The result holds every percentile that succeeded; if any failed, an error joining the
+// per-percentile errors is returned alongside it.
+func (c *Client) GetBaseFeePercentiles(ctx context.Context, chainID int64, percentiles ...int) (map[int]*BaseFeePercentile, error) {
+	if len(percentiles) == 0 {
+		return nil, fmt.Errorf("at least one percentile is required")
+	}
+	for _, p := range percentiles {
+		if p < 0 || p > 100 {
+			return nil, fmt.Errorf("percentile must be between 0 and 100, got %d", p)
+		}
+	}
+	unique := slices.Compact(slices.Sorted(slices.Values(percentiles)))
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		slots   = make(chan struct{}, maxPercentileRequests)
+		results = make(map[int]*BaseFeePercentile, len(unique))
+		failed  = make(map[int]error)
+	)
+	for _, p := range unique {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			query := url.Values{"percentile": {strconv.Itoa(p)}}
+			result, err := Get[BaseFeePercentile](ctx, c, chainID, "baseFeePercentile", query)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failed[p] = err
+				return
+			}
+			results[p] = &result
+		}()
+	}
+	wg.Wait()
+
+	var errs []error
+	for _, p := range unique {
+		if err, ok := failed[p]; ok {
+			errs = append(errs, fmt.Errorf("failed to get base fee percentile %d: %w", p, err))
+		}
+	}
+	return results, errors.Join(errs...)
+}
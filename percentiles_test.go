@@ -0,0 +1,104 @@
+package infura
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestGetBaseFeePercentiles(t *testing.T) {
+	var (
+		mu        sync.Mutex
+		requested []string
+		inFlight  atomic.Int32
+		maxFlight atomic.Int32
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxFlight.Load()
+			if n <= m || maxFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+
+		p := r.URL.Query().Get("percentile")
+		mu.Lock()
+		requested = append(requested, p)
+		mu.Unlock()
+		if !strings.HasSuffix(r.URL.Path, "/networks/1/baseFeePercentile") {
+			t.Errorf("Expected the baseFeePercentile path of chain 1, got %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"baseFeePercentile": "` + p + `.5"}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithOptions("test-api-key", "test-api-secret", WithBaseURL(server.URL))
+	got, err := client.GetBaseFeePercentiles(context.Background(), 1, 90, 10, 50, 10, 0, 100, 25, 75)
+	if err != nil {
+		t.Fatalf("GetBaseFeePercentiles failed: %v", err)
+	}
+
+	want := []int{0, 10, 25, 50, 75, 90, 100}
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d percentiles, got %d", len(want), len(got))
+	}
+	for _, p := range want {
+		if got[p] == nil || got[p].BaseFeePercentile != strconv.Itoa(p)+".5" {
+			t.Errorf("Expected percentile %d to be %d.5, got %+v", p, p, got[p])
+		}
+	}
+	if len(requested) != len(want) {
+		t.Errorf("Expected one request per unique percentile, got %v", requested)
+	}
+	if m := maxFlight.Load(); m > maxPercentileRequests {
+		t.Errorf("Expected at most %d concurrent requests, got %d", maxPercentileRequests, m)
+	}
+}
+
+func TestGetBaseFeePercentiles_PartialFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("percentile") == "90" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`{"baseFeePercentile": "20"}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithOptions("test-api-key", "test-api-secret", WithBaseURL(server.URL))
+	got, err := client.GetBaseFeePercentiles(context.Background(), 1, 10, 90)
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || !strings.Contains(err.Error(), "percentile 90") {
+		t.Fatalf("Expected an APIError for percentile 90, got %v", err)
+	}
+	if len(got) != 1 || got[10] == nil {
+		t.Errorf("Expected only percentile 10, got %v", got)
+	}
+}
+
+func TestGetBaseFeePercentiles_Invalid(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	defer server.Close()
+
+	client := NewClientWithOptions("test-api-key", "test-api-secret", WithBaseURL(server.URL))
+	for _, percentiles := range [][]int{{50, 101}, {-1}, nil} {
+		if _, err := client.GetBaseFeePercentiles(context.Background(), 1, percentiles...); err == nil {
+			t.Errorf("Expected an error for percentiles %v, got nil", percentiles)
+		}
+	}
+	if n := calls.Load(); n != 0 {
+		t.Errorf("Expected no request for invalid percentiles, got %d", n)
+	}
+}
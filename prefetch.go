@@ -0,0 +1,102 @@
+package infura
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"sync"
+)
+
+// EndpointKind names a per-network Gas API endpoint that Prefetch can warm up
+type EndpointKind string
+
+// Endpoints served by GetSuggestedGasFees, GetBaseFeeHistory, GetBaseFeePercentile and
+// GetBusyThreshold
+const (
+	EndpointSuggestedGasFees  EndpointKind = "suggestedGasFees"
+	EndpointBaseFeeHistory    EndpointKind = "baseFeeHistory"
+	EndpointBaseFeePercentile EndpointKind = "baseFeePercentile"
+	EndpointBusyThreshold     EndpointKind = "busyThreshold"
+)
+
+// maxPrefetchRequests bounds the concurrent requests of Prefetch
+const maxPrefetchRequests = 8
+
+// prefetchResults returns a new result of the type each endpoint decodes into, so that
+// prefetched responses are validated like those of the endpoint methods
+var prefetchResults = map[EndpointKind]func() interface{}{
+	EndpointSuggestedGasFees:  func() interface{} { return new(SuggestedGasFees) },
+	EndpointBaseFeeHistory:    func() interface{} { return new(BaseFeeHistory) },
+	EndpointBaseFeePercentile: func() interface{} { return new(BaseFeePercentile) },
+	EndpointBusyThreshold:     func() interface{} { return new(BusyThreshold) },
+}
+
+// Prefetch fetches the given endpoints (suggestedGasFees if none) for every chain and stores
+// the responses in the cache set with WithCache, so that the first calls after startup are
+// served from the cache instead of paying a round trip. Entries still fresh in the cache are
+// not fetched again.
+//
+// At most 8 requests run at a time, and WithRateLimit and WithMaxInFlight apply as for any
+// call. Whatever succeeded is cached; if any chain failed, a *BatchError keyed by chain ID
+// is returned. Unknown endpoints fail the call before any request is made. Without
+// WithCache there is nothing to warm up: Prefetch logs a warning and returns nil.
+func (c *Client) Prefetch(ctx context.Context, chainIDs []int64, endpoints ...EndpointKind) error {
+	if len(endpoints) == 0 {
+		endpoints = []EndpointKind{EndpointSuggestedGasFees}
+	}
+	for _, endpoint := range endpoints {
+		if _, ok := prefetchResults[endpoint]; !ok {
+			return fmt.Errorf("unknown endpoint %q", endpoint)
+		}
+	}
+	if c.cache == nil {
+		log.Printf("[WARN] Prefetch has no effect: caching is not enabled (use WithCache)\n")
+		return nil
+	}
+
+	chains := slices.Compact(slices.Sorted(slices.Values(chainIDs)))
+	endpoints = slices.Compact(slices.Sorted(slices.Values(endpoints)))
+
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		slots  = make(chan struct{}, maxPrefetchRequests)
+		failed = make(map[int64]map[EndpointKind]error)
+	)
+	for _, chainID := range chains {
+		for _, endpoint := range endpoints {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				slots <- struct{}{}
+				defer func() { <-slots }()
+
+				err := c.getNetworkResource(ctx, chainID, string(endpoint), prefetchResults[endpoint]())
+				if err == nil {
+					return
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				if failed[chainID] == nil {
+					failed[chainID] = make(map[EndpointKind]error)
+				}
+				failed[chainID][endpoint] = err
+			}()
+		}
+	}
+	wg.Wait()
+
+	joined := make(map[int64]error, len(failed))
+	for chainID, byEndpoint := range failed {
+		var errs []error
+		for _, endpoint := range endpoints {
+			if err, ok := byEndpoint[endpoint]; ok {
+				errs = append(errs, fmt.Errorf("failed to prefetch %s: %w", endpoint, err))
+			}
+		}
+		joined[chainID] = errors.Join(errs...)
+	}
+	return newBatchError(joined, len(chains))
+}
@@ -0,0 +1,128 @@
+package infura
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newPrefetchServer serves every endpoint, failing chain 59144, and counts the requests
+func newPrefetchServer(t *testing.T, requests *atomic.Int64) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if strings.Contains(r.URL.Path, "/networks/59144/") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch path.Base(r.URL.Path) {
+		case "baseFeeHistory":
+			w.Write([]byte(`["10", "11"]`))
+		default:
+			w.Write([]byte(`{"estimatedBaseFee": "10"}`))
+		}
+	}))
+}
+
+func TestPrefetch_WarmsCache(t *testing.T) {
+	var requests atomic.Int64
+	server := newPrefetchServer(t, &requests)
+	defer server.Close()
+
+	clock := newFakeClock(time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC))
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithClock(clock),
+		WithCache(time.Minute))
+
+	if err := client.Prefetch(context.Background(), []int64{1, 137, 1}, EndpointSuggestedGasFees, EndpointBaseFeeHistory); err != nil {
+		t.Fatalf("Prefetch failed: %v", err)
+	}
+	if got := requests.Load(); got != 4 {
+		t.Fatalf("Expected 4 prefetch requests, got %d", got)
+	}
+
+	requests.Store(0)
+	clock.Advance(30 * time.Second)
+	for _, chainID := range []int64{1, 137} {
+		var meta CallMeta
+		ctx := ContextWithCallMeta(context.Background(), &meta)
+		if _, err := client.GetSuggestedGasFees(ctx, chainID); err != nil {
+			t.Fatalf("GetSuggestedGasFees failed: %v", err)
+		}
+		if !meta.Cached {
+			t.Errorf("Expected chain %d fees to be served from the cache", chainID)
+		}
+		if _, err := client.GetBaseFeeHistory(context.Background(), chainID); err != nil {
+			t.Fatalf("GetBaseFeeHistory failed: %v", err)
+		}
+	}
+	if got := requests.Load(); got != 0 {
+		t.Errorf("Expected no upstream requests within the TTL, got %d", got)
+	}
+}
+
+func TestPrefetch_PartialFailure(t *testing.T) {
+	var requests atomic.Int64
+	server := newPrefetchServer(t, &requests)
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithCache(time.Minute))
+
+	err := client.Prefetch(context.Background(), []int64{1, 59144})
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("Expected *BatchError, got %v", err)
+	}
+	if failed := batchErr.Failed(); len(failed) != 1 || !errors.Is(failed[59144], ErrNotFound) {
+		t.Errorf("Expected chain 59144 to fail with ErrNotFound, got %v", failed)
+	}
+
+	requests.Store(0)
+	if _, err := client.GetSuggestedGasFees(context.Background(), 1); err != nil {
+		t.Fatalf("GetSuggestedGasFees failed: %v", err)
+	}
+	if got := requests.Load(); got != 0 {
+		t.Errorf("Expected the successful chain to be cached, got %d requests", got)
+	}
+}
+
+func TestPrefetch_WithoutCache(t *testing.T) {
+	var requests atomic.Int64
+	server := newPrefetchServer(t, &requests)
+	defer server.Close()
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL))
+	if err := client.Prefetch(context.Background(), []int64{1}); err != nil {
+		t.Fatalf("Prefetch failed: %v", err)
+	}
+	if got := requests.Load(); got != 0 {
+		t.Errorf("Expected no requests without a cache, got %d", got)
+	}
+	if !strings.Contains(buf.String(), "[WARN]") {
+		t.Errorf("Expected a warning, got %q", buf.String())
+	}
+}
+
+func TestPrefetch_UnknownEndpoint(t *testing.T) {
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithCache(time.Minute))
+	if err := client.Prefetch(context.Background(), []int64{1}, "gasPrice"); err == nil {
+		t.Error("Expected an error for an unknown endpoint")
+	}
+}
@@ -0,0 +1,101 @@
+package infura
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// RateLimitMode selects how requests behave when the client-side rate limiter is saturated
+type RateLimitMode struct {
+	failFast bool
+	maxWait  time.Duration
+}
+
+var (
+	// RateLimitBlock waits for a token for as long as the context allows (the default)
+	RateLimitBlock = RateLimitMode{}
+	// RateLimitFailFast returns ErrRateLimitedLocally when no token is immediately available
+	RateLimitFailFast = RateLimitMode{failFast: true}
+)
+
+// RateLimitWaitMax waits up to d for a token and returns ErrRateLimitedLocally if none
+// becomes available in time
+func RateLimitWaitMax(d time.Duration) RateLimitMode {
+	return RateLimitMode{maxWait: d}
+}
+
+// String describes the mode
+func (m RateLimitMode) String() string {
+	switch {
+	case m.failFast:
+		return "fail-fast"
+	case m.maxWait > 0:
+		return "wait-max(" + m.maxWait.String() + ")"
+	default:
+		return "block"
+	}
+}
+
+// WithRateLimitMode sets the behavior of the rate limiter configured with WithRateLimit
+// when it is saturated. ErrRateLimitedLocally is not retried by WithRetry.
+func WithRateLimitMode(mode RateLimitMode) ClientOption {
+	return func(c *Client) {
+		c.rateMode = mode
+	}
+}
+
+// waitRateLimit takes a token from limiter according to the configured mode
+func (c *Client) waitRateLimit(ctx context.Context, limiter *rate.Limiter) error {
+	if c.rateMode.failFast {
+		if !limiter.Allow() {
+			return ErrRateLimitedLocally
+		}
+		return nil
+	}
+	return reserveToken(ctx, limiter, c.rateMode.maxWait)
+}
+
+// reserveToken reserves a token from limiter and waits until it may be used. With a
+// positive maxWait, ErrRateLimitedLocally is returned if the wait would exceed maxWait or
+// the context deadline. A reservation that is not waited out, because it would exceed a
+// limit or ctx is done during the wait, is cancelled so the token is returned to the
+// limiter for other requests; rate.Limiter.Wait does the same, but relies on it silently.
+func reserveToken(ctx context.Context, limiter *rate.Limiter, maxWait time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	r := limiter.ReserveN(now, 1)
+	if !r.OK() {
+		return fmt.Errorf("rate limiter burst %d does not allow a request", limiter.Burst())
+	}
+	delay := r.DelayFrom(now)
+	if delay == 0 {
+		return nil
+	}
+
+	deadline, hasDeadline := ctx.Deadline()
+	exceedsDeadline := hasDeadline && now.Add(delay).After(deadline)
+	if maxWait > 0 && (delay > maxWait || exceedsDeadline) {
+		r.CancelAt(now)
+		return ErrRateLimitedLocally
+	}
+	if exceedsDeadline {
+		r.CancelAt(now)
+		return fmt.Errorf("waiting %v for a token would exceed the context deadline", delay)
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		r.Cancel()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,202 @@
+package infura
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newCountingServer(t *testing.T) (*httptest.Server, *int32) {
+	t.Helper()
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	return server, &calls
+}
+
+func TestRateLimitMode_Block(t *testing.T) {
+	server, calls := newCountingServer(t)
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithRateLimit(20, 1),
+		WithRateLimitMode(RateLimitBlock))
+
+	start := time.Now()
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetSuggestedGasFees(context.Background(), 1); err != nil {
+			t.Fatalf("request %d failed: %v", i, err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("Expected second request to block for a token, took %v", elapsed)
+	}
+	if got := atomic.LoadInt32(calls); got != 2 {
+		t.Errorf("Expected 2 requests, got %d", got)
+	}
+}
+
+func TestRateLimitMode_FailFast(t *testing.T) {
+	server, calls := newCountingServer(t)
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithRateLimit(1, 1),
+		WithRateLimitMode(RateLimitFailFast),
+		WithRetry(3, time.Millisecond))
+
+	if _, err := client.GetSuggestedGasFees(context.Background(), 1); err != nil {
+		t.Fatalf("first request failed: %v", err)
+	}
+
+	start := time.Now()
+	_, err := client.GetSuggestedGasFees(context.Background(), 1)
+	if !errors.Is(err, ErrRateLimitedLocally) {
+		t.Fatalf("Expected ErrRateLimitedLocally, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("Expected immediate failure, took %v", elapsed)
+	}
+	if got := atomic.LoadInt32(calls); got != 1 {
+		t.Errorf("Expected saturated request not to be sent, got %d requests", got)
+	}
+}
+
+func TestRateLimitMode_WaitMax(t *testing.T) {
+	server, calls := newCountingServer(t)
+	defer server.Close()
+
+	t.Run("token not available in time", func(t *testing.T) {
+		client := NewClientWithAPIKeyAndOptions("test-api-key",
+			WithBaseURL(server.URL),
+			WithRateLimit(1, 1),
+			WithRateLimitMode(RateLimitWaitMax(20*time.Millisecond)))
+
+		if _, err := client.GetSuggestedGasFees(context.Background(), 1); err != nil {
+			t.Fatalf("first request failed: %v", err)
+		}
+		before := atomic.LoadInt32(calls)
+
+		start := time.Now()
+		_, err := client.GetSuggestedGasFees(context.Background(), 1)
+		if !errors.Is(err, ErrRateLimitedLocally) {
+			t.Fatalf("Expected ErrRateLimitedLocally, got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+			t.Errorf("Expected failure within the max wait, took %v", elapsed)
+		}
+		if got := atomic.LoadInt32(calls); got != before {
+			t.Errorf("Expected saturated request not to be sent")
+		}
+	})
+
+	t.Run("token available in time", func(t *testing.T) {
+		client := NewClientWithAPIKeyAndOptions("test-api-key",
+			WithBaseURL(server.URL),
+			WithRateLimit(20, 1),
+			WithRateLimitMode(RateLimitWaitMax(time.Second)))
+
+		for i := 0; i < 2; i++ {
+			if _, err := client.GetSuggestedGasFees(context.Background(), 1); err != nil {
+				t.Fatalf("request %d failed: %v", i, err)
+			}
+		}
+	})
+
+	t.Run("context cancelled", func(t *testing.T) {
+		client := NewClientWithAPIKeyAndOptions("test-api-key",
+			WithBaseURL(server.URL),
+			WithRateLimit(1, 1),
+			WithRateLimitMode(RateLimitWaitMax(time.Minute)))
+
+		if _, err := client.GetSuggestedGasFees(context.Background(), 1); err != nil {
+			t.Fatalf("first request failed: %v", err)
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := client.GetSuggestedGasFees(ctx, 1)
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("Expected context.Canceled, got %v", err)
+		}
+	})
+}
+
+func TestRateLimitMode_String(t *testing.T) {
+	for mode, want := range map[RateLimitMode]string{
+		RateLimitBlock:                    "block",
+		RateLimitFailFast:                 "fail-fast",
+		RateLimitWaitMax(2 * time.Second): "wait-max(2s)",
+	} {
+		if got := mode.String(); got != want {
+			t.Errorf("Expected %q, got %q", want, got)
+		}
+	}
+}
+
+func TestRateLimit_CancelledWaitReturnsToken(t *testing.T) {
+	server, calls := newCountingServer(t)
+	defer server.Close()
+
+	for _, mode := range []RateLimitMode{RateLimitBlock, RateLimitWaitMax(time.Minute)} {
+		t.Run(mode.String(), func(t *testing.T) {
+			client := NewClientWithAPIKeyAndOptions("test-api-key",
+				WithBaseURL(server.URL),
+				WithRateLimit(1, 1),
+				WithRateLimitMode(mode))
+
+			if _, err := client.GetSuggestedGasFees(context.Background(), 1); err != nil {
+				t.Fatalf("first request failed: %v", err)
+			}
+			before := client.rateLimiter.Tokens()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			time.AfterFunc(20*time.Millisecond, cancel)
+			if _, err := client.GetSuggestedGasFees(ctx, 1); !errors.Is(err, context.Canceled) {
+				t.Fatalf("Expected context.Canceled, got %v", err)
+			}
+
+			// A consumed token would leave the limiter about one token below where it was
+			if after := client.rateLimiter.Tokens(); after < before {
+				t.Errorf("Tokens() = %.3f after the cancelled wait, was %.3f; the reserved token was not returned", after, before)
+			}
+		})
+	}
+	if got := atomic.LoadInt32(calls); got != 2 {
+		t.Errorf("Expected 2 requests, got %d", got)
+	}
+}
+
+func TestRateLimit_DeadlineTooShortKeepsToken(t *testing.T) {
+	server, _ := newCountingServer(t)
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithRateLimit(1, 1))
+	if _, err := client.GetSuggestedGasFees(context.Background(), 1); err != nil {
+		t.Fatalf("first request failed: %v", err)
+	}
+	before := client.rateLimiter.Tokens()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := client.GetSuggestedGasFees(ctx, 1); err == nil {
+		t.Fatal("Expected an error when the wait exceeds the deadline")
+	}
+	if elapsed := time.Since(start); elapsed > 40*time.Millisecond {
+		t.Errorf("request waited %v, want an immediate failure", elapsed)
+	}
+	if after := client.rateLimiter.Tokens(); after < before {
+		t.Errorf("Tokens() = %.3f, was %.3f; the reservation was not cancelled", after, before)
+	}
+}
@@ -0,0 +1,78 @@
+package infura
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// maxRedirects is the redirect limit of http.Client when its CheckRedirect is nil
+const maxRedirects = 10
+
+// WithRedirectAllowedHosts lists the hosts a redirect may send the credentials to.
+// When the API or a proxy answers with a redirect to another host, net/http drops the
+// Authorization header of Basic Auth requests, which would otherwise surface as a
+// confusing 401. The client detects this: for a host in the list, the header is restored
+// on the redirected request; for any other host, the redirect is not followed and the call
+// fails with ErrRedirectAuthStripped naming the hosts. Credentials are never restored on a
+// redirect from https to http.
+//
+// Hosts are host names without a port (e.g. "gas.example.com") and are matched exactly,
+// ignoring case. Repeated calls add to the list. An empty host is invalid. Redirects that
+// keep the header, e.g. to the same host, are followed as before, as are all redirects of
+// a client using API Key authentication in the URL path.
+func WithRedirectAllowedHosts(hosts ...string) ClientOption {
+	return func(c *Client) {
+		for _, host := range hosts {
+			if strings.TrimSpace(host) == "" || strings.ContainsAny(host, "/: ") {
+				c.rejectOption("WithRedirectAllowedHosts", "host must be a plain host name, got %q", host)
+				return
+			}
+		}
+		if c.redirectHosts == nil {
+			c.redirectHosts = make(map[string]bool, len(hosts))
+		}
+		for _, host := range hosts {
+			c.redirectHosts[strings.ToLower(host)] = true
+		}
+	}
+}
+
+// finalizeRedirects installs the redirect check on a copy of the HTTP client once all
+// options are applied, keeping the client's own CheckRedirect policy
+func (c *Client) finalizeRedirects() {
+	httpClient := *c.httpClient
+	next := httpClient.CheckRedirect
+	httpClient.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if err := c.checkRedirectAuth(req, via); err != nil {
+			return err
+		}
+		if next != nil {
+			return next(req, via)
+		}
+		if len(via) >= maxRedirects {
+			return errors.New("stopped after 10 redirects")
+		}
+		return nil
+	}
+	c.httpClient = &httpClient
+}
+
+// checkRedirectAuth restores the Authorization header net/http removed from a redirect
+// to an allowed host, and refuses the redirect for other hosts
+func (c *Client) checkRedirectAuth(req *http.Request, via []*http.Request) error {
+	auth := via[0].Header.Get("Authorization")
+	if auth == "" || req.Header.Get("Authorization") != "" {
+		return nil
+	}
+	from := via[len(via)-1].URL
+	if via[0].URL.Scheme == "https" && req.URL.Scheme != "https" {
+		return fmt.Errorf("%w: %s redirected to %s over plain HTTP", ErrRedirectAuthStripped, from.Host, req.URL.Host)
+	}
+	if !c.redirectHosts[strings.ToLower(req.URL.Hostname())] {
+		return fmt.Errorf("%w: %s redirected to %s, which is not allowed to receive the credentials (see WithRedirectAllowedHosts)", ErrRedirectAuthStripped, from.Host, req.URL.Host)
+	}
+	req.Header.Set("Authorization", auth)
+	return nil
+}
@@ -0,0 +1,113 @@
+package infura
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// newRedirectTarget returns a server on localhost that answers fees only to Basic Auth
+// requests, and counts the requests it received
+func newRedirectTarget(t *testing.T) (*httptest.Server, string, *int32) {
+	t.Helper()
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if _, _, ok := r.BasicAuth(); !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"estimatedBaseFee": "10"}`))
+	}))
+	// httptest listens on 127.0.0.1; "localhost" is another host for net/http
+	targetURL := strings.Replace(server.URL, "127.0.0.1", "localhost", 1)
+	return server, targetURL, &calls
+}
+
+func newRedirectingServer(target string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, target+r.URL.Path, http.StatusFound)
+	}))
+}
+
+func TestRedirect_ForeignHostRefused(t *testing.T) {
+	target, targetURL, calls := newRedirectTarget(t)
+	defer target.Close()
+	server := newRedirectingServer(targetURL)
+	defer server.Close()
+
+	client := NewClientWithOptions("test-api-key", "test-secret", WithBaseURL(server.URL), WithRetry(3, 0))
+	_, err := client.GetSuggestedGasFees(context.Background(), 1)
+	if !errors.Is(err, ErrRedirectAuthStripped) {
+		t.Fatalf("Expected ErrRedirectAuthStripped, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "WithRedirectAllowedHosts") {
+		t.Errorf("Expected the error to explain the allow-list, got %v", err)
+	}
+	if got := atomic.LoadInt32(calls); got != 0 {
+		t.Errorf("Expected the redirect not to be followed, got %d requests", got)
+	}
+}
+
+func TestRedirect_AllowedHost(t *testing.T) {
+	target, targetURL, calls := newRedirectTarget(t)
+	defer target.Close()
+	server := newRedirectingServer(targetURL)
+	defer server.Close()
+
+	client := NewClientWithOptions("test-api-key", "test-secret",
+		WithBaseURL(server.URL),
+		WithRedirectAllowedHosts("LOCALHOST"))
+	fees, err := client.GetSuggestedGasFees(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetSuggestedGasFees failed: %v", err)
+	}
+	if fees.EstimatedBaseFee != "10" || atomic.LoadInt32(calls) != 1 {
+		t.Errorf("Expected the redirected request to succeed once, got %+v after %d requests", fees, *calls)
+	}
+}
+
+func TestRedirect_SameHostKeepsAuth(t *testing.T) {
+	target, _, _ := newRedirectTarget(t)
+	defer target.Close()
+	server := newRedirectingServer(target.URL)
+	defer server.Close()
+
+	client := NewClientWithOptions("test-api-key", "test-secret", WithBaseURL(server.URL))
+	if _, err := client.GetSuggestedGasFees(context.Background(), 1); err != nil {
+		t.Fatalf("Expected a redirect to the same host to keep the credentials, got %v", err)
+	}
+}
+
+func TestRedirect_KeepsProvidedPolicy(t *testing.T) {
+	target, _, calls := newRedirectTarget(t)
+	defer target.Close()
+	server := newRedirectingServer(target.URL)
+	defer server.Close()
+
+	provided := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	client := NewClientWithOptions("test-api-key", "test-secret", WithBaseURL(server.URL), WithHTTPClient(provided))
+	_, err := client.GetSuggestedGasFees(context.Background(), 1)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusFound {
+		t.Errorf("Expected the provided policy to stop at the 302, got %v", err)
+	}
+	if atomic.LoadInt32(calls) != 0 {
+		t.Error("Expected the redirect not to be followed")
+	}
+}
+
+func TestWithRedirectAllowedHosts_Invalid(t *testing.T) {
+	for _, host := range []string{"", "example.com:443", "https://example.com"} {
+		if _, err := New("key", "", WithRedirectAllowedHosts(host)); !errors.Is(err, ErrInvalidOption) {
+			t.Errorf("%q: expected ErrInvalidOption, got %v", host, err)
+		}
+	}
+}
@@ -1,18 +1,20 @@
 package infura
 
+import "fmt"
+
 // SuggestedGasFees represents the response from the suggestedGasFees endpoint
 type SuggestedGasFees struct {
 	Low    GasFeeLevel `json:"low"`
 	Medium GasFeeLevel `json:"medium"`
 	High   GasFeeLevel `json:"high"`
 
-	EstimatedBaseFee           string   `json:"estimatedBaseFee"`
-	NetworkCongestion          float64  `json:"networkCongestion"`
-	LatestPriorityFeeRange     []string `json:"latestPriorityFeeRange"`
-	HistoricalPriorityFeeRange []string `json:"historicalPriorityFeeRange"`
-	HistoricalBaseFeeRange     []string `json:"historicalBaseFeeRange"`
-	PriorityFeeTrend           string   `json:"priorityFeeTrend"`
-	BaseFeeTrend               string   `json:"baseFeeTrend"`
+	EstimatedBaseFee           string     `json:"estimatedBaseFee"`
+	NetworkCongestion          Congestion `json:"networkCongestion"`
+	LatestPriorityFeeRange     []string   `json:"latestPriorityFeeRange"`
+	HistoricalPriorityFeeRange []string   `json:"historicalPriorityFeeRange"`
+	HistoricalBaseFeeRange     []string   `json:"historicalBaseFeeRange"`
+	PriorityFeeTrend           string     `json:"priorityFeeTrend"`
+	BaseFeeTrend               string     `json:"baseFeeTrend"`
 }
 
 // GasFeeLevel represents a gas fee level (low, medium, or high)
@@ -21,6 +23,8 @@ type GasFeeLevel struct {
 	SuggestedMaxFeePerGas         string `json:"suggestedMaxFeePerGas"`
 	MinWaitTimeEstimate           int64  `json:"minWaitTimeEstimate"`
 	MaxWaitTimeEstimate           int64  `json:"maxWaitTimeEstimate"`
+	// Clamped is true when a fee of this level was lowered to the cap set with WithMaxFeeCap
+	Clamped bool `json:"-"`
 }
 
 // BaseFeeHistory represents the response from the baseFeeHistory endpoint
@@ -32,7 +36,53 @@ type BaseFeePercentile struct {
 	BaseFeePercentile string `json:"baseFeePercentile"`
 }
 
+// BaseFeeSnapshot combines baseFeeHistory and baseFeePercentile fetched together
+type BaseFeeSnapshot struct {
+	History    BaseFeeHistory     `json:"baseFeeHistory"`
+	Percentile *BaseFeePercentile `json:"baseFeePercentile"`
+}
+
 // BusyThreshold represents the response from the busyThreshold endpoint
 type BusyThreshold struct {
 	BusyThreshold string `json:"busyThreshold"`
 }
+
+// Priority identifies one of the gas fee levels (low, medium, or high)
+type Priority int
+
+const (
+	// PriorityLow selects the low fee level
+	PriorityLow Priority = iota
+	// PriorityMedium selects the medium fee level
+	PriorityMedium
+	// PriorityHigh selects the high fee level
+	PriorityHigh
+)
+
+// String returns the lowercase name of the priority as used by the API
+func (p Priority) String() string {
+	switch p {
+	case PriorityLow:
+		return "low"
+	case PriorityMedium:
+		return "medium"
+	case PriorityHigh:
+		return "high"
+	default:
+		return fmt.Sprintf("Priority(%d)", int(p))
+	}
+}
+
+// Level returns the fee level matching the given priority
+func (f *SuggestedGasFees) Level(p Priority) (GasFeeLevel, error) {
+	switch p {
+	case PriorityLow:
+		return f.Low, nil
+	case PriorityMedium:
+		return f.Medium, nil
+	case PriorityHigh:
+		return f.High, nil
+	default:
+		return GasFeeLevel{}, fmt.Errorf("unknown priority: %d", int(p))
+	}
+}
@@ -0,0 +1,151 @@
+package infura
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// roundTripTypes maps fixture name prefixes in testdata/roundtrip to their response types
+var roundTripTypes = map[string]func() interface{}{
+	"suggested_gas_fees":  func() interface{} { return new(SuggestedGasFees) },
+	"base_fee_history":    func() interface{} { return new(BaseFeeHistory) },
+	"base_fee_percentile": func() interface{} { return new(BaseFeePercentile) },
+	"busy_threshold":      func() interface{} { return new(BusyThreshold) },
+	"base_fee_snapshot":   func() interface{} { return new(BaseFeeSnapshot) },
+	"fee_history":         func() interface{} { return new(FeeHistory) },
+}
+
+// TestResponseRoundTrip decodes captured payloads into their response types and checks
+// that marshaling them again yields semantically identical JSON
+func TestResponseRoundTrip(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "roundtrip", "*.json"))
+	if err != nil || len(files) == 0 {
+		t.Fatalf("No fixtures found: %v", err)
+	}
+	for _, file := range files {
+		name := strings.TrimSuffix(filepath.Base(file), ".json")
+		t.Run(name, func(t *testing.T) {
+			var newResult func() interface{}
+			for prefix, fn := range roundTripTypes {
+				if strings.HasPrefix(name, prefix) {
+					newResult = fn
+				}
+			}
+			if newResult == nil {
+				t.Fatalf("No response type for fixture %s", name)
+			}
+
+			original, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			result := newResult()
+			if err := json.Unmarshal(original, result); err != nil {
+				t.Fatalf("Unmarshal failed: %v", err)
+			}
+			marshaled, err := json.Marshal(result)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			assertSameJSON(t, original, marshaled)
+
+			// A second round trip must be stable byte for byte
+			again := newResult()
+			if err := json.Unmarshal(marshaled, again); err != nil {
+				t.Fatalf("Second Unmarshal failed: %v", err)
+			}
+			remarshaled, err := json.Marshal(again)
+			if err != nil || !bytes.Equal(marshaled, remarshaled) {
+				t.Errorf("Second round trip changed the JSON:\n%s\n%s (%v)", marshaled, remarshaled, err)
+			}
+		})
+	}
+}
+
+func TestFeeHistoryRoundTripKeepsPercentiles(t *testing.T) {
+	history := FeeHistory{
+		OldestBlock:       big.NewInt(100),
+		BaseFeePerGas:     []*big.Int{big.NewInt(7), big.NewInt(8)},
+		GasUsedRatio:      []float64{0.5},
+		Reward:            [][]*big.Int{{big.NewInt(1), big.NewInt(2)}},
+		RewardPercentiles: []float64{25, 75},
+	}
+	data, err := json.Marshal(history)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var decoded FeeHistory
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	tip, err := decoded.SuggestPriorityFee(75)
+	if err != nil || tip.Int64() != 2 || decoded.OldestBlock.Int64() != 100 {
+		t.Errorf("Unexpected decoded history %+v (tip %v, %v)", decoded, tip, err)
+	}
+}
+
+// assertSameJSON fails the test unless want and got have the same keys, the same string
+// values and numerically equal numbers, with numbers compared exactly
+func assertSameJSON(t *testing.T, want, got []byte) {
+	t.Helper()
+	decode := func(data []byte) interface{} {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		var v interface{}
+		if err := dec.Decode(&v); err != nil {
+			t.Fatalf("Invalid JSON %s: %v", data, err)
+		}
+		return v
+	}
+	if path, ok := sameJSONValue("$", decode(want), decode(got)); !ok {
+		t.Errorf("JSON differs at %s:\nwant %s\ngot  %s", path, want, got)
+	}
+}
+
+// sameJSONValue compares two decoded JSON values, returning the path of the first difference
+func sameJSONValue(path string, want, got interface{}) (string, bool) {
+	switch w := want.(type) {
+	case map[string]interface{}:
+		g, ok := got.(map[string]interface{})
+		if !ok || len(g) != len(w) {
+			return path, false
+		}
+		for key, wv := range w {
+			gv, ok := g[key]
+			if !ok {
+				return path + "." + key, false
+			}
+			if p, ok := sameJSONValue(path+"."+key, wv, gv); !ok {
+				return p, false
+			}
+		}
+		return "", true
+	case []interface{}:
+		g, ok := got.([]interface{})
+		if !ok || len(g) != len(w) {
+			return path, false
+		}
+		for i := range w {
+			if p, ok := sameJSONValue(path+"["+strconv.Itoa(i)+"]", w[i], g[i]); !ok {
+				return p, false
+			}
+		}
+		return "", true
+	case json.Number:
+		g, ok := got.(json.Number)
+		if !ok {
+			return path, false
+		}
+		wr, wok := new(big.Rat).SetString(string(w))
+		gr, gok := new(big.Rat).SetString(string(g))
+		return path, wok && gok && wr.Cmp(gr) == 0
+	default:
+		return path, want == got
+	}
+}
@@ -0,0 +1,103 @@
+package infura
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// maxRetryDelay caps the exponential backoff between retry attempts
+const maxRetryDelay = 30 * time.Second
+
+// retryConfig holds the retry settings of a client
+type retryConfig struct {
+	maxAttempts int
+	baseDelay   time.Duration
+	// maxElapsed bounds the time spent retrying a request (0 = unbounded)
+	maxElapsed time.Duration
+	// backoff replaces the default backoff derived from baseDelay (see WithRetryBackoff)
+	backoff *Backoff
+}
+
+// ErrRetryBudgetExhausted is wrapped, together with the last error, by requests that stopped
+// retrying because the budget set with WithMaxRetryElapsed ran out
+var ErrRetryBudgetExhausted = errors.New("retry budget exhausted")
+
+// WithRetry enables retrying of failed requests
+// maxAttempts is the total number of attempts including the first one
+// baseDelay is the wait before the first retry; it doubles on every further retry (capped at
+// 30s) unless WithRetryBackoff is used
+// Only rate limiting (429), server errors (5xx) and temporary network failures (see
+// IsTemporary) are retried; other network failures, such as an unknown host, are not
+func WithRetry(maxAttempts int, baseDelay time.Duration) ClientOption {
+	return func(c *Client) {
+		c.retry.maxAttempts = maxAttempts
+		c.retry.baseDelay = baseDelay
+	}
+}
+
+// WithRetryBackoff sets the delays between the retries enabled with WithRetry, e.g. to
+// add jitter, in place of the default that doubles the base delay of WithRetry up to 30s
+func WithRetryBackoff(backoff Backoff) ClientOption {
+	return func(c *Client) {
+		if backoff.Base < 0 || backoff.Cap < 0 {
+			c.rejectOption("WithRetryBackoff", "delays must not be negative, got base %v and cap %v", backoff.Base, backoff.Cap)
+			return
+		}
+		c.retry.backoff = &backoff
+	}
+}
+
+// WithMaxRetryElapsed bounds the time a request spends retrying, measured on the client's
+// clock from the start of its first attempt (0 = unbounded, the default)
+// No attempt is started once the budget has elapsed, and the backoff before a retry is
+// shortened so it ends at the budget at the latest. A request that stops for this reason
+// returns its last error wrapped with ErrRetryBudgetExhausted and the number of attempts
+// made; failover URLs (see WithFailoverURLs) are not tried after that.
+func WithMaxRetryElapsed(d time.Duration) ClientOption {
+	return func(c *Client) {
+		if d < 0 {
+			c.rejectOption("WithMaxRetryElapsed", "budget must not be negative, got %v", d)
+			return
+		}
+		c.retry.maxElapsed = d
+	}
+}
+
+// maxAttempts returns the number of attempts allowed per request (at least 1)
+func (c *Client) maxAttempts() int {
+	if c.retry.maxAttempts < 1 {
+		return 1
+	}
+	return c.retry.maxAttempts
+}
+
+// retryDelay returns the wait before the retry following the given attempt number
+func (c *Client) retryDelay(attempt int) time.Duration {
+	if c.retry.backoff != nil {
+		return c.retry.backoff.NextDelay(attempt)
+	}
+	return Backoff{Base: c.retry.baseDelay, Cap: maxRetryDelay}.NextDelay(attempt)
+}
+
+// retryBudgetError returns lastErr wrapped with ErrRetryBudgetExhausted if the retry budget of
+// a request whose first attempt started at start has run out, or nil otherwise
+func (c *Client) retryBudgetError(start time.Time, attempts int, lastErr error) error {
+	if c.retry.maxElapsed <= 0 {
+		return nil
+	}
+	if elapsed := c.clock.Now().Sub(start); elapsed >= c.retry.maxElapsed {
+		return fmt.Errorf("%w after %d attempts in %v (budget %v): %w", ErrRetryBudgetExhausted, attempts, elapsed, c.retry.maxElapsed, lastErr)
+	}
+	return nil
+}
+
+// retryBackoff returns the wait before the retry following the given attempt, shortened so
+// it ends when the retry budget of a request started at start runs out
+func (c *Client) retryBackoff(start time.Time, attempt int) time.Duration {
+	delay := c.retryDelay(attempt)
+	if c.retry.maxElapsed <= 0 {
+		return delay
+	}
+	return min(delay, c.retry.maxElapsed-c.clock.Now().Sub(start))
+}
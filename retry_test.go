@@ -0,0 +1,242 @@
+package infura
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithRetry_RetriesUntilSuccess(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"busyThreshold": "10"}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithRetry(3, time.Millisecond))
+
+	result, err := client.GetBusyThreshold(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetBusyThreshold failed: %v", err)
+	}
+	if result.BusyThreshold != "10" {
+		t.Errorf("Expected busyThreshold 10, got %s", result.BusyThreshold)
+	}
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Errorf("Expected 3 attempts, got %d", got)
+	}
+}
+
+func TestWithRetry_Exhausted(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithRetry(4, time.Millisecond))
+
+	_, err := client.GetBusyThreshold(context.Background(), 1)
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("Expected ErrRateLimited, got %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 4 {
+		t.Errorf("Expected 4 attempts, got %d", got)
+	}
+}
+
+func TestWithRetry_DoesNotRetryClientErrors(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithRetry(5, time.Millisecond))
+
+	_, err := client.GetBusyThreshold(context.Background(), 1)
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("Expected ErrUnauthorized, got %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("Expected a single attempt, got %d", got)
+	}
+}
+
+func TestWithRetry_DoesNotRetryDailyQuota(t *testing.T) {
+	var calls, failoverCalls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"error": {"code": -32005, "message": "daily request count exceeded, request rate limited"}}`))
+	}))
+	defer server.Close()
+	failover := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&failoverCalls, 1)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"busyThreshold": "10"}`))
+	}))
+	defer failover.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithFailoverURLs(failover.URL),
+		WithRetry(3, time.Millisecond))
+
+	_, err := client.GetBusyThreshold(context.Background(), 1)
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("Expected ErrRateLimited, got %v", err)
+	}
+	if errors.Is(err, ErrUnauthorized) {
+		t.Errorf("Expected the daily quota error not to match ErrUnauthorized, got %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("Expected a single attempt, got %d", got)
+	}
+	if got := atomic.LoadInt32(&failoverCalls); got != 0 {
+		t.Errorf("Expected no failover attempt, got %d", got)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	client := NewClientWithOptions("test-api-key", "", WithRetry(10, time.Second))
+
+	expected := []time.Duration{
+		time.Second,
+		2 * time.Second,
+		4 * time.Second,
+		8 * time.Second,
+		16 * time.Second,
+		maxRetryDelay,
+		maxRetryDelay,
+	}
+	for i, want := range expected {
+		if got := client.retryDelay(i + 1); got != want {
+			t.Errorf("retryDelay(%d) = %v, want %v", i+1, got, want)
+		}
+	}
+}
+
+func TestWithMaxRetryElapsed_StopsAtBudget(t *testing.T) {
+	clock := newFakeClock(time.Unix(0, 0))
+	var mu sync.Mutex
+	var offsets []time.Duration
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		offsets = append(offsets, clock.Now().Sub(time.Unix(0, 0)))
+		mu.Unlock()
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithClock(clock),
+		WithRetry(10, time.Second),
+		WithMaxRetryElapsed(5*time.Second))
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.GetBusyThreshold(context.Background(), 1)
+		done <- err
+	}()
+	err := driveClock(t, clock, 100*time.Millisecond, done)
+
+	if !errors.Is(err, ErrRetryBudgetExhausted) || !errors.Is(err, ErrServerError) {
+		t.Fatalf("Expected the last error wrapped with ErrRetryBudgetExhausted, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "after 4 attempts") {
+		t.Errorf("Expected the attempt count in the error, got %v", err)
+	}
+	// Backoff of 1s, 2s, then 4s shortened to the 2s left of the budget
+	want := []time.Duration{0, time.Second, 3 * time.Second, 5 * time.Second}
+	mu.Lock()
+	defer mu.Unlock()
+	if !reflect.DeepEqual(offsets, want) {
+		t.Errorf("Attempts at %v, want %v", offsets, want)
+	}
+}
+
+func TestWithMaxRetryElapsed_MaxAttemptsReachedFirst(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithMaxRetryElapsed(time.Minute),
+		WithRetry(3, time.Millisecond))
+
+	_, err := client.GetBusyThreshold(context.Background(), 1)
+	if !errors.Is(err, ErrRateLimited) || errors.Is(err, ErrRetryBudgetExhausted) {
+		t.Fatalf("Expected a plain ErrRateLimited, got %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Errorf("Expected 3 attempts, got %d", got)
+	}
+}
+
+func TestWithMaxRetryElapsed_NoFailoverAfterBudget(t *testing.T) {
+	clock := newFakeClock(time.Unix(0, 0))
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer primary.Close()
+	var secondaryCalls int32
+	secondary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&secondaryCalls, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer secondary.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(primary.URL),
+		WithFailoverURLs(secondary.URL),
+		WithClock(clock),
+		WithRetry(2, 3*time.Second),
+		WithMaxRetryElapsed(2*time.Second))
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.GetBusyThreshold(context.Background(), 1)
+		done <- err
+	}()
+	err := driveClock(t, clock, 500*time.Millisecond, done)
+
+	if !errors.Is(err, ErrRetryBudgetExhausted) || !strings.Contains(err.Error(), "after 2 attempts") {
+		t.Fatalf("Expected the budget to stop the request after 2 attempts, got %v", err)
+	}
+	if got := atomic.LoadInt32(&secondaryCalls); got != 0 {
+		t.Errorf("Expected no failover after the budget ran out, got %d calls", got)
+	}
+}
+
+func TestWithMaxRetryElapsed_RejectsNegative(t *testing.T) {
+	if _, err := New("test-api-key", "", WithMaxRetryElapsed(-time.Second)); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("Expected ErrInvalidOption, got %v", err)
+	}
+}
@@ -0,0 +1,177 @@
+package infura
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync/atomic"
+)
+
+// RPCCaller performs Ethereum JSON-RPC calls against a given chain
+// Implement it to plug in an existing RPC client or a mock in tests
+type RPCCaller interface {
+	CallRPC(ctx context.Context, chainID int64, method string, params []interface{}, result interface{}) error
+}
+
+// WithRPC sets the JSON-RPC backend used by RPC-based helpers such as GetGasPrice
+func WithRPC(rpc RPCCaller) ClientOption {
+	return func(c *Client) {
+		c.rpc = rpc
+	}
+}
+
+// RPCError is a JSON-RPC error object returned by a node
+type RPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Error implements the error interface
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("RPC error %d: %s", e.Code, e.Message)
+}
+
+// HTTPRPC is a minimal JSON-RPC 2.0 client sending requests over HTTP to per-chain endpoints
+type HTTPRPC struct {
+	endpoints  map[int64]string
+	httpClient *http.Client
+	nextID     atomic.Int64
+}
+
+// NewHTTPRPC creates a JSON-RPC client for the given chain ID to endpoint URL mapping
+// (e.g. 1: "https://mainnet.infura.io/v3/{apiKey}"). If httpClient is nil, a client with
+// DefaultTimeout is used
+func NewHTTPRPC(endpoints map[int64]string, httpClient *http.Client) *HTTPRPC {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: DefaultTimeout}
+	}
+	copied := make(map[int64]string, len(endpoints))
+	for chainID, url := range endpoints {
+		copied[chainID] = url
+	}
+	return &HTTPRPC{endpoints: copied, httpClient: httpClient}
+}
+
+type rpcRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      int64         `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *RPCError       `json:"error"`
+}
+
+// CallRPC sends a JSON-RPC request to the endpoint configured for chainID and decodes the result
+func (r *HTTPRPC) CallRPC(ctx context.Context, chainID int64, method string, params []interface{}, result interface{}) error {
+	endpoint, ok := r.endpoints[chainID]
+	if !ok {
+		return fmt.Errorf("no RPC endpoint configured for chain %d", chainID)
+	}
+	if params == nil {
+		params = []interface{}{}
+	}
+
+	reqBody, err := json.Marshal(rpcRequest{JSONRPC: "2.0", ID: r.nextID.Add(1), Method: method, Params: params})
+	if err != nil {
+		return fmt.Errorf("failed to marshal RPC request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(reqBody))
+	if err != nil {
+		return fmt.Errorf("failed to create RPC request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", defaultUserAgent)
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute RPC request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read RPC response body: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("RPC request failed with status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var rpcResp rpcResponse
+	if err := json.Unmarshal(respBody, &rpcResp); err != nil {
+		return fmt.Errorf("failed to decode RPC response: %w", err)
+	}
+	if rpcResp.Error != nil {
+		return rpcResp.Error
+	}
+	if result != nil {
+		if err := json.Unmarshal(rpcResp.Result, result); err != nil {
+			return fmt.Errorf("failed to decode RPC result: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetGasPrice retrieves the legacy gas price in wei via eth_gasPrice
+// Requires an RPC backend configured with WithRPC
+func (c *Client) GetGasPrice(ctx context.Context, chainID int64, opts ...CallOption) (*big.Int, error) {
+	ctx, cancel := withCallOptions(ctx, opts)
+	defer cancel()
+
+	return c.callRPCQuantity(ctx, chainID, "eth_gasPrice")
+}
+
+// GetMaxPriorityFeePerGas retrieves the node's suggested priority fee in wei via eth_maxPriorityFeePerGas
+// Requires an RPC backend configured with WithRPC
+func (c *Client) GetMaxPriorityFeePerGas(ctx context.Context, chainID int64, opts ...CallOption) (*big.Int, error) {
+	ctx, cancel := withCallOptions(ctx, opts)
+	defer cancel()
+
+	return c.callRPCQuantity(ctx, chainID, "eth_maxPriorityFeePerGas")
+}
+
+// callRPCQuantity calls a parameterless RPC method that returns a hex quantity
+func (c *Client) callRPCQuantity(ctx context.Context, chainID int64, method string) (*big.Int, error) {
+	if c.rpc == nil {
+		return nil, fmt.Errorf("no RPC backend configured (use WithRPC)")
+	}
+	var hex string
+	if err := c.callRPC(ctx, chainID, method, nil, &hex); err != nil {
+		return nil, err
+	}
+	return parseHexQuantity(hex)
+}
+
+// callRPC calls the RPC backend while holding an in-flight slot (see WithMaxInFlight)
+func (c *Client) callRPC(ctx context.Context, chainID int64, method string, params []interface{}, result interface{}) error {
+	if err := c.inFlight.acquire(ctx); err != nil {
+		return err
+	}
+	defer c.inFlight.release()
+	return c.rpc.CallRPC(ctx, chainID, method, params, result)
+}
+
+// parseHexQuantity decodes an Ethereum JSON-RPC hex quantity such as "0x1a2b"
+func parseHexQuantity(s string) (*big.Int, error) {
+	digits, ok := strings.CutPrefix(s, "0x")
+	if !ok {
+		digits, ok = strings.CutPrefix(s, "0X")
+	}
+	if !ok || digits == "" || digits[0] == '+' || digits[0] == '-' {
+		return nil, fmt.Errorf("invalid hex quantity: %q", s)
+	}
+	value, ok := new(big.Int).SetString(digits, 16)
+	if !ok {
+		return nil, fmt.Errorf("invalid hex quantity: %q", s)
+	}
+	return value, nil
+}
@@ -0,0 +1,114 @@
+package infura
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// mockRPC is an RPCCaller returning canned hex results per method
+type mockRPC struct {
+	results map[string]string
+	err     error
+	calls   int
+}
+
+func (m *mockRPC) CallRPC(ctx context.Context, chainID int64, method string, params []interface{}, result interface{}) error {
+	m.calls++
+	if m.err != nil {
+		return m.err
+	}
+	raw, _ := json.Marshal(m.results[method])
+	return json.Unmarshal(raw, result)
+}
+
+func TestHTTPRPC_CallRPC(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req rpcRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		if req.JSONRPC != "2.0" || req.Method != "eth_gasPrice" {
+			t.Errorf("unexpected request: %+v", req)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x3b9aca00"}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithRPC(NewHTTPRPC(map[int64]string{1: server.URL}, nil)))
+
+	price, err := client.GetGasPrice(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetGasPrice failed: %v", err)
+	}
+	if price.String() != "1000000000" {
+		t.Errorf("Expected 1000000000 wei, got %s", price)
+	}
+}
+
+func TestHTTPRPC_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"method not found"}}`))
+	}))
+	defer server.Close()
+
+	rpc := NewHTTPRPC(map[int64]string{1: server.URL}, nil)
+
+	var result string
+	err := rpc.CallRPC(context.Background(), 1, "eth_maxPriorityFeePerGas", nil, &result)
+	var rpcErr *RPCError
+	if !errors.As(err, &rpcErr) || rpcErr.Code != -32601 {
+		t.Fatalf("Expected RPCError -32601, got %v", err)
+	}
+
+	if err := rpc.CallRPC(context.Background(), 137, "eth_gasPrice", nil, &result); err == nil {
+		t.Error("Expected error for chain without endpoint")
+	}
+}
+
+func TestGetGasPrice_NoRPC(t *testing.T) {
+	client := NewClientWithAPIKey("test-api-key")
+	if _, err := client.GetGasPrice(context.Background(), 1); err == nil {
+		t.Error("Expected error without RPC backend")
+	}
+}
+
+func TestParseHexQuantity(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "0x0", want: "0"},
+		{input: "0x1a", want: "26"},
+		{input: "0X3B9ACA00", want: "1000000000"},
+		{input: "", wantErr: true},
+		{input: "0x", wantErr: true},
+		{input: "1a", wantErr: true},
+		{input: "0x-1", wantErr: true},
+		{input: "0xzz", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseHexQuantity(tt.input)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseHexQuantity(%q): expected error", tt.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseHexQuantity(%q) failed: %v", tt.input, err)
+			continue
+		}
+		if got.String() != tt.want {
+			t.Errorf("parseHexQuantity(%q) = %s, want %s", tt.input, got, tt.want)
+		}
+	}
+}
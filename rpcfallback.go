@@ -0,0 +1,65 @@
+package infura
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/big"
+)
+
+// GasSource identifies where a GasResult came from
+type GasSource string
+
+const (
+	// GasSourceGasAPI means the result holds suggestedGasFees from the Gas API
+	GasSourceGasAPI GasSource = "gas-api"
+	// GasSourceRPC means the Gas API failed and the result holds eth_gasPrice from the RPC backend
+	GasSourceRPC GasSource = "rpc"
+)
+
+// GasResult is the result of GetGasWithFallback
+type GasResult struct {
+	Source GasSource
+	// Fees is set when Source is GasSourceGasAPI
+	Fees *SuggestedGasFees
+	// GasPrice is the legacy gas price in wei, set when Source is GasSourceRPC
+	GasPrice *big.Int
+	// APIError is the Gas API error that caused the fallback, set when Source is GasSourceRPC
+	APIError error
+}
+
+// GetGasWithFallback retrieves suggested gas fees and falls back to the legacy eth_gasPrice
+// over JSON-RPC when the Gas API is unavailable. Requires an RPC backend configured with WithRPC.
+//
+// The fallback is used for the same failures as WithFallbackFees: rate limiting, 5xx and
+// transport errors that persist through all retry attempts. Authentication failures, unknown
+// chains and cancelled contexts are returned as is. Static fees configured with
+// WithFallbackFees are applied by GetSuggestedGasFees first and reported as GasSourceGasAPI
+// (use CallMeta.Fallback to detect them). If the RPC call fails too, the returned error
+// wraps both errors.
+func (c *Client) GetGasWithFallback(ctx context.Context, chainID int64, opts ...CallOption) (*GasResult, error) {
+	if c.rpc == nil {
+		return nil, fmt.Errorf("no RPC backend configured (use WithRPC)")
+	}
+
+	ctx, cancel := withCallOptions(ctx, opts)
+	defer cancel()
+
+	fees, apiErr := c.GetSuggestedGasFees(ctx, chainID)
+	if apiErr == nil {
+		return &GasResult{Source: GasSourceGasAPI, Fees: fees}, nil
+	}
+	if !isRetryable(apiErr) || ctx.Err() != nil {
+		return nil, apiErr
+	}
+
+	if logger := c.debugLogger(ctx); logger != nil {
+		logger.Printf("[DEBUG] Gas API failed for chain %d, falling back to eth_gasPrice: %v\n", chainID, apiErr)
+	}
+	c.logStructured(ctx, "infura rpc fallback", slog.Int64("chain_id", chainID), slog.String("error", c.mask(apiErr.Error())))
+	price, err := c.GetGasPrice(ctx, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("gas API failed: %w; RPC fallback failed: %w", apiErr, err)
+	}
+	return &GasResult{Source: GasSourceRPC, GasPrice: price, APIError: apiErr}, nil
+}
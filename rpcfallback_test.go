@@ -0,0 +1,105 @@
+package infura
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newFallbackServer returns a Gas API server answering suggestedGasFees with the given status
+func newFallbackServer(status int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		if status == http.StatusOK {
+			w.Write([]byte(`{"estimatedBaseFee":"24.0","networkCongestion":0.5}`))
+			return
+		}
+		w.Write([]byte(`{"error":"unavailable"}`))
+	}))
+}
+
+func TestGetGasWithFallback_GasAPI(t *testing.T) {
+	server := newFallbackServer(http.StatusOK)
+	defer server.Close()
+
+	// The RPC backend is down, but it is not needed
+	rpc := &mockRPC{err: errors.New("node down")}
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL), WithRPC(rpc))
+
+	result, err := client.GetGasWithFallback(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetGasWithFallback failed: %v", err)
+	}
+	if result.Source != GasSourceGasAPI || result.Fees == nil || result.Fees.EstimatedBaseFee != "24.0" {
+		t.Errorf("Expected Gas API fees, got %+v", result)
+	}
+	if result.GasPrice != nil || result.APIError != nil {
+		t.Errorf("Expected no RPC fields, got %+v", result)
+	}
+	if rpc.calls != 0 {
+		t.Errorf("Expected no RPC calls, got %d", rpc.calls)
+	}
+}
+
+func TestGetGasWithFallback_RPC(t *testing.T) {
+	server := newFallbackServer(http.StatusServiceUnavailable)
+	defer server.Close()
+
+	rpc := &mockRPC{results: map[string]string{"eth_gasPrice": "0x3b9aca00"}}
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL), WithRPC(rpc))
+
+	result, err := client.GetGasWithFallback(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetGasWithFallback failed: %v", err)
+	}
+	if result.Source != GasSourceRPC || result.GasPrice == nil || result.GasPrice.String() != "1000000000" {
+		t.Errorf("Expected the RPC gas price, got %+v", result)
+	}
+	if result.Fees != nil {
+		t.Errorf("Expected no Gas API fees, got %+v", result.Fees)
+	}
+	if !errors.Is(result.APIError, ErrServerError) {
+		t.Errorf("Expected the 503 that caused the fallback, got %v", result.APIError)
+	}
+}
+
+func TestGetGasWithFallback_BothFail(t *testing.T) {
+	server := newFallbackServer(http.StatusBadGateway)
+	defer server.Close()
+
+	rpcErr := errors.New("node down")
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL), WithRPC(&mockRPC{err: rpcErr}))
+
+	_, err := client.GetGasWithFallback(context.Background(), 1)
+	if !errors.Is(err, ErrServerError) || !errors.Is(err, rpcErr) {
+		t.Errorf("Expected both errors to be wrapped, got %v", err)
+	}
+}
+
+func TestGetGasWithFallback_NoFallbackForAuthErrors(t *testing.T) {
+	server := newFallbackServer(http.StatusUnauthorized)
+	defer server.Close()
+
+	rpc := &mockRPC{results: map[string]string{"eth_gasPrice": "0x1"}}
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL), WithRPC(rpc))
+
+	if _, err := client.GetGasWithFallback(context.Background(), 1); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("Expected ErrUnauthorized, got %v", err)
+	}
+	if rpc.calls != 0 {
+		t.Errorf("Expected no RPC calls, got %d", rpc.calls)
+	}
+}
+
+func TestGetGasWithFallback_RequiresRPC(t *testing.T) {
+	server := newFallbackServer(http.StatusOK)
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL))
+	if _, err := client.GetGasWithFallback(context.Background(), 1); err == nil {
+		t.Error("Expected an error without an RPC backend")
+	}
+}
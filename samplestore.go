@@ -0,0 +1,262 @@
+package infura
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+)
+
+// ErrNoSamples is returned by SampleStore statistics when no sample falls in the window
+var ErrNoSamples = errors.New("no samples in window")
+
+// Sample is a fee suggestion recorded by a SampleStore
+type Sample struct {
+	ChainID int64
+	Time    time.Time
+	Fees    *SuggestedGasFees
+}
+
+// SampleStats summarizes a fee over the samples of a window
+type SampleStats struct {
+	// Count is the number of samples in the window
+	Count int
+	// Min, Max and Avg are exact; Avg is rounded to the nearest wei
+	Min Gwei
+	Max Gwei
+	Avg Gwei
+	// From and To are the times of the oldest and newest sample in the window
+	From time.Time
+	To   time.Time
+}
+
+// SampleStoreConfig configures a SampleStore
+type SampleStoreConfig struct {
+	// Capacity is the maximum number of samples kept per chain; the oldest sample is
+	// evicted when a chain is full. It must be positive.
+	Capacity int
+	// Retention is how long samples are kept (0 = until evicted by Capacity)
+	Retention time.Duration
+	// Clock measures sample age for Retention and windows; nil uses the system clock
+	Clock Clock
+}
+
+// SampleStore remembers the recent fee suggestions of each chain in a bounded ring buffer,
+// so a process can ask what fees were over the last hour. Feed it from a watcher with
+// Stream or Observe, and query it with Range, Latest, Stats and BaseFeeStats.
+// A SampleStore is safe for concurrent use by the writer and any number of readers.
+type SampleStore struct {
+	capacity  int
+	retention time.Duration
+	clock     Clock
+
+	mu     sync.RWMutex
+	chains map[int64]*sampleRing
+}
+
+// sampleRing is a fixed-size ring of samples, oldest first from start
+type sampleRing struct {
+	samples []Sample
+	start   int
+	n       int
+}
+
+// NewSampleStore creates an empty SampleStore
+func NewSampleStore(cfg SampleStoreConfig) (*SampleStore, error) {
+	if cfg.Capacity <= 0 {
+		return nil, fmt.Errorf("capacity must be positive, got %d", cfg.Capacity)
+	}
+	if cfg.Retention < 0 {
+		return nil, fmt.Errorf("retention must not be negative, got %v", cfg.Retention)
+	}
+	clock := cfg.Clock
+	if clock == nil {
+		clock = realClock{}
+	}
+	return &SampleStore{
+		capacity:  cfg.Capacity,
+		retention: cfg.Retention,
+		clock:     clock,
+		chains:    make(map[int64]*sampleRing),
+	}, nil
+}
+
+// Stream records every successful event read from in and forwards all events unchanged
+// The returned channel is closed when in is closed or ctx is done
+func (s *SampleStore) Stream(ctx context.Context, in <-chan WatchEvent) <-chan WatchEvent {
+	out := make(chan WatchEvent)
+	go func() {
+		defer close(out)
+		for {
+			var event WatchEvent
+			var ok bool
+			select {
+			case event, ok = <-in:
+				if !ok {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+
+			if event.Err == nil && event.Fees != nil {
+				s.Observe(event.ChainID, event.Time, event.Fees)
+			}
+
+			select {
+			case out <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// Observe records a copy of a suggestion for chainID taken at the given time
+// Samples are expected in time order; the oldest sample is evicted when the chain is full,
+// and samples older than the retention are dropped. A nil suggestion is ignored.
+func (s *SampleStore) Observe(chainID int64, at time.Time, fees *SuggestedGasFees) {
+	if fees == nil {
+		return
+	}
+	sample := cloneSuggestedGasFees(*fees)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ring, ok := s.chains[chainID]
+	if !ok {
+		ring = &sampleRing{samples: make([]Sample, s.capacity)}
+		s.chains[chainID] = ring
+	}
+	ring.push(Sample{ChainID: chainID, Time: at, Fees: &sample})
+	if cutoff, ok := s.cutoff(); ok {
+		ring.evictBefore(cutoff)
+	}
+}
+
+// Latest returns the newest sample of chainID, or false if there is none within the retention
+func (s *SampleStore) Latest(chainID int64) (Sample, bool) {
+	samples := s.window(chainID, time.Time{}, time.Time{})
+	if len(samples) == 0 {
+		return Sample{}, false
+	}
+	return samples[len(samples)-1], true
+}
+
+// Range returns the samples of chainID taken between from and to, both inclusive, oldest first
+// A zero from or to leaves that end of the range open.
+func (s *SampleStore) Range(chainID int64, from, to time.Time) []Sample {
+	return s.window(chainID, from, to)
+}
+
+// Stats returns the minimum, maximum and average suggestedMaxFeePerGas of the given level
+// over the samples of chainID taken in the last window
+func (s *SampleStore) Stats(chainID int64, window time.Duration, p Priority) (SampleStats, error) {
+	if p != PriorityLow && p != PriorityMedium && p != PriorityHigh {
+		return SampleStats{}, fmt.Errorf("unknown priority: %d", int(p))
+	}
+	return s.stats(chainID, window, func(fees *SuggestedGasFees) string {
+		level, _ := fees.Level(p)
+		return level.SuggestedMaxFeePerGas
+	})
+}
+
+// BaseFeeStats returns the minimum, maximum and average estimatedBaseFee over the samples
+// of chainID taken in the last window
+func (s *SampleStore) BaseFeeStats(chainID int64, window time.Duration) (SampleStats, error) {
+	return s.stats(chainID, window, func(fees *SuggestedGasFees) string {
+		return fees.EstimatedBaseFee
+	})
+}
+
+// stats aggregates the value selected by field over the last window; samples whose value
+// does not parse fail the query
+func (s *SampleStore) stats(chainID int64, window time.Duration, field func(*SuggestedGasFees) string) (SampleStats, error) {
+	if window <= 0 {
+		return SampleStats{}, fmt.Errorf("window must be positive, got %v", window)
+	}
+	samples := s.window(chainID, s.clock.Now().Add(-window), time.Time{})
+	if len(samples) == 0 {
+		return SampleStats{}, ErrNoSamples
+	}
+
+	stats := SampleStats{Count: len(samples), From: samples[0].Time, To: samples[len(samples)-1].Time}
+	var sum Gwei
+	for i, sample := range samples {
+		value, err := ParseGwei(field(sample.Fees))
+		if err != nil {
+			return SampleStats{}, fmt.Errorf("invalid sample at %v: %w", sample.Time, err)
+		}
+		if i == 0 || value.Cmp(stats.Min) < 0 {
+			stats.Min = value
+		}
+		if i == 0 || value.Cmp(stats.Max) > 0 {
+			stats.Max = value
+		}
+		sum = sum.Add(value)
+	}
+	avg := new(big.Rat).SetFrac(sum.Wei(), big.NewInt(int64(len(samples))))
+	stats.Avg = GweiFromWei(roundRat(avg))
+	return stats, nil
+}
+
+// window returns copies of the samples of chainID within the retention and [from, to]
+func (s *SampleStore) window(chainID int64, from, to time.Time) []Sample {
+	if cutoff, ok := s.cutoff(); ok && cutoff.After(from) {
+		from = cutoff
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	ring, ok := s.chains[chainID]
+	if !ok {
+		return nil
+	}
+	var samples []Sample
+	for i := 0; i < ring.n; i++ {
+		sample := ring.at(i)
+		if (!from.IsZero() && sample.Time.Before(from)) || (!to.IsZero() && sample.Time.After(to)) {
+			continue
+		}
+		fees := cloneSuggestedGasFees(*sample.Fees)
+		sample.Fees = &fees
+		samples = append(samples, sample)
+	}
+	return samples
+}
+
+// cutoff returns the time before which samples are past the retention, if one is set
+func (s *SampleStore) cutoff() (time.Time, bool) {
+	if s.retention <= 0 {
+		return time.Time{}, false
+	}
+	return s.clock.Now().Add(-s.retention), true
+}
+
+// push appends a sample, overwriting the oldest one when the ring is full
+func (r *sampleRing) push(sample Sample) {
+	if r.n < len(r.samples) {
+		r.samples[(r.start+r.n)%len(r.samples)] = sample
+		r.n++
+		return
+	}
+	r.samples[r.start] = sample
+	r.start = (r.start + 1) % len(r.samples)
+}
+
+// at returns the i-th oldest sample
+func (r *sampleRing) at(i int) Sample {
+	return r.samples[(r.start+i)%len(r.samples)]
+}
+
+// evictBefore drops the samples taken before cutoff from the start of the ring
+func (r *sampleRing) evictBefore(cutoff time.Time) {
+	for r.n > 0 && r.at(0).Time.Before(cutoff) {
+		r.samples[r.start] = Sample{}
+		r.start = (r.start + 1) % len(r.samples)
+		r.n--
+	}
+}
@@ -0,0 +1,170 @@
+package infura
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// sampleFees returns a suggestion with the given medium maxFeePerGas and base fee
+func sampleFees(medium, baseFee string) *SuggestedGasFees {
+	return &SuggestedGasFees{Medium: GasFeeLevel{SuggestedMaxFeePerGas: medium}, EstimatedBaseFee: baseFee}
+}
+
+func TestSampleStore_WindowedQueries(t *testing.T) {
+	start := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	clock := newFakeClock(start)
+	store, err := NewSampleStore(SampleStoreConfig{Capacity: 100, Retention: time.Hour, Clock: clock})
+	if err != nil {
+		t.Fatalf("NewSampleStore failed: %v", err)
+	}
+
+	// One sample per 10 minutes: medium 30, 31, ..., 36 Gwei
+	series := []string{"30", "31", "32", "33", "34", "35", "36"}
+	for i, fee := range series {
+		if i > 0 {
+			clock.Advance(10 * time.Minute)
+		}
+		store.Observe(1, clock.Now(), sampleFees(fee, "20."+fee))
+	}
+	store.Observe(137, clock.Now(), sampleFees("100", "90"))
+
+	latest, ok := store.Latest(1)
+	if !ok || latest.Fees.Medium.SuggestedMaxFeePerGas != "36" || !latest.Time.Equal(start.Add(time.Hour)) {
+		t.Errorf("Latest = %+v, %v", latest, ok)
+	}
+
+	samples := store.Range(1, start.Add(15*time.Minute), start.Add(40*time.Minute))
+	if len(samples) != 3 || samples[0].Fees.Medium.SuggestedMaxFeePerGas != "32" || samples[2].Fees.Medium.SuggestedMaxFeePerGas != "34" {
+		t.Errorf("Range returned %d samples: %+v", len(samples), samples)
+	}
+
+	stats, err := store.Stats(1, 30*time.Minute, PriorityMedium)
+	if err != nil {
+		t.Fatalf("Stats failed: %v", err)
+	}
+	if stats.Count != 4 || stats.Min.String() != "33" || stats.Max.String() != "36" || stats.Avg.String() != "34.5" {
+		t.Errorf("Stats = count %d min %s max %s avg %s", stats.Count, stats.Min, stats.Max, stats.Avg)
+	}
+	if !stats.From.Equal(start.Add(30*time.Minute)) || !stats.To.Equal(start.Add(time.Hour)) {
+		t.Errorf("Stats window = %v to %v", stats.From, stats.To)
+	}
+
+	baseStats, err := store.BaseFeeStats(1, 20*time.Minute)
+	if err != nil {
+		t.Fatalf("BaseFeeStats failed: %v", err)
+	}
+	if baseStats.Count != 3 || baseStats.Min.String() != "20.34" || baseStats.Max.String() != "20.36" || baseStats.Avg.String() != "20.35" {
+		t.Errorf("BaseFeeStats = count %d min %s max %s avg %s", baseStats.Count, baseStats.Min, baseStats.Max, baseStats.Avg)
+	}
+
+	if stats, err := store.Stats(137, time.Minute, PriorityMedium); err != nil || stats.Count != 1 || stats.Avg.String() != "100" {
+		t.Errorf("Expected chains to be kept apart, got %+v, %v", stats, err)
+	}
+	if _, err := store.Stats(10, time.Hour, PriorityMedium); !errors.Is(err, ErrNoSamples) {
+		t.Errorf("Expected ErrNoSamples for an unknown chain, got %v", err)
+	}
+}
+
+func TestSampleStore_Eviction(t *testing.T) {
+	start := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	clock := newFakeClock(start)
+	store, err := NewSampleStore(SampleStoreConfig{Capacity: 3, Retention: 25 * time.Minute, Clock: clock})
+	if err != nil {
+		t.Fatalf("NewSampleStore failed: %v", err)
+	}
+
+	for _, fee := range []string{"1", "2", "3", "4"} {
+		store.Observe(1, clock.Now(), sampleFees(fee, fee))
+		clock.Advance(time.Minute)
+	}
+	if samples := store.Range(1, time.Time{}, time.Time{}); len(samples) != 3 || samples[0].Fees.Medium.SuggestedMaxFeePerGas != "2" {
+		t.Errorf("Expected the oldest sample to be evicted by capacity, got %+v", samples)
+	}
+
+	// At start+28m, only the sample taken at start+3m is within the retention
+	clock.Advance(24 * time.Minute)
+	if samples := store.Range(1, time.Time{}, time.Time{}); len(samples) != 1 || samples[0].Fees.Medium.SuggestedMaxFeePerGas != "4" {
+		t.Errorf("Expected samples past the retention to be hidden, got %+v", samples)
+	}
+	clock.Advance(10 * time.Minute)
+	if _, ok := store.Latest(1); ok {
+		t.Error("Expected no sample within the retention")
+	}
+	store.Observe(1, clock.Now(), sampleFees("5", "5"))
+	if samples := store.Range(1, time.Time{}, time.Time{}); len(samples) != 1 || samples[0].Fees.Medium.SuggestedMaxFeePerGas != "5" {
+		t.Errorf("Expected expired samples to be evicted on insert, got %+v", samples)
+	}
+}
+
+func TestSampleStore_ObserveNil(t *testing.T) {
+	clock := newFakeClock(time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC))
+	store, err := NewSampleStore(SampleStoreConfig{Capacity: 3, Retention: time.Hour, Clock: clock})
+	if err != nil {
+		t.Fatalf("NewSampleStore failed: %v", err)
+	}
+
+	store.Observe(1, clock.Now(), sampleFees("1", "1"))
+	clock.Advance(time.Minute)
+	store.Observe(1, clock.Now(), nil)
+	if samples := store.Range(1, time.Time{}, time.Time{}); len(samples) != 1 || samples[0].Fees.Medium.SuggestedMaxFeePerGas != "1" {
+		t.Errorf("Expected a nil suggestion to be ignored, got %+v", samples)
+	}
+	store.Observe(137, clock.Now(), nil)
+	if _, ok := store.Latest(137); ok {
+		t.Error("Expected no sample for a chain that only observed nil")
+	}
+}
+
+func TestSampleStore_StreamConcurrentReaders(t *testing.T) {
+	clock := newFakeClock(time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC))
+	store, err := NewSampleStore(SampleStoreConfig{Capacity: 10, Clock: clock})
+	if err != nil {
+		t.Fatalf("NewSampleStore failed: %v", err)
+	}
+
+	in := make(chan WatchEvent)
+	out := store.Stream(context.Background(), in)
+	go func() {
+		defer close(in)
+		for i := 0; i < 50; i++ {
+			in <- WatchEvent{ChainID: 1, Time: clock.Now(), Fees: sampleFees("30", "20")}
+		}
+		in <- WatchEvent{ChainID: 1, Time: clock.Now(), Err: errors.New("poll failed")}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				store.Latest(1)
+				store.Stats(1, time.Minute, PriorityMedium)
+			}
+		}()
+	}
+	forwarded := 0
+	for range out {
+		forwarded++
+	}
+	wg.Wait()
+
+	if forwarded != 51 {
+		t.Errorf("Expected all 51 events to be forwarded, got %d", forwarded)
+	}
+	if samples := store.Range(1, time.Time{}, time.Time{}); len(samples) != 10 {
+		t.Errorf("Expected 10 samples at capacity, got %d", len(samples))
+	}
+}
+
+func TestNewSampleStore_Invalid(t *testing.T) {
+	if _, err := NewSampleStore(SampleStoreConfig{}); err == nil {
+		t.Error("Expected an error for a zero capacity")
+	}
+	if _, err := NewSampleStore(SampleStoreConfig{Capacity: 1, Retention: -time.Second}); err == nil {
+		t.Error("Expected an error for a negative retention")
+	}
+}
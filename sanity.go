@@ -0,0 +1,173 @@
+package infura
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"sync"
+	"time"
+)
+
+// ErrSuspiciousData indicates the Gas API suggestion diverges too far from the node's gas price
+var ErrSuspiciousData = errors.New("suspicious gas data")
+
+// SuspiciousDataError is returned by GetSuggestedGasFees when the sanity check fails
+// It matches ErrSuspiciousData with errors.Is
+type SuspiciousDataError struct {
+	ChainID int64
+	// Suggested is the Gas API estimate in wei (estimatedBaseFee + medium maxPriorityFeePerGas)
+	Suggested *big.Int
+	// Reference is the node's eth_gasPrice in wei
+	Reference *big.Int
+	// MaxFactor is the configured divergence limit
+	MaxFactor float64
+}
+
+// Error implements the error interface
+func (e *SuspiciousDataError) Error() string {
+	return fmt.Sprintf("suggested gas price %s wei for chain %d diverges from eth_gasPrice %s wei by more than %gx",
+		e.Suggested, e.ChainID, e.Reference, e.MaxFactor)
+}
+
+// Is reports whether target is ErrSuspiciousData
+func (e *SuspiciousDataError) Is(target error) bool {
+	return target == ErrSuspiciousData
+}
+
+// SanityCheck configures the cross-check of suggestedGasFees against eth_gasPrice
+type SanityCheck struct {
+	// MaxFactor is the largest allowed ratio between the Gas API estimate and eth_gasPrice,
+	// in either direction (e.g. 3 accepts anything from a third to three times the node price)
+	MaxFactor float64
+	// RefreshInterval is how long a fetched eth_gasPrice is reused per chain (0 = every call)
+	RefreshInterval time.Duration
+	// OnSuspicious, if set, is called with the divergence details and the data is returned
+	// as usual; if nil, GetSuggestedGasFees returns a *SuspiciousDataError instead
+	OnSuspicious func(*SuspiciousDataError)
+}
+
+// WithSanityCheck compares every suggestedGasFees result with the node's eth_gasPrice obtained
+// through the RPC backend configured with WithRPC. The Gas API estimate used for the
+// comparison is estimatedBaseFee + medium maxPriorityFeePerGas, computed exactly in wei.
+// If the RPC backend is missing or the RPC call fails, the check is skipped. A MaxFactor
+// below 1, which would flag every response, or NaN is invalid.
+func WithSanityCheck(check SanityCheck) ClientOption {
+	return func(c *Client) {
+		if !(check.MaxFactor >= 1) {
+			c.rejectOption("WithSanityCheck", "MaxFactor must be at least 1, got %v", check.MaxFactor)
+			return
+		}
+		c.sanity = &sanityChecker{config: check, references: make(map[int64]referencePrice)}
+	}
+}
+
+// sanityChecker caches reference gas prices per chain
+type sanityChecker struct {
+	config     SanityCheck
+	mu         sync.Mutex
+	references map[int64]referencePrice
+}
+
+type referencePrice struct {
+	price     *big.Int
+	fetchedAt time.Time
+}
+
+// checkSuggestedGasFees validates fees against the reference gas price for the chain
+func (c *Client) checkSuggestedGasFees(ctx context.Context, chainID int64, fees *SuggestedGasFees) error {
+	if c.sanity == nil || c.rpc == nil {
+		return nil
+	}
+
+	reference, err := c.referenceGasPrice(ctx, chainID)
+	if err != nil {
+		if logger := c.debugLogger(ctx); logger != nil {
+			logger.Printf("[DEBUG] Skipping sanity check for chain %d: %v\n", chainID, err)
+		}
+		c.logStructured(ctx, "infura sanity check skipped", slog.Int64("chain_id", chainID), slog.String("error", c.mask(err.Error())))
+		return nil
+	}
+
+	suggested, err := suggestedGasPriceWei(fees)
+	if err != nil {
+		if logger := c.debugLogger(ctx); logger != nil {
+			logger.Printf("[DEBUG] Skipping sanity check for chain %d: %v\n", chainID, err)
+		}
+		c.logStructured(ctx, "infura sanity check skipped", slog.Int64("chain_id", chainID), slog.String("error", c.mask(err.Error())))
+		return nil
+	}
+
+	if !divergesBeyond(suggested, reference, c.sanity.config.MaxFactor) {
+		return nil
+	}
+
+	suspicious := &SuspiciousDataError{
+		ChainID:   chainID,
+		Suggested: suggested,
+		Reference: reference,
+		MaxFactor: c.sanity.config.MaxFactor,
+	}
+	if c.sanity.config.OnSuspicious != nil {
+		c.sanity.config.OnSuspicious(suspicious)
+		return nil
+	}
+	return suspicious
+}
+
+// referenceGasPrice returns the cached eth_gasPrice for the chain, refreshing it when expired
+func (c *Client) referenceGasPrice(ctx context.Context, chainID int64) (*big.Int, error) {
+	s := c.sanity
+	now := c.clock.Now()
+
+	s.mu.Lock()
+	ref, ok := s.references[chainID]
+	s.mu.Unlock()
+	if ok && s.config.RefreshInterval > 0 && now.Sub(ref.fetchedAt) < s.config.RefreshInterval {
+		return ref.price, nil
+	}
+
+	price, err := c.GetGasPrice(ctx, chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.references[chainID] = referencePrice{price: price, fetchedAt: now}
+	s.mu.Unlock()
+	return price, nil
+}
+
+// suggestedGasPriceWei returns estimatedBaseFee + medium maxPriorityFeePerGas in wei
+func suggestedGasPriceWei(fees *SuggestedGasFees) (*big.Int, error) {
+	baseFee, err := ParseGweiToWei(fees.EstimatedBaseFee)
+	if err != nil {
+		return nil, fmt.Errorf("invalid estimated base fee: %w", err)
+	}
+	tip, err := ParseGweiToWei(fees.Medium.SuggestedMaxPriorityFeePerGas)
+	if err != nil {
+		return nil, fmt.Errorf("invalid medium priority fee: %w", err)
+	}
+	return baseFee.Add(baseFee, tip), nil
+}
+
+// divergesBeyond reports whether max(a, b) / min(a, b) exceeds factor, using exact arithmetic
+func divergesBeyond(a, b *big.Int, factor float64) bool {
+	larger, smaller := a, b
+	if larger.Cmp(smaller) < 0 {
+		larger, smaller = smaller, larger
+	}
+	if smaller.Sign() <= 0 {
+		return larger.Sign() > 0
+	}
+
+	limit, ok := new(big.Rat).SetString(fmt.Sprintf("%g", factor))
+	if !ok {
+		return false
+	}
+	// larger / smaller > num / den  <=>  larger * den > smaller * num
+	lhs := new(big.Int).Mul(larger, limit.Denom())
+	rhs := new(big.Int).Mul(smaller, limit.Num())
+	return lhs.Cmp(rhs) > 0
+}
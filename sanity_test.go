@@ -0,0 +1,215 @@
+package infura
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newFeesServer returns a mock server serving a fixed suggestedGasFees body
+func newFeesServer(t *testing.T, body string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+}
+
+// 30 gwei base fee + 2 gwei medium tip = 32 gwei
+const sanityFeesBody = `{"medium": {"suggestedMaxPriorityFeePerGas": "2"}, "estimatedBaseFee": "30"}`
+
+func TestSanityCheck_Agreement(t *testing.T) {
+	server := newFeesServer(t, sanityFeesBody)
+	defer server.Close()
+
+	// 40 gwei reference
+	rpc := &mockRPC{results: map[string]string{"eth_gasPrice": "0x9502f9000"}}
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithRPC(rpc),
+		WithSanityCheck(SanityCheck{MaxFactor: 2}))
+
+	result, err := client.GetSuggestedGasFees(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("Expected fees within threshold, got error: %v", err)
+	}
+	if result.EstimatedBaseFee != "30" {
+		t.Errorf("Expected estimated base fee 30, got %s", result.EstimatedBaseFee)
+	}
+}
+
+func TestSanityCheck_Divergence(t *testing.T) {
+	server := newFeesServer(t, sanityFeesBody)
+	defer server.Close()
+
+	// 100 gwei reference, more than 3x the 32 gwei suggestion
+	rpc := &mockRPC{results: map[string]string{"eth_gasPrice": "0x174876e800"}}
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithRPC(rpc),
+		WithSanityCheck(SanityCheck{MaxFactor: 3}))
+
+	result, err := client.GetSuggestedGasFees(context.Background(), 1)
+	if !errors.Is(err, ErrSuspiciousData) {
+		t.Fatalf("Expected ErrSuspiciousData, got %v", err)
+	}
+	if result != nil {
+		t.Error("Expected nil result on suspicious data")
+	}
+
+	var suspicious *SuspiciousDataError
+	if !errors.As(err, &suspicious) {
+		t.Fatalf("Expected *SuspiciousDataError, got %T", err)
+	}
+	if suspicious.Suggested.String() != "32000000000" || suspicious.Reference.String() != "100000000000" {
+		t.Errorf("Unexpected values: suggested %s, reference %s", suspicious.Suggested, suspicious.Reference)
+	}
+}
+
+func TestSanityCheck_Callback(t *testing.T) {
+	server := newFeesServer(t, sanityFeesBody)
+	defer server.Close()
+
+	rpc := &mockRPC{results: map[string]string{"eth_gasPrice": "0x1"}}
+	var reported *SuspiciousDataError
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithRPC(rpc),
+		WithSanityCheck(SanityCheck{
+			MaxFactor:    3,
+			OnSuspicious: func(e *SuspiciousDataError) { reported = e },
+		}))
+
+	result, err := client.GetSuggestedGasFees(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("Expected data with callback configured, got error: %v", err)
+	}
+	if result == nil || reported == nil {
+		t.Fatal("Expected both data and a callback invocation")
+	}
+	if reported.ChainID != 1 {
+		t.Errorf("Expected chain ID 1, got %d", reported.ChainID)
+	}
+}
+
+func TestSanityCheck_RPCFailureSkipsCheck(t *testing.T) {
+	server := newFeesServer(t, sanityFeesBody)
+	defer server.Close()
+
+	rpc := &mockRPC{err: errors.New("node unreachable")}
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithRPC(rpc),
+		WithSanityCheck(SanityCheck{MaxFactor: 1.5}))
+
+	if _, err := client.GetSuggestedGasFees(context.Background(), 1); err != nil {
+		t.Fatalf("Expected RPC failure to skip the check, got %v", err)
+	}
+	if rpc.calls != 1 {
+		t.Errorf("Expected 1 RPC call, got %d", rpc.calls)
+	}
+}
+
+func TestSanityCheck_SkippedRecordMasksKey(t *testing.T) {
+	server := newFeesServer(t, sanityFeesBody)
+	defer server.Close()
+
+	const apiKey = "0123456789abcdef0123456789abcdef"
+	handler := &recordingHandler{level: slog.LevelDebug}
+	rpc := &mockRPC{err: errors.New("dial https://mainnet.infura.io/v3/" + apiKey + ": node unreachable")}
+	client := NewClientWithAPIKeyAndOptions(apiKey,
+		WithBaseURL(server.URL),
+		WithRPC(rpc),
+		WithSlogLogger(slog.New(handler)),
+		WithSanityCheck(SanityCheck{MaxFactor: 1.5}))
+
+	if _, err := client.GetSuggestedGasFees(context.Background(), 1); err != nil {
+		t.Fatalf("Expected RPC failure to skip the check, got %v", err)
+	}
+
+	messages, attrs := handler.attrs()
+	var found bool
+	for i, message := range messages {
+		if message != "infura sanity check skipped" {
+			continue
+		}
+		found = true
+		if got := attrs[i]["error"].String(); strings.Contains(got, apiKey) {
+			t.Errorf("Expected the API key to be masked, got %q", got)
+		}
+	}
+	if !found {
+		t.Error("Expected an \"infura sanity check skipped\" record")
+	}
+}
+
+func TestSanityCheck_ReferenceRefresh(t *testing.T) {
+	server := newFeesServer(t, sanityFeesBody)
+	defer server.Close()
+
+	clock := newFakeClock(time.Unix(0, 0))
+	rpc := &mockRPC{results: map[string]string{"eth_gasPrice": "0x9502f9000"}}
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithClock(clock),
+		WithRPC(rpc),
+		WithSanityCheck(SanityCheck{MaxFactor: 2, RefreshInterval: time.Minute}))
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.GetSuggestedGasFees(context.Background(), 1); err != nil {
+			t.Fatalf("call %d failed: %v", i, err)
+		}
+	}
+	if rpc.calls != 1 {
+		t.Errorf("Expected cached reference price, got %d RPC calls", rpc.calls)
+	}
+
+	clock.Advance(time.Minute)
+	if _, err := client.GetSuggestedGasFees(context.Background(), 1); err != nil {
+		t.Fatalf("call after refresh failed: %v", err)
+	}
+	if rpc.calls != 2 {
+		t.Errorf("Expected reference refresh after interval, got %d RPC calls", rpc.calls)
+	}
+}
+
+func TestWithSanityCheck_InvalidMaxFactor(t *testing.T) {
+	for _, factor := range []float64{0, 0.5, -2, math.NaN()} {
+		_, err := New("test-api-key", "", WithSanityCheck(SanityCheck{MaxFactor: factor}))
+		if !errors.Is(err, ErrInvalidOption) {
+			t.Errorf("Expected ErrInvalidOption for MaxFactor %v, got %v", factor, err)
+		}
+	}
+	if _, err := New("test-api-key", "", WithSanityCheck(SanityCheck{MaxFactor: 1})); err != nil {
+		t.Errorf("Expected MaxFactor 1 to be valid, got %v", err)
+	}
+}
+
+func TestDivergesBeyond(t *testing.T) {
+	tests := []struct {
+		a, b   int64
+		factor float64
+		want   bool
+	}{
+		{a: 100, b: 100, factor: 1, want: false},
+		{a: 300, b: 100, factor: 3, want: false},
+		{a: 301, b: 100, factor: 3, want: true},
+		{a: 100, b: 301, factor: 3, want: true},
+		{a: 150, b: 100, factor: 1.5, want: false},
+		{a: 0, b: 0, factor: 2, want: false},
+		{a: 0, b: 1, factor: 2, want: true},
+	}
+
+	for _, tt := range tests {
+		if got := divergesBeyond(big.NewInt(tt.a), big.NewInt(tt.b), tt.factor); got != tt.want {
+			t.Errorf("divergesBeyond(%d, %d, %g) = %v, want %v", tt.a, tt.b, tt.factor, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package infura
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+)
+
+// BaseFeeSeries accumulates base fee history fetched repeatedly into a deduplicated,
+// ordered series, e.g. for a live chart. It is safe for concurrent use.
+type BaseFeeSeries struct {
+	mu     sync.RWMutex
+	maxLen int
+	values []*big.Float
+}
+
+// NewBaseFeeSeries creates an empty series retaining at most maxLen entries (oldest are
+// dropped first); maxLen <= 0 retains everything
+func NewBaseFeeSeries(maxLen int) *BaseFeeSeries {
+	return &BaseFeeSeries{maxLen: maxLen}
+}
+
+// Append merges a freshly fetched history window (ordered oldest first) into the series
+// and returns the number of entries added
+// The overlap is the longest suffix of the series equal to a prefix of history; only the
+// entries after it are appended. Without overlap the whole window is appended. Values are
+// compared numerically, so "24.0" and "24" match. If any entry is invalid, the series is
+// left unchanged and the error reports every invalid entry (see BaseFeeHistory.Floats).
+func (s *BaseFeeSeries) Append(history BaseFeeHistory) (int, error) {
+	if len(history) == 0 {
+		return 0, nil
+	}
+	parsed, err := parseHistory(history)
+	if err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	added := parsed[overlapLength(s.values, parsed):]
+	s.values = append(s.values, added...)
+	if s.maxLen > 0 && len(s.values) > s.maxLen {
+		s.values = append([]*big.Float(nil), s.values[len(s.values)-s.maxLen:]...)
+	}
+	return len(added), nil
+}
+
+// Values returns a copy of the series in Gwei, oldest first
+func (s *BaseFeeSeries) Values() []*big.Float {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	values := make([]*big.Float, len(s.values))
+	for i, v := range s.values {
+		values[i] = new(big.Float).Copy(v)
+	}
+	return values
+}
+
+// Len returns the number of entries in the series
+func (s *BaseFeeSeries) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.values)
+}
+
+// parseHistory parses every entry of a history as Gwei, reporting all invalid entries
+func parseHistory(history BaseFeeHistory) ([]*big.Float, error) {
+	values := make([]*big.Float, len(history))
+	var errs []error
+	for i, entry := range history {
+		gwei, err := parseGwei(entry)
+		if err != nil {
+			errs = append(errs, &HistoryEntryError{Index: i, Value: entry, Err: err})
+			continue
+		}
+		values[i] = gwei
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return values, nil
+}
+
+// overlapLength returns the length of the longest suffix of series equal to a prefix of next
+func overlapLength(series, next []*big.Float) int {
+	for n := min(len(series), len(next)); n > 0; n-- {
+		if equalFloats(series[len(series)-n:], next[:n]) {
+			return n
+		}
+	}
+	return 0
+}
+
+func equalFloats(a, b []*big.Float) bool {
+	for i := range a {
+		if a[i].Cmp(b[i]) != 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,126 @@
+package infura
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+)
+
+// seriesText renders the series values for comparison
+func seriesText(s *BaseFeeSeries) []string {
+	var out []string
+	for _, v := range s.Values() {
+		out = append(out, v.Text('f', -1))
+	}
+	return out
+}
+
+func TestBaseFeeSeries_OverlappingWindows(t *testing.T) {
+	series := NewBaseFeeSeries(0)
+
+	steps := []struct {
+		window    BaseFeeHistory
+		wantAdded int
+		want      []string
+	}{
+		{BaseFeeHistory{"10", "11", "12", "13"}, 4, []string{"10", "11", "12", "13"}},
+		// shifted by two blocks
+		{BaseFeeHistory{"12", "13", "14", "15"}, 2, []string{"10", "11", "12", "13", "14", "15"}},
+		// same window again
+		{BaseFeeHistory{"12", "13", "14", "15"}, 0, []string{"10", "11", "12", "13", "14", "15"}},
+		// overlap of one, formatted differently
+		{BaseFeeHistory{"15.0", "16", "17", "18"}, 3, []string{"10", "11", "12", "13", "14", "15", "16", "17", "18"}},
+		// no overlap: a gap in polling
+		{BaseFeeHistory{"30", "31"}, 2, []string{"10", "11", "12", "13", "14", "15", "16", "17", "18", "30", "31"}},
+	}
+
+	for i, step := range steps {
+		added, err := series.Append(step.window)
+		if err != nil {
+			t.Fatalf("step %d: Append failed: %v", i, err)
+		}
+		if added != step.wantAdded {
+			t.Errorf("step %d: expected %d added, got %d", i, step.wantAdded, added)
+		}
+		if got := seriesText(series); !slices.Equal(got, step.want) {
+			t.Errorf("step %d: expected %v, got %v", i, step.want, got)
+		}
+	}
+}
+
+func TestBaseFeeSeries_RepeatedValues(t *testing.T) {
+	series := NewBaseFeeSeries(0)
+	series.Append(BaseFeeHistory{"5", "5", "5"})
+
+	// The longest overlap wins, so a steady fee is not duplicated
+	added, err := series.Append(BaseFeeHistory{"5", "5", "5", "6"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if added != 1 || series.Len() != 4 {
+		t.Errorf("Expected 1 added and 4 retained, got %d added and %v", added, seriesText(series))
+	}
+}
+
+func TestBaseFeeSeries_MaxLen(t *testing.T) {
+	series := NewBaseFeeSeries(5)
+	series.Append(BaseFeeHistory{"1", "2", "3", "4"})
+	series.Append(BaseFeeHistory{"3", "4", "5", "6", "7"})
+
+	if got, want := seriesText(series), []string{"3", "4", "5", "6", "7"}; !slices.Equal(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	// Overlap is still found against the retained tail
+	added, _ := series.Append(BaseFeeHistory{"6", "7", "8"})
+	if got, want := seriesText(series), []string{"4", "5", "6", "7", "8"}; added != 1 || !slices.Equal(got, want) {
+		t.Errorf("Expected %v after adding 1, got %v after adding %d", want, got, added)
+	}
+}
+
+func TestBaseFeeSeries_InvalidEntry(t *testing.T) {
+	series := NewBaseFeeSeries(0)
+	series.Append(BaseFeeHistory{"1", "2"})
+
+	_, err := series.Append(BaseFeeHistory{"2", "x", "4"})
+	var entryErr *HistoryEntryError
+	if !errors.As(err, &entryErr) || entryErr.Index != 1 {
+		t.Fatalf("Expected a HistoryEntryError for index 1, got %v", err)
+	}
+	if series.Len() != 2 {
+		t.Errorf("Expected the series to be unchanged, got %v", seriesText(series))
+	}
+}
+
+func TestBaseFeeSeries_ValuesAreCopies(t *testing.T) {
+	series := NewBaseFeeSeries(0)
+	series.Append(BaseFeeHistory{"1"})
+
+	series.Values()[0].SetInt64(99)
+	if got := seriesText(series); got[0] != "1" {
+		t.Errorf("Expected Values to return copies, series is now %v", got)
+	}
+}
+
+func TestBaseFeeSeries_Concurrent(t *testing.T) {
+	series := NewBaseFeeSeries(50)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				series.Append(BaseFeeHistory{"1", "2", "3"})
+				series.Values()
+				series.Len()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if series.Len() > 50 {
+		t.Errorf("Expected at most 50 entries, got %d", series.Len())
+	}
+}
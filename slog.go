@@ -0,0 +1,83 @@
+package infura
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WithSlogLogger sends debug output to logger as structured records instead of the
+// free-form [DEBUG] lines of WithDebug. Every request attempt is logged at slog.LevelDebug
+// with the attributes method, url_masked, status, duration_ms, chain_id and attempt (and
+// error for failed attempts); retries, failovers and fallbacks are logged with chain_id,
+// attempt and error as available. The API key is masked in URLs and errors.
+//
+// Records are emitted whenever the logger's handler is enabled for debug level,
+// independently of WithDebug, and replace the text output: with a logger configured,
+// WithDebug and SetDebug print nothing. A writer attached with ContextWithDebugWriter
+// or ContextWithDebug still gets the text output for its calls.
+func WithSlogLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) {
+		if logger == nil {
+			c.rejectOption("WithSlogLogger", "logger must not be nil")
+			return
+		}
+		c.slogger = logger
+	}
+}
+
+// structuredLogger returns the slog logger for debug records of a call, or nil if the
+// call uses text output or the handler discards debug records
+func (c *Client) structuredLogger(ctx context.Context) *slog.Logger {
+	if c.slogger == nil || ctx.Value(debugKey{}) != nil || !c.slogger.Enabled(ctx, slog.LevelDebug) {
+		return nil
+	}
+	return c.slogger
+}
+
+// logStructured emits a debug record if a slog logger is configured for the call
+func (c *Client) logStructured(ctx context.Context, msg string, attrs ...slog.Attr) {
+	if logger := c.structuredLogger(ctx); logger != nil {
+		logger.LogAttrs(ctx, slog.LevelDebug, msg, attrs...)
+	}
+}
+
+// logAttempt emits the record of a single request attempt; statusCode is 0 and err is
+// set when no response was received
+func (c *Client) logAttempt(ctx context.Context, creds *credentials, settings requestSettings, method, url, endpoint string, start time.Time, statusCode int, err error) {
+	logger := c.structuredLogger(ctx)
+	if logger == nil {
+		return
+	}
+	attrs := []slog.Attr{
+		slog.String("method", method),
+		slog.String("url_masked", creds.mask(url)),
+		slog.Int("status", statusCode),
+		slog.Int64("duration_ms", time.Since(start).Milliseconds()),
+	}
+	if chainID, ok := chainIDOfEndpoint(endpoint); ok {
+		attrs = append(attrs, slog.Int64("chain_id", chainID))
+	}
+	if settings.attempt > 0 {
+		attrs = append(attrs, slog.Int("attempt", settings.attempt))
+	}
+	msg := "infura request"
+	if err != nil {
+		msg = "infura request failed"
+		attrs = append(attrs, slog.String("error", creds.mask(err.Error())))
+	}
+	logger.LogAttrs(ctx, slog.LevelDebug, msg, attrs...)
+}
+
+// chainIDOfEndpoint extracts the chain ID from a /networks/{chainID}/... endpoint
+func chainIDOfEndpoint(endpoint string) (int64, bool) {
+	_, rest, ok := strings.Cut(endpoint, "/networks/")
+	if !ok {
+		return 0, false
+	}
+	id, _, _ := strings.Cut(rest, "/")
+	chainID, err := strconv.ParseInt(id, 10, 64)
+	return chainID, err == nil
+}
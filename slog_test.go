@@ -0,0 +1,190 @@
+package infura
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// recordingHandler is a slog.Handler that keeps the records it receives
+type recordingHandler struct {
+	level   slog.Level
+	mu      sync.Mutex
+	records []slog.Record
+}
+
+func (h *recordingHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level
+}
+
+func (h *recordingHandler) Handle(_ context.Context, r slog.Record) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.records = append(h.records, r)
+	return nil
+}
+
+func (h *recordingHandler) WithAttrs([]slog.Attr) slog.Handler { return h }
+func (h *recordingHandler) WithGroup(string) slog.Handler      { return h }
+
+// attrs returns the messages and attributes of the records received so far
+func (h *recordingHandler) attrs() ([]string, []map[string]slog.Value) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var messages []string
+	var attrs []map[string]slog.Value
+	for _, r := range h.records {
+		values := map[string]slog.Value{}
+		r.Attrs(func(a slog.Attr) bool {
+			values[a.Key] = a.Value
+			return true
+		})
+		messages = append(messages, r.Message)
+		attrs = append(attrs, values)
+	}
+	return messages, attrs
+}
+
+func TestWithSlogLogger_RequestAttributes(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"busyThreshold":"30"}`))
+	}))
+	defer server.Close()
+	global := captureLog(t)
+
+	handler := &recordingHandler{level: slog.LevelDebug}
+	client := NewClientWithOptions("test-api-key-0123456789", "",
+		WithBaseURL(server.URL),
+		WithRetry(2, time.Millisecond),
+		WithDebug(true),
+		WithSlogLogger(slog.New(handler)))
+
+	if _, err := client.GetBusyThreshold(context.Background(), 137); err != nil {
+		t.Fatalf("GetBusyThreshold failed: %v", err)
+	}
+
+	if out := global.String(); out != "" {
+		t.Errorf("Expected no text debug output with a slog logger, got:\n%s", out)
+	}
+
+	messages, attrs := handler.attrs()
+	wantMessages := []string{"infura request", "infura retry", "infura request"}
+	if strings.Join(messages, ",") != strings.Join(wantMessages, ",") {
+		t.Fatalf("Expected messages %q, got %q", wantMessages, messages)
+	}
+
+	for i, want := range []struct {
+		status  int64
+		attempt int64
+	}{{status: 503, attempt: 1}, {status: 200, attempt: 2}} {
+		got := attrs[i*2]
+		if got["method"].String() != http.MethodGet {
+			t.Errorf("Expected record %d method GET, got %v", i, got["method"])
+		}
+		url := got["url_masked"].String()
+		if strings.Contains(url, "test-api-key-0123456789") || !strings.Contains(url, "/v3/test...6789/networks/137/busyThreshold") {
+			t.Errorf("Expected record %d url_masked with a masked key, got %q", i, url)
+		}
+		if got["status"].Int64() != want.status {
+			t.Errorf("Expected record %d status %d, got %v", i, want.status, got["status"])
+		}
+		if got["attempt"].Int64() != want.attempt {
+			t.Errorf("Expected record %d attempt %d, got %v", i, want.attempt, got["attempt"])
+		}
+		if got["chain_id"].Int64() != 137 {
+			t.Errorf("Expected record %d chain_id 137, got %v", i, got["chain_id"])
+		}
+		if v, ok := got["duration_ms"]; !ok || v.Kind() != slog.KindInt64 {
+			t.Errorf("Expected record %d duration_ms as an integer, got %v", i, v)
+		}
+	}
+	if attrs[1]["attempt"].Int64() != 1 || !strings.Contains(attrs[1]["error"].String(), "503") {
+		t.Errorf("Expected a retry record for attempt 1 with the 503 error, got %v", attrs[1])
+	}
+}
+
+func TestWithSlogLogger_TransportError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	handler := &recordingHandler{level: slog.LevelDebug}
+	client := NewClientWithOptions("test-api-key", "test-api-secret",
+		WithBaseURL(server.URL),
+		WithSlogLogger(slog.New(handler)))
+
+	if _, err := client.GetBusyThreshold(context.Background(), 1); err == nil {
+		t.Fatal("Expected a transport error, got nil")
+	}
+
+	messages, attrs := handler.attrs()
+	if len(messages) != 1 || messages[0] != "infura request failed" {
+		t.Fatalf("Expected a single \"infura request failed\" record, got %q", messages)
+	}
+	if attrs[0]["status"].Int64() != 0 || attrs[0]["error"].String() == "" {
+		t.Errorf("Expected status 0 and an error, got %v", attrs[0])
+	}
+}
+
+func TestWithSlogLogger_LevelAndContextWriter(t *testing.T) {
+	server := newDebugServer()
+	defer server.Close()
+
+	handler := &recordingHandler{level: slog.LevelInfo}
+	client := NewClientWithOptions("test-api-key", "test-api-secret",
+		WithBaseURL(server.URL),
+		WithSlogLogger(slog.New(handler)))
+	if _, err := client.GetBusyThreshold(context.Background(), 1); err != nil {
+		t.Fatalf("GetBusyThreshold failed: %v", err)
+	}
+	if messages, _ := handler.attrs(); len(messages) != 0 {
+		t.Errorf("Expected no records below the handler level, got %q", messages)
+	}
+
+	handler.level = slog.LevelDebug
+	var debugOut syncBuffer
+	if _, err := client.GetBusyThreshold(ContextWithDebugWriter(context.Background(), &debugOut), 1); err != nil {
+		t.Fatalf("GetBusyThreshold failed: %v", err)
+	}
+	if messages, _ := handler.attrs(); len(messages) != 0 {
+		t.Errorf("Expected no records for a call with a debug writer, got %q", messages)
+	}
+	if !strings.Contains(debugOut.String(), "[DEBUG] ========== HTTP Request ==========") {
+		t.Errorf("Expected request output in the debug writer, got %q", debugOut.String())
+	}
+}
+
+func TestWithSlogLogger_RejectsNil(t *testing.T) {
+	if _, err := New("test-api-key", "", WithSlogLogger(nil)); err == nil {
+		t.Error("Expected an invalid option error, got nil")
+	}
+}
+
+func TestChainIDOfEndpoint(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		want     int64
+		ok       bool
+	}{
+		{endpoint: "/networks/1/suggestedGasFees", want: 1, ok: true},
+		{endpoint: "/v3/key/networks/59144/busyThreshold", want: 59144, ok: true},
+		{endpoint: "/networks/abc/busyThreshold"},
+		{endpoint: "/v3/key"},
+	}
+	for _, tt := range tests {
+		got, ok := chainIDOfEndpoint(tt.endpoint)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Expected chain %d (%v) for %q, got %d (%v)", tt.want, tt.ok, tt.endpoint, got, ok)
+		}
+	}
+}
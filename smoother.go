@@ -0,0 +1,219 @@
+package infura
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SmoothedEvent pairs a raw watcher event with its exponentially smoothed counterpart
+type SmoothedEvent struct {
+	// Raw is the event as received from the watcher
+	Raw WatchEvent
+	// Smoothed is a synthetic suggestion whose maxFeePerGas and maxPriorityFeePerGas values
+	// are moving averages (rounded to 9 decimals); all other fields are copied from Raw.Fees.
+	// It is nil when Raw.Err is set.
+	Smoothed *SuggestedGasFees
+}
+
+// smoothedSeries is the number of averaged values per chain: maxFee and priorityFee per level
+const smoothedSeries = 6
+
+// FeeSmoother keeps exponentially weighted moving averages of each level's maxFeePerGas and
+// maxPriorityFeePerGas per chain, along with the last raw sample. Its state can be persisted
+// with Save and restored with Load so a restarted process resumes with warm averages.
+// A FeeSmoother is safe for concurrent use.
+type FeeSmoother struct {
+	alpha float64
+
+	mu     sync.Mutex
+	chains map[int64]*smootherChain
+}
+
+type smootherChain struct {
+	ema        *emaSmoother
+	lastSample *SuggestedGasFees
+	lastTime   time.Time
+}
+
+// NewFeeSmoother creates a FeeSmoother with smoothing factor alpha in (0, 1]
+// The first sample of each chain seeds its averages
+func NewFeeSmoother(alpha float64) (*FeeSmoother, error) {
+	if !(alpha > 0 && alpha <= 1) {
+		return nil, fmt.Errorf("smoothing factor must be in (0, 1], got %v", alpha)
+	}
+	return &FeeSmoother{alpha: alpha, chains: make(map[int64]*smootherChain)}, nil
+}
+
+// SmoothFeeStream wraps a watcher channel and emits, for every event, an exponentially
+// weighted moving average of each level's maxFeePerGas and maxPriorityFeePerGas.
+// alpha is the smoothing factor in (0, 1]; the first sample seeds the average. Values that
+// cannot be parsed are skipped without affecting the running average, and fields with no
+// valid sample yet are left empty. The returned channel is closed when in is closed or ctx is done.
+func SmoothFeeStream(ctx context.Context, in <-chan WatchEvent, alpha float64) (<-chan SmoothedEvent, error) {
+	smoother, err := NewFeeSmoother(alpha)
+	if err != nil {
+		return nil, err
+	}
+	return smoother.Stream(ctx, in), nil
+}
+
+// Stream emits a SmoothedEvent for every event read from in, averaging per chain
+// The returned channel is closed when in is closed or ctx is done
+func (s *FeeSmoother) Stream(ctx context.Context, in <-chan WatchEvent) <-chan SmoothedEvent {
+	out := make(chan SmoothedEvent)
+	go func() {
+		defer close(out)
+		for {
+			var event WatchEvent
+			var ok bool
+			select {
+			case event, ok = <-in:
+				if !ok {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+
+			smoothed := SmoothedEvent{Raw: event}
+			if event.Err == nil && event.Fees != nil {
+				smoothed.Smoothed = s.Observe(event.ChainID, event.Time, event.Fees)
+			}
+
+			select {
+			case out <- smoothed:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// Observe folds a sample for chainID into the averages and returns the synthetic smoothed suggestion
+func (s *FeeSmoother) Observe(chainID int64, at time.Time, fees *SuggestedGasFees) *SuggestedGasFees {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	chain := s.chain(chainID)
+	sample := cloneSuggestedGasFees(*fees)
+	chain.lastSample = &sample
+	chain.lastTime = at
+
+	result := cloneSuggestedGasFees(*fees)
+	for i, level := range []*GasFeeLevel{&result.Low, &result.Medium, &result.High} {
+		level.SuggestedMaxFeePerGas = formatSmoothedGwei(chain.ema.observe(2*i, level.SuggestedMaxFeePerGas))
+		level.SuggestedMaxPriorityFeePerGas = formatSmoothedGwei(chain.ema.observe(2*i+1, level.SuggestedMaxPriorityFeePerGas))
+	}
+	return &result
+}
+
+// LastSample returns the last raw sample observed for chainID and when it was taken
+func (s *FeeSmoother) LastSample(chainID int64) (*SuggestedGasFees, time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	chain, ok := s.chains[chainID]
+	if !ok || chain.lastSample == nil {
+		return nil, time.Time{}, false
+	}
+	sample := cloneSuggestedGasFees(*chain.lastSample)
+	return &sample, chain.lastTime, true
+}
+
+// chain returns the state of chainID, creating it if needed; s.mu must be held
+func (s *FeeSmoother) chain(chainID int64) *smootherChain {
+	chain, ok := s.chains[chainID]
+	if !ok {
+		chain = &smootherChain{ema: newEMASmoother(s.alpha, smoothedSeries)}
+		s.chains[chainID] = chain
+	}
+	return chain
+}
+
+type smootherSnapshot struct {
+	Version int                             `json:"version"`
+	Chains  map[int64]smootherChainSnapshot `json:"chains"`
+}
+
+type smootherChainSnapshot struct {
+	LastSample *SuggestedGasFees `json:"lastSample,omitempty"`
+	LastTime   time.Time         `json:"lastTime"`
+	// Averages holds the running averages in Gwei; an empty string means no sample yet
+	Averages []string `json:"averages"`
+}
+
+// Save writes the smoother state (averages and last samples per chain) as versioned JSON
+func (s *FeeSmoother) Save(w io.Writer) error {
+	s.mu.Lock()
+	snapshot := smootherSnapshot{Version: snapshotVersion, Chains: make(map[int64]smootherChainSnapshot, len(s.chains))}
+	for chainID, chain := range s.chains {
+		averages := make([]string, len(chain.ema.values))
+		for i, v := range chain.ema.values {
+			if v != nil {
+				averages[i] = v.Text('g', -1)
+			}
+		}
+		snapshot.Chains[chainID] = smootherChainSnapshot{
+			LastSample: chain.lastSample,
+			LastTime:   chain.lastTime,
+			Averages:   averages,
+		}
+	}
+	s.mu.Unlock()
+
+	if err := json.NewEncoder(w).Encode(snapshot); err != nil {
+		return fmt.Errorf("failed to encode smoother snapshot: %w", err)
+	}
+	return nil
+}
+
+// Load replaces the smoother state with a snapshot written by Save
+// The configured alpha is kept; the snapshot only restores averages and samples
+func (s *FeeSmoother) Load(r io.Reader) error {
+	var snapshot smootherSnapshot
+	if err := decodeSnapshot(r, &snapshot, &snapshot.Version); err != nil {
+		return err
+	}
+
+	chains := make(map[int64]*smootherChain, len(snapshot.Chains))
+	for chainID, saved := range snapshot.Chains {
+		chain := &smootherChain{ema: newEMASmoother(s.alpha, smoothedSeries), lastTime: saved.LastTime}
+		if saved.LastSample != nil {
+			sample := cloneSuggestedGasFees(*saved.LastSample)
+			chain.lastSample = &sample
+		}
+		for i, average := range saved.Averages {
+			if i >= smoothedSeries || average == "" {
+				continue
+			}
+			v, ok := new(big.Float).SetPrec(gweiPrecision).SetString(average)
+			if !ok {
+				return fmt.Errorf("invalid average %q for chain %d in snapshot", average, chainID)
+			}
+			chain.ema.values[i] = v
+		}
+		chains[chainID] = chain
+	}
+
+	s.mu.Lock()
+	s.chains = chains
+	s.mu.Unlock()
+	return nil
+}
+
+// formatSmoothedGwei renders an average with wei precision, trimming trailing zeros
+func formatSmoothedGwei(f *big.Float) string {
+	if f == nil {
+		return ""
+	}
+	s := f.Text('f', gweiDecimals)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
@@ -0,0 +1,181 @@
+package infura
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSmoothFeeStream(t *testing.T) {
+	sample := func(maxFee, priorityFee string) WatchEvent {
+		level := GasFeeLevel{SuggestedMaxFeePerGas: maxFee, SuggestedMaxPriorityFeePerGas: priorityFee}
+		return WatchEvent{ChainID: 1, Fees: &SuggestedGasFees{Low: level, Medium: level, High: level, EstimatedBaseFee: maxFee}}
+	}
+
+	in := make(chan WatchEvent, 6)
+	in <- sample("10", "1")
+	in <- sample("20", "3")
+	in <- WatchEvent{ChainID: 1, Err: errors.New("poll failed")}
+	in <- sample("NaN", "garbage")
+	in <- sample("30", "Inf")
+	in <- sample("30", "2")
+	close(in)
+
+	out, err := SmoothFeeStream(context.Background(), in, 0.5)
+	if err != nil {
+		t.Fatalf("SmoothFeeStream failed: %v", err)
+	}
+
+	expected := []struct {
+		maxFee      string
+		priorityFee string
+		failed      bool
+	}{
+		{maxFee: "10", priorityFee: "1"},
+		{maxFee: "15", priorityFee: "2"},
+		{failed: true},
+		{maxFee: "15", priorityFee: "2"},
+		{maxFee: "22.5", priorityFee: "2"},
+		{maxFee: "26.25", priorityFee: "2"},
+	}
+
+	i := 0
+	for event := range out {
+		if i >= len(expected) {
+			t.Fatalf("Unexpected extra event %d", i)
+		}
+		want := expected[i]
+		if want.failed {
+			if event.Raw.Err == nil || event.Smoothed != nil {
+				t.Errorf("event %d: expected error passthrough without smoothed values", i)
+			}
+			i++
+			continue
+		}
+
+		for _, level := range []GasFeeLevel{event.Smoothed.Low, event.Smoothed.Medium, event.Smoothed.High} {
+			if level.SuggestedMaxFeePerGas != want.maxFee || level.SuggestedMaxPriorityFeePerGas != want.priorityFee {
+				t.Errorf("event %d: expected %s/%s, got %s/%s", i, want.maxFee, want.priorityFee,
+					level.SuggestedMaxFeePerGas, level.SuggestedMaxPriorityFeePerGas)
+			}
+		}
+		if event.Smoothed.EstimatedBaseFee != event.Raw.Fees.EstimatedBaseFee {
+			t.Errorf("event %d: expected non-fee fields to be copied from the raw sample", i)
+		}
+		if event.Smoothed == event.Raw.Fees {
+			t.Errorf("event %d: expected a separate synthetic suggestion", i)
+		}
+		i++
+	}
+	if i != len(expected) {
+		t.Errorf("Expected %d events, got %d", len(expected), i)
+	}
+}
+
+func TestSmoothFeeStream_RoundsToWei(t *testing.T) {
+	in := make(chan WatchEvent, 2)
+	level := func(v string) GasFeeLevel { return GasFeeLevel{SuggestedMaxFeePerGas: v} }
+	in <- WatchEvent{Fees: &SuggestedGasFees{Low: level("0")}}
+	in <- WatchEvent{Fees: &SuggestedGasFees{Low: level("1")}}
+	close(in)
+
+	out, err := SmoothFeeStream(context.Background(), in, 1.0/3)
+	if err != nil {
+		t.Fatalf("SmoothFeeStream failed: %v", err)
+	}
+	<-out
+	second := <-out
+	if got := second.Smoothed.Low.SuggestedMaxFeePerGas; got != "0.333333333" {
+		t.Errorf("Expected 0.333333333, got %s", got)
+	}
+	if got := second.Smoothed.Medium.SuggestedMaxFeePerGas; got != "" {
+		t.Errorf("Expected empty value for a level without samples, got %q", got)
+	}
+}
+
+func TestSmoothFeeStream_InvalidAlpha(t *testing.T) {
+	for _, alpha := range []float64{0, -0.5, 1.5, math.NaN()} {
+		if _, err := NewFeeSmoother(alpha); err == nil {
+			t.Errorf("Expected NewFeeSmoother to reject alpha %v", alpha)
+		}
+		if _, err := SmoothFeeStream(context.Background(), make(chan WatchEvent), alpha); err == nil {
+			t.Errorf("Expected error for alpha %v", alpha)
+		}
+	}
+}
+
+func TestFeeSmoother_PerChain(t *testing.T) {
+	smoother, err := NewFeeSmoother(0.5)
+	if err != nil {
+		t.Fatalf("NewFeeSmoother failed: %v", err)
+	}
+
+	level := func(v string) GasFeeLevel { return GasFeeLevel{SuggestedMaxFeePerGas: v} }
+	smoother.Observe(1, time.Time{}, &SuggestedGasFees{Low: level("10")})
+	smoother.Observe(137, time.Time{}, &SuggestedGasFees{Low: level("100")})
+	got := smoother.Observe(1, time.Time{}, &SuggestedGasFees{Low: level("20")})
+
+	if got.Low.SuggestedMaxFeePerGas != "15" {
+		t.Errorf("Expected chains to be averaged independently, got %s", got.Low.SuggestedMaxFeePerGas)
+	}
+}
+
+func TestFeeSmoother_SaveLoad(t *testing.T) {
+	sample := func(maxFee, priorityFee string) *SuggestedGasFees {
+		level := GasFeeLevel{SuggestedMaxFeePerGas: maxFee, SuggestedMaxPriorityFeePerGas: priorityFee}
+		return &SuggestedGasFees{Low: level, Medium: level, High: level, HistoricalBaseFeeRange: []string{"1", "2"}}
+	}
+	at := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	original, _ := NewFeeSmoother(1.0 / 3)
+	original.Observe(1, at, sample("10", "1"))
+	original.Observe(1, at.Add(time.Minute), sample("11", ""))
+
+	var buf bytes.Buffer
+	if err := original.Save(&buf); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	restored, _ := NewFeeSmoother(1.0 / 3)
+	if err := restored.Load(&buf); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	last, lastTime, ok := restored.LastSample(1)
+	if !ok || last.Low.SuggestedMaxFeePerGas != "11" || !lastTime.Equal(at.Add(time.Minute)) {
+		t.Fatalf("Expected last sample to be restored, got %+v at %v", last, lastTime)
+	}
+	if len(last.HistoricalBaseFeeRange) != 2 {
+		t.Errorf("Expected full sample to be restored, got %+v", last)
+	}
+
+	// Both smoothers must produce identical output for the next sample
+	next := sample("40", "4")
+	want := original.Observe(1, at.Add(2*time.Minute), next)
+	got := restored.Observe(1, at.Add(2*time.Minute), next)
+	if got.Medium.SuggestedMaxFeePerGas != want.Medium.SuggestedMaxFeePerGas ||
+		got.Medium.SuggestedMaxPriorityFeePerGas != want.Medium.SuggestedMaxPriorityFeePerGas {
+		t.Errorf("Expected restored averages %s/%s, got %s/%s",
+			want.Medium.SuggestedMaxFeePerGas, want.Medium.SuggestedMaxPriorityFeePerGas,
+			got.Medium.SuggestedMaxFeePerGas, got.Medium.SuggestedMaxPriorityFeePerGas)
+	}
+}
+
+func TestFeeSmoother_LoadRejectsUnknownVersion(t *testing.T) {
+	smoother, _ := NewFeeSmoother(0.5)
+
+	for _, input := range []string{`{"version": 99, "chains": {}}`, `{"chains": {}}`, `not json`} {
+		if err := smoother.Load(strings.NewReader(input)); err == nil {
+			t.Errorf("Expected error loading %q", input)
+		}
+	}
+
+	// Unknown fields from future minor additions are ignored
+	if err := smoother.Load(strings.NewReader(`{"version": 1, "chains": {}, "extra": true}`)); err != nil {
+		t.Errorf("Expected unknown fields to be ignored, got %v", err)
+	}
+}
@@ -0,0 +1,22 @@
+package infura
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// snapshotVersion is the current version of persisted state written by Save methods
+// Snapshots with a newer version are rejected; unknown fields are ignored
+const snapshotVersion = 1
+
+// decodeSnapshot decodes a versioned JSON snapshot into v and validates *version
+func decodeSnapshot(r io.Reader, v interface{}, version *int) error {
+	if err := json.NewDecoder(r).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode snapshot: %w", err)
+	}
+	if *version < 1 || *version > snapshotVersion {
+		return fmt.Errorf("unsupported snapshot version %d (latest supported is %d)", *version, snapshotVersion)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package infura
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// PriorityFeeSpread returns max - min of LatestPriorityFeeRange in Gwei
+func (f *SuggestedGasFees) PriorityFeeSpread() (*big.Float, error) {
+	low, high, err := f.latestPriorityFeeBounds()
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Float).SetPrec(gweiPrecision).Sub(high, low), nil
+}
+
+// SpreadRatio returns (max - min) / min of LatestPriorityFeeRange
+// An error is returned if the minimum is zero
+func (f *SuggestedGasFees) SpreadRatio() (float64, error) {
+	low, high, err := f.latestPriorityFeeBounds()
+	if err != nil {
+		return 0, err
+	}
+	if low.Sign() == 0 {
+		return 0, errors.New("latest priority fee range minimum is zero")
+	}
+
+	spread := new(big.Float).SetPrec(gweiPrecision).Sub(high, low)
+	ratio, _ := spread.Quo(spread, low).Float64()
+	return ratio, nil
+}
+
+// latestPriorityFeeBounds parses the [min, max] pair of LatestPriorityFeeRange
+func (f *SuggestedGasFees) latestPriorityFeeBounds() (low, high *big.Float, err error) {
+	if len(f.LatestPriorityFeeRange) != 2 {
+		return nil, nil, fmt.Errorf("latest priority fee range must have 2 entries, got %d", len(f.LatestPriorityFeeRange))
+	}
+	low, err = parseGwei(f.LatestPriorityFeeRange[0])
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse latest priority fee range minimum: %w", err)
+	}
+	high, err = parseGwei(f.LatestPriorityFeeRange[1])
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse latest priority fee range maximum: %w", err)
+	}
+	if high.Cmp(low) < 0 {
+		return nil, nil, fmt.Errorf("latest priority fee range maximum %s is below minimum %s",
+			f.LatestPriorityFeeRange[1], f.LatestPriorityFeeRange[0])
+	}
+	return low, high, nil
+}
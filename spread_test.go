@@ -0,0 +1,62 @@
+package infura
+
+import "testing"
+
+func TestSuggestedGasFees_PriorityFeeSpread(t *testing.T) {
+	tests := []struct {
+		name       string
+		feeRange   []string
+		wantSpread string
+		wantRatio  float64
+	}{
+		{"sample range", []string{"0.001", "2"}, "1.999", 1999},
+		{"narrow range", []string{"1.5", "1.5"}, "0", 0},
+		{"fractional", []string{"0.25", "1.75"}, "1.5", 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fees := &SuggestedGasFees{LatestPriorityFeeRange: tt.feeRange}
+
+			spread, err := fees.PriorityFeeSpread()
+			if err != nil {
+				t.Fatalf("PriorityFeeSpread failed: %v", err)
+			}
+			if got := spread.Text('f', -1); got != tt.wantSpread {
+				t.Errorf("Expected spread %s, got %s", tt.wantSpread, got)
+			}
+
+			ratio, err := fees.SpreadRatio()
+			if err != nil {
+				t.Fatalf("SpreadRatio failed: %v", err)
+			}
+			if !almostEqual(ratio, tt.wantRatio) {
+				t.Errorf("Expected ratio %v, got %v", tt.wantRatio, ratio)
+			}
+		})
+	}
+}
+
+func TestSuggestedGasFees_SpreadRatio_ZeroMinimum(t *testing.T) {
+	fees := &SuggestedGasFees{LatestPriorityFeeRange: []string{"0", "2"}}
+
+	spread, err := fees.PriorityFeeSpread()
+	if err != nil || spread.Text('f', -1) != "2" {
+		t.Errorf("Expected spread 2, got %v (err %v)", spread, err)
+	}
+	if _, err := fees.SpreadRatio(); err == nil {
+		t.Error("Expected error for zero minimum")
+	}
+}
+
+func TestSuggestedGasFees_PriorityFeeSpread_Invalid(t *testing.T) {
+	for _, feeRange := range [][]string{nil, {"1"}, {"1", "2", "3"}, {"abc", "2"}, {"1", "-2"}, {"3", "2"}} {
+		fees := &SuggestedGasFees{LatestPriorityFeeRange: feeRange}
+		if _, err := fees.PriorityFeeSpread(); err == nil {
+			t.Errorf("range %q: expected PriorityFeeSpread error", feeRange)
+		}
+		if _, err := fees.SpreadRatio(); err == nil {
+			t.Errorf("range %q: expected SpreadRatio error", feeRange)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package infura
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// String returns a compact summary, e.g.
+// "low 24.1 gwei (~15s–30s) | med 32.5 gwei (~15s–45s) | high 41.2 gwei (~15s–30s) | base 24 gwei | congestion 71%"
+func (f SuggestedGasFees) String() string {
+	var b strings.Builder
+	for i, level := range []GasFeeLevel{f.Low, f.Medium, f.High} {
+		if i > 0 {
+			b.WriteString(" | ")
+		}
+		b.WriteString([]string{"low", "med", "high"}[i])
+		b.WriteString(" ")
+		b.WriteString(formatGweiField(level.SuggestedMaxFeePerGas))
+		b.WriteString(" gwei")
+		if wait := formatWait(level); wait != "" {
+			b.WriteString(" (" + wait + ")")
+		}
+	}
+	fmt.Fprintf(&b, " | base %s gwei | congestion %s", formatGweiField(f.EstimatedBaseFee), formatCongestion(f.NetworkCongestion))
+	return b.String()
+}
+
+// String returns a compact summary, e.g. "32.5 gwei (tip 2, ~15s–45s)"
+func (l GasFeeLevel) String() string {
+	s := formatGweiField(l.SuggestedMaxFeePerGas) + " gwei (tip " + formatGweiField(l.SuggestedMaxPriorityFeePerGas)
+	if wait := formatWait(l); wait != "" {
+		s += ", " + wait
+	}
+	s += ")"
+	if l.Clamped {
+		s += " [clamped]"
+	}
+	return s
+}
+
+// String returns a compact summary, e.g. "base fee percentile 24 gwei"
+func (p BaseFeePercentile) String() string {
+	return "base fee percentile " + formatGweiField(p.BaseFeePercentile) + " gwei"
+}
+
+// String returns a compact summary, e.g. "busy threshold 30 gwei"
+func (t BusyThreshold) String() string {
+	return "busy threshold " + formatGweiField(t.BusyThreshold) + " gwei"
+}
+
+// formatGweiField normalizes a Gwei string through exact wei math; values that do not parse
+// are shown verbatim, and empty values as "?"
+func formatGweiField(gwei string) string {
+	if gwei == "" {
+		return "?"
+	}
+	wei, err := ParseGweiToWei(gwei)
+	if err != nil {
+		return gwei
+	}
+	return formatWeiAsGwei(wei)
+}
+
+// formatWait renders the wait estimates of a level, or "" if neither is set
+func formatWait(l GasFeeLevel) string {
+	if l.MinWaitTimeEstimate == 0 && l.MaxWaitTimeEstimate == 0 {
+		return ""
+	}
+	minWait := time.Duration(l.MinWaitTimeEstimate) * time.Millisecond
+	maxWait := time.Duration(l.MaxWaitTimeEstimate) * time.Millisecond
+	return "~" + minWait.String() + "–" + maxWait.String()
+}
+
+// formatCongestion renders a congestion ratio as a whole percentage, or "?" when unset
+func formatCongestion(c Congestion) string {
+	if !c.IsSet() {
+		return "?"
+	}
+	return fmt.Sprintf("%.0f%%", c.Float64()*100)
+}
@@ -0,0 +1,41 @@
+package infura
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestStringers(t *testing.T) {
+	fees := SuggestedGasFees{
+		Low:               GasFeeLevel{SuggestedMaxPriorityFeePerGas: "0.05", SuggestedMaxFeePerGas: "24.100000000", MinWaitTimeEstimate: 15000, MaxWaitTimeEstimate: 30000},
+		Medium:            GasFeeLevel{SuggestedMaxPriorityFeePerGas: "2", SuggestedMaxFeePerGas: "32.5", MinWaitTimeEstimate: 15000, MaxWaitTimeEstimate: 45000},
+		High:              GasFeeLevel{SuggestedMaxPriorityFeePerGas: "5", SuggestedMaxFeePerGas: "41.2", MinWaitTimeEstimate: 1500, MaxWaitTimeEstimate: 90000, Clamped: true},
+		EstimatedBaseFee:  "24.0",
+		NetworkCongestion: NewCongestion(0.7071),
+	}
+	odd := SuggestedGasFees{
+		Low:              GasFeeLevel{SuggestedMaxFeePerGas: "n/a"},
+		EstimatedBaseFee: "0.000000001",
+	}
+
+	values := []fmt.Stringer{
+		fees,
+		&fees,
+		fees.Medium,
+		fees.High,
+		SuggestedGasFees{},
+		GasFeeLevel{},
+		odd,
+		BaseFeePercentile{BaseFeePercentile: "23.50"},
+		BaseFeePercentile{},
+		BusyThreshold{BusyThreshold: "30"},
+		BusyThreshold{},
+	}
+
+	var buf bytes.Buffer
+	for _, v := range values {
+		fmt.Fprintf(&buf, "%v\n", v)
+	}
+	assertGolden(t, "stringers.txt", buf.Bytes())
+}
@@ -0,0 +1,28 @@
+package infura
+
+import (
+	"context"
+	"maps"
+)
+
+// tagsKey is the context key of the tags set with ContextWithTags
+type tagsKey struct{}
+
+// ContextWithTags returns a context whose calls report tags, such as a tenant or a
+// caller name, to the hook set with WithMetricsHook. Tags already on ctx are kept unless
+// tags sets the same key. The map is copied, so the caller may reuse it.
+func ContextWithTags(ctx context.Context, tags map[string]string) context.Context {
+	merged := maps.Clone(TagsFromContext(ctx))
+	if merged == nil {
+		merged = make(map[string]string, len(tags))
+	}
+	maps.Copy(merged, tags)
+	return context.WithValue(ctx, tagsKey{}, merged)
+}
+
+// TagsFromContext returns the tags set on ctx with ContextWithTags, or nil if there are
+// none. The map must not be modified.
+func TagsFromContext(ctx context.Context) map[string]string {
+	tags, _ := ctx.Value(tagsKey{}).(map[string]string)
+	return tags
+}
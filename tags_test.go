@@ -0,0 +1,165 @@
+package infura
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingHook records the observations it receives
+type recordingHook struct {
+	mu      sync.Mutex
+	metrics []RequestMetric
+}
+
+func (h *recordingHook) ObserveRequest(metric RequestMetric) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.metrics = append(h.metrics, metric)
+}
+
+func TestContextWithTags_ReachMetricsHook(t *testing.T) {
+	server := newFeesServer(t, `{"estimatedBaseFee": "10"}`)
+	defer server.Close()
+
+	hook := &recordingHook{}
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL), WithMetricsHook(hook))
+
+	tags := map[string]string{"tenant": "acme"}
+	ctx := ContextWithTags(context.Background(), tags)
+	ctx = ContextWithTags(ctx, map[string]string{"caller": "pricing"})
+	tags["tenant"] = "changed"
+	if _, err := client.GetSuggestedGasFees(ctx, 137); err != nil {
+		t.Fatalf("GetSuggestedGasFees failed: %v", err)
+	}
+
+	if len(hook.metrics) != 1 {
+		t.Fatalf("Expected 1 observation, got %d", len(hook.metrics))
+	}
+	metric := hook.metrics[0]
+	if metric.Tags["tenant"] != "acme" || metric.Tags["caller"] != "pricing" || len(metric.Tags) != 2 {
+		t.Errorf("Expected both tags, got %v", metric.Tags)
+	}
+	if metric.Endpoint != "suggestedGasFees" || metric.ChainID != 137 || metric.Attempt != 1 || metric.StatusCode != http.StatusOK || metric.Err != nil {
+		t.Errorf("Unexpected observation: %+v", metric)
+	}
+	if metric.Duration <= 0 {
+		t.Errorf("Expected a positive duration, got %v", metric.Duration)
+	}
+}
+
+func TestContextWithTags_Optional(t *testing.T) {
+	server := newFeesServer(t, `{"estimatedBaseFee": "10"}`)
+	defer server.Close()
+
+	hook := &recordingHook{}
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL), WithMetricsHook(hook))
+	if _, err := client.GetSuggestedGasFees(context.Background(), 1); err != nil {
+		t.Fatalf("GetSuggestedGasFees failed: %v", err)
+	}
+	if len(hook.metrics) != 1 || hook.metrics[0].Tags != nil {
+		t.Errorf("Expected one observation without tags, got %+v", hook.metrics)
+	}
+}
+
+func TestContextWithTags_OverrideKey(t *testing.T) {
+	ctx := ContextWithTags(context.Background(), map[string]string{"tenant": "a", "region": "eu"})
+	child := ContextWithTags(ctx, map[string]string{"tenant": "b"})
+
+	if got := TagsFromContext(child); got["tenant"] != "b" || got["region"] != "eu" {
+		t.Errorf("Expected the later tag to win, got %v", got)
+	}
+	if got := TagsFromContext(ctx); got["tenant"] != "a" {
+		t.Errorf("Expected the parent tags to be unchanged, got %v", got)
+	}
+	if got := TagsFromContext(context.Background()); got != nil {
+		t.Errorf("Expected no tags, got %v", got)
+	}
+}
+
+func TestWithMetricsHook_Retries(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"estimatedBaseFee": "10"}`))
+	}))
+	defer server.Close()
+
+	hook := &recordingHook{}
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithRetry(2, time.Millisecond),
+		WithMetricsHook(hook))
+
+	ctx := ContextWithTags(context.Background(), map[string]string{"tenant": "acme"})
+	if _, err := client.GetSuggestedGasFees(ctx, 1); err != nil {
+		t.Fatalf("GetSuggestedGasFees failed: %v", err)
+	}
+	if len(hook.metrics) != 2 {
+		t.Fatalf("Expected 2 observations, got %d", len(hook.metrics))
+	}
+	for i, want := range []int{http.StatusServiceUnavailable, http.StatusOK} {
+		metric := hook.metrics[i]
+		if metric.Attempt != i+1 || metric.StatusCode != want || metric.Tags["tenant"] != "acme" {
+			t.Errorf("Observation %d: unexpected %+v", i, metric)
+		}
+	}
+}
+
+func TestWithMetricsHook_FailoverAttempts(t *testing.T) {
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer primary.Close()
+	secondary := newFeesServer(t, `{"estimatedBaseFee": "10"}`)
+	defer secondary.Close()
+
+	hook := &recordingHook{}
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(primary.URL),
+		WithFailoverURLs(secondary.URL),
+		WithRetry(2, time.Millisecond),
+		WithMetricsHook(hook))
+
+	if _, err := client.GetSuggestedGasFees(context.Background(), 1); err != nil {
+		t.Fatalf("GetSuggestedGasFees failed: %v", err)
+	}
+	if len(hook.metrics) != 3 {
+		t.Fatalf("Expected 3 observations, got %d", len(hook.metrics))
+	}
+	for i, metric := range hook.metrics {
+		if metric.Attempt != i+1 {
+			t.Errorf("Expected observation %d to be attempt %d, got %d", i, i+1, metric.Attempt)
+		}
+	}
+}
+
+func TestWithMetricsHook_TransportError(t *testing.T) {
+	server := newFeesServer(t, `{}`)
+	url := server.URL
+	server.Close()
+
+	hook := &recordingHook{}
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(url), WithMetricsHook(hook))
+	if _, err := client.GetSuggestedGasFees(context.Background(), 1); err == nil {
+		t.Fatal("Expected an error")
+	}
+	if len(hook.metrics) != 1 || hook.metrics[0].StatusCode != 0 || hook.metrics[0].Err == nil {
+		t.Errorf("Expected one failed observation, got %+v", hook.metrics)
+	}
+}
+
+func TestWithMetricsHook_Nil(t *testing.T) {
+	if _, err := New("key", "", WithMetricsHook(nil)); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("Expected ErrInvalidOption, got %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+package infura
+
+import (
+	"context"
+	"crypto/tls"
+)
+
+// TLSInfo describes the TLS connection a response was received on
+type TLSInfo struct {
+	// Endpoint is the request path, with the API key redacted
+	Endpoint string
+	// Version is the negotiated TLS version (e.g. tls.VersionTLS13) and VersionName its
+	// name (e.g. "TLS 1.3")
+	Version     uint16
+	VersionName string
+	// CipherSuite is the negotiated cipher suite and CipherSuiteName its name
+	// (e.g. "TLS_AES_128_GCM_SHA256")
+	CipherSuite     uint16
+	CipherSuiteName string
+	// ServerName is the server name sent in the SNI extension
+	ServerName string
+	// NegotiatedProtocol is the ALPN protocol, e.g. "h2", empty if none was negotiated
+	NegotiatedProtocol string
+	// DidResume is true when the session was resumed from a previous connection
+	DidResume bool
+	// PeerSubject and PeerIssuer are the distinguished names of the server's leaf
+	// certificate, empty if it sent none
+	PeerSubject string
+	PeerIssuer  string
+}
+
+// WithTLSInfo captures the TLS details of every response, e.g. to audit that connections
+// use TLS 1.3: they are recorded in CallMeta.TLS and, if handler is not nil, passed to
+// handler, which runs synchronously on the calling goroutine. Responses received over
+// plain HTTP carry no TLS details and are skipped.
+func WithTLSInfo(handler func(TLSInfo)) ClientOption {
+	return func(c *Client) {
+		c.tlsInfo = true
+		c.tlsInfoHandler = handler
+	}
+}
+
+// newTLSInfo returns the TLS details of a connection state
+func newTLSInfo(state *tls.ConnectionState) TLSInfo {
+	info := TLSInfo{
+		Version:            state.Version,
+		VersionName:        tls.VersionName(state.Version),
+		CipherSuite:        state.CipherSuite,
+		CipherSuiteName:    tls.CipherSuiteName(state.CipherSuite),
+		ServerName:         state.ServerName,
+		NegotiatedProtocol: state.NegotiatedProtocol,
+		DidResume:          state.DidResume,
+	}
+	if len(state.PeerCertificates) > 0 {
+		leaf := state.PeerCertificates[0]
+		info.PeerSubject = leaf.Subject.String()
+		info.PeerIssuer = leaf.Issuer.String()
+	}
+	return info
+}
+
+// captureTLS reports the TLS details of a response to CallMeta and the configured handler
+func (c *Client) captureTLS(ctx context.Context, creds *credentials, endpoint string, state *tls.ConnectionState) {
+	if !c.tlsInfo || state == nil {
+		return
+	}
+	info := newTLSInfo(state)
+	info.Endpoint = redactAPIKey(creds, endpoint)
+
+	recordCallMeta(ctx, func(meta *CallMeta) {
+		meta.TLS = &info
+	})
+	if c.tlsInfoHandler != nil {
+		c.tlsInfoHandler(info)
+	}
+}
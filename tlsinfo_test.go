@@ -0,0 +1,87 @@
+package infura
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newTLSFeesServer() *httptest.Server {
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"estimatedBaseFee": "10"}`))
+	}))
+}
+
+func TestWithTLSInfo(t *testing.T) {
+	server := newTLSFeesServer()
+	defer server.Close()
+
+	var infos []TLSInfo
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithHTTPClient(server.Client()),
+		WithTLSInfo(func(info TLSInfo) { infos = append(infos, info) }))
+
+	var meta CallMeta
+	if _, err := client.GetSuggestedGasFees(ContextWithCallMeta(context.Background(), &meta), 1); err != nil {
+		t.Fatalf("GetSuggestedGasFees failed: %v", err)
+	}
+
+	if meta.TLS == nil {
+		t.Fatal("Expected TLS details in CallMeta")
+	}
+	info := *meta.TLS
+	if info.Version != tls.VersionTLS13 || info.VersionName != "TLS 1.3" {
+		t.Errorf("Expected TLS 1.3, got %d (%s)", info.Version, info.VersionName)
+	}
+	if info.CipherSuite == 0 || info.CipherSuiteName == "" {
+		t.Errorf("Expected a cipher suite, got %+v", info)
+	}
+	if !strings.Contains(info.PeerSubject, "Acme Co") {
+		t.Errorf("Expected the test certificate subject, got %q", info.PeerSubject)
+	}
+	if strings.Contains(info.Endpoint, "test-api-key") || !strings.Contains(info.Endpoint, "suggestedGasFees") {
+		t.Errorf("Expected the redacted endpoint, got %q", info.Endpoint)
+	}
+	if len(infos) != 1 || infos[0] != info {
+		t.Errorf("Expected the handler to receive the same details, got %+v", infos)
+	}
+}
+
+func TestWithTLSInfo_PlainHTTP(t *testing.T) {
+	server := newFeesServer(t, `{"estimatedBaseFee": "10"}`)
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithTLSInfo(nil))
+
+	var meta CallMeta
+	if _, err := client.GetSuggestedGasFees(ContextWithCallMeta(context.Background(), &meta), 1); err != nil {
+		t.Fatalf("GetSuggestedGasFees failed: %v", err)
+	}
+	if meta.TLS != nil {
+		t.Errorf("Expected no TLS details over plain HTTP, got %+v", meta.TLS)
+	}
+}
+
+func TestTLSInfo_NotCapturedByDefault(t *testing.T) {
+	server := newTLSFeesServer()
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithHTTPClient(server.Client()))
+
+	var meta CallMeta
+	if _, err := client.GetSuggestedGasFees(ContextWithCallMeta(context.Background(), &meta), 1); err != nil {
+		t.Fatalf("GetSuggestedGasFees failed: %v", err)
+	}
+	if meta.TLS != nil {
+		t.Errorf("Expected no TLS details without WithTLSInfo, got %+v", meta.TLS)
+	}
+}
@@ -0,0 +1,92 @@
+package infura
+
+import (
+	"context"
+	"net"
+	"net/http"
+)
+
+// WithDisableKeepAlives closes each connection after its request instead of keeping it
+// open for reuse, so a short-lived process such as a CLI making a single call can exit
+// without idle connections lingering. Long-running processes should not use it: every
+// request then pays for a new TCP and TLS handshake, which adds latency and load.
+//
+// It applies to the transport owned by the client, the default or an *http.Transport set
+// with WithTransport, which is cloned rather than modified. It has no effect when
+// WithHTTPClient is given, since that client's transport is configured by the caller, nor
+// on a transport that is not an *http.Transport.
+func WithDisableKeepAlives() ClientOption {
+	return func(c *Client) {
+		c.disableKeepAlives = true
+	}
+}
+
+// WithDialContext sets the function used to open network connections, e.g. to route all
+// traffic through a SOCKS5 bastion or a dedicated network namespace
+// It is installed on a copy of the client's transport: the default, an *http.Transport set
+// with WithTransport, or the Transport of a client passed to WithHTTPClient, which is not
+// modified. A transport that is not an *http.Transport, or one with its own DialTLSContext
+// or DialTLS (which would bypass the dialer for HTTPS), is incompatible and makes the
+// option invalid; with the constructors that ignore invalid options, every request of the
+// client then fails with the option's error rather than bypassing the dialer. Like WithTransport, it changes the transport, so the constructor panics
+// when it is combined with WithUseProvidedTransportAsIs. A nil dialer is invalid.
+func WithDialContext(dial func(ctx context.Context, network, addr string) (net.Conn, error)) ClientOption {
+	return func(c *Client) {
+		if dial == nil {
+			c.rejectOption("WithDialContext", "dialer must not be nil")
+			return
+		}
+		c.dialContext = dial
+		c.transportOptions = append(c.transportOptions, "WithDialContext")
+	}
+}
+
+// finalizeTransportSettings applies the transport settings of the client's own options to
+// a copy of its transport once all options are applied
+func (c *Client) finalizeTransportSettings() {
+	disableKeepAlives := c.disableKeepAlives && !c.providedHTTPClient
+	if !disableKeepAlives && c.dialContext == nil {
+		return
+	}
+	transport, ok := cloneTransport(c.httpClient.Transport)
+	if !ok {
+		if c.dialContext != nil {
+			c.rejectDialer("the transport %T is not an *http.Transport, so its dialer cannot be set", c.httpClient.Transport)
+		}
+		return
+	}
+	if c.dialContext != nil {
+		if transport.DialTLSContext != nil || transport.DialTLS != nil {
+			c.rejectDialer("the transport has its own DialTLSContext or DialTLS, which would bypass the dialer")
+			return
+		}
+		transport.DialContext = c.dialContext
+	}
+	if disableKeepAlives {
+		transport.DisableKeepAlives = true
+	}
+
+	httpClient := *c.httpClient
+	httpClient.Transport = transport
+	c.httpClient = &httpClient
+}
+
+// rejectDialer records a dialer that cannot be installed; every request of the client then
+// fails, as sending traffic without the dialer would bypass the route it sets
+func (c *Client) rejectDialer(format string, args ...interface{}) {
+	c.rejectOption("WithDialContext", format, args...)
+	c.transportErr = c.optionErrs[len(c.optionErrs)-1]
+}
+
+// cloneTransport returns a copy of transport, or of http.DefaultTransport if it is nil
+// It reports false if the transport is not an *http.Transport and cannot be configured
+func cloneTransport(transport http.RoundTripper) (*http.Transport, bool) {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	t, ok := transport.(*http.Transport)
+	if !ok {
+		return nil, false
+	}
+	return t.Clone(), true
+}
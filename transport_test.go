@@ -0,0 +1,188 @@
+package infura
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestWithDisableKeepAlives(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !r.Close {
+			t.Error("Expected the request to ask for the connection to be closed")
+		}
+		w.Write([]byte(`{"busyThreshold": "10"}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL), WithDisableKeepAlives())
+
+	transport, ok := client.httpClient.Transport.(*http.Transport)
+	if !ok || !transport.DisableKeepAlives {
+		t.Fatalf("Expected an *http.Transport with DisableKeepAlives, got %#v", client.httpClient.Transport)
+	}
+	if http.DefaultTransport.(*http.Transport).DisableKeepAlives {
+		t.Error("Expected http.DefaultTransport to be left unchanged")
+	}
+	if _, err := client.GetBusyThreshold(context.Background(), 1); err != nil {
+		t.Fatalf("GetBusyThreshold failed: %v", err)
+	}
+}
+
+func TestWithDisableKeepAlives_ClonesWithTransport(t *testing.T) {
+	own := &http.Transport{MaxIdleConns: 7}
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithTransport(own), WithDisableKeepAlives())
+
+	transport, ok := client.httpClient.Transport.(*http.Transport)
+	if !ok || !transport.DisableKeepAlives || transport.MaxIdleConns != 7 {
+		t.Fatalf("Expected a configured clone of the transport, got %#v", client.httpClient.Transport)
+	}
+	if own.DisableKeepAlives {
+		t.Error("Expected the transport passed to WithTransport to be left unchanged")
+	}
+}
+
+func TestWithDisableKeepAlives_NoOpWithHTTPClient(t *testing.T) {
+	provided := &http.Transport{}
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithHTTPClient(&http.Client{Transport: provided}),
+		WithDisableKeepAlives())
+
+	if client.httpClient.Transport != provided || provided.DisableKeepAlives {
+		t.Errorf("Expected the provided transport to be used unchanged, got %#v", client.httpClient.Transport)
+	}
+}
+
+// recordingDialer dials target whatever address is requested and records the addresses
+type recordingDialer struct {
+	target string
+	mu     sync.Mutex
+	addrs  []string
+}
+
+func (d *recordingDialer) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	d.mu.Lock()
+	d.addrs = append(d.addrs, addr)
+	d.mu.Unlock()
+	var dialer net.Dialer
+	return dialer.DialContext(ctx, network, d.target)
+}
+
+func TestWithDialContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"busyThreshold": "10"}`))
+	}))
+	defer server.Close()
+
+	dialer := &recordingDialer{target: server.Listener.Addr().String()}
+	// The host does not resolve, so every request must go through the dialer; keep-alives
+	// are disabled so each request dials
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL("http://gas.bastion.invalid:8080"),
+		WithDialContext(dialer.DialContext),
+		WithDisableKeepAlives())
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.GetBusyThreshold(context.Background(), 1); err != nil {
+			t.Fatalf("GetBusyThreshold failed: %v", err)
+		}
+	}
+	dialer.mu.Lock()
+	defer dialer.mu.Unlock()
+	if len(dialer.addrs) != 3 {
+		t.Fatalf("Expected 3 dials, got %v", dialer.addrs)
+	}
+	for _, addr := range dialer.addrs {
+		if addr != "gas.bastion.invalid:8080" {
+			t.Errorf("Expected the dialer to be asked for the API host, got %s", addr)
+		}
+	}
+}
+
+func TestWithDialContext_ClonesProvidedTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"busyThreshold": "10"}`))
+	}))
+	defer server.Close()
+
+	provided := &http.Client{Transport: &http.Transport{MaxIdleConns: 7}}
+	dialer := &recordingDialer{target: server.Listener.Addr().String()}
+	client, err := New("test-api-key", "",
+		WithBaseURL("http://gas.bastion.invalid"),
+		WithHTTPClient(provided),
+		WithDialContext(dialer.DialContext))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	if _, err := client.GetBusyThreshold(context.Background(), 1); err != nil {
+		t.Fatalf("GetBusyThreshold failed: %v", err)
+	}
+	if len(dialer.addrs) != 1 {
+		t.Errorf("Expected the dialer to be used, got %v", dialer.addrs)
+	}
+	if transport := client.httpClient.Transport.(*http.Transport); transport.MaxIdleConns != 7 {
+		t.Error("Expected the settings of the provided transport to be kept")
+	}
+	if provided.Transport.(*http.Transport).DialContext != nil {
+		t.Error("Expected the provided transport to be left unchanged")
+	}
+}
+
+func TestWithDialContext_Incompatible(t *testing.T) {
+	dial := (&net.Dialer{}).DialContext
+	roundTripper := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return nil, errors.New("unreachable")
+	})
+	tlsDialer := &http.Transport{DialTLSContext: dial}
+
+	tests := map[string][]ClientOption{
+		"nil dialer":          {WithDialContext(nil)},
+		"custom RoundTripper": {WithTransport(roundTripper), WithDialContext(dial)},
+		"provided client":     {WithHTTPClient(&http.Client{Transport: roundTripper}), WithDialContext(dial)},
+		"own TLS dialer":      {WithHTTPClient(&http.Client{Transport: tlsDialer}), WithDialContext(dial)},
+	}
+	for name, opts := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := New("test-api-key", "", opts...)
+			if !errors.Is(err, ErrInvalidOption) || !strings.Contains(err.Error(), "WithDialContext") {
+				t.Errorf("Expected ErrInvalidOption for WithDialContext, got %v", err)
+			}
+		})
+	}
+}
+
+func TestWithDialContext_IncompatibleFailsRequests(t *testing.T) {
+	var requests int
+	roundTripper := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		requests++
+		return nil, errors.New("sent without the dialer")
+	})
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithTransport(roundTripper),
+		WithDialContext((&net.Dialer{}).DialContext))
+
+	_, err := client.GetSuggestedGasFees(context.Background(), 1)
+	if !errors.Is(err, ErrInvalidOption) || !strings.Contains(err.Error(), "WithDialContext") {
+		t.Errorf("Expected the WithDialContext error, got %v", err)
+	}
+	if requests != 0 {
+		t.Errorf("Expected no request to bypass the dialer, got %d", requests)
+	}
+}
+
+func TestWithDialContext_PanicsWithTransportAsIs(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(r.(string), "WithDialContext") {
+			t.Errorf("Expected a panic naming WithDialContext, got %v", r)
+		}
+	}()
+	NewClientWithAPIKeyAndOptions("test-api-key",
+		WithHTTPClient(&http.Client{}),
+		WithUseProvidedTransportAsIs(),
+		WithDialContext((&net.Dialer{}).DialContext))
+}
@@ -0,0 +1,246 @@
+package infura
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// gweiPrecision is the mantissa precision used for big.Float fee arithmetic
+const gweiPrecision = 256
+
+// parseGwei parses a decimal Gwei string as returned by the API into a big.Float
+// It accepts the same forms as ParseGweiToWei but without the 9-decimal limit
+func parseGwei(s string) (*big.Float, error) {
+	intPart, fracPart, err := splitGwei(s)
+	if err != nil {
+		return nil, err
+	}
+	f, ok := new(big.Float).SetPrec(gweiPrecision).SetString(intPart + "." + fracPart + "0")
+	if !ok {
+		return nil, fmt.Errorf("invalid gwei value: %q", s)
+	}
+	return f, nil
+}
+
+// weiPerGwei is the number of wei in one Gwei
+var weiPerGwei = big.NewInt(1_000_000_000)
+
+// gweiDecimals is the number of decimal places representable in wei when expressed in Gwei
+const gweiDecimals = 9
+
+// ParseGweiToWei converts a decimal Gwei string as returned by the API (e.g. "24.086058416")
+// into an exact integer amount of wei.
+//
+// Accepted: plain decimal digits with an optional single "." and at most 9 fractional digits
+// ("24", "24.5", ".5", "24."), optionally surrounded by whitespace.
+// Rejected with an error: empty strings, signs ("+1", "-1"), exponents ("1e10"), hex ("0x5"),
+// digit separators ("1,000", "1_000"), comma decimal separators ("24,5"), multiple dots,
+// NaN/Inf, and more than 9 fractional digits (not representable in wei).
+func ParseGweiToWei(gwei string) (*big.Int, error) {
+	intPart, fracPart, err := splitGwei(gwei)
+	if err != nil {
+		return nil, err
+	}
+	if len(fracPart) > gweiDecimals {
+		return nil, fmt.Errorf("gwei value %q has more than %d decimal places", gwei, gweiDecimals)
+	}
+
+	digits := intPart + fracPart + strings.Repeat("0", gweiDecimals-len(fracPart))
+	wei, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid gwei value: %q", gwei)
+	}
+	return wei, nil
+}
+
+// RoundMode selects how FeeToWei rounds Gwei amounts with more than 9 decimal places
+type RoundMode int
+
+const (
+	// RoundUp rounds to the next wei; use it for maxFeePerGas and maxPriorityFeePerGas so
+	// that a fee is never priced below the suggestion
+	RoundUp RoundMode = iota
+	// RoundDown truncates to the wei
+	RoundDown
+	// RoundNearest rounds to the nearest wei, halves up
+	RoundNearest
+)
+
+// String returns the lowercase name of the mode
+func (m RoundMode) String() string {
+	switch m {
+	case RoundUp:
+		return "up"
+	case RoundDown:
+		return "down"
+	case RoundNearest:
+		return "nearest"
+	default:
+		return fmt.Sprintf("RoundMode(%d)", int(m))
+	}
+}
+
+// FeeToWeiRoundUp converts a Gwei fee to integer wei, rounding any fraction of a wei up
+// This is the usual policy for transaction fields: rounding maxFeePerGas or
+// maxPriorityFeePerGas down would underprice the transaction, even if only by a wei.
+func FeeToWeiRoundUp(gwei string) (*big.Int, error) {
+	return FeeToWei(gwei, RoundUp)
+}
+
+// FeeToWei converts a Gwei fee to integer wei, rounding digits beyond the 9th decimal place
+// according to mode. The math works on the decimal digits, without floats, so
+// "24.0860584161" becomes 24086058417 wei rounded up and 24086058416 rounded down or to
+// nearest. Amounts with at most 9 decimal places are exact in every mode. The syntax is
+// that of ParseGweiToWei without the limit on decimal places.
+func FeeToWei(gwei string, mode RoundMode) (*big.Int, error) {
+	if mode != RoundUp && mode != RoundDown && mode != RoundNearest {
+		return nil, fmt.Errorf("unknown round mode %v", mode)
+	}
+	intPart, fracPart, err := splitGwei(gwei)
+	if err != nil {
+		return nil, err
+	}
+
+	var rest string
+	if len(fracPart) > gweiDecimals {
+		fracPart, rest = fracPart[:gweiDecimals], fracPart[gweiDecimals:]
+	}
+	digits := intPart + fracPart + strings.Repeat("0", gweiDecimals-len(fracPart))
+	wei, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid gwei value: %q", gwei)
+	}
+
+	var roundUp bool
+	switch mode {
+	case RoundUp:
+		roundUp = strings.Trim(rest, "0") != ""
+	case RoundNearest:
+		roundUp = rest != "" && rest[0] >= '5'
+	}
+	if roundUp {
+		wei.Add(wei, big.NewInt(1))
+	}
+	return wei, nil
+}
+
+// splitGwei validates the syntax of a Gwei string and returns its integer and fractional
+// digits; at least one of them is non-empty
+func splitGwei(gwei string) (intPart, fracPart string, err error) {
+	trimmed := strings.TrimSpace(gwei)
+	if trimmed == "" {
+		return "", "", fmt.Errorf("empty gwei value")
+	}
+	intPart, fracPart, _ = strings.Cut(trimmed, ".")
+	if intPart == "" && fracPart == "" {
+		return "", "", fmt.Errorf("invalid gwei value: %q", gwei)
+	}
+	if !isDigits(intPart) || !isDigits(fracPart) {
+		return "", "", fmt.Errorf("invalid gwei value: %q", gwei)
+	}
+	return intPart, fracPart, nil
+}
+
+// isDigits reports whether s consists only of ASCII digits (an empty string qualifies)
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// formatWeiAsGwei renders a wei amount as an exact decimal Gwei string without trailing zeros
+func formatWeiAsGwei(wei *big.Int) string {
+	return formatUnits(wei, gweiDecimals)
+}
+
+// formatUnits renders a non-negative integer amount of base units as an exact decimal string
+// with the given number of decimals, without trailing zeros
+func formatUnits(amount *big.Int, decimals int) string {
+	unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	quotient, remainder := new(big.Int).QuoRem(amount, unit, new(big.Int))
+	if remainder.Sign() == 0 {
+		return quotient.String()
+	}
+	frac := fmt.Sprintf("%0*s", decimals, remainder.String())
+	return quotient.String() + "." + strings.TrimRight(frac, "0")
+}
+
+// NormalizeGwei returns the canonical spelling of a Gwei amount, so equal values compare
+// equal as strings, e.g. as map keys: no leading or trailing zeros, no sign, "0" for zero
+// and at most 9 decimal places, so "024.50", "+24.5" and "24.500000000" all become
+// "24.5". It accepts the forms ParseGweiToWei does plus a leading "+".
+func NormalizeGwei(gwei string) (string, error) {
+	wei, err := gweiToWei(gwei)
+	if err != nil {
+		return "", err
+	}
+	return formatWeiAsGwei(wei), nil
+}
+
+// CompareGwei compares two Gwei amounts numerically and returns -1, 0 or +1
+// The amounts are compared as exact amounts of wei, without converting to float. It
+// accepts the same input as NormalizeGwei, so more than 9 decimal places is an error.
+func CompareGwei(a, b string) (int, error) {
+	x, err := gweiToWei(a)
+	if err != nil {
+		return 0, err
+	}
+	y, err := gweiToWei(b)
+	if err != nil {
+		return 0, err
+	}
+	return x.Cmp(y), nil
+}
+
+// gweiToWei converts a Gwei amount in any form NormalizeGwei and CompareGwei accept to wei
+func gweiToWei(gwei string) (*big.Int, error) {
+	return ParseGweiToWei(trimPlus(gwei))
+}
+
+// trimPlus removes surrounding whitespace and a single leading "+"
+func trimPlus(gwei string) string {
+	return strings.TrimPrefix(strings.TrimSpace(gwei), "+")
+}
+
+// GweiUnit is the unit label of the fee amounts returned by the Gas API
+const GweiUnit = "Gwei"
+
+// DenominatedFee is a fee amount paired with the labels to display it with
+type DenominatedFee struct {
+	Wei *big.Int
+	// Unit is the denomination Amount is expressed in, always GweiUnit
+	Unit string
+	// Symbol is the native currency symbol of the chain, e.g. "ETH" or "POL"
+	Symbol string
+}
+
+// DenominateFee parses a Gwei amount as returned by the API, e.g. a level's
+// SuggestedMaxFeePerGas, and labels it with the native currency of chainID
+// (see NativeCurrencyOf)
+func DenominateFee(chainID int64, gwei string) (DenominatedFee, error) {
+	wei, err := ParseGweiToWei(gwei)
+	if err != nil {
+		return DenominatedFee{}, err
+	}
+	_, symbol := NativeCurrencyOf(chainID)
+	return DenominatedFee{Wei: wei, Unit: GweiUnit, Symbol: symbol}, nil
+}
+
+// Amount returns the fee in Gwei as an exact decimal string
+func (f DenominatedFee) Amount() string {
+	return formatWeiAsGwei(f.Wei)
+}
+
+// Label returns the display unit, e.g. "Gwei (POL)"
+func (f DenominatedFee) Label() string {
+	return f.Unit + " (" + f.Symbol + ")"
+}
+
+// String formats the fee with its label, e.g. "30.5 Gwei (POL)"
+func (f DenominatedFee) String() string {
+	return f.Amount() + " " + f.Label()
+}
@@ -0,0 +1,283 @@
+package infura
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestParseGweiToWei(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "0", want: "0"},
+		{input: "1", want: "1000000000"},
+		{input: "24.086058416", want: "24086058416"},
+		{input: "0.000000001", want: "1"},
+		{input: ".5", want: "500000000"},
+		{input: "2.", want: "2000000000"},
+		{input: "", wantErr: true},
+		{input: ".", wantErr: true},
+		{input: "-1", wantErr: true},
+		{input: "1e9", wantErr: true},
+		{input: "0.0000000001", wantErr: true},
+		{input: "1.2.3", wantErr: true},
+		{input: "  24 ", want: "24000000000"},
+		{input: "\t0.5\n", want: "500000000"},
+		{input: "1e10", wantErr: true},
+		{input: "0x5", wantErr: true},
+		{input: "+1", wantErr: true},
+		{input: "1,000", wantErr: true},
+		{input: "24,5", wantErr: true},
+		{input: "1_000", wantErr: true},
+		{input: "1 000", wantErr: true},
+		{input: "NaN", wantErr: true},
+		{input: "Inf", wantErr: true},
+		{input: "１２", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseGweiToWei(tt.input)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseGweiToWei(%q): expected error, got %s", tt.input, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseGweiToWei(%q) failed: %v", tt.input, err)
+			continue
+		}
+		if got.String() != tt.want {
+			t.Errorf("ParseGweiToWei(%q) = %s, want %s", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestFormatWeiAsGwei(t *testing.T) {
+	for _, tc := range []struct {
+		wei  int64
+		want string
+	}{
+		{wei: 0, want: "0"},
+		{wei: 1, want: "0.000000001"},
+		{wei: 1_000_000_000, want: "1"},
+		{wei: 24_086_058_416, want: "24.086058416"},
+		{wei: 1_500_000_000, want: "1.5"},
+	} {
+		if got := formatWeiAsGwei(big.NewInt(tc.wei)); got != tc.want {
+			t.Errorf("formatWeiAsGwei(%d) = %s, want %s", tc.wei, got, tc.want)
+		}
+	}
+}
+
+func TestParseGwei_MatchesParseGweiToWeiSyntax(t *testing.T) {
+	for _, input := range []string{"1e10", "0x5", "+1", "-1", "NaN", "Inf", "1,5", ""} {
+		if _, err := parseGwei(input); err == nil {
+			t.Errorf("parseGwei(%q): expected error", input)
+		}
+	}
+	for input, want := range map[string]string{"24": "24", " .5 ": "0.5", "2.": "2", "0.0000000001": "1e-10"} {
+		got, err := parseGwei(input)
+		if err != nil {
+			t.Errorf("parseGwei(%q) failed: %v", input, err)
+			continue
+		}
+		if got.Text('g', -1) != want {
+			t.Errorf("parseGwei(%q) = %s, want %s", input, got.Text('g', -1), want)
+		}
+	}
+}
+
+func FuzzParseGweiToWei(f *testing.F) {
+	for _, seed := range []string{
+		"0", "24.086058416", "0.000000001", ".5", "2.", "  24 ", "1e10", "0x5", "+1", "-1",
+		"1.2.3", "1,000", "24,5", "NaN", "Inf", "", ".", "0.0000000001", "99999999999999999999999999",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, input string) {
+		wei, err := ParseGweiToWei(input)
+		float, floatErr := parseGwei(input)
+		if err != nil {
+			if wei != nil {
+				t.Fatalf("ParseGweiToWei(%q) returned a value alongside error %v", input, err)
+			}
+			return
+		}
+		if wei.Sign() < 0 {
+			t.Fatalf("ParseGweiToWei(%q) returned negative %s", input, wei)
+		}
+		if floatErr != nil || float.Sign() < 0 {
+			t.Fatalf("parseGwei(%q) rejected input accepted by ParseGweiToWei: %v", input, floatErr)
+		}
+
+		// Formatting the result must parse back to the same amount
+		again, err := ParseGweiToWei(formatWeiAsGwei(wei))
+		if err != nil || again.Cmp(wei) != 0 {
+			t.Fatalf("round trip of %q failed: %s -> %v (%v)", input, wei, again, err)
+		}
+	})
+}
+
+func TestDenominateFee(t *testing.T) {
+	tests := []struct {
+		chainID int64
+		gwei    string
+		want    string
+	}{
+		{1, "30.500", "30.5 Gwei (ETH)"},
+		{137, "42", "42 Gwei (POL)"},
+		{42161, "0.01", "0.01 Gwei (ETH)"},
+		{999999, "1.25", "1.25 Gwei (NATIVE)"},
+	}
+	for _, tt := range tests {
+		fee, err := DenominateFee(tt.chainID, tt.gwei)
+		if err != nil {
+			t.Fatalf("DenominateFee(%d, %q) failed: %v", tt.chainID, tt.gwei, err)
+		}
+		if got := fee.String(); got != tt.want {
+			t.Errorf("DenominateFee(%d, %q) = %q, want %q", tt.chainID, tt.gwei, got, tt.want)
+		}
+	}
+
+	fee, _ := DenominateFee(137, "1.5")
+	if fee.Wei.Cmp(big.NewInt(1_500_000_000)) != 0 || fee.Unit != GweiUnit || fee.Amount() != "1.5" || fee.Label() != "Gwei (POL)" {
+		t.Errorf("Unexpected fee %+v", fee)
+	}
+	if _, err := DenominateFee(1, "abc"); err == nil {
+		t.Error("Expected an error for an invalid amount")
+	}
+}
+
+func TestNormalizeGwei(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"24.086058416", "24.086058416"},
+		{"0.05", "0.05"},
+		{"113", "113"},
+		{"0", "0"},
+		{"0.000", "0"},
+		{".0", "0"},
+		{"000", "0"},
+		{"24.500000000", "24.5"},
+		{"024.50", "24.5"},
+		{"+24.5", "24.5"},
+		{" 24.5 ", "24.5"},
+		{".5", "0.5"},
+		{"5.", "5"},
+		{"0.000000001", "0.000000001"},
+	}
+	for _, tt := range tests {
+		got, err := NormalizeGwei(tt.input)
+		if err != nil || got != tt.want {
+			t.Errorf("NormalizeGwei(%q) = %q, %v; want %q", tt.input, got, err, tt.want)
+		}
+	}
+
+	for _, invalid := range []string{"", "-1", "1e9", "0x5", "1,5", "++1", "0.0000000001", "abc"} {
+		if got, err := NormalizeGwei(invalid); err == nil {
+			t.Errorf("NormalizeGwei(%q) = %q, expected an error", invalid, got)
+		}
+	}
+}
+
+func TestNormalizeGwei_EqualSpellings(t *testing.T) {
+	spellings := [][]string{
+		{"24.5", "24.50", "024.5", "+24.500000000", "24.5 "},
+		{"0", "0.0", "000.000000000", ".0", "0."},
+		{"113", "113.0", "0113", "+113."},
+	}
+	for _, group := range spellings {
+		want, err := NormalizeGwei(group[0])
+		if err != nil {
+			t.Fatalf("NormalizeGwei(%q) failed: %v", group[0], err)
+		}
+		for _, spelling := range group[1:] {
+			if got, err := NormalizeGwei(spelling); err != nil || got != want {
+				t.Errorf("NormalizeGwei(%q) = %q, %v; want %q like %q", spelling, got, err, want, group[0])
+			}
+			if c, err := CompareGwei(spelling, group[0]); err != nil || c != 0 {
+				t.Errorf("CompareGwei(%q, %q) = %d, %v; want 0", spelling, group[0], c, err)
+			}
+		}
+	}
+}
+
+func TestCompareGwei(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"0.05", "24.086058416", -1},
+		{"113", "24.086058416", 1},
+		{"24.1", "24.09", 1},
+		{"24.09", "24.1", -1},
+		{"9", "10", -1},
+		{"0010", "9.999", 1},
+		{"0.000000001", "0", 1},
+		{"1.000000001", "1", 1},
+		{"+2", "2.0", 0},
+	}
+	for _, tt := range tests {
+		got, err := CompareGwei(tt.a, tt.b)
+		if err != nil || got != tt.want {
+			t.Errorf("CompareGwei(%q, %q) = %d, %v; want %d", tt.a, tt.b, got, err, tt.want)
+		}
+	}
+	if _, err := CompareGwei("1", "-1"); err == nil {
+		t.Error("Expected an error for an invalid operand")
+	}
+	// Both helpers reject more than 9 decimal places
+	if _, err := CompareGwei("0.0000000001", "0"); err == nil {
+		t.Error("Expected CompareGwei to reject more than 9 decimal places")
+	}
+	if _, err := NormalizeGwei("0.0000000001"); err == nil {
+		t.Error("Expected NormalizeGwei to reject more than 9 decimal places")
+	}
+}
+
+func TestFeeToWei(t *testing.T) {
+	tests := []struct {
+		gwei              string
+		up, down, nearest string
+	}{
+		{"24.086058416", "24086058416", "24086058416", "24086058416"},
+		{"24.0860584161", "24086058417", "24086058416", "24086058416"},
+		{"24.0860584165", "24086058417", "24086058416", "24086058417"},
+		{"24.0860584169999", "24086058417", "24086058416", "24086058417"},
+		{"24.0860584160000", "24086058416", "24086058416", "24086058416"},
+		{"0.0000000004", "1", "0", "0"},
+		{"0.0000000005", "1", "0", "1"},
+		{"0.9999999999", "1000000000", "999999999", "1000000000"},
+		{" 30 ", "30000000000", "30000000000", "30000000000"},
+	}
+	for _, tt := range tests {
+		for mode, want := range map[RoundMode]string{RoundUp: tt.up, RoundDown: tt.down, RoundNearest: tt.nearest} {
+			got, err := FeeToWei(tt.gwei, mode)
+			if err != nil {
+				t.Errorf("FeeToWei(%q, %v) failed: %v", tt.gwei, mode, err)
+				continue
+			}
+			if got.String() != want {
+				t.Errorf("FeeToWei(%q, %v) = %s, want %s", tt.gwei, mode, got, want)
+			}
+		}
+		if got, err := FeeToWeiRoundUp(tt.gwei); err != nil || got.String() != tt.up {
+			t.Errorf("FeeToWeiRoundUp(%q) = %v, %v; want %s", tt.gwei, got, err, tt.up)
+		}
+	}
+
+	for _, input := range []string{"", "-1", "1e9", "24,5"} {
+		if _, err := FeeToWeiRoundUp(input); err == nil {
+			t.Errorf("FeeToWeiRoundUp(%q): expected error", input)
+		}
+	}
+	if _, err := FeeToWei("1", RoundMode(7)); err == nil {
+		t.Error("Expected an error for an unknown round mode")
+	}
+}
@@ -0,0 +1,120 @@
+package infura
+
+import (
+	"maps"
+	"sync"
+	"time"
+)
+
+// Usage is a snapshot of the credits and requests counted by WithUsageTracker for the
+// current UTC day
+type Usage struct {
+	// Day is the start (00:00 UTC) of the day the counters cover
+	Day time.Time
+	// Credits is the credits spent today, using the costs set with WithCreditCosts
+	Credits int64
+	// Requests counts the request attempts sent today, retries included
+	Requests int64
+	// CreditsByChain and CreditsByEndpoint break Credits down by chain ID and by endpoint
+	// name (e.g. "suggestedGasFees"); requests outside /networks/{chainId}/ count as chain 0
+	CreditsByChain    map[int64]int64
+	CreditsByEndpoint map[string]int64
+	// SoftLimit is the limit given to WithUsageTracker (0 = none)
+	SoftLimit int64
+}
+
+// usageTracker accumulates Usage per UTC day
+type usageTracker struct {
+	mu          sync.Mutex
+	softLimit   int64
+	onSoftLimit func(Usage)
+	usage       Usage
+	// notified is set once onSoftLimit was called for the current day
+	notified bool
+}
+
+// WithUsageTracker counts the credits and requests spent per UTC day, per chain and per
+// endpoint, to forecast whether the daily allowance will be exceeded; read them with Usage.
+// Each request attempt sent is charged its cost from WithCreditCosts (1 by default), as
+// for WithDailyCreditLimit, but nothing is ever blocked. The counters reset at midnight
+// UTC on the client's clock.
+//
+// If softLimit is positive, onSoftLimit (which may be nil) is called once per day with a
+// snapshot when the day's credits reach it. It runs synchronously on the goroutine of the
+// request that crossed the limit, so it should return quickly. A negative softLimit is
+// invalid.
+//
+// Without this option the client does no usage accounting and Usage returns a zero value.
+func WithUsageTracker(softLimit int64, onSoftLimit func(Usage)) ClientOption {
+	return func(c *Client) {
+		if softLimit < 0 {
+			c.rejectOption("WithUsageTracker", "soft limit must not be negative, got %d", softLimit)
+			return
+		}
+		c.usage = &usageTracker{softLimit: softLimit, onSoftLimit: onSoftLimit}
+	}
+}
+
+// Usage returns the usage counted by WithUsageTracker for the current UTC day, or a zero
+// value if usage tracking is not enabled
+func (c *Client) Usage() Usage {
+	if c.usage == nil {
+		return Usage{}
+	}
+	return c.usage.snapshot(c.clock.Now())
+}
+
+// observe counts a request sent to endpoint costing cost credits
+func (t *usageTracker) observe(endpoint string, cost int64, now time.Time) {
+	chainID, _ := chainIDOfEndpoint(endpoint)
+
+	t.mu.Lock()
+	t.rollover(now)
+	t.usage.Requests++
+	t.usage.Credits += cost
+	t.usage.CreditsByChain[chainID] += cost
+	t.usage.CreditsByEndpoint[metricsEndpoint(endpoint)] += cost
+
+	var crossed *Usage
+	if t.softLimit > 0 && !t.notified && t.usage.Credits >= t.softLimit {
+		t.notified = true
+		snapshot := t.copyUsage()
+		crossed = &snapshot
+	}
+	t.mu.Unlock()
+
+	if crossed != nil && t.onSoftLimit != nil {
+		t.onSoftLimit(*crossed)
+	}
+}
+
+// snapshot returns a copy of the usage of the day containing now
+func (t *usageTracker) snapshot(now time.Time) Usage {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.rollover(now)
+	return t.copyUsage()
+}
+
+// rollover resets the counters when now is in another UTC day than the current counters
+func (t *usageTracker) rollover(now time.Time) {
+	day := now.UTC().Truncate(24 * time.Hour)
+	if t.usage.Day.Equal(day) && t.usage.CreditsByChain != nil {
+		return
+	}
+	t.usage = Usage{
+		Day:               day,
+		CreditsByChain:    make(map[int64]int64),
+		CreditsByEndpoint: make(map[string]int64),
+		SoftLimit:         t.softLimit,
+	}
+	t.notified = false
+}
+
+// copyUsage returns a copy of the current usage that does not share its maps
+func (t *usageTracker) copyUsage() Usage {
+	usage := t.usage
+	usage.CreditsByChain = maps.Clone(t.usage.CreditsByChain)
+	usage.CreditsByEndpoint = maps.Clone(t.usage.CreditsByEndpoint)
+	return usage
+}
@@ -0,0 +1,99 @@
+package infura
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func newUsageServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"busyThreshold": "10"}`))
+	}))
+}
+
+func TestWithUsageTracker(t *testing.T) {
+	server := newUsageServer()
+	defer server.Close()
+
+	clock := newFakeClock(time.Date(2026, 3, 1, 23, 58, 0, 0, time.UTC))
+	var notified []Usage
+	client := NewClientWithAPIKeyAndOptions("test-api-key",
+		WithBaseURL(server.URL),
+		WithClock(clock),
+		WithCreditCosts(map[string]int64{"busyThreshold": 80}),
+		WithUsageTracker(200, func(u Usage) { notified = append(notified, u) }))
+
+	ctx := context.Background()
+	for _, chainID := range []int64{1, 1, 137} {
+		if _, err := client.GetBusyThreshold(ctx, chainID); err != nil {
+			t.Fatalf("GetBusyThreshold failed: %v", err)
+		}
+	}
+	if _, err := Get[BusyThreshold](ctx, client, 1, "baseFeePercentile", nil); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+
+	usage := client.Usage()
+	if !usage.Day.Equal(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)) || usage.Requests != 4 || usage.Credits != 241 || usage.SoftLimit != 200 {
+		t.Errorf("Unexpected usage: %+v", usage)
+	}
+	if want := map[int64]int64{1: 161, 137: 80}; !reflect.DeepEqual(usage.CreditsByChain, want) {
+		t.Errorf("CreditsByChain = %v, want %v", usage.CreditsByChain, want)
+	}
+	if want := map[string]int64{"busyThreshold": 240, "baseFeePercentile": 1}; !reflect.DeepEqual(usage.CreditsByEndpoint, want) {
+		t.Errorf("CreditsByEndpoint = %v, want %v", usage.CreditsByEndpoint, want)
+	}
+
+	// The callback fired once, on the request that reached the soft limit
+	if len(notified) != 1 || notified[0].Credits != 240 {
+		t.Fatalf("Expected one notification at 240 credits, got %+v", notified)
+	}
+
+	// Midnight UTC starts a new day
+	clock.Advance(2 * time.Minute)
+	usage = client.Usage()
+	if !usage.Day.Equal(time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)) || usage.Credits != 0 || usage.Requests != 0 || len(usage.CreditsByChain) != 0 {
+		t.Errorf("Expected the counters to reset at midnight UTC, got %+v", usage)
+	}
+	for i := 0; i < 3; i++ {
+		client.GetBusyThreshold(ctx, 1)
+	}
+	if len(notified) != 2 || !notified[1].Day.Equal(usage.Day) {
+		t.Errorf("Expected the soft limit to fire again on the new day, got %+v", notified)
+	}
+}
+
+func TestWithUsageTracker_SnapshotIsCopy(t *testing.T) {
+	server := newUsageServer()
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL), WithUsageTracker(0, nil))
+	if _, err := client.GetBusyThreshold(context.Background(), 1); err != nil {
+		t.Fatalf("GetBusyThreshold failed: %v", err)
+	}
+	usage := client.Usage()
+	usage.CreditsByChain[1] = 1000
+	if got := client.Usage().CreditsByChain[1]; got != 1 {
+		t.Errorf("Expected the snapshot not to share its maps, got %d", got)
+	}
+}
+
+func TestUsageWithoutTracker(t *testing.T) {
+	server := newUsageServer()
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL))
+	client.GetBusyThreshold(context.Background(), 1)
+	if usage := client.Usage(); !reflect.DeepEqual(usage, Usage{}) {
+		t.Errorf("Expected a zero Usage without tracking, got %+v", usage)
+	}
+
+	if _, err := New("test-api-key", "", WithUsageTracker(-1, nil)); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("Expected ErrInvalidOption for a negative soft limit, got %v", err)
+	}
+}
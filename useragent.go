@@ -0,0 +1,53 @@
+package infura
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// modulePath is the import path of this module, used to find its version in the build info
+const modulePath = "github.com/ABT-Tech-Limited/infura-go"
+
+// defaultUserAgent is sent with every request, e.g. "infura-go/v1.4.0 Go/go1.25.1"
+var defaultUserAgent = "infura-go/" + moduleVersion(debug.ReadBuildInfo()) + " Go/" + runtime.Version()
+
+// moduleVersion returns the version of this module recorded in the build info, or "devel"
+// when there is none (tests, GOPATH builds, or the module built as the main module)
+func moduleVersion(info *debug.BuildInfo, ok bool) string {
+	if !ok || info == nil {
+		return "devel"
+	}
+	mod := &info.Main
+	if mod.Path != modulePath {
+		mod = nil
+		for _, dep := range info.Deps {
+			if dep.Path == modulePath {
+				mod = dep
+				break
+			}
+		}
+	}
+	if mod == nil {
+		return "devel"
+	}
+	if mod.Replace != nil && mod.Replace.Version != "" {
+		mod = mod.Replace
+	}
+	if mod.Version == "" || mod.Version == "(devel)" {
+		return "devel"
+	}
+	return mod.Version
+}
+
+// WithUserAgent appends product to the default User-Agent, which identifies the library
+// and Go versions: "infura-go/<version> Go/<version> <product>"
+// The default is kept so Infura support can tell which client produced the traffic
+func WithUserAgent(product string) ClientOption {
+	return func(c *Client) {
+		if product == "" {
+			c.rejectOption("WithUserAgent", "product must not be empty")
+			return
+		}
+		c.userAgent = defaultUserAgent + " " + product
+	}
+}
@@ -0,0 +1,88 @@
+package infura
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"runtime"
+	"runtime/debug"
+	"testing"
+)
+
+var userAgentPattern = regexp.MustCompile(`^infura-go/(devel|v\d+\.\d+\.\d+\S*) Go/\S+$`)
+
+func TestDefaultUserAgent(t *testing.T) {
+	if !userAgentPattern.MatchString(defaultUserAgent) {
+		t.Errorf("Unexpected default User-Agent %q", defaultUserAgent)
+	}
+
+	var got []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get("User-Agent"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL))
+	if _, err := client.GetBusyThreshold(context.Background(), 1); err != nil {
+		t.Fatalf("GetBusyThreshold failed: %v", err)
+	}
+	client = NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL), WithUserAgent("my-wallet/2.1"))
+	if _, err := client.GetBusyThreshold(context.Background(), 1); err != nil {
+		t.Fatalf("GetBusyThreshold failed: %v", err)
+	}
+
+	if got[0] != defaultUserAgent {
+		t.Errorf("Expected User-Agent %q, got %q", defaultUserAgent, got[0])
+	}
+	if want := defaultUserAgent + " my-wallet/2.1"; got[1] != want {
+		t.Errorf("Expected User-Agent %q, got %q", want, got[1])
+	}
+	if _, err := New("test-api-key", "", WithUserAgent("")); err == nil {
+		t.Error("Expected an error for an empty product")
+	}
+}
+
+func TestModuleVersion(t *testing.T) {
+	tests := []struct {
+		name string
+		info *debug.BuildInfo
+		ok   bool
+		want string
+	}{
+		{"no build info", nil, false, "devel"},
+		{"main module", &debug.BuildInfo{Main: debug.Module{Path: modulePath, Version: "(devel)"}}, true, "devel"},
+		{"tagged main module", &debug.BuildInfo{Main: debug.Module{Path: modulePath, Version: "v1.2.0"}}, true, "v1.2.0"},
+		{"dependency", &debug.BuildInfo{
+			Main: debug.Module{Path: "example.com/app"},
+			Deps: []*debug.Module{{Path: "golang.org/x/time", Version: "v0.5.0"}, {Path: modulePath, Version: "v1.3.1"}},
+		}, true, "v1.3.1"},
+		{"replaced dependency", &debug.BuildInfo{
+			Main: debug.Module{Path: "example.com/app"},
+			Deps: []*debug.Module{{Path: modulePath, Version: "v1.3.1", Replace: &debug.Module{Path: "example.com/fork", Version: "v1.3.2-fork"}}},
+		}, true, "v1.3.2-fork"},
+		{"local replacement", &debug.BuildInfo{
+			Main: debug.Module{Path: "example.com/app"},
+			Deps: []*debug.Module{{Path: modulePath, Version: "v1.3.1", Replace: &debug.Module{Path: "../infura-go"}}},
+		}, true, "v1.3.1"},
+		{"not a dependency", &debug.BuildInfo{Main: debug.Module{Path: "example.com/app"}}, true, "devel"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := moduleVersion(tt.info, tt.ok); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+
+	// Test binaries carry build info without a module version
+	if got := moduleVersion(debug.ReadBuildInfo()); got != "devel" {
+		t.Errorf("Expected devel in a test binary, got %q", got)
+	}
+	if !regexp.MustCompile(`Go/` + regexp.QuoteMeta(runtime.Version()) + `$`).MatchString(defaultUserAgent) {
+		t.Errorf("Expected the Go runtime version in %q", defaultUserAgent)
+	}
+}
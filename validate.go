@@ -0,0 +1,98 @@
+package infura
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// FeeParams are the EIP-1559 fee parameters of a transaction, in wei
+type FeeParams struct {
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+}
+
+// ValidationPolicy configures the optional checks of ValidateFeeParams
+type ValidationPolicy struct {
+	// MinPriorityFee is the lowest acceptable tip in wei; nil disables the check
+	MinPriorityFee *big.Int
+	// RequireHeadroom requires maxFeePerGas >= 2 * base fee + tip, so the transaction stays
+	// valid after a full-block base fee increase
+	RequireHeadroom bool
+}
+
+// ViolationCode is a machine-readable identifier of a fee parameter violation
+type ViolationCode string
+
+// Violation codes reported by ValidateFeeParams
+const (
+	ViolationMaxFeeNotPositive      ViolationCode = "max_fee_not_positive"
+	ViolationPriorityFeeNotPositive ViolationCode = "priority_fee_not_positive"
+	ViolationPriorityFeeAboveMaxFee ViolationCode = "priority_fee_above_max_fee"
+	ViolationPriorityFeeBelowFloor  ViolationCode = "priority_fee_below_floor"
+	ViolationMaxFeeBelowBaseFee     ViolationCode = "max_fee_below_base_fee"
+	ViolationInsufficientHeadroom   ViolationCode = "insufficient_headroom"
+	ViolationBaseFeeUnavailable     ViolationCode = "base_fee_unavailable"
+)
+
+// Violation describes one failed fee parameter check
+type Violation struct {
+	Code    ViolationCode
+	Message string
+}
+
+// String returns the code and message of the violation
+func (v Violation) String() string {
+	return fmt.Sprintf("%s: %s", v.Code, v.Message)
+}
+
+// ValidateFeeParams checks fee parameters before broadcasting and returns every violation
+// found, or nil if the parameters are valid. Checks:
+//   - both fees are set and positive
+//   - the tip does not exceed the max fee
+//   - the tip is at least policy.MinPriorityFee, if set
+//   - the max fee covers the estimated base fee of current, if current is not nil
+//   - the max fee is at least 2 * base fee + tip, if policy.RequireHeadroom is set
+func ValidateFeeParams(params FeeParams, current *SuggestedGasFees, policy ValidationPolicy) []Violation {
+	var violations []Violation
+	add := func(code ViolationCode, format string, args ...interface{}) {
+		violations = append(violations, Violation{Code: code, Message: fmt.Sprintf(format, args...)})
+	}
+
+	maxFee, tip := params.MaxFeePerGas, params.MaxPriorityFeePerGas
+	maxFeeValid := maxFee != nil && maxFee.Sign() > 0
+	tipValid := tip != nil && tip.Sign() > 0
+	if !maxFeeValid {
+		add(ViolationMaxFeeNotPositive, "maxFeePerGas must be positive, got %v", maxFee)
+	}
+	if !tipValid {
+		add(ViolationPriorityFeeNotPositive, "maxPriorityFeePerGas must be positive, got %v", tip)
+	}
+
+	if maxFeeValid && tipValid && tip.Cmp(maxFee) > 0 {
+		add(ViolationPriorityFeeAboveMaxFee, "maxPriorityFeePerGas %s wei exceeds maxFeePerGas %s wei", tip, maxFee)
+	}
+	if tipValid && policy.MinPriorityFee != nil && tip.Cmp(policy.MinPriorityFee) < 0 {
+		add(ViolationPriorityFeeBelowFloor, "maxPriorityFeePerGas %s wei is below the floor of %s wei", tip, policy.MinPriorityFee)
+	}
+
+	if current == nil || !maxFeeValid {
+		return violations
+	}
+	baseFee, err := ParseGweiToWei(current.EstimatedBaseFee)
+	if err != nil {
+		add(ViolationBaseFeeUnavailable, "failed to parse estimated base fee: %v", err)
+		return violations
+	}
+	if maxFee.Cmp(baseFee) < 0 {
+		add(ViolationMaxFeeBelowBaseFee, "maxFeePerGas %s wei is below the estimated base fee of %s wei", maxFee, baseFee)
+	}
+	if policy.RequireHeadroom && tipValid {
+		required := new(big.Int).Lsh(baseFee, 1)
+		required.Add(required, tip)
+		if maxFee.Cmp(required) < 0 {
+			add(ViolationInsufficientHeadroom, "maxFeePerGas %s wei is below 2 * base fee + tip = %s wei", maxFee, required)
+		}
+	}
+
+	return violations
+}
@@ -0,0 +1,115 @@
+package infura
+
+import (
+	"math/big"
+	"slices"
+	"testing"
+)
+
+func TestValidateFeeParams(t *testing.T) {
+	current := &SuggestedGasFees{EstimatedBaseFee: "30"}
+	strict := ValidationPolicy{MinPriorityFee: big.NewInt(1_000_000_000), RequireHeadroom: true}
+
+	tests := []struct {
+		name    string
+		params  FeeParams
+		current *SuggestedGasFees
+		policy  ValidationPolicy
+		want    []ViolationCode
+	}{
+		{
+			name:    "valid",
+			params:  FeeParams{MaxFeePerGas: mustWei(t, "62"), MaxPriorityFeePerGas: mustWei(t, "2")},
+			current: current,
+			policy:  strict,
+		},
+		{
+			name:   "valid without current fees",
+			params: FeeParams{MaxFeePerGas: mustWei(t, "1"), MaxPriorityFeePerGas: mustWei(t, "1")},
+		},
+		{
+			name:    "zero max fee",
+			params:  FeeParams{MaxFeePerGas: big.NewInt(0), MaxPriorityFeePerGas: mustWei(t, "2")},
+			current: current,
+			want:    []ViolationCode{ViolationMaxFeeNotPositive},
+		},
+		{
+			name:   "nil max fee",
+			params: FeeParams{MaxPriorityFeePerGas: mustWei(t, "2")},
+			want:   []ViolationCode{ViolationMaxFeeNotPositive},
+		},
+		{
+			name:    "negative tip",
+			params:  FeeParams{MaxFeePerGas: mustWei(t, "40"), MaxPriorityFeePerGas: big.NewInt(-1)},
+			current: current,
+			want:    []ViolationCode{ViolationPriorityFeeNotPositive},
+		},
+		{
+			name:    "tip above max fee",
+			params:  FeeParams{MaxFeePerGas: mustWei(t, "40"), MaxPriorityFeePerGas: mustWei(t, "41")},
+			current: current,
+			want:    []ViolationCode{ViolationPriorityFeeAboveMaxFee},
+		},
+		{
+			name:    "tip below floor",
+			params:  FeeParams{MaxFeePerGas: mustWei(t, "40"), MaxPriorityFeePerGas: mustWei(t, "0.5")},
+			current: current,
+			policy:  ValidationPolicy{MinPriorityFee: big.NewInt(1_000_000_000)},
+			want:    []ViolationCode{ViolationPriorityFeeBelowFloor},
+		},
+		{
+			name:    "max fee below base fee",
+			params:  FeeParams{MaxFeePerGas: mustWei(t, "29"), MaxPriorityFeePerGas: mustWei(t, "2")},
+			current: current,
+			want:    []ViolationCode{ViolationMaxFeeBelowBaseFee},
+		},
+		{
+			name:    "insufficient headroom",
+			params:  FeeParams{MaxFeePerGas: mustWei(t, "61.999999999"), MaxPriorityFeePerGas: mustWei(t, "2")},
+			current: current,
+			policy:  ValidationPolicy{RequireHeadroom: true},
+			want:    []ViolationCode{ViolationInsufficientHeadroom},
+		},
+		{
+			name:    "unparseable base fee",
+			params:  FeeParams{MaxFeePerGas: mustWei(t, "40"), MaxPriorityFeePerGas: mustWei(t, "2")},
+			current: &SuggestedGasFees{EstimatedBaseFee: "n/a"},
+			want:    []ViolationCode{ViolationBaseFeeUnavailable},
+		},
+		{
+			name:    "all violations reported",
+			params:  FeeParams{MaxFeePerGas: mustWei(t, "0.5"), MaxPriorityFeePerGas: mustWei(t, "0.6")},
+			current: current,
+			policy:  ValidationPolicy{MinPriorityFee: big.NewInt(1_000_000_000), RequireHeadroom: true},
+			want: []ViolationCode{
+				ViolationPriorityFeeAboveMaxFee,
+				ViolationPriorityFeeBelowFloor,
+				ViolationMaxFeeBelowBaseFee,
+				ViolationInsufficientHeadroom,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations := ValidateFeeParams(tt.params, tt.current, tt.policy)
+			if tt.want == nil {
+				if violations != nil {
+					t.Fatalf("Expected nil, got %v", violations)
+				}
+				return
+			}
+
+			var codes []ViolationCode
+			for _, v := range violations {
+				codes = append(codes, v.Code)
+				if v.Message == "" {
+					t.Errorf("Expected a message for %s", v.Code)
+				}
+			}
+			if !slices.Equal(codes, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, violations)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package infura
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrEmptyHistory is returned by BaseFeeHistory statistics when the history has no entries
+var ErrEmptyHistory = errors.New("empty base fee history")
+
+// HistoryEntryError reports a base fee history entry that is not a valid Gwei value
+type HistoryEntryError struct {
+	Index int
+	Value string
+	Err   error
+}
+
+func (e *HistoryEntryError) Error() string {
+	return fmt.Sprintf("invalid base fee history entry %d (%q): %v", e.Index, e.Value, e.Err)
+}
+
+func (e *HistoryEntryError) Unwrap() error {
+	return e.Err
+}
+
+// Floats parses every entry of the history as Gwei
+// Every invalid entry is reported as a *HistoryEntryError, joined with errors.Join;
+// an empty history returns ErrEmptyHistory
+func (h BaseFeeHistory) Floats() ([]float64, error) {
+	if len(h) == 0 {
+		return nil, ErrEmptyHistory
+	}
+
+	parsed, err := parseHistory(h)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]float64, len(parsed))
+	for i, gwei := range parsed {
+		values[i], _ = gwei.Float64()
+	}
+	return values, nil
+}
+
+// Volatility returns the coefficient of variation of the history (population standard
+// deviation divided by the mean); a constant series has zero volatility
+func (h BaseFeeHistory) Volatility() (float64, error) {
+	values, err := h.Floats()
+	if err != nil {
+		return 0, err
+	}
+
+	mean, stdDev := meanStdDev(values)
+	if mean == 0 {
+		return 0, nil
+	}
+	return stdDev / mean, nil
+}
+
+// RollingStdDev returns the population standard deviation of every window of the given
+// size, oldest first; the result has len(h)-window+1 entries
+func (h BaseFeeHistory) RollingStdDev(window int) ([]float64, error) {
+	values, err := h.Floats()
+	if err != nil {
+		return nil, err
+	}
+	if window < 1 || window > len(values) {
+		return nil, fmt.Errorf("invalid window %d for history of %d entries", window, len(values))
+	}
+
+	result := make([]float64, len(values)-window+1)
+	for i := range result {
+		_, result[i] = meanStdDev(values[i : i+window])
+	}
+	return result, nil
+}
+
+// Histogram counts the history entries per bucket
+// buckets are strictly increasing inclusive upper bounds in Gwei; counts[i] is the number
+// of entries in (buckets[i-1], buckets[i]] and the extra last count holds entries above
+// the highest bound, so len(counts) == len(buckets)+1
+func (h BaseFeeHistory) Histogram(buckets []float64) ([]int, error) {
+	for i, bound := range buckets {
+		if math.IsNaN(bound) || (i > 0 && bound <= buckets[i-1]) {
+			return nil, fmt.Errorf("histogram buckets must be strictly increasing: %v", buckets)
+		}
+	}
+
+	values, err := h.Floats()
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make([]int, len(buckets)+1)
+	for _, v := range values {
+		i := 0
+		for i < len(buckets) && v > buckets[i] {
+			i++
+		}
+		counts[i]++
+	}
+	return counts, nil
+}
+
+// meanStdDev returns the mean and population standard deviation of a non-empty series
+func meanStdDev(values []float64) (mean, stdDev float64) {
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+
+	var variance float64
+	for _, v := range values {
+		d := v - mean
+		variance += d * d
+	}
+	return mean, math.Sqrt(variance / float64(len(values)))
+}
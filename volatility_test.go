@@ -0,0 +1,128 @@
+package infura
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// Mean 20, population variance (100+0+0+100)/4 = 50
+var volatilityFixture = BaseFeeHistory{"10", "20", "20", "30"}
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestBaseFeeHistory_Volatility(t *testing.T) {
+	tests := []struct {
+		name    string
+		history BaseFeeHistory
+		want    float64
+	}{
+		{"fixture", volatilityFixture, math.Sqrt(50) / 20},
+		{"constant", BaseFeeHistory{"12.5", "12.5", "12.5"}, 0},
+		{"single", BaseFeeHistory{"7"}, 0},
+		{"all zero", BaseFeeHistory{"0", "0"}, 0},
+		{"two points", BaseFeeHistory{"1", "3"}, 0.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.history.Volatility()
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !almostEqual(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestBaseFeeHistory_RollingStdDev(t *testing.T) {
+	got, err := volatilityFixture.RollingStdDev(2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := []float64{5, 0, 5}
+	if len(got) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if !almostEqual(got[i], want[i]) {
+			t.Errorf("window %d: expected %v, got %v", i, want[i], got[i])
+		}
+	}
+
+	full, err := volatilityFixture.RollingStdDev(4)
+	if err != nil || len(full) != 1 || !almostEqual(full[0], math.Sqrt(50)) {
+		t.Errorf("Expected [%v], got %v (err %v)", math.Sqrt(50), full, err)
+	}
+
+	for _, window := range []int{0, -1, 5} {
+		if _, err := volatilityFixture.RollingStdDev(window); err == nil {
+			t.Errorf("window %d: expected error", window)
+		}
+	}
+}
+
+func TestBaseFeeHistory_Histogram(t *testing.T) {
+	history := BaseFeeHistory{"5", "10", "10.5", "20", "25", "100"}
+
+	got, err := history.Histogram([]float64{10, 20, 50})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := []int{2, 2, 1, 1}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Expected %v, got %v", want, got)
+		}
+	}
+
+	all, err := history.Histogram(nil)
+	if err != nil || len(all) != 1 || all[0] != len(history) {
+		t.Errorf("Expected a single overflow bucket, got %v (err %v)", all, err)
+	}
+
+	for _, buckets := range [][]float64{{10, 10}, {20, 10}, {math.NaN()}} {
+		if _, err := history.Histogram(buckets); err == nil {
+			t.Errorf("buckets %v: expected error", buckets)
+		}
+	}
+}
+
+func TestBaseFeeHistory_Empty(t *testing.T) {
+	var history BaseFeeHistory
+	if _, err := history.Volatility(); !errors.Is(err, ErrEmptyHistory) {
+		t.Errorf("Volatility: expected ErrEmptyHistory, got %v", err)
+	}
+	if _, err := history.RollingStdDev(1); !errors.Is(err, ErrEmptyHistory) {
+		t.Errorf("RollingStdDev: expected ErrEmptyHistory, got %v", err)
+	}
+	if _, err := history.Histogram([]float64{1}); !errors.Is(err, ErrEmptyHistory) {
+		t.Errorf("Histogram: expected ErrEmptyHistory, got %v", err)
+	}
+}
+
+func TestBaseFeeHistory_InvalidEntries(t *testing.T) {
+	history := BaseFeeHistory{"10", "abc", "20", "-5"}
+
+	_, err := history.Volatility()
+	if err == nil {
+		t.Fatal("Expected error for invalid entries")
+	}
+
+	var entryErr *HistoryEntryError
+	if !errors.As(err, &entryErr) {
+		t.Fatalf("Expected *HistoryEntryError, got %T", err)
+	}
+	if entryErr.Index != 1 || entryErr.Value != "abc" {
+		t.Errorf("Expected first invalid entry 1 (abc), got %d (%s)", entryErr.Index, entryErr.Value)
+	}
+
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok || len(joined.Unwrap()) != 2 {
+		t.Errorf("Expected both invalid entries to be reported, got %v", err)
+	}
+}
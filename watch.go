@@ -0,0 +1,318 @@
+package infura
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// WatchEvent represents a single poll result emitted by WatchSuggestedGasFees
+type WatchEvent struct {
+	ChainID int64
+	Time    time.Time
+	// Fees holds the raw suggestion returned by the API (nil if Err is set)
+	Fees *SuggestedGasFees
+	// Smoothed holds the EMA-smoothed maxFeePerGas values in Gwei
+	// Only populated when the watcher was started with WithSmoothing
+	Smoothed *SmoothedFees
+	// Err is set when the poll failed; the watcher keeps polling afterwards
+	Err error
+}
+
+// SmoothedFees holds exponentially smoothed maxFeePerGas values (in Gwei) for each level
+type SmoothedFees struct {
+	Low    *big.Float
+	Medium *big.Float
+	High   *big.Float
+}
+
+// Level returns the smoothed value for the given priority
+func (s *SmoothedFees) Level(p Priority) (*big.Float, error) {
+	switch p {
+	case PriorityLow:
+		return s.Low, nil
+	case PriorityMedium:
+		return s.Medium, nil
+	case PriorityHigh:
+		return s.High, nil
+	default:
+		return nil, fmt.Errorf("unknown priority: %d", int(p))
+	}
+}
+
+// WatchOption is a function that configures a watcher
+type WatchOption func(*watchConfig)
+
+type watchConfig struct {
+	smoothing float64
+}
+
+// WithSmoothing enables exponential-moving-average smoothing of maxFeePerGas
+// alpha is the smoothing factor in (0, 1]; higher values react faster to new samples
+// The first sample seeds the average
+func WithSmoothing(alpha float64) WatchOption {
+	return func(cfg *watchConfig) {
+		cfg.smoothing = alpha
+	}
+}
+
+// WatchSuggestedGasFees polls suggestedGasFees for a chain at the given interval and
+// emits each result on the returned channel. The first poll happens immediately.
+// Poll errors are delivered as events with Err set and do not stop the watcher.
+// The channel is closed when ctx is cancelled.
+func (c *Client) WatchSuggestedGasFees(ctx context.Context, chainID int64, interval time.Duration, opts ...WatchOption) (<-chan WatchEvent, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("watch interval must be positive, got %v", interval)
+	}
+
+	var cfg watchConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	var smoother *emaSmoother
+	if cfg.smoothing != 0 {
+		if !(cfg.smoothing > 0 && cfg.smoothing <= 1) {
+			return nil, fmt.Errorf("smoothing factor must be in (0, 1], got %v", cfg.smoothing)
+		}
+		smoother = newEMASmoother(cfg.smoothing, 3)
+	}
+
+	events := make(chan WatchEvent)
+	go func() {
+		defer close(events)
+
+		for {
+			event := c.pollSuggestedGasFees(ctx, chainID)
+			if smoother != nil && event.Err == nil {
+				event.Smoothed = smoother.update(event.Fees)
+			}
+
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+
+			if c.sleep(ctx, interval) != nil {
+				return
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// pollSuggestedGasFees performs a single watcher poll
+func (c *Client) pollSuggestedGasFees(ctx context.Context, chainID int64) WatchEvent {
+	fees, err := c.GetSuggestedGasFees(ctx, chainID)
+	return WatchEvent{
+		ChainID: chainID,
+		Time:    c.clock.Now(),
+		Fees:    fees,
+		Err:     err,
+	}
+}
+
+// emaSmoother keeps a running exponential moving average for a fixed number of series
+type emaSmoother struct {
+	alpha      *big.Float
+	complement *big.Float
+	values     []*big.Float
+}
+
+func newEMASmoother(alpha float64, series int) *emaSmoother {
+	a := new(big.Float).SetPrec(gweiPrecision).SetFloat64(alpha)
+	one := new(big.Float).SetPrec(gweiPrecision).SetInt64(1)
+	return &emaSmoother{
+		alpha:      a,
+		complement: new(big.Float).SetPrec(gweiPrecision).Sub(one, a),
+		values:     make([]*big.Float, series),
+	}
+}
+
+// observe folds a Gwei sample into series i and returns a copy of its average
+// Samples that cannot be parsed or are infinite keep the previous average (nil if none yet)
+func (s *emaSmoother) observe(i int, raw string) *big.Float {
+	sample, err := parseGwei(raw)
+	if err != nil || sample.IsInf() {
+		return copyFloat(s.values[i])
+	}
+	if s.values[i] == nil {
+		s.values[i] = sample
+		return copyFloat(sample)
+	}
+	weighted := new(big.Float).SetPrec(gweiPrecision).Mul(s.alpha, sample)
+	previous := new(big.Float).SetPrec(gweiPrecision).Mul(s.complement, s.values[i])
+	s.values[i] = weighted.Add(weighted, previous)
+	return copyFloat(s.values[i])
+}
+
+// update folds the maxFeePerGas of each level into the average and returns a snapshot
+// Levels whose maxFeePerGas cannot be parsed keep their previous average
+func (s *emaSmoother) update(fees *SuggestedGasFees) *SmoothedFees {
+	return &SmoothedFees{
+		Low:    s.observe(0, fees.Low.SuggestedMaxFeePerGas),
+		Medium: s.observe(1, fees.Medium.SuggestedMaxFeePerGas),
+		High:   s.observe(2, fees.High.SuggestedMaxFeePerGas),
+	}
+}
+
+// copyFloat returns an independent copy of f, or nil if f is nil
+func copyFloat(f *big.Float) *big.Float {
+	if f == nil {
+		return nil
+	}
+	return new(big.Float).Copy(f)
+}
+
+// WaitForGasBelow polls suggestedGasFees until the maxFeePerGas of the chosen level is at or
+// below targetGwei, then returns nil. Poll errors are ignored and polling continues.
+// It returns ctx.Err() if the context is cancelled or its deadline expires first.
+// Requests go through the client's regular request path, so any configured rate limit applies.
+func (c *Client) WaitForGasBelow(ctx context.Context, chainID int64, level Priority, targetGwei *big.Float, pollInterval time.Duration) error {
+	if targetGwei == nil {
+		return fmt.Errorf("target gwei must not be nil")
+	}
+	if _, err := (&SuggestedGasFees{}).Level(level); err != nil {
+		return err
+	}
+
+	return c.waitForFees(ctx, chainID, pollInterval, func(fees *SuggestedGasFees) bool {
+		feeLevel, _ := fees.Level(level)
+		fee, err := parseGwei(feeLevel.SuggestedMaxFeePerGas)
+		return err == nil && fee.Cmp(targetGwei) <= 0
+	})
+}
+
+// WaitForCongestionBelow polls suggestedGasFees until networkCongestion is at or below
+// target, then returns nil. Like WaitForGasBelow, poll errors and responses without a
+// congestion value are ignored and polling continues; use WaitForLowCongestion to give up
+// after repeated errors. It returns ctx.Err() if the context is cancelled or its deadline
+// expires first. Requests go through the client's regular request path, so any configured
+// rate limit applies.
+func (c *Client) WaitForCongestionBelow(ctx context.Context, chainID int64, target float64, pollInterval time.Duration) error {
+	if !(target >= 0) {
+		return fmt.Errorf("target congestion must not be negative, got %v", target)
+	}
+
+	return c.waitForFees(ctx, chainID, pollInterval, func(fees *SuggestedGasFees) bool {
+		return fees.NetworkCongestion.IsSet() && fees.NetworkCongestion.Float64() <= target
+	})
+}
+
+// waitForFees watches suggestedGasFees until done reports true for a successful poll
+func (c *Client) waitForFees(ctx context.Context, chainID int64, pollInterval time.Duration, done func(*SuggestedGasFees) bool) error {
+	watchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	events, err := c.WatchSuggestedGasFees(watchCtx, chainID, pollInterval)
+	if err != nil {
+		return err
+	}
+
+	for event := range events {
+		if event.Err == nil && done(event.Fees) {
+			return nil
+		}
+	}
+
+	return ctx.Err()
+}
+
+// defaultFailureBudget is the number of consecutive poll errors tolerated by WaitForLowCongestion
+const defaultFailureBudget = 5
+
+// WaitOption is a function that configures a blocking wait helper
+type WaitOption func(*waitConfig)
+
+type waitConfig struct {
+	failureBudget int
+}
+
+// WithFailureBudget sets how many consecutive poll errors a wait tolerates before giving up
+// (default 5). Failed polls are retried with exponential backoff starting at the poll interval.
+// A budget of 0 aborts on the first error.
+func WithFailureBudget(n int) WaitOption {
+	return func(cfg *waitConfig) {
+		cfg.failureBudget = n
+	}
+}
+
+// WaitForLowCongestion polls suggestedGasFees until networkCongestion is at or below
+// maxCongestion, then returns nil. If maxCongestion is negative, the condition is instead
+// that estimatedBaseFee is below the chain's busyThreshold.
+// Poll errors are retried with backoff until the failure budget (see WithFailureBudget) is
+// exhausted, in which case the last error is returned.
+// It returns ctx.Err() if the context is cancelled or its deadline expires first.
+func (c *Client) WaitForLowCongestion(ctx context.Context, chainID int64, maxCongestion float64, pollInterval time.Duration, opts ...WaitOption) error {
+	if pollInterval <= 0 {
+		return fmt.Errorf("poll interval must be positive, got %v", pollInterval)
+	}
+
+	cfg := waitConfig{failureBudget: defaultFailureBudget}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	failures := 0
+	for {
+		calm, err := c.isCongestionLow(ctx, chainID, maxCongestion)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		delay := pollInterval
+		if err != nil {
+			failures++
+			if failures > cfg.failureBudget {
+				return fmt.Errorf("failed to poll network congestion after %d consecutive errors: %w", failures, err)
+			}
+			delay = backoffDelay(pollInterval, failures)
+		} else {
+			if calm {
+				return nil
+			}
+			failures = 0
+		}
+
+		if err := c.sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// isCongestionLow performs a single congestion poll for WaitForLowCongestion
+func (c *Client) isCongestionLow(ctx context.Context, chainID int64, maxCongestion float64) (bool, error) {
+	fees, err := c.GetSuggestedGasFees(ctx, chainID)
+	if err != nil {
+		return false, err
+	}
+	if maxCongestion >= 0 {
+		if !fees.NetworkCongestion.IsSet() {
+			return false, fmt.Errorf("network congestion missing from response")
+		}
+		return fees.NetworkCongestion.Float64() <= maxCongestion, nil
+	}
+
+	threshold, err := c.GetBusyThreshold(ctx, chainID)
+	if err != nil {
+		return false, err
+	}
+	baseFee, err := parseGwei(fees.EstimatedBaseFee)
+	if err != nil {
+		return false, fmt.Errorf("invalid estimated base fee: %w", err)
+	}
+	busy, err := parseGwei(threshold.BusyThreshold)
+	if err != nil {
+		return false, fmt.Errorf("invalid busy threshold: %w", err)
+	}
+	return baseFee.Cmp(busy) < 0, nil
+}
+
+// backoffDelay doubles base for every failure after the first, capped at maxRetryDelay
+// (or at base itself if that is larger)
+func backoffDelay(base time.Duration, failures int) time.Duration {
+	return Backoff{Base: base, Cap: max(base, maxRetryDelay)}.NextDelay(failures)
+}
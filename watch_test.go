@@ -0,0 +1,526 @@
+package infura
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newSequenceServer returns a mock server that serves one suggestedGasFees response per
+// value in maxFees (low = v, medium = 2v, high = 3v), repeating the last value afterwards
+func newSequenceServer(t *testing.T, maxFees []string) (*httptest.Server, *int32) {
+	t.Helper()
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(&calls, 1)) - 1
+		if n >= len(maxFees) {
+			n = len(maxFees) - 1
+		}
+		value, _ := new(big.Float).SetString(maxFees[n])
+		level := func(mul int64) GasFeeLevel {
+			v := new(big.Float).Mul(value, big.NewFloat(float64(mul)))
+			return GasFeeLevel{SuggestedMaxFeePerGas: v.Text('f', -1)}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(SuggestedGasFees{
+			Low:    level(1),
+			Medium: level(2),
+			High:   level(3),
+		})
+	}))
+	return server, &calls
+}
+
+func TestWatchSuggestedGasFees(t *testing.T) {
+	server, _ := newSequenceServer(t, []string{"10", "20", "30"})
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := client.WatchSuggestedGasFees(ctx, 1, 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("WatchSuggestedGasFees failed: %v", err)
+	}
+
+	expected := []string{"10", "20", "30"}
+	for i, want := range expected {
+		event := <-events
+		if event.Err != nil {
+			t.Fatalf("event %d: unexpected error: %v", i, event.Err)
+		}
+		if event.ChainID != 1 {
+			t.Errorf("event %d: expected chain ID 1, got %d", i, event.ChainID)
+		}
+		if event.Fees.Low.SuggestedMaxFeePerGas != want {
+			t.Errorf("event %d: expected low maxFee %s, got %s", i, want, event.Fees.Low.SuggestedMaxFeePerGas)
+		}
+		if event.Smoothed != nil {
+			t.Errorf("event %d: expected no smoothed values without WithSmoothing", i)
+		}
+	}
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestWatchSuggestedGasFees_Smoothing(t *testing.T) {
+	server, _ := newSequenceServer(t, []string{"10", "20", "30", "30"})
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := client.WatchSuggestedGasFees(ctx, 1, 10*time.Millisecond, WithSmoothing(0.5))
+	if err != nil {
+		t.Fatalf("WatchSuggestedGasFees failed: %v", err)
+	}
+
+	// EMA with alpha 0.5: 10 -> 15 -> 22.5 -> 26.25
+	expected := []string{"10", "15", "22.5", "26.25"}
+	for i, want := range expected {
+		event := <-events
+		if event.Err != nil {
+			t.Fatalf("event %d: unexpected error: %v", i, event.Err)
+		}
+		if event.Smoothed == nil {
+			t.Fatalf("event %d: expected smoothed values", i)
+		}
+
+		for mul, p := range []Priority{PriorityLow, PriorityMedium, PriorityHigh} {
+			got, err := event.Smoothed.Level(p)
+			if err != nil {
+				t.Fatalf("Level(%s) failed: %v", p, err)
+			}
+			wantLevel, _ := new(big.Float).SetString(want)
+			wantLevel.Mul(wantLevel, big.NewFloat(float64(mul+1)))
+			if got.Cmp(wantLevel) != 0 {
+				t.Errorf("event %d: expected %s smoothed %s, got %s", i, p, wantLevel.Text('f', -1), got.Text('f', -1))
+			}
+		}
+
+		if event.Fees.Low.SuggestedMaxFeePerGas == "" {
+			t.Errorf("event %d: expected raw fees alongside smoothed values", i)
+		}
+	}
+}
+
+func TestWatchSuggestedGasFees_ErrorsDoNotStopWatcher(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"error": "boom"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"low": {"suggestedMaxFeePerGas": "1"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := client.WatchSuggestedGasFees(ctx, 1, 10*time.Millisecond, WithSmoothing(0.2))
+	if err != nil {
+		t.Fatalf("WatchSuggestedGasFees failed: %v", err)
+	}
+
+	first := <-events
+	if first.Err == nil {
+		t.Fatal("Expected first event to carry an error")
+	}
+	if first.Smoothed != nil {
+		t.Error("Expected no smoothed values on a failed poll")
+	}
+
+	second := <-events
+	if second.Err != nil {
+		t.Fatalf("Expected second event to succeed, got %v", second.Err)
+	}
+	if second.Smoothed.Low.Cmp(big.NewFloat(1)) != 0 {
+		t.Errorf("Expected first successful sample to seed the average, got %s", second.Smoothed.Low.Text('f', -1))
+	}
+}
+
+func TestWatchSuggestedGasFees_InvalidArguments(t *testing.T) {
+	client := NewClientWithAPIKey("test-api-key")
+
+	tests := []struct {
+		name     string
+		interval time.Duration
+		opts     []WatchOption
+	}{
+		{name: "zero interval", interval: 0},
+		{name: "negative interval", interval: -time.Second},
+		{name: "negative smoothing", interval: time.Second, opts: []WatchOption{WithSmoothing(-0.1)}},
+		{name: "smoothing above one", interval: time.Second, opts: []WatchOption{WithSmoothing(1.5)}},
+		{name: "NaN smoothing", interval: time.Second, opts: []WatchOption{WithSmoothing(math.NaN())}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.WatchSuggestedGasFees(context.Background(), 1, tt.interval, tt.opts...)
+			if err == nil {
+				t.Fatal("Expected error but got nil")
+			}
+		})
+	}
+}
+
+func TestEMASmoother_SkipsUnparseableLevels(t *testing.T) {
+	smoother := newEMASmoother(0.5, 3)
+
+	smoother.update(&SuggestedGasFees{
+		Low:    GasFeeLevel{SuggestedMaxFeePerGas: "10"},
+		Medium: GasFeeLevel{SuggestedMaxFeePerGas: "20"},
+		High:   GasFeeLevel{SuggestedMaxFeePerGas: "30"},
+	})
+	smoothed := smoother.update(&SuggestedGasFees{
+		Low:    GasFeeLevel{SuggestedMaxFeePerGas: "not-a-number"},
+		Medium: GasFeeLevel{SuggestedMaxFeePerGas: "40"},
+		High:   GasFeeLevel{SuggestedMaxFeePerGas: ""},
+	})
+
+	for _, tc := range []struct {
+		got  *big.Float
+		want string
+	}{
+		{smoothed.Low, "10"},
+		{smoothed.Medium, "30"},
+		{smoothed.High, "30"},
+	} {
+		if got := tc.got.Text('f', -1); got != tc.want {
+			t.Errorf("Expected %s, got %s", tc.want, got)
+		}
+	}
+}
+
+func TestPriority_String(t *testing.T) {
+	for p, want := range map[Priority]string{
+		PriorityLow:    "low",
+		PriorityMedium: "medium",
+		PriorityHigh:   "high",
+		Priority(7):    fmt.Sprintf("Priority(%d)", 7),
+	} {
+		if got := p.String(); got != want {
+			t.Errorf("Expected %q, got %q", want, got)
+		}
+	}
+}
+
+func TestWaitForGasBelow(t *testing.T) {
+	// The medium level (2x) drops to 20 gwei on the fourth poll
+	server, calls := newSequenceServer(t, []string{"50", "40", "30", "10"})
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := client.WaitForGasBelow(ctx, 1, PriorityMedium, big.NewFloat(20), 5*time.Millisecond)
+	if err != nil {
+		t.Fatalf("WaitForGasBelow failed: %v", err)
+	}
+
+	if got := atomic.LoadInt32(calls); got != 4 {
+		t.Errorf("Expected 4 polls, got %d", got)
+	}
+}
+
+func TestWaitForGasBelow_ContextExpires(t *testing.T) {
+	server, _ := newSequenceServer(t, []string{"100"})
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := client.WaitForGasBelow(ctx, 1, PriorityLow, big.NewFloat(1), 5*time.Millisecond)
+	if err != context.DeadlineExceeded {
+		t.Fatalf("Expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestWaitForGasBelow_InvalidArguments(t *testing.T) {
+	client := NewClientWithAPIKey("test-api-key")
+
+	if err := client.WaitForGasBelow(context.Background(), 1, PriorityLow, nil, time.Second); err == nil {
+		t.Error("Expected error for nil target")
+	}
+	if err := client.WaitForGasBelow(context.Background(), 1, Priority(9), big.NewFloat(1), time.Second); err == nil {
+		t.Error("Expected error for unknown priority")
+	}
+	if err := client.WaitForGasBelow(context.Background(), 1, PriorityLow, big.NewFloat(1), 0); err == nil {
+		t.Error("Expected error for non-positive poll interval")
+	}
+}
+
+// newCongestionServer returns a mock server that serves one suggestedGasFees response per
+// value in congestion, repeating the last value afterwards. A negative value makes that poll
+// fail with a 500. busyThreshold is served as 30 gwei and estimatedBaseFee mirrors the
+// congestion value multiplied by 100.
+func newCongestionServer(t *testing.T, congestion []float64) (*httptest.Server, *int32) {
+	t.Helper()
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/busyThreshold") {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"busyThreshold": "30"}`))
+			return
+		}
+		n := int(atomic.AddInt32(&calls, 1)) - 1
+		if n >= len(congestion) {
+			n = len(congestion) - 1
+		}
+		if congestion[n] < 0 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(SuggestedGasFees{
+			NetworkCongestion: NewCongestion(congestion[n]),
+			EstimatedBaseFee:  fmt.Sprintf("%g", congestion[n]*100),
+		})
+	}))
+	return server, &calls
+}
+
+// driveClock advances the fake clock by step whenever a timer is pending until done yields a result
+func driveClock(t *testing.T, clock *fakeClock, step time.Duration, done <-chan error) error {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		select {
+		case err := <-done:
+			return err
+		default:
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out driving the fake clock")
+		}
+		if clock.Waiters() > 0 {
+			clock.Advance(step)
+		} else {
+			time.Sleep(time.Millisecond)
+		}
+	}
+}
+
+func TestWaitForLowCongestion(t *testing.T) {
+	server, calls := newCongestionServer(t, []float64{0.9, 0.8, 0.3})
+	defer server.Close()
+
+	clock := newFakeClock(time.Unix(0, 0))
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL), WithClock(clock))
+
+	done := make(chan error, 1)
+	go func() {
+		done <- client.WaitForLowCongestion(context.Background(), 1, 0.5, time.Minute)
+	}()
+
+	if err := driveClock(t, clock, time.Minute, done); err != nil {
+		t.Fatalf("WaitForLowCongestion failed: %v", err)
+	}
+	if got := atomic.LoadInt32(calls); got != 3 {
+		t.Errorf("Expected 3 polls, got %d", got)
+	}
+}
+
+func TestWaitForLowCongestion_BusyThreshold(t *testing.T) {
+	// estimatedBaseFee 50 -> 40 -> 20 against a busy threshold of 30
+	server, calls := newCongestionServer(t, []float64{0.5, 0.4, 0.2})
+	defer server.Close()
+
+	clock := newFakeClock(time.Unix(0, 0))
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL), WithClock(clock))
+
+	done := make(chan error, 1)
+	go func() {
+		done <- client.WaitForLowCongestion(context.Background(), 1, -1, time.Minute)
+	}()
+
+	if err := driveClock(t, clock, time.Minute, done); err != nil {
+		t.Fatalf("WaitForLowCongestion failed: %v", err)
+	}
+	if got := atomic.LoadInt32(calls); got != 3 {
+		t.Errorf("Expected 3 polls, got %d", got)
+	}
+}
+
+func TestWaitForLowCongestion_ContextCancelled(t *testing.T) {
+	server, calls := newCongestionServer(t, []float64{0.9})
+	defer server.Close()
+
+	clock := newFakeClock(time.Unix(0, 0))
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL), WithClock(clock))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- client.WaitForLowCongestion(ctx, 1, 0.5, time.Minute)
+	}()
+
+	for i := 0; i < 3; i++ {
+		waitForWaiters(t, clock, 1)
+		clock.Advance(time.Minute)
+	}
+	waitForWaiters(t, clock, 1)
+	cancel()
+
+	if err := <-done; err != context.Canceled {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+	if got := atomic.LoadInt32(calls); got != 4 {
+		t.Errorf("Expected 4 polls, got %d", got)
+	}
+}
+
+func TestWaitForLowCongestion_ErrorsRetriedWithBackoff(t *testing.T) {
+	server, calls := newCongestionServer(t, []float64{-1, -1, 0.1})
+	defer server.Close()
+
+	clock := newFakeClock(time.Unix(0, 0))
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL), WithClock(clock))
+
+	done := make(chan error, 1)
+	go func() {
+		done <- client.WaitForLowCongestion(context.Background(), 1, 0.5, time.Minute)
+	}()
+
+	// First failure waits one interval, the second waits two
+	waitForWaiters(t, clock, 1)
+	clock.Advance(time.Minute)
+	waitForWaiters(t, clock, 1)
+	clock.Advance(time.Minute)
+	if got := atomic.LoadInt32(calls); got != 2 {
+		t.Fatalf("Expected backoff to delay the third poll, got %d polls", got)
+	}
+	clock.Advance(time.Minute)
+
+	if err := <-done; err != nil {
+		t.Fatalf("Expected errors to be retried, got %v", err)
+	}
+	if got := atomic.LoadInt32(calls); got != 3 {
+		t.Errorf("Expected 3 polls, got %d", got)
+	}
+}
+
+func TestWaitForLowCongestion_FailureBudgetExhausted(t *testing.T) {
+	server, calls := newCongestionServer(t, []float64{-1})
+	defer server.Close()
+
+	clock := newFakeClock(time.Unix(0, 0))
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL), WithClock(clock))
+
+	done := make(chan error, 1)
+	go func() {
+		done <- client.WaitForLowCongestion(context.Background(), 1, 0.5, time.Minute, WithFailureBudget(2))
+	}()
+
+	err := driveClock(t, clock, time.Minute, done)
+	if !errors.Is(err, ErrServerError) {
+		t.Fatalf("Expected ErrServerError after budget exhausted, got %v", err)
+	}
+	if got := atomic.LoadInt32(calls); got != 3 {
+		t.Errorf("Expected 3 polls, got %d", got)
+	}
+}
+
+func TestWaitForCongestionBelow(t *testing.T) {
+	// Congestion drops below the target on the fourth poll; the failed poll is ignored
+	server, calls := newCongestionServer(t, []float64{0.9, -1, 0.7, 0.4})
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL), WithRetry(1, 0))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := client.WaitForCongestionBelow(ctx, 1, 0.5, 5*time.Millisecond); err != nil {
+		t.Fatalf("WaitForCongestionBelow failed: %v", err)
+	}
+	if got := atomic.LoadInt32(calls); got != 4 {
+		t.Errorf("Expected 4 polls, got %d", got)
+	}
+}
+
+func TestWaitForCongestionBelow_AtTarget(t *testing.T) {
+	server, calls := newCongestionServer(t, []float64{0.5})
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL))
+	if err := client.WaitForCongestionBelow(context.Background(), 1, 0.5, time.Hour); err != nil {
+		t.Fatalf("WaitForCongestionBelow failed: %v", err)
+	}
+	if got := atomic.LoadInt32(calls); got != 1 {
+		t.Errorf("Expected a single poll, got %d", got)
+	}
+}
+
+func TestWaitForCongestionBelow_ContextExpires(t *testing.T) {
+	server, _ := newCongestionServer(t, []float64{0.9})
+	defer server.Close()
+
+	client := NewClientWithAPIKeyAndOptions("test-api-key", WithBaseURL(server.URL))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if err := client.WaitForCongestionBelow(ctx, 1, 0.5, 5*time.Millisecond); err != context.DeadlineExceeded {
+		t.Fatalf("Expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestWaitForCongestionBelow_InvalidArguments(t *testing.T) {
+	client := NewClientWithAPIKey("test-api-key")
+	if err := client.WaitForCongestionBelow(context.Background(), 1, -0.1, time.Second); err == nil {
+		t.Error("Expected error for a negative target")
+	}
+	if err := client.WaitForCongestionBelow(context.Background(), 1, math.NaN(), time.Second); err == nil {
+		t.Error("Expected error for a NaN target")
+	}
+	if err := client.WaitForCongestionBelow(context.Background(), 1, 0.5, 0); err == nil {
+		t.Error("Expected error for non-positive poll interval")
+	}
+}
+
+func TestBackoffDelay(t *testing.T) {
+	for _, tc := range []struct {
+		base     time.Duration
+		failures int
+		want     time.Duration
+	}{
+		{base: time.Second, failures: 1, want: time.Second},
+		{base: time.Second, failures: 3, want: 4 * time.Second},
+		{base: time.Second, failures: 10, want: maxRetryDelay},
+		{base: time.Minute, failures: 3, want: time.Minute},
+	} {
+		if got := backoffDelay(tc.base, tc.failures); got != tc.want {
+			t.Errorf("backoffDelay(%v, %d) = %v, want %v", tc.base, tc.failures, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,435 @@
+package infura
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// WebhookSignatureHeader is the header carrying the HMAC-SHA256 signature of a webhook
+// body, as "sha256=" followed by the hex digest, when WebhookConfig.Secret is set
+const WebhookSignatureHeader = "X-Webhook-Signature-256"
+
+// Webhook trigger reasons, sent as WebhookPayload.Reason
+const (
+	// WebhookReasonFeeChange is sent when the estimated base fee moved by at least
+	// WebhookConfig.MinChange since the previous notification of the chain
+	WebhookReasonFeeChange = "fee_change"
+	// WebhookReasonAlertAbove and WebhookReasonAlertBelow are sent for the AlertAbove and
+	// AlertBelow events of an Alerter
+	WebhookReasonAlertAbove = "alert_above"
+	WebhookReasonAlertBelow = "alert_below"
+)
+
+// Defaults of WebhookConfig
+const (
+	DefaultWebhookMaxAttempts = 3
+	DefaultWebhookQueueSize   = 64
+	DefaultWebhookTimeout     = 10 * time.Second
+)
+
+// DefaultWebhookBackoff is the delay between delivery attempts unless WebhookConfig.Backoff
+// is set
+var DefaultWebhookBackoff = Backoff{Base: 500 * time.Millisecond, Cap: 30 * time.Second, Jitter: 0.2}
+
+// WebhookPayload is the JSON body POSTed by a WebhookNotifier
+type WebhookPayload struct {
+	ChainID int64 `json:"chainId"`
+	// Reason is one of the WebhookReason constants
+	Reason    string    `json:"reason"`
+	Timestamp time.Time `json:"timestamp"`
+	// BaseFee is the estimated base fee in Gwei that triggered the notification, and
+	// PreviousBaseFee the one of the previous notification of the chain (empty for the first)
+	BaseFee         string `json:"baseFee"`
+	PreviousBaseFee string `json:"previousBaseFee,omitempty"`
+	// Fees and PreviousFees are the full suggestions, for notifications from a watcher
+	Fees         *SuggestedGasFees `json:"fees,omitempty"`
+	PreviousFees *SuggestedGasFees `json:"previousFees,omitempty"`
+	// Threshold is the crossed threshold in Gwei, for notifications from an Alerter
+	Threshold string `json:"threshold,omitempty"`
+}
+
+// WebhookConfig configures a WebhookNotifier
+type WebhookConfig struct {
+	// URLs are the endpoints every payload is POSTed to; at least one is required
+	URLs []string
+	// Secret, if set, signs each body with HMAC-SHA256 in the WebhookSignatureHeader header
+	Secret []byte
+	// MinChange is the relative change of the estimated base fee since the previous
+	// notification of a chain that triggers a fee_change notification, e.g. 0.1 for 10%;
+	// 0 notifies every change. The first reading of a chain only sets the baseline.
+	MinChange float64
+	// MaxAttempts is the number of delivery attempts per endpoint for a payload, retrying
+	// transport errors, 429 and 5xx responses (0 = DefaultWebhookMaxAttempts)
+	MaxAttempts int
+	// Backoff is the delay between attempts (zero value = DefaultWebhookBackoff)
+	Backoff Backoff
+	// QueueSize is the number of payloads buffered per endpoint; when an endpoint falls
+	// behind, new payloads for it are dropped (0 = DefaultWebhookQueueSize)
+	QueueSize int
+	// HTTPClient sends the requests (nil = a client with DefaultWebhookTimeout)
+	HTTPClient *http.Client
+	// Clock timestamps payloads and times retries; nil uses the system clock
+	Clock Clock
+}
+
+// WebhookStats are the delivery counters of one endpoint, as returned by Stats
+type WebhookStats struct {
+	URL string
+	// Delivered counts payloads acknowledged with a 2xx response
+	Delivered int64
+	// Failed counts payloads given up on after a non-retryable response or MaxAttempts
+	Failed int64
+	// Retries counts attempts after the first
+	Retries int64
+	// Dropped counts payloads discarded because the endpoint's queue was full
+	Dropped int64
+	// LastStatus is the status of the last attempt (0 if no response was received), and
+	// LastError its error, empty on success
+	LastStatus int
+	LastError  string
+	// LastDelivered is when a payload was last delivered
+	LastDelivered time.Time
+}
+
+// WebhookNotifier POSTs a JSON WebhookPayload to one or more URLs when fees change
+// materially, for consumers that are not Go processes. Feed it from a watcher with Stream
+// or from an Alerter with AlertHandler.
+//
+// Each endpoint has its own queue and delivery goroutine, so a slow or failing endpoint
+// never delays the others, nor the watcher. Call Close to flush the queues when done.
+type WebhookNotifier struct {
+	secret      []byte
+	minChange   float64
+	maxAttempts int
+	backoff     Backoff
+	httpClient  *http.Client
+	clock       Clock
+
+	endpoints []*webhookEndpoint
+	wg        sync.WaitGroup
+
+	mu     sync.Mutex
+	last   map[int64]webhookReading
+	closed bool
+}
+
+// webhookReading is the reading of the previous notification of a chain
+type webhookReading struct {
+	baseFee *big.Int
+	fees    *SuggestedGasFees
+}
+
+// webhookEndpoint is a delivery target with its queue and counters
+type webhookEndpoint struct {
+	url   string
+	queue chan []byte
+
+	mu    sync.Mutex
+	stats WebhookStats
+}
+
+// NewWebhookNotifier creates a WebhookNotifier and starts its delivery goroutines
+func NewWebhookNotifier(cfg WebhookConfig) (*WebhookNotifier, error) {
+	if len(cfg.URLs) == 0 {
+		return nil, fmt.Errorf("at least one webhook URL is required")
+	}
+	if cfg.MinChange < 0 {
+		return nil, fmt.Errorf("minimum change must not be negative, got %v", cfg.MinChange)
+	}
+	if cfg.MaxAttempts < 0 {
+		return nil, fmt.Errorf("max attempts must not be negative, got %d", cfg.MaxAttempts)
+	}
+	if cfg.QueueSize < 0 {
+		return nil, fmt.Errorf("queue size must not be negative, got %d", cfg.QueueSize)
+	}
+	for _, raw := range cfg.URLs {
+		u, err := url.Parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid webhook URL %q: %w", raw, err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+			return nil, fmt.Errorf("invalid webhook URL %q: must be an absolute http or https URL", raw)
+		}
+	}
+
+	n := &WebhookNotifier{
+		secret:      bytes.Clone(cfg.Secret),
+		minChange:   cfg.MinChange,
+		maxAttempts: cfg.MaxAttempts,
+		backoff:     cfg.Backoff,
+		httpClient:  cfg.HTTPClient,
+		clock:       cfg.Clock,
+		last:        make(map[int64]webhookReading),
+	}
+	if n.maxAttempts == 0 {
+		n.maxAttempts = DefaultWebhookMaxAttempts
+	}
+	if n.backoff == (Backoff{}) {
+		n.backoff = DefaultWebhookBackoff
+	}
+	if n.httpClient == nil {
+		n.httpClient = &http.Client{Timeout: DefaultWebhookTimeout}
+	}
+	if n.clock == nil {
+		n.clock = realClock{}
+	}
+	queueSize := cfg.QueueSize
+	if queueSize == 0 {
+		queueSize = DefaultWebhookQueueSize
+	}
+
+	for _, u := range cfg.URLs {
+		endpoint := &webhookEndpoint{url: u, queue: make(chan []byte, queueSize), stats: WebhookStats{URL: u}}
+		n.endpoints = append(n.endpoints, endpoint)
+		n.wg.Add(1)
+		go func() {
+			defer n.wg.Done()
+			for body := range endpoint.queue {
+				n.deliver(endpoint, body)
+			}
+		}()
+	}
+	return n, nil
+}
+
+// Stream notifies the material fee changes of the successful events read from in and
+// forwards all events unchanged. The returned channel is closed when in is closed or ctx
+// is done.
+func (n *WebhookNotifier) Stream(ctx context.Context, in <-chan WatchEvent) <-chan WatchEvent {
+	out := make(chan WatchEvent)
+	go func() {
+		defer close(out)
+		for {
+			var event WatchEvent
+			var ok bool
+			select {
+			case event, ok = <-in:
+				if !ok {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+
+			if event.Err == nil && event.Fees != nil {
+				n.Observe(event.ChainID, event.Time, event.Fees)
+			}
+
+			select {
+			case out <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// Observe notifies a fee suggestion of a chain if its estimated base fee moved by at least
+// MinChange since the previous notification. Suggestions without a valid estimated base
+// fee are ignored.
+func (n *WebhookNotifier) Observe(chainID int64, at time.Time, fees *SuggestedGasFees) {
+	baseFee, err := ParseGweiToWei(fees.EstimatedBaseFee)
+	if err != nil {
+		return
+	}
+
+	n.mu.Lock()
+	prev, seen := n.last[chainID]
+	if seen && !n.changed(prev.baseFee, baseFee) {
+		n.mu.Unlock()
+		return
+	}
+	current := cloneSuggestedGasFees(*fees)
+	n.last[chainID] = webhookReading{baseFee: baseFee, fees: &current}
+	n.mu.Unlock()
+	if !seen {
+		return
+	}
+
+	n.notify(WebhookPayload{
+		ChainID:         chainID,
+		Reason:          WebhookReasonFeeChange,
+		Timestamp:       at,
+		BaseFee:         formatWeiAsGwei(baseFee),
+		PreviousBaseFee: formatWeiAsGwei(prev.baseFee),
+		Fees:            &current,
+		PreviousFees:    prev.fees,
+	})
+}
+
+// changed reports whether the base fee moved by at least minChange relative to prev
+func (n *WebhookNotifier) changed(prev, current *big.Int) bool {
+	diff := new(big.Int).Sub(current, prev)
+	if diff.Sign() == 0 {
+		return false
+	}
+	if n.minChange == 0 || prev.Sign() == 0 {
+		return true
+	}
+	change := new(big.Rat).SetFrac(diff.Abs(diff), prev)
+	threshold := new(big.Rat)
+	threshold.SetFloat64(n.minChange)
+	return change.Cmp(threshold) >= 0
+}
+
+// AlertHandler returns a callback for NewAlerter that notifies every threshold crossing
+// of the Alerter, regardless of MinChange
+func (n *WebhookNotifier) AlertHandler() func(AlertEvent) {
+	return func(event AlertEvent) {
+		reason := WebhookReasonAlertAbove
+		if event.Direction == AlertBelow {
+			reason = WebhookReasonAlertBelow
+		}
+		payload := WebhookPayload{
+			ChainID:   event.ChainID,
+			Reason:    reason,
+			Timestamp: event.Time,
+			BaseFee:   formatWeiAsGwei(event.BaseFee),
+			Threshold: formatWeiAsGwei(event.Threshold),
+		}
+
+		n.mu.Lock()
+		if prev, ok := n.last[event.ChainID]; ok {
+			payload.PreviousBaseFee = formatWeiAsGwei(prev.baseFee)
+		}
+		n.last[event.ChainID] = webhookReading{baseFee: copyInt(event.BaseFee)}
+		n.mu.Unlock()
+
+		n.notify(payload)
+	}
+}
+
+// notify queues payload on every endpoint, dropping it for endpoints whose queue is full
+func (n *WebhookNotifier) notify(payload WebhookPayload) {
+	if payload.Timestamp.IsZero() {
+		payload.Timestamp = n.clock.Now()
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		log.Printf("[WARN] failed to marshal webhook payload: %v", err)
+		return
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.closed {
+		return
+	}
+	for _, endpoint := range n.endpoints {
+		select {
+		case endpoint.queue <- body:
+		default:
+			endpoint.mu.Lock()
+			endpoint.stats.Dropped++
+			endpoint.mu.Unlock()
+		}
+	}
+}
+
+// deliver POSTs body to endpoint, retrying retryable failures
+func (n *WebhookNotifier) deliver(endpoint *webhookEndpoint, body []byte) {
+	for attempt := 1; ; attempt++ {
+		status, err := n.post(endpoint.url, body)
+		retryable := err != nil || status == http.StatusTooManyRequests || status >= 500
+
+		endpoint.mu.Lock()
+		if attempt > 1 {
+			endpoint.stats.Retries++
+		}
+		endpoint.stats.LastStatus = status
+		endpoint.stats.LastError = ""
+		switch {
+		case err != nil:
+			endpoint.stats.LastError = err.Error()
+		case status < 200 || status >= 300:
+			endpoint.stats.LastError = fmt.Sprintf("unexpected status %d", status)
+		default:
+			endpoint.stats.Delivered++
+			endpoint.stats.LastDelivered = n.clock.Now()
+		}
+		failed := endpoint.stats.LastError != ""
+		if failed && (!retryable || attempt >= n.maxAttempts) {
+			endpoint.stats.Failed++
+		}
+		endpoint.mu.Unlock()
+
+		if !failed || !retryable || attempt >= n.maxAttempts {
+			return
+		}
+		<-n.clock.After(n.backoff.NextDelay(attempt))
+	}
+}
+
+// post sends a single delivery attempt and returns the response status
+func (n *WebhookNotifier) post(target string, body []byte) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(n.secret) > 0 {
+		req.Header.Set(WebhookSignatureHeader, SignWebhookBody(n.secret, body))
+	}
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	return resp.StatusCode, nil
+}
+
+// Stats returns the delivery counters of each endpoint, in the order of WebhookConfig.URLs
+func (n *WebhookNotifier) Stats() []WebhookStats {
+	stats := make([]WebhookStats, len(n.endpoints))
+	for i, endpoint := range n.endpoints {
+		endpoint.mu.Lock()
+		stats[i] = endpoint.stats
+		endpoint.mu.Unlock()
+	}
+	return stats
+}
+
+// Close stops accepting notifications and waits until the queued payloads are delivered
+// or given up on. Notifications after Close are discarded.
+func (n *WebhookNotifier) Close() {
+	n.mu.Lock()
+	if n.closed {
+		n.mu.Unlock()
+		return
+	}
+	n.closed = true
+	for _, endpoint := range n.endpoints {
+		close(endpoint.queue)
+	}
+	n.mu.Unlock()
+	n.wg.Wait()
+}
+
+// SignWebhookBody returns the WebhookSignatureHeader value of body signed with secret
+func SignWebhookBody(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhookSignature reports whether signature, the WebhookSignatureHeader value of a
+// received webhook, matches body signed with secret. Receivers should verify the raw body
+// before decoding it.
+func VerifyWebhookSignature(secret, body []byte, signature string) bool {
+	return hmac.Equal([]byte(signature), []byte(SignWebhookBody(secret, body)))
+}
@@ -0,0 +1,271 @@
+package infura
+
+import (
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// webhookReceiver records the bodies and signatures it receives
+type webhookReceiver struct {
+	mu         sync.Mutex
+	bodies     [][]byte
+	signatures []string
+}
+
+func newWebhookReceiver(t *testing.T, status func(call int32) int) (*httptest.Server, *webhookReceiver, *int32) {
+	t.Helper()
+	receiver := &webhookReceiver{}
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		call := atomic.AddInt32(&calls, 1)
+		body, _ := io.ReadAll(r.Body)
+		receiver.mu.Lock()
+		receiver.bodies = append(receiver.bodies, body)
+		receiver.signatures = append(receiver.signatures, r.Header.Get(WebhookSignatureHeader))
+		receiver.mu.Unlock()
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(status(call))
+	}))
+	return server, receiver, &calls
+}
+
+func alwaysOK(int32) int { return http.StatusOK }
+
+func webhookFees(baseFee string) *SuggestedGasFees {
+	return &SuggestedGasFees{EstimatedBaseFee: baseFee, Medium: GasFeeLevel{SuggestedMaxFeePerGas: "2"}}
+}
+
+func TestWebhookNotifier_PayloadAndSignature(t *testing.T) {
+	server, receiver, _ := newWebhookReceiver(t, alwaysOK)
+	defer server.Close()
+
+	secret := []byte("shared-secret")
+	notifier, err := NewWebhookNotifier(WebhookConfig{URLs: []string{server.URL}, Secret: secret, MinChange: 0.1})
+	if err != nil {
+		t.Fatalf("NewWebhookNotifier failed: %v", err)
+	}
+
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	notifier.Observe(1, at, webhookFees("10"))                    // baseline
+	notifier.Observe(1, at.Add(time.Second), webhookFees("10.5")) // +5%, below MinChange
+	notifier.Observe(1, at.Add(2*time.Second), webhookFees("12")) // +20%
+	notifier.Close()
+
+	if len(receiver.bodies) != 1 {
+		t.Fatalf("Expected 1 delivery, got %d", len(receiver.bodies))
+	}
+	body := receiver.bodies[0]
+	if !VerifyWebhookSignature(secret, body, receiver.signatures[0]) {
+		t.Errorf("Signature %q does not verify", receiver.signatures[0])
+	}
+	if VerifyWebhookSignature([]byte("other"), body, receiver.signatures[0]) {
+		t.Error("Expected the signature to fail with another secret")
+	}
+
+	var payload map[string]interface{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("Invalid JSON payload: %v", err)
+	}
+	want := map[string]interface{}{
+		"chainId":         1.0,
+		"reason":          WebhookReasonFeeChange,
+		"timestamp":       "2026-01-02T03:04:07Z",
+		"baseFee":         "12",
+		"previousBaseFee": "10",
+	}
+	for key, value := range want {
+		if payload[key] != value {
+			t.Errorf("Expected %s = %v, got %v", key, value, payload[key])
+		}
+	}
+	fees, _ := payload["fees"].(map[string]interface{})
+	previous, _ := payload["previousFees"].(map[string]interface{})
+	if fees["estimatedBaseFee"] != "12" || previous["estimatedBaseFee"] != "10" {
+		t.Errorf("Expected current and previous fees, got %v and %v", fees, previous)
+	}
+}
+
+func TestWebhookNotifier_Unsigned(t *testing.T) {
+	server, receiver, _ := newWebhookReceiver(t, alwaysOK)
+	defer server.Close()
+
+	notifier, err := NewWebhookNotifier(WebhookConfig{URLs: []string{server.URL}})
+	if err != nil {
+		t.Fatalf("NewWebhookNotifier failed: %v", err)
+	}
+	notifier.Observe(1, time.Now(), webhookFees("10"))
+	notifier.Observe(1, time.Now(), webhookFees("10.000000001"))
+	notifier.Close()
+
+	if len(receiver.signatures) != 1 || receiver.signatures[0] != "" {
+		t.Errorf("Expected one unsigned delivery, got %q", receiver.signatures)
+	}
+}
+
+func TestWebhookNotifier_RetryOn500(t *testing.T) {
+	server, receiver, calls := newWebhookReceiver(t, func(call int32) int {
+		if call < 3 {
+			return http.StatusInternalServerError
+		}
+		return http.StatusNoContent
+	})
+	defer server.Close()
+
+	notifier, err := NewWebhookNotifier(WebhookConfig{
+		URLs:        []string{server.URL},
+		MaxAttempts: 3,
+		Backoff:     Backoff{Base: time.Millisecond},
+	})
+	if err != nil {
+		t.Fatalf("NewWebhookNotifier failed: %v", err)
+	}
+	notifier.Observe(1, time.Now(), webhookFees("10"))
+	notifier.Observe(1, time.Now(), webhookFees("20"))
+	notifier.Close()
+
+	if got := atomic.LoadInt32(calls); got != 3 {
+		t.Fatalf("Expected 3 attempts, got %d", got)
+	}
+	if string(receiver.bodies[0]) != string(receiver.bodies[2]) {
+		t.Error("Expected retries to resend the same body")
+	}
+	stats := notifier.Stats()[0]
+	if stats.Delivered != 1 || stats.Retries != 2 || stats.Failed != 0 || stats.LastStatus != http.StatusNoContent || stats.LastError != "" {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+}
+
+func TestWebhookNotifier_GivesUp(t *testing.T) {
+	server, _, calls := newWebhookReceiver(t, func(int32) int { return http.StatusBadGateway })
+	defer server.Close()
+	rejecting, _, rejected := newWebhookReceiver(t, func(int32) int { return http.StatusBadRequest })
+	defer rejecting.Close()
+
+	notifier, err := NewWebhookNotifier(WebhookConfig{
+		URLs:        []string{server.URL, rejecting.URL},
+		MaxAttempts: 2,
+		Backoff:     Backoff{Base: time.Millisecond},
+	})
+	if err != nil {
+		t.Fatalf("NewWebhookNotifier failed: %v", err)
+	}
+	notifier.Observe(1, time.Now(), webhookFees("10"))
+	notifier.Observe(1, time.Now(), webhookFees("20"))
+	notifier.Close()
+
+	if atomic.LoadInt32(calls) != 2 || atomic.LoadInt32(rejected) != 1 {
+		t.Errorf("Expected 2 attempts on 502 and 1 on 400, got %d and %d", *calls, *rejected)
+	}
+	for _, stats := range notifier.Stats() {
+		if stats.Failed != 1 || stats.Delivered != 0 || stats.LastError == "" {
+			t.Errorf("Unexpected stats: %+v", stats)
+		}
+	}
+}
+
+func TestWebhookNotifier_SlowEndpointDoesNotBlockOthers(t *testing.T) {
+	release := make(chan struct{})
+	var releaseOnce sync.Once
+	unblock := func() { releaseOnce.Do(func() { close(release) }) }
+	started := make(chan struct{}, 8)
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-release
+	}))
+	// Cleanups run last in, first out: the handler is released before the server closes
+	t.Cleanup(slow.Close)
+	t.Cleanup(unblock)
+	fast, receiver, _ := newWebhookReceiver(t, alwaysOK)
+	defer fast.Close()
+
+	notifier, err := NewWebhookNotifier(WebhookConfig{URLs: []string{slow.URL, fast.URL}, QueueSize: 1})
+	if err != nil {
+		t.Fatalf("NewWebhookNotifier failed: %v", err)
+	}
+	waitForDeliveries := func(want int) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for {
+			receiver.mu.Lock()
+			n := len(receiver.bodies)
+			receiver.mu.Unlock()
+			if n == want {
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("Expected %d deliveries to the fast endpoint while the slow one hangs, got %d", want, n)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	notifier.Observe(1, time.Now(), webhookFees("10"))
+	for i, fee := range []string{"20", "30", "40", "50"} {
+		notifier.Observe(1, time.Now(), webhookFees(fee))
+		if i == 0 {
+			<-started
+		}
+		waitForDeliveries(i + 1)
+	}
+
+	unblock()
+	notifier.Close()
+	stats := notifier.Stats()
+	// The slow endpoint holds one payload in flight and one queued; the others are dropped
+	if stats[0].Dropped != 2 || stats[0].Delivered != 2 || stats[1].Delivered != 4 || stats[1].Dropped != 0 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+}
+
+func TestWebhookNotifier_AlertHandler(t *testing.T) {
+	server, receiver, _ := newWebhookReceiver(t, alwaysOK)
+	defer server.Close()
+
+	notifier, err := NewWebhookNotifier(WebhookConfig{URLs: []string{server.URL}})
+	if err != nil {
+		t.Fatalf("NewWebhookNotifier failed: %v", err)
+	}
+	onAlert := notifier.AlertHandler()
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	onAlert(AlertEvent{ChainID: 1, BaseFee: big.NewInt(60e9), Threshold: big.NewInt(50e9), Direction: AlertAbove, Time: at})
+	onAlert(AlertEvent{ChainID: 1, BaseFee: big.NewInt(30e9), Threshold: big.NewInt(40e9), Direction: AlertBelow, Time: at})
+	notifier.Close()
+
+	if len(receiver.bodies) != 2 {
+		t.Fatalf("Expected 2 deliveries, got %d", len(receiver.bodies))
+	}
+	var above, below WebhookPayload
+	json.Unmarshal(receiver.bodies[0], &above)
+	json.Unmarshal(receiver.bodies[1], &below)
+	if above.Reason != WebhookReasonAlertAbove || above.BaseFee != "60" || above.Threshold != "50" || above.PreviousBaseFee != "" || above.Fees != nil {
+		t.Errorf("Unexpected above payload: %+v", above)
+	}
+	if below.Reason != WebhookReasonAlertBelow || below.BaseFee != "30" || below.PreviousBaseFee != "60" || !below.Timestamp.Equal(at) {
+		t.Errorf("Unexpected below payload: %+v", below)
+	}
+}
+
+func TestNewWebhookNotifier_Invalid(t *testing.T) {
+	for name, cfg := range map[string]WebhookConfig{
+		"no URLs":          {},
+		"relative URL":     {URLs: []string{"/hook"}},
+		"other scheme":     {URLs: []string{"ftp://example.com"}},
+		"negative change":  {URLs: []string{"http://example.com"}, MinChange: -1},
+		"negative retries": {URLs: []string{"http://example.com"}, MaxAttempts: -1},
+		"negative queue":   {URLs: []string{"http://example.com"}, QueueSize: -1},
+	} {
+		if _, err := NewWebhookNotifier(cfg); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}